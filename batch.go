@@ -0,0 +1,152 @@
+package rod
+
+import (
+	"github.com/go-rod/rod/lib/js"
+)
+
+// Batch queues the element actions and flushes them in a single cdp round trip, it's useful for the high-volume
+// form automation. The actions are done via js, they won't scroll to the elements or wait for them to be visible,
+// enabled or writable, and Batch.Click dispatches the click event instead of the native mouse events.
+// All the elements must belong to the frame of the page, or Batch.Do returns ErrBatchFrame. Use Page.Batch to create it.
+// Batch 将元素的操作排队，并在一次 cdp 往返中执行它们，适用于大量的表单自动化。这些操作通过 js 完成，
+// 它们不会滚动到元素或者等待元素可见、可用或者可写，并且 Batch.Click 派发的是 click 事件而不是原生的鼠标事件。
+// 所有元素必须属于该页面的 frame，否则 Batch.Do 返回 ErrBatchFrame。使用 Page.Batch 创建它。
+type Batch struct {
+	page  *Page
+	ops   []*batchOp
+	els   []*Element
+	index map[*Element]int
+	err   error
+}
+
+// the js of Batch.Do, it's not in the lib/js/helper.js because only Batch uses it
+// Batch.Do 的 js，它不在 lib/js/helper.js 中，因为只有 Batch 使用它
+var jsBatch = &js.Function{
+	Name: "batch",
+	Definition: `function(ops, ...els) {
+		return ops.map((op, i) => {
+			const el = els[op.el]
+			try {
+				switch (op.op) {
+				case 'focus':
+					el.focus()
+					return null
+				case 'input':
+					el.focus()
+					if (!document.execCommand('insertText', false, op.text)) {
+						el.value += op.text
+					}
+					functions.inputEvent.call(el)
+					return null
+				case 'click':
+					el.click()
+					return null
+				case 'text':
+					return functions.text.call(el)
+				}
+			} catch (e) {
+				throw new Error('batch op ' + i + ' ' + op.op + ': ' + e.message)
+			}
+		})
+	}`,
+	Dependencies: []*js.Function{js.InputEvent, js.Text},
+}
+
+type batchOp struct {
+	Op   string `json:"op"`
+	El   int    `json:"el"`
+	Text string `json:"text,omitempty"`
+
+	out *string
+}
+
+// Batch creates an empty Batch for the page
+// Batch 为页面创建一个空的 Batch
+func (p *Page) Batch() *Batch {
+	return &Batch{page: p, index: map[*Element]int{}}
+}
+
+// Focus the element
+// 聚焦到元素上
+func (b *Batch) Focus(el *Element) *Batch {
+	return b.add("focus", el, "", nil)
+}
+
+// Input focuses the element and inserts the text at the cursor, then dispatches the input and change events
+// Input 聚焦到元素上并在光标处插入文本，然后派发 input 和 change 事件
+func (b *Batch) Input(el *Element, text string) *Batch {
+	return b.add("input", el, text, nil)
+}
+
+// Click dispatches the click event on the element
+// Click 在元素上派发 click 事件
+func (b *Batch) Click(el *Element) *Batch {
+	return b.add("click", el, "", nil)
+}
+
+// Text of the element will be written to out after Batch.Do
+// 元素的文本会在 Batch.Do 之后写入 out
+func (b *Batch) Text(el *Element, out *string) *Batch {
+	return b.add("text", el, "", out)
+}
+
+// Len of the queued actions
+// 排队的操作数量
+func (b *Batch) Len() int {
+	return len(b.ops)
+}
+
+// Do flushes the queued actions in order, it stops at the first failed action and returns its error.
+// The queue will be empty after it, so the batch can be reused.
+// Do 按顺序执行排队的操作，它会在第一个失败的操作处停止并返回它的错误。之后队列会被清空，所以 batch 可以被复用。
+func (b *Batch) Do() error {
+	ops, els, err := b.ops, b.els, b.err
+	b.ops, b.els, b.index, b.err = nil, nil, map[*Element]int{}, nil
+
+	if err != nil {
+		return err
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	args := []interface{}{ops}
+	for _, el := range els {
+		args = append(args, el.Object)
+	}
+
+	res, err := b.page.Evaluate(evalHelper(jsBatch, args...).ByUser())
+	if err != nil {
+		return err
+	}
+
+	list := res.Value.Arr()
+	for i, op := range ops {
+		if op.out != nil && i < len(list) {
+			*op.out = list[i].Str()
+		}
+	}
+	return nil
+}
+
+func (b *Batch) add(op string, el *Element, text string, out *string) *Batch {
+	if b.err != nil {
+		return b
+	}
+
+	// the objects of the elements only work in the js context of their own frame
+	// 元素的对象只在它们自己 frame 的 js 上下文中有效
+	if el.page.SessionID != b.page.SessionID || el.page.FrameID != b.page.FrameID {
+		b.err = &ErrBatchFrame{Op: len(b.ops), Element: el}
+		return b
+	}
+
+	i, has := b.index[el]
+	if !has {
+		i = len(b.els)
+		b.els = append(b.els, el)
+		b.index[el] = i
+	}
+	b.ops = append(b.ops, &batchOp{Op: op, El: i, Text: text, out: out})
+	return b
+}
@@ -0,0 +1,40 @@
+package rod_test
+
+import (
+	"testing"
+
+	"github.com/go-rod/rod"
+)
+
+func TestBatch(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.blank()).MustSetDocumentContent(
+		`<input type="text"><button onclick="document.title = 'clicked'">ok</button>`,
+	)
+	el := p.MustElement("input")
+	btn := p.MustElement("button")
+
+	var text string
+	b := p.Batch().Focus(el).Input(el, "abc").Click(btn).Text(el, &text)
+	g.Eq(b.Len(), 4)
+	b.MustDo()
+
+	g.Eq(text, "abc")
+	g.Eq(p.MustInfo().Title, "clicked")
+	g.Eq(b.Len(), 0)
+	g.Nil(b.Do())
+
+	// a text node has no click method
+	node := p.MustElementByJS(`() => document.createTextNode('x')`)
+	err := p.Batch().Text(el, &text).Click(node).Do()
+	g.Has(err.Error(), "batch op 1 click")
+
+	// the element in an iframe belongs to another frame
+	frame := g.page.MustNavigate(g.srcFile("fixtures/click-iframe.html")).MustElement("iframe").MustFrame()
+	btn = frame.MustElement("button")
+	b = g.page.Batch().Click(btn)
+	g.Is(b.Do(), &rod.ErrBatchFrame{})
+	g.Eq(b.Len(), 0)
+	g.Nil(frame.Batch().Click(btn).Do())
+}
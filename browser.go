@@ -39,17 +39,33 @@ type Browser struct {
 
 	sleeper func() utils.Sleeper
 
-	logger utils.Logger
+	logger        utils.LeveledLogger
+	plainLogger   utils.Logger
+	logSubsystems map[LogSubsystem]bool
+	tracer        Tracer
 
-	slowMotion time.Duration // 查看 defaults.slow
-	trace      bool          // 查看 defaults.Trace
-	monitor    string
+	strictSessions bool
+	protocolPin    *ProtocolPin
+
+	experimentalPolicy MethodPolicy
+	deprecatedPolicy   MethodPolicy
+
+	validation *proto.Protocol
+
+	cdpMiddlewares []CDPMiddleware
+
+	slowMotion   time.Duration // 查看 defaults.slow
+	trace        bool          // 查看 defaults.Trace
+	traceOverlay *TraceOverlayOptions
+	monitor      string
 
 	defaultDevice devices.Device
 
 	controlURL  string
+	reconnect   *ReconnectPolicy
+	crashPolicy *CrashPolicy
 	client      CDPClient
-	event       *goob.Observable // 来自cdp客户端的所有浏览器事件
+	router      *sessionRouter // 来自cdp客户端的所有浏览器事件，被所有克隆共享
 	targetsLock *sync.Mutex
 
 	// 存储之前所有相同类型的cdp调用。浏览器没有足够的API让我们检索它所有的内部状态。这是一个变通办法，把它们映射到本地。
@@ -67,16 +83,19 @@ func New() *Browser {
 		slowMotion:    defaults.Slow,
 		trace:         defaults.Trace,
 		monitor:       defaults.Monitor,
-		logger:        DefaultLogger,
+		logger:        utils.Leveled(DefaultLogger, utils.LogDebug),
+		plainLogger:   DefaultLogger,
+		logSubsystems: map[LogSubsystem]bool{LogTrace: true},
 		defaultDevice: devices.LaptopWithMDPIScreen.Landescape(),
 		targetsLock:   &sync.Mutex{},
+		router:        newSessionRouter(),
 		states:        &sync.Map{},
 	}).WithPanic(utils.Panic)
 }
 
 // Incognito 创建了一个无痕浏览器
 func (b *Browser) Incognito() (*Browser, error) {
-	res, err := proto.TargetCreateBrowserContext{}.Call(b)
+	res, err := proto.TargetCreateBrowserContext{}.Call(rodCall(b))
 	if err != nil {
 		return nil, err
 	}
@@ -93,6 +112,19 @@ func (b *Browser) ControlURL(url string) *Browser {
 	return b
 }
 
+// Reconnect 启用自动重连。当与浏览器的 websocket 连接断开时（例如网络抖动、代理重启），
+// 它会按照策略重新连接控制URL，重新附加之前附加的目标，并从 state 中恢复已启用的 domain，
+// 正在等待的事件和调用会在重连后继续。policy 为 nil 时使用默认策略。
+// 重连前创建的远程对象（例如 Element）会失效，需要重新获取。
+// 它只在 Connect 之前调用并且没有设置 Client 时有效。
+func (b *Browser) Reconnect(policy *ReconnectPolicy) *Browser {
+	if policy == nil {
+		policy = &ReconnectPolicy{}
+	}
+	b.reconnect = policy
+	return b
+}
+
 // SlowMotion设置每个控制动作的延迟，如模拟人的输入。
 func (b *Browser) SlowMotion(delay time.Duration) *Browser {
 	b.slowMotion = delay
@@ -111,9 +143,11 @@ func (b *Browser) Monitor(url string) *Browser {
 	return b
 }
 
-// Logger覆盖了默认的日志功能，用于追踪
+// Logger覆盖了默认的日志功能，用于追踪。追踪的日志仍然以 l.Println(类型, 信息..., 页面或元素) 的形式输出，
+// 其他子系统的日志会通过 utils.Leveled(l, utils.LogDebug) 输出
 func (b *Browser) Logger(l utils.Logger) *Browser {
-	b.logger = l
+	b.LeveledLogger(utils.Leveled(l, utils.LogDebug))
+	b.plainLogger = l
 	return b
 }
 
@@ -139,7 +173,8 @@ func (b *Browser) NoDefaultDevice() *Browser {
 // Connect 用于连接浏览器并且控制浏览器.
 // 如果连接失败，尝试启动一个本地浏览器，如果没有找到本地浏览器，尝试下载一个。
 func (b *Browser) Connect() error {
-	if b.client == nil {
+	opened := b.client == nil
+	if opened {
 		u := b.controlURL
 		if u == "" {
 			var err error
@@ -149,11 +184,24 @@ func (b *Browser) Connect() error {
 			}
 		}
 
-		c, err := cdp.StartWithURL(b.ctx, u, nil)
-		if err != nil {
-			return err
+		if b.reconnect == nil {
+			c, err := cdp.StartWithURL(b.ctx, u, nil)
+			if err != nil {
+				return err
+			}
+			b.client = c
+		} else {
+			var relaunch func() (string, error)
+			if b.crashPolicy != nil {
+				relaunch = b.crashPolicy.Relaunch
+			}
+			c, err := newReconnectClient(b.ctx, u, b.reconnect, relaunch, b.states)
+			if err != nil {
+				return err
+			}
+			b.client = c
 		}
-		b.client = c
+		b.states.Store(connectedURLKey{}, u)
 	}
 
 	b.initEvents()
@@ -162,15 +210,25 @@ func (b *Browser) Connect() error {
 		launcher.Open(b.ServeMonitor(b.monitor))
 	}
 
-	return proto.TargetSetDiscoverTargets{Discover: true}.Call(b)
+	err := proto.TargetSetDiscoverTargets{Discover: true}.Call(b)
+	if err != nil {
+		return err
+	}
+
+	err = b.checkProtocolPin()
+	if err != nil && opened {
+		b.closeClient()
+	}
+	return err
 }
 
 // Close 关闭浏览器
 func (b *Browser) Close() error {
 	if b.BrowserContextID == "" {
+		b.states.Store(closedKey{}, true)
 		return proto.BrowserClose{}.Call(b)
 	}
-	return proto.TargetDisposeBrowserContext{BrowserContextID: b.BrowserContextID}.Call(b)
+	return proto.TargetDisposeBrowserContext{BrowserContextID: b.BrowserContextID}.Call(rodCall(b))
 }
 
 // Page 创建一个新的浏览器标签。如果opts.URL为空，默认值将是 "about:blank"。
@@ -183,6 +241,8 @@ func (b *Browser) Page(opts proto.TargetCreateTarget) (p *Page, err error) {
 	if err != nil {
 		return nil, err
 	}
+	// 浏览器能够创建目标，说明它已经从崩溃中恢复
+	b.states.Delete(crashKey{})
 	defer func() {
 		// 如果Navigate或PageFromTarget失败，我们应该关闭目标以防止泄漏
 		if err != nil {
@@ -227,13 +287,121 @@ func (b *Browser) Pages() (Pages, error) {
 	return pageList, nil
 }
 
+// ActivatePage 激活第一个 matcher 返回 true 的页面，matcher 的参数是页面的目标信息，例如 URL 和标题。
+// 如果没有匹配的页面，将返回 ErrPageNotFound。
+func (b *Browser) ActivatePage(matcher func(*proto.TargetTargetInfo) bool) (*Page, error) {
+	list, err := b.Targets(proto.TargetTargetInfoTypePage)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range list {
+		if !matcher(info) {
+			continue
+		}
+
+		page, err := b.PageFromTarget(info.TargetID)
+		if err != nil {
+			return nil, err
+		}
+		return page.Activate()
+	}
+
+	return nil, &ErrPageNotFound{}
+}
+
+// Targets 检索浏览器的所有目标，包括 page 以外的目标，例如 worker、扩展的后台页面、service worker 等。
+// 如果指定了 types，则只返回这些类型的目标。
+func (b *Browser) Targets(types ...proto.TargetTargetInfoType) ([]*proto.TargetTargetInfo, error) {
+	list, err := proto.TargetGetTargets{}.Call(b)
+	if err != nil {
+		return nil, err
+	}
+
+	match := targetTypeMatcher(types)
+
+	targets := []*proto.TargetTargetInfo{}
+	for _, info := range list.TargetInfos {
+		if match(info.Type) {
+			targets = append(targets, info)
+		}
+	}
+	return targets, nil
+}
+
+// OnTargetCreated 订阅整个浏览器的目标创建事件，例如新标签页、弹出窗口、worker 等。
+// 如果指定了 types，则只有这些类型的目标会触发 fn。调用返回的 cancel 函数来取消订阅。
+func (b *Browser) OnTargetCreated(fn func(*proto.TargetTargetInfo), types ...proto.TargetTargetInfoType) (cancel func()) {
+	match := targetTypeMatcher(types)
+
+	b, cancel = b.WithCancel()
+	go b.EachEvent(func(e *proto.TargetTargetCreated) {
+		if match(e.TargetInfo.Type) {
+			fn(e.TargetInfo)
+		}
+	})()
+
+	return
+}
+
+// OnTargetDestroyed 订阅整个浏览器的目标销毁事件。调用返回的 cancel 函数来取消订阅。
+func (b *Browser) OnTargetDestroyed(fn func(proto.TargetTargetID)) (cancel func()) {
+	b, cancel = b.WithCancel()
+	go b.EachEvent(func(e *proto.TargetTargetDestroyed) {
+		fn(e.TargetID)
+	})()
+
+	return
+}
+
+func targetTypeMatcher(types []proto.TargetTargetInfoType) func(proto.TargetTargetInfoType) bool {
+	return func(t proto.TargetTargetInfoType) bool {
+		if len(types) == 0 {
+			return true
+		}
+		for _, tt := range types {
+			if tt == t {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 // Call 用于直接调用原始cdp接口
 func (b *Browser) Call(ctx context.Context, sessionID, methodName string, params interface{}) (res []byte, err error) {
-	res, err = b.client.Call(ctx, sessionID, methodName, params)
-	if err != nil {
+	ctx, end := b.startSpan(ctx, "cdp "+methodName, func() map[string]interface{} {
+		return map[string]interface{}{"method": methodName, "session_id": sessionID}
+	})
+	defer func() { end(err) }()
+
+	if b.strictSessions && sessionID != "" && !b.router.owns(proto.TargetSessionID(sessionID)) {
+		return nil, &ErrSessionMisuse{SessionID: proto.TargetSessionID(sessionID), Method: methodName}
+	}
+
+	if err = b.gateMethod(ctx, methodName); err != nil {
+		return nil, err
+	}
+
+	if err = b.validateParams(methodName, params); err != nil {
 		return nil, err
 	}
 
+	logResult := b.logCall(sessionID, methodName, params)
+	res, err = b.callClient(ctx, sessionID, methodName, params)
+	logResult(res, err)
+	if err != nil {
+		b.log(LogCDP).Debug("call", "method", methodName, "session", sessionID, "err", err)
+	} else {
+		b.log(LogCDP).Debug("call", "method", methodName, "session", sessionID)
+	}
+	if err != nil {
+		b.warnMissingMethod(methodName, err)
+		return nil, b.crashErr(err)
+	}
+
+	b.validateResult(methodName, res)
+	b.router.track(methodName, params, res)
 	b.set(proto.TargetSessionID(sessionID), methodName, params)
 	return
 }
@@ -248,6 +416,8 @@ func (b *Browser) PageFromSession(sessionID proto.TargetSessionID) *Page {
 		sleeper:       b.sleeper,
 		browser:       b,
 		SessionID:     sessionID,
+
+		inputRecording: &inputRecording{},
 	}
 }
 
@@ -283,10 +453,12 @@ func (b *Browser) PageFromTarget(targetID proto.TargetTargetID) (*Page, error) {
 		jsCtxLock:     &sync.Mutex{},
 		jsCtxID:       new(proto.RuntimeRemoteObjectID),
 		helpersLock:   &sync.Mutex{},
+
+		inputRecording: &inputRecording{},
 	}
 
 	page.root = page
-	page.newKeyboard().newMouse().newTouch()
+	page.newKeyboard().newMouse().newTouch().newPen()
 
 	if !b.defaultDevice.IsClear() {
 		err = page.Emulate(b.defaultDevice)
@@ -311,13 +483,29 @@ func (b *Browser) EachEvent(callbacks ...interface{}) (wait func()) {
 	return b.eachEvent("", callbacks...)
 }
 
+// EachEventContext 类似于 Browser.EachEvent，但是等待函数接受一个 ctx，当 ctx 结束时返回 ctx 的错误，
+// 当事件流在任何回调返回 true 之前关闭时返回 ErrWaitAborted，例如连接断开。
+func (b *Browser) EachEventContext(callbacks ...interface{}) (wait func(ctx context.Context) error) {
+	return b.eachEventContext("", callbacks...)
+}
+
 // WaitEvent 等待下一个事件的发生，时间为一次。它也会将数据加载到事件对象中。
 func (b *Browser) WaitEvent(e proto.Event) (wait func()) {
 	return b.waitEvent("", e)
 }
 
+// WaitEventContext 类似于 Browser.WaitEvent，等待函数的错误与 Browser.EachEventContext 相同。
+func (b *Browser) WaitEventContext(e proto.Event) (wait func(ctx context.Context) error) {
+	return b.waitEventContext("", e)
+}
+
 // 等待下一个事件的发生，等待一次。它也会将数据加载到事件对象中。
 func (b *Browser) waitEvent(sessionID proto.TargetSessionID, e proto.Event) (wait func()) {
+	w := b.waitEventContext(sessionID, e)
+	return func() { _ = w(context.Background()) }
+}
+
+func (b *Browser) waitEventContext(sessionID proto.TargetSessionID, e proto.Event) (wait func(ctx context.Context) error) {
 	valE := reflect.ValueOf(e)
 	valTrue := reflect.ValueOf(true)
 
@@ -337,13 +525,21 @@ func (b *Browser) waitEvent(sessionID proto.TargetSessionID, e proto.Event) (wai
 		return []reflect.Value{valTrue}
 	})
 
-	return b.eachEvent(sessionID, fnVal.Interface())
+	return b.eachEventContext(sessionID, fnVal.Interface())
 }
 
 // 如果任何回调返回true，事件循环将停止。
 // 如果没有启用相关的domain，它将启用相domain，并在等待结束后恢复这些domain。
 func (b *Browser) eachEvent(sessionID proto.TargetSessionID, callbacks ...interface{}) (wait func()) {
+	w := b.eachEventContext(sessionID, callbacks...)
+	return func() { _ = w(context.Background()) }
+}
+
+func (b *Browser) eachEventContext(
+	sessionID proto.TargetSessionID, callbacks ...interface{},
+) (wait func(ctx context.Context) error) {
 	cbMap := map[string]reflect.Value{}
+	methods := []string{}
 	restores := []func(){}
 
 	for _, cb := range callbacks {
@@ -351,6 +547,7 @@ func (b *Browser) eachEvent(sessionID proto.TargetSessionID, callbacks ...interf
 		eType := cbVal.Type().In(0)
 		name := reflect.New(eType.Elem()).Interface().(proto.Event).ProtoEvent()
 		cbMap[name] = cbVal
+		methods = append(methods, name)
 
 		// 只有启用的domain才会向cdp客户端发出事件。
 		// 如果没有启用相关domain，我们就为事件类型启用domain。
@@ -363,9 +560,9 @@ func (b *Browser) eachEvent(sessionID proto.TargetSessionID, callbacks ...interf
 	}
 
 	b, cancel := b.WithCancel()
-	messages := b.Event()
+	messages := b.messages(b.router.subscribeMethods(b.ctx, sessionID, methods, false))
 
-	return func() {
+	return func(ctx context.Context) error {
 		if messages == nil {
 			panic("can't use wait function twice")
 		}
@@ -378,22 +575,38 @@ func (b *Browser) eachEvent(sessionID proto.TargetSessionID, callbacks ...interf
 			}
 		}()
 
-		for msg := range messages {
+		for {
+			var msg *Message
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case m, ok := <-messages:
+				if !ok {
+					return &ErrWaitAborted{}
+				}
+				msg = m
+			}
+
 			if !(sessionID == "" || msg.SessionID == sessionID) {
 				continue
 			}
 
 			if cbVal, has := cbMap[msg.Method]; has {
-				e := reflect.New(proto.GetType(msg.Method))
-				msg.Load(e.Interface().(proto.Event))
-				args := []reflect.Value{e}
+				// use the generated constructor, unless the callback uses another type of the event
+				// 使用生成的构造函数，除非回调使用的是该事件的另一种类型
+				e := proto.NewEvent(msg.Method)
+				if eType := cbVal.Type().In(0); e == nil || reflect.TypeOf(e) != eType {
+					e = reflect.New(eType.Elem()).Interface().(proto.Event)
+				}
+				msg.Load(e)
+				args := []reflect.Value{reflect.ValueOf(e)}
 				if cbVal.Type().NumIn() == 2 {
 					args = append(args, reflect.ValueOf(msg.SessionID))
 				}
 				res := cbVal.Call(args)
 				if len(res) > 0 {
 					if res[0].Bool() {
-						return
+						return nil
 					}
 				}
 			}
@@ -403,7 +616,16 @@ func (b *Browser) eachEvent(sessionID proto.TargetSessionID, callbacks ...interf
 
 // Event 浏览器事件
 func (b *Browser) Event() <-chan *Message {
-	src := b.event.Subscribe(b.ctx)
+	return b.messages(b.router.subscribeMethods(b.ctx, "", nil, true))
+}
+
+// the events of the session in order, the channel will be closed when the session is detached
+// session 的事件，按顺序送达，当 session 被分离时通道会被关闭
+func (b *Browser) sessionEvent(sessionID proto.TargetSessionID) <-chan *Message {
+	return b.messages(b.router.subscribe(b.ctx, sessionID))
+}
+
+func (b *Browser) messages(src goob.Events) <-chan *Message {
 	dst := make(chan *Message)
 	go func() {
 		defer close(dst)
@@ -428,24 +650,30 @@ func (b *Browser) Event() <-chan *Message {
 
 func (b *Browser) initEvents() {
 	ctx, cancel := context.WithCancel(b.ctx)
-	b.event = goob.New(ctx)
+	b.router.start(ctx)
 	event := b.client.Event()
 
 	go func() {
 		defer cancel()
 		for e := range event {
-			b.event.Publish(&Message{
+			b.watchCrash(e)
+			b.logEvent(e.SessionID, e.Method, e.Params)
+			if b.logSubsystems[LogCDP] {
+				b.log(LogCDP).Debug("event", "method", e.Method, "session", e.SessionID)
+			}
+			b.router.publish(&Message{
 				SessionID: proto.TargetSessionID(e.SessionID),
 				Method:    e.Method,
 				lock:      &sync.Mutex{},
 				data:      e.Params,
 			})
 		}
+		b.lostConnection()
 	}()
 }
 
 func (b *Browser) pageInfo(id proto.TargetTargetID) (*proto.TargetTargetInfo, error) {
-	res, err := proto.TargetGetTargetInfo{TargetID: id}.Call(b)
+	res, err := proto.TargetGetTargetInfo{TargetID: id}.Call(rodCall(b))
 	if err != nil {
 		return nil, err
 	}
@@ -454,12 +682,12 @@ func (b *Browser) pageInfo(id proto.TargetTargetID) (*proto.TargetTargetInfo, er
 
 // IgnoreCertErrors 开关。如果启用，所有证书错误将被忽略。
 func (b *Browser) IgnoreCertErrors(enable bool) error {
-	return proto.SecuritySetIgnoreCertificateErrors{Ignore: enable}.Call(b)
+	return proto.SecuritySetIgnoreCertificateErrors{Ignore: enable}.Call(rodCall(b))
 }
 
 // GetCookies 从浏览器获取Cookie
 func (b *Browser) GetCookies() ([]*proto.NetworkCookie, error) {
-	res, err := proto.StorageGetCookies{BrowserContextID: b.BrowserContextID}.Call(b)
+	res, err := proto.StorageGetCookies{BrowserContextID: b.BrowserContextID}.Call(rodCall(b))
 	if err != nil {
 		return nil, err
 	}
@@ -469,19 +697,47 @@ func (b *Browser) GetCookies() ([]*proto.NetworkCookie, error) {
 // SetCookies 为浏览器设置Cookie，如果Cookie为nil则将所有Cookie清零
 func (b *Browser) SetCookies(cookies []*proto.NetworkCookieParam) error {
 	if cookies == nil {
-		return proto.StorageClearCookies{BrowserContextID: b.BrowserContextID}.Call(b)
+		return proto.StorageClearCookies{BrowserContextID: b.BrowserContextID}.Call(rodCall(b))
 	}
 
 	return proto.StorageSetCookies{
 		Cookies:          cookies,
 		BrowserContextID: b.BrowserContextID,
-	}.Call(b)
+	}.Call(rodCall(b))
 }
 
 // WaitDownload 返回一个helper，以获得下一个下载文件。
 // 文件路径:
 //     filepath.Join(dir, info.GUID)
+// 如果需要处理并发的下载，请使用 Browser.DownloadManager 。
+// 如果需要观察下载的进度或者取消下载，请使用 Browser.WaitDownloadBegin 。
 func (b *Browser) WaitDownload(dir string) func() (info *proto.PageDownloadWillBegin) {
+	return b.WaitDownloadMatch(dir, func(*proto.PageDownloadWillBegin) bool { return true })
+}
+
+// WaitDownloadMatch 类似于 WaitDownload，但是只等待 match 返回 true 的下载完成，其他的下载会被忽略。
+// 当页面触发了多个下载时，可以用它等待特定的下载，例如：
+//     b.WaitDownloadMatch(dir, func(e *proto.PageDownloadWillBegin) bool {
+//         return strings.HasSuffix(e.SuggestedFilename, ".pdf")
+//     })
+func (b *Browser) WaitDownloadMatch(dir string, match func(*proto.PageDownloadWillBegin) bool) func() (info *proto.PageDownloadWillBegin) {
+	wait := b.WaitDownloadMatchContext(dir, match)
+	return func() *proto.PageDownloadWillBegin {
+		info, _ := wait(context.Background())
+		return info
+	}
+}
+
+// WaitDownloadContext 类似于 Browser.WaitDownload，但是等待函数接受一个 ctx，它的错误与 Browser.EachEventContext 相同
+func (b *Browser) WaitDownloadContext(dir string) func(ctx context.Context) (*proto.PageDownloadWillBegin, error) {
+	return b.WaitDownloadMatchContext(dir, func(*proto.PageDownloadWillBegin) bool { return true })
+}
+
+// WaitDownloadMatchContext 类似于 Browser.WaitDownloadMatch，但是等待函数接受一个 ctx，
+// 它的错误与 Browser.EachEventContext 相同
+func (b *Browser) WaitDownloadMatchContext(
+	dir string, match func(*proto.PageDownloadWillBegin) bool,
+) func(ctx context.Context) (*proto.PageDownloadWillBegin, error) {
 	var oldDownloadBehavior proto.BrowserSetDownloadBehavior
 	has := b.LoadState("", &oldDownloadBehavior)
 
@@ -489,31 +745,62 @@ func (b *Browser) WaitDownload(dir string) func() (info *proto.PageDownloadWillB
 		Behavior:         proto.BrowserSetDownloadBehaviorBehaviorAllowAndName,
 		BrowserContextID: b.BrowserContextID,
 		DownloadPath:     dir,
-	}.Call(b)
+	}.Call(rodCall(b))
 
 	var start *proto.PageDownloadWillBegin
 
-	waitProgress := b.EachEvent(func(e *proto.PageDownloadWillBegin) {
-		start = e
+	waitProgress := b.EachEventContext(func(e *proto.PageDownloadWillBegin) {
+		if start == nil && match(e) {
+			start = e
+		}
 	}, func(e *proto.PageDownloadProgress) bool {
 		return start != nil && start.GUID == e.GUID && e.State == proto.PageDownloadProgressStateCompleted
 	})
 
-	return func() *proto.PageDownloadWillBegin {
+	return func(ctx context.Context) (*proto.PageDownloadWillBegin, error) {
 		defer func() {
 			if has {
-				_ = oldDownloadBehavior.Call(b)
+				_ = oldDownloadBehavior.Call(rodCall(b))
 			} else {
 				_ = proto.BrowserSetDownloadBehavior{
 					Behavior:         proto.BrowserSetDownloadBehaviorBehaviorDefault,
 					BrowserContextID: b.BrowserContextID,
-				}.Call(b)
+				}.Call(rodCall(b))
 			}
 		}()
 
-		waitProgress()
+		err := waitProgress(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return start, nil
+	}
+}
+
+// WaitDownloadBegin 类似于 WaitDownload，但是下载一开始等待函数就会返回该下载，
+// 这样就可以通过 Download.OnProgress 和 Download.Status 观察它的进度，或者通过 Download.Cancel 取消它。
+// 下载结束后会恢复下载行为。
+func (b *Browser) WaitDownloadBegin(dir string) (wait func() (*Download, error)) {
+	m, err := b.DownloadManager(dir)
+	if err != nil {
+		return func() (*Download, error) { return nil, err }
+	}
+
+	waitBegin := m.WaitBegin()
+
+	return func() (*Download, error) {
+		d := waitBegin()
+		if d == nil {
+			_ = m.Close()
+			return nil, m.ctx.Err()
+		}
+
+		go func() {
+			_ = d.Wait()
+			_ = m.Close()
+		}()
 
-		return start
+		return d, nil
 	}
 }
 
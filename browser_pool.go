@@ -0,0 +1,239 @@
+package rod
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// ErrPoolClosed is returned when a page is requested after the ManagedBrowserPool.Cleanup or
+// the ManagedPagePool.Cleanup
+// ErrPoolClosed 在 ManagedBrowserPool.Cleanup 或者 ManagedPagePool.Cleanup 之后请求页面时返回
+var ErrPoolClosed = errors.New("the pool is closed")
+
+// BrowserPoolOptions for NewManagedBrowserPool
+// NewManagedBrowserPool 的选项
+type BrowserPoolOptions struct {
+	// Limit of the browsers, including the retired ones that are waiting for their pages to be put back,
+	// 0 means no limit
+	// Limit 是浏览器的数量，包括正在等待其页面被放回的已退役浏览器，0 表示没有限制
+	Limit int
+
+	// Launch a new connected browser for the pool, default is to launch one via Browser.Connect
+	// Launch 为池启动一个新的已连接的浏览器，默认通过 Browser.Connect 启动一个
+	Launch func() (*Browser, error)
+
+	// MaxPages of each browser at the same time, 0 means no limit
+	// MaxPages 是每个浏览器同时打开的页面数量，0 表示没有限制
+	MaxPages int
+
+	// RestartAfterPages retires a browser after it has opened that many pages, 0 means no limit
+	// RestartAfterPages 在浏览器打开了这么多页面之后让它退役，0 表示没有限制
+	RestartAfterPages int
+
+	// RestartAfter retires a browser after it has been running for the duration, 0 means no limit
+	// RestartAfter 在浏览器运行了这么长时间之后让它退役，0 表示没有限制
+	RestartAfter time.Duration
+}
+
+// ManagedBrowserPool is similar to BrowserPool, but it owns the browsers. It launches the browsers on demand,
+// limits the pages of each browser, and restarts the browsers to contain their memory growth:
+// a retired browser won't open new pages, it will be closed after all its pages are put back.
+// ManagedBrowserPool 类似于 BrowserPool，但是它拥有这些浏览器。它按需启动浏览器，限制每个浏览器的页面数量，
+// 并通过重启浏览器来控制它们的内存增长：已退役的浏览器不会再打开新页面，它会在所有页面被放回之后关闭。
+type ManagedBrowserPool struct {
+	opts BrowserPoolOptions
+
+	lock      sync.Mutex
+	cond      *sync.Cond
+	list      []*pooledBrowser
+	launching int
+	pages     map[proto.TargetTargetID]*pooledBrowser
+	closed    bool
+}
+
+type pooledBrowser struct {
+	browser *Browser
+	pages   int // the pages in use
+	opened  int // the pages have been opened
+	since   time.Time
+	retired bool
+}
+
+// NewManagedBrowserPool instance
+// NewManagedBrowserPool 实例
+func NewManagedBrowserPool(opts BrowserPoolOptions) *ManagedBrowserPool {
+	if opts.Launch == nil {
+		opts.Launch = func() (*Browser, error) {
+			b := New()
+			return b, b.Connect()
+		}
+	}
+
+	bp := &ManagedBrowserPool{
+		opts:  opts,
+		list:  []*pooledBrowser{},
+		pages: map[proto.TargetTargetID]*pooledBrowser{},
+	}
+	bp.cond = sync.NewCond(&bp.lock)
+	return bp
+}
+
+// Page opens a page on the least loaded browser of the pool, it blocks until a page is available.
+// Use ManagedBrowserPool.Put to close the page and release it.
+// Page 在池中负载最小的浏览器上打开一个页面，它会阻塞直到有页面可用。使用 ManagedBrowserPool.Put 关闭页面并释放它。
+func (bp *ManagedBrowserPool) Page() (*Page, error) {
+	bp.lock.Lock()
+
+	for {
+		if bp.closed {
+			bp.lock.Unlock()
+			return nil, ErrPoolClosed
+		}
+
+		if pb := bp.pick(); pb != nil {
+			pb.pages++
+			pb.opened++
+			bp.lock.Unlock()
+
+			p, err := pb.browser.Page(proto.TargetCreateTarget{})
+
+			bp.lock.Lock()
+			if err != nil {
+				pb.pages--
+				bp.release(pb)
+				bp.lock.Unlock()
+				return nil, err
+			}
+			bp.pages[p.TargetID] = pb
+			bp.lock.Unlock()
+			return p, nil
+		}
+
+		if bp.canLaunch() {
+			bp.launching++
+			bp.lock.Unlock()
+
+			b, err := bp.opts.Launch()
+
+			bp.lock.Lock()
+			bp.launching--
+			if err != nil {
+				bp.cond.Broadcast()
+				bp.lock.Unlock()
+				return nil, err
+			}
+			bp.list = append(bp.list, &pooledBrowser{browser: b, since: time.Now()})
+			bp.cond.Broadcast()
+			continue
+		}
+
+		bp.cond.Wait()
+	}
+}
+
+// Put closes the page and releases it, a retired browser will be closed after its last page is put back.
+// The page can be a clone of the one from ManagedBrowserPool.Page, such as via Page.Timeout.
+// Put 关闭页面并释放它，已退役的浏览器会在它的最后一个页面被放回之后关闭。
+// 页面可以是 ManagedBrowserPool.Page 返回页面的一个克隆，例如通过 Page.Timeout 得到的。
+func (bp *ManagedBrowserPool) Put(p *Page) {
+	bp.lock.Lock()
+	pb, has := bp.pages[p.TargetID]
+	delete(bp.pages, p.TargetID)
+	bp.lock.Unlock()
+
+	if !has {
+		return
+	}
+
+	// the clone may have a canceled context
+	// 克隆的页面可能有一个已经取消的 context
+	_ = p.Context(pb.browser.ctx).Close()
+
+	bp.lock.Lock()
+	defer bp.lock.Unlock()
+	pb.pages--
+	bp.release(pb)
+}
+
+// Cleanup stops the pool from opening new pages, waits for the pages in use to be put back,
+// then closes all the browsers
+// Cleanup 停止池打开新页面，等待正在使用的页面被放回，然后关闭所有浏览器
+func (bp *ManagedBrowserPool) Cleanup() {
+	bp.lock.Lock()
+	bp.closed = true
+	bp.cond.Broadcast()
+
+	for len(bp.pages) > 0 || bp.launching > 0 {
+		bp.cond.Wait()
+	}
+
+	list := bp.list
+	bp.list = []*pooledBrowser{}
+	bp.lock.Unlock()
+
+	for _, pb := range list {
+		_ = pb.browser.Close()
+	}
+}
+
+// the least loaded browser that can open a new page, the caller must hold the lock.
+// The idle browsers that are retired by time are closed, so they won't occupy the Limit.
+// 可以打开新页面的负载最小的浏览器，调用者必须持有锁。因时间而退役的空闲浏览器会被关闭，这样它们就不会占用 Limit。
+func (bp *ManagedBrowserPool) pick() *pooledBrowser {
+	for _, pb := range append([]*pooledBrowser{}, bp.list...) {
+		bp.retire(pb)
+		if pb.retired && pb.pages == 0 {
+			bp.release(pb)
+		}
+	}
+
+	var picked *pooledBrowser
+	for _, pb := range bp.list {
+		if pb.retired || (bp.opts.MaxPages > 0 && pb.pages >= bp.opts.MaxPages) {
+			continue
+		}
+		if picked == nil || pb.pages < picked.pages {
+			picked = pb
+		}
+	}
+	return picked
+}
+
+// without the Limit, only one browser is launched at a time, the waiting pages will use it if it's not full.
+// The caller must hold the lock.
+// 没有 Limit 时，同一时间只会启动一个浏览器，如果它还没满，等待中的页面会使用它。调用者必须持有锁。
+func (bp *ManagedBrowserPool) canLaunch() bool {
+	if bp.opts.Limit <= 0 {
+		return bp.launching == 0
+	}
+	return len(bp.list)+bp.launching < bp.opts.Limit
+}
+
+func (bp *ManagedBrowserPool) retire(pb *pooledBrowser) {
+	if (bp.opts.RestartAfterPages > 0 && pb.opened >= bp.opts.RestartAfterPages) ||
+		(bp.opts.RestartAfter > 0 && time.Since(pb.since) >= bp.opts.RestartAfter) {
+		pb.retired = true
+	}
+}
+
+// close the browser if it's retired and has no page in use, the caller must hold the lock
+// 如果浏览器已退役并且没有正在使用的页面则关闭它，调用者必须持有锁
+func (bp *ManagedBrowserPool) release(pb *pooledBrowser) {
+	defer bp.cond.Broadcast()
+
+	bp.retire(pb)
+	if !pb.retired || pb.pages > 0 {
+		return
+	}
+
+	for i, it := range bp.list {
+		if it == pb {
+			bp.list = append(bp.list[:i:i], bp.list[i+1:]...)
+			break
+		}
+	}
+	go func() { _ = pb.browser.Close() }()
+}
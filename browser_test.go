@@ -1,8 +1,11 @@
 package rod_test
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"os/exec"
@@ -120,6 +123,41 @@ func TestBrowserPages(t *testing.T) {
 	})
 }
 
+func TestBrowserTargets(t *testing.T) {
+	g := setup(t)
+
+	b := g.browser
+
+	created := make(chan *proto.TargetTargetInfo, 10)
+	cancelCreated := b.OnTargetCreated(func(info *proto.TargetTargetInfo) {
+		created <- info
+	}, proto.TargetTargetInfoTypePage)
+	defer cancelCreated()
+
+	destroyed := make(chan proto.TargetTargetID, 10)
+	cancelDestroyed := b.OnTargetDestroyed(func(id proto.TargetTargetID) {
+		destroyed <- id
+	})
+	defer cancelDestroyed()
+
+	p := b.MustPage()
+	g.Eq((<-created).TargetID, p.TargetID)
+
+	list := b.MustTargets(proto.TargetTargetInfoTypePage)
+	g.Gte(len(list), 2)
+	for _, info := range list {
+		g.Eq(info.Type, proto.TargetTargetInfoTypePage)
+	}
+	g.Len(b.MustTargets(proto.TargetTargetInfoTypeSharedWorker), 0)
+	g.Gte(len(b.MustTargets()), len(list))
+
+	p.MustClose()
+	g.Eq(<-destroyed, p.TargetID)
+
+	g.mc.stubErr(1, proto.TargetGetTargets{})
+	g.Err(b.Targets())
+}
+
 func TestBrowserClearStates(t *testing.T) {
 	g := setup(t)
 
@@ -159,6 +197,26 @@ func TestBrowserWaitEvent(t *testing.T) {
 	wait()
 }
 
+type customFrameNavigated struct {
+	Frame struct {
+		URL string `json:"url"`
+	} `json:"frame"`
+}
+
+func (e customFrameNavigated) ProtoEvent() string { return "Page.frameNavigated" }
+
+func TestBrowserCustomEventType(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage()
+
+	wait := p.EachEvent(func(e *customFrameNavigated) bool {
+		return e.Frame.URL == g.blank()
+	})
+	p.MustNavigate(g.blank())
+	wait()
+}
+
 func TestBrowserCrash(t *testing.T) {
 	g := setup(t)
 
@@ -333,6 +391,29 @@ func TestWaitDownload(t *testing.T) {
 	g.Eq(content, string(data))
 }
 
+func TestWaitDownloadMatch(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+
+	s.Route("/a", ".bin", "file a")
+	s.Route("/b", ".bin", "file b")
+	s.Route("/page", ".html", fmt.Sprintf(
+		`<html><a id="a" href="%s/a" download>a</a><a id="b" href="%s/b" download>b</a></html>`,
+		s.URL(), s.URL(),
+	))
+
+	page := g.page.MustNavigate(s.URL("/page"))
+
+	wait := g.browser.MustWaitDownloadMatch(func(e *proto.PageDownloadWillBegin) bool {
+		return e.URL == s.URL("/b")
+	})
+	page.MustElement("#a").MustClick()
+	page.MustElement("#b").MustClick()
+
+	g.Eq("file b", string(wait()))
+}
+
 func TestWaitDownloadDataURI(t *testing.T) {
 	g := setup(t)
 
@@ -427,6 +508,73 @@ func TestStreamReader(t *testing.T) {
 	g.Err(err)
 }
 
+func TestStreamReaderSeek(t *testing.T) {
+	g := setup(t)
+
+	offsets := []interface{}{}
+	stubRead := func(data string, eof bool) {
+		g.mc.setCall(func(ctx context.Context, sessionID, method string, params interface{}) ([]byte, error) {
+			req, ok := params.(proto.IORead)
+			if !ok {
+				return g.mc.principal.Call(ctx, sessionID, method, params)
+			}
+			g.mc.resetCall()
+			if req.Offset == nil {
+				offsets = append(offsets, nil)
+			} else {
+				offsets = append(offsets, *req.Offset)
+			}
+			return gson.New(proto.IOReadResult{Data: data, EOF: eof}).MarshalJSON()
+		})
+	}
+
+	r := rod.NewStreamReader(g.page, "")
+
+	_, known := r.Size()
+	g.False(known)
+	_, err := r.Seek(0, io.SeekEnd)
+	g.Err(err)
+	_, err = r.Seek(-1, io.SeekStart)
+	g.Err(err)
+
+	stubRead("abc", false)
+	b := make([]byte, 2)
+	_, _ = r.Read(b)
+	g.Eq(string(b), "ab")
+	pos, err := r.Seek(0, io.SeekCurrent)
+	g.E(err)
+	g.Eq(pos, int64(2))
+
+	pos, err = r.Seek(-1, io.SeekCurrent)
+	g.E(err)
+	g.Eq(pos, int64(1))
+
+	stubRead("bc", true)
+	buf := bytes.NewBuffer(nil)
+	n, err := r.CopyTo(buf)
+	g.E(err)
+	g.Eq(n, int64(2))
+	g.Eq(buf.String(), "bc")
+	g.Eq(offsets, []interface{}{nil, 1})
+
+	size, known := r.Size()
+	g.True(known)
+	g.Eq(size, int64(3))
+
+	pos, err = r.Seek(-3, io.SeekEnd)
+	g.E(err)
+	g.Eq(pos, int64(0))
+
+	size, known = rod.NewStreamReader(g.page, "").SetSize(10).Size()
+	g.True(known)
+	g.Eq(size, int64(10))
+
+	ctx, cancel := context.WithCancel(g.Context())
+	cancel()
+	_, err = rod.NewStreamReader(g.page, "").WithContext(ctx).Read(b)
+	g.Eq(err, context.Canceled)
+}
+
 func TestBrowserConnectFailure(t *testing.T) {
 	g := setup(t)
 
@@ -448,6 +596,103 @@ func TestBrowserPool(t *testing.T) {
 	})
 }
 
+func TestManagedBrowserPool(t *testing.T) {
+	g := setup(t)
+
+	launched := 0
+	pool := rod.NewManagedBrowserPool(rod.BrowserPoolOptions{
+		Limit: 1,
+		Launch: func() (*rod.Browser, error) {
+			launched++
+			b := rod.New()
+			return b, b.Connect()
+		},
+		MaxPages:          2,
+		RestartAfterPages: 2,
+	})
+
+	p1, err := pool.Page()
+	g.E(err)
+	p2, err := pool.Page()
+	g.E(err)
+	g.Eq(p1.Browser(), p2.Browser())
+
+	// the browser is full and retired, the next page waits for it to be drained and restarted
+	wait := make(chan *rod.Page)
+	go func() {
+		p, err := pool.Page()
+		g.E(err)
+		wait <- p
+	}()
+
+	pool.Put(p1)
+	pool.Put(p2)
+
+	p3 := <-wait
+	g.Neq(p3.Browser(), p1.Browser())
+	g.Eq(launched, 2)
+
+	done := make(chan struct{})
+	go func() {
+		pool.Cleanup()
+		close(done)
+	}()
+	pool.Put(p3)
+	<-done
+
+	_, err = pool.Page()
+	g.Eq(err, rod.ErrPoolClosed)
+}
+
+func TestManagedBrowserPoolZeroOptions(t *testing.T) {
+	g := setup(t)
+
+	pool := rod.NewManagedBrowserPool(rod.BrowserPoolOptions{})
+
+	p1, err := pool.Page()
+	g.E(err)
+	p2, err := pool.Page()
+	g.E(err)
+	g.Eq(p1.Browser(), p2.Browser())
+
+	pool.Put(p1.Timeout(time.Second))
+	pool.Put(p2)
+	pool.Cleanup()
+
+	_, err = pool.Page()
+	g.Eq(err, rod.ErrPoolClosed)
+}
+
+func TestManagedBrowserPoolRestartAfter(t *testing.T) {
+	g := setup(t)
+
+	launched := []*rod.Browser{}
+	pool := rod.NewManagedBrowserPool(rod.BrowserPoolOptions{
+		Limit: 1,
+		Launch: func() (*rod.Browser, error) {
+			b := rod.New()
+			launched = append(launched, b)
+			return b, b.Connect()
+		},
+		RestartAfter: 100 * time.Millisecond,
+	})
+	defer pool.Cleanup()
+
+	p, err := pool.Page()
+	g.E(err)
+	first := p.Browser()
+	pool.Put(p)
+
+	// the idle browser is retired by time, it shouldn't block the next page
+	utils.Sleep(0.2)
+
+	p, err = pool.Page()
+	g.E(err)
+	g.Len(launched, 2)
+	g.Neq(p.Browser(), first)
+	pool.Put(p)
+}
+
 func TestOldBrowser(t *testing.T) {
 	t.Skip()
 
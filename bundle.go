@@ -0,0 +1,75 @@
+package rod
+
+import (
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// InjectBundle serves the files of the bundle under a random path of the page's current origin via HijackRouter,
+// then injects the entry file of the bundle, so that the helper libraries can be shipped with the automation.
+// Serving them from the same origin makes the relative imports of the modules work and avoids the CORS issues.
+// If module is true the entry will be injected as an ES module. The files will keep being served until stop is called.
+// Like Page.HijackRequests, it shouldn't be used with other Fetch domain interceptions of the page.
+// The bundle can be an http.Dir, or an embed.FS wrapped by http.FS .
+// InjectBundle 通过 HijackRouter 将 bundle 中的文件挂载到页面当前源的一个随机路径下，然后注入 bundle 的入口文件，
+// 这样辅助的 js 库就可以和自动化程序一起发布。从同一个源提供这些文件可以让模块的相对导入正常工作，并且避免 CORS 问题。
+// 如果 module 为 true，入口文件将作为 ES module 注入。在调用 stop 之前，这些文件会一直被提供。
+// 与 Page.HijackRequests 一样，它不应该和页面上其他的 Fetch 域拦截同时使用。
+// bundle 可以是一个 http.Dir，或者是用 http.FS 包装的 embed.FS 。
+func (p *Page) InjectBundle(bundle http.FileSystem, entry string, module bool) (stop func() error, err error) {
+	origin, err := p.origin()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := "/rod-bundle-" + utils.RandString(8) + "/"
+
+	router := p.HijackRequests()
+
+	err = router.Add(origin+prefix+"*", "", func(ctx *Hijack) {
+		name := strings.TrimPrefix(ctx.Request.URL().Path, prefix)
+
+		body, err := readBundleFile(bundle, name)
+		if err != nil {
+			ctx.Response.Payload().ResponseCode = 404
+			return
+		}
+
+		contentType := mime.TypeByExtension(path.Ext(name))
+		if strings.HasSuffix(name, ".js") || strings.HasSuffix(name, ".mjs") {
+			contentType = "text/javascript; charset=utf-8"
+		}
+		if contentType != "" {
+			ctx.Response.SetHeader("Content-Type", contentType)
+		}
+		ctx.Response.SetBody(body)
+	})
+	if err != nil {
+		_ = router.Stop()
+		return nil, err
+	}
+
+	go router.Run()
+
+	err = p.AddScript(&ScriptTagOptions{URL: origin + prefix + strings.TrimPrefix(entry, "/"), Module: module})
+	if err != nil {
+		_ = router.Stop()
+		return nil, err
+	}
+
+	return router.Stop, nil
+}
+
+func readBundleFile(bundle http.FileSystem, name string) ([]byte, error) {
+	f, err := bundle.Open("/" + name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return ioutil.ReadAll(f)
+}
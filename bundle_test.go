@@ -0,0 +1,43 @@
+package rod_test
+
+import (
+	"net/http"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestPageInjectBundle(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/", ".html", `<html></html>`)
+
+	bundle := http.FS(fstest.MapFS{
+		"main.js":    {Data: []byte(`import { x } from './lib/x.js'; window.bundled = x`)},
+		"lib/x.js":   {Data: []byte(`export const x = 'ok'`)},
+		"classic.js": {Data: []byte(`var classic = 'ok'`)},
+	})
+
+	page := g.newPage(s.URL()).MustWaitLoad()
+
+	stop := page.MustInjectBundle(bundle, "main.js", true)
+	g.Eq(page.MustEval(`() => window.bundled`).Str(), "ok")
+
+	page.MustInjectBundle(bundle, "/classic.js", false)
+	g.Eq(page.MustEval(`() => classic`).Str(), "ok")
+
+	g.Err(page.InjectBundle(bundle, "not-exists.js", false))
+
+	stop()
+
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		page.MustInjectBundle(bundle, "main.js", true)
+	})
+	g.Panic(func() {
+		g.mc.stubErr(2, proto.FetchEnable{})
+		page.MustInjectBundle(bundle, "main.js", true)
+	})
+}
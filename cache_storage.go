@@ -0,0 +1,125 @@
+package rod
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// Cache is a cache of the CacheStorage, such as the one opened by a service worker via caches.open
+// Cache 是 CacheStorage 中的一个缓存，例如 service worker 通过 caches.open 打开的缓存
+type Cache struct {
+	*proto.CacheStorageCache
+
+	page *Page
+}
+
+// the page size to request the entries
+// 请求缓存条目时的分页大小
+const cacheEntriesPageSize = 50
+
+// Caches lists the caches of the page's current origin
+// Caches 列出页面当前源的所有缓存
+func (p *Page) Caches() ([]*Cache, error) {
+	origin, err := p.origin()
+	if err != nil {
+		return nil, err
+	}
+	return p.cachesOf(origin)
+}
+
+func (p *Page) cachesOf(origin string) ([]*Cache, error) {
+	res, err := proto.CacheStorageRequestCacheNames{SecurityOrigin: origin}.Call(rodCall(p))
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*Cache{}
+	for _, c := range res.Caches {
+		list = append(list, &Cache{c, p})
+	}
+	return list, nil
+}
+
+// Cache returns the cache with the name of the page's current origin
+// Cache 返回页面当前源中指定名称的缓存
+func (p *Page) Cache(name string) (*Cache, error) {
+	list, err := p.Caches()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range list {
+		if c.CacheName == name {
+			return c, nil
+		}
+	}
+	return nil, &ErrCacheNotFound{name}
+}
+
+// Entries of the cache, if the pathFilter is not empty only the entries whose url path contains it will be returned
+// Entries 返回缓存中的条目，如果 pathFilter 不为空，则只返回 url 路径包含它的条目
+func (c *Cache) Entries(pathFilter string) ([]*proto.CacheStorageDataEntry, error) {
+	list := []*proto.CacheStorageDataEntry{}
+
+	for {
+		skip, size := len(list), cacheEntriesPageSize
+		res, err := proto.CacheStorageRequestEntries{
+			CacheID:    c.CacheID,
+			SkipCount:  &skip,
+			PageSize:   &size,
+			PathFilter: pathFilter,
+		}.Call(rodCall(c.page))
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, res.CacheDataEntries...)
+
+		if len(res.CacheDataEntries) == 0 || float64(len(list)) >= res.ReturnCount {
+			return list, nil
+		}
+	}
+}
+
+// Response returns the body of the cached response of the entry
+// Response 返回条目对应的缓存响应的内容
+func (c *Cache) Response(entry *proto.CacheStorageDataEntry) ([]byte, error) {
+	res, err := proto.CacheStorageRequestCachedResponse{
+		CacheID:        c.CacheID,
+		RequestURL:     entry.RequestURL,
+		RequestHeaders: entry.RequestHeaders,
+	}.Call(rodCall(c.page))
+	if err != nil {
+		return nil, err
+	}
+	return res.Response.Body, nil
+}
+
+// Match returns the entry and the body of the cached response of the url, the url must be the same as the
+// request url of the entry. If there's no match ErrCacheNotFound will be returned.
+// Match 返回 url 对应的条目和缓存响应的内容，url 必须与条目的请求 url 相同。如果没有匹配项，将返回 ErrCacheNotFound。
+func (c *Cache) Match(url string) (*proto.CacheStorageDataEntry, []byte, error) {
+	list, err := c.Entries("")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, e := range list {
+		if e.RequestURL == url {
+			body, err := c.Response(e)
+			return e, body, err
+		}
+	}
+	return nil, nil, &ErrCacheNotFound{url}
+}
+
+// DeleteEntry deletes the entry of the request url
+// DeleteEntry 删除请求 url 对应的条目
+func (c *Cache) DeleteEntry(url string) error {
+	return proto.CacheStorageDeleteEntry{CacheID: c.CacheID, Request: url}.Call(rodCall(c.page))
+}
+
+// Delete the whole cache
+// 删除整个缓存
+func (c *Cache) Delete() error {
+	return proto.CacheStorageDeleteCache{CacheID: c.CacheID}.Call(rodCall(c.page))
+}
@@ -0,0 +1,65 @@
+package rod_test
+
+import (
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestCacheStorage(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/", ".html", `<html></html>`)
+
+	page := g.newPage(s.URL()).MustWaitLoad()
+	page.MustEval(`async () => {
+		const cache = await caches.open("pwa")
+		for (let i = 0; i < 60; i++) {
+			await cache.put("/item/" + i, new Response("item " + i))
+		}
+		await cache.put("/app.js", new Response("app", { headers: { "Content-Type": "text/javascript" } }))
+		await caches.open("empty")
+	}`)
+	defer page.MustEval(`async () => { await caches.delete("pwa"); await caches.delete("empty") }`)
+
+	g.Len(page.MustCaches(), 2)
+
+	cache := page.MustCache("pwa")
+	g.Eq(cache.CacheName, "pwa")
+
+	// more than one page of entries
+	g.Len(cache.MustEntries(""), 61)
+	g.Len(cache.MustEntries("app"), 1)
+
+	entry, body := cache.MustMatch(s.URL("/app.js"))
+	g.Eq(entry.ResponseStatus, 200)
+	g.Eq(string(body), "app")
+	g.Eq(string(cache.MustResponse(entry)), "app")
+
+	cache.MustDeleteEntry(s.URL("/app.js"))
+	_, _, err := cache.Match(s.URL("/app.js"))
+	g.Is(err, &rod.ErrCacheNotFound{})
+
+	page.MustCache("empty").MustDelete()
+	_, err = page.Cache("empty")
+	g.Eq(err.Error(), "cannot find cache: empty")
+
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.CacheStorageRequestCacheNames{})
+		page.MustCaches()
+	})
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		page.MustCache("pwa")
+	})
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.CacheStorageRequestEntries{})
+		cache.MustMatch("/")
+	})
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.CacheStorageRequestCachedResponse{})
+		cache.MustResponse(entry)
+	})
+}
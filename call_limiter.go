@@ -0,0 +1,237 @@
+package rod
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CallPriority of a cdp call, the queued calls with higher priority will be sent first
+// CallPriority 是 cdp 调用的优先级，排队中优先级更高的调用会被先发送
+type CallPriority int
+
+const (
+	// CallPriorityLow for the polling calls, such as the evals of the waits
+	// CallPriorityLow 用于轮询的调用，例如等待中的 eval
+	CallPriorityLow CallPriority = iota
+	// CallPriorityNormal for the other calls
+	// CallPriorityNormal 用于其他调用
+	CallPriorityNormal
+	// CallPriorityHigh for the input and navigation
+	// CallPriorityHigh 用于输入和导航
+	CallPriorityHigh
+)
+
+// DefaultCallPriority is high for the "Input" domain and navigation, low for the evals and queries, normal for others
+// DefaultCallPriority 对 "Input" domain 和导航为高优先级，对 eval 和查询为低优先级，其他为普通优先级
+func DefaultCallPriority(method string) CallPriority {
+	switch {
+	case strings.HasPrefix(method, "Input."),
+		method == "Page.navigate", method == "Page.reload", method == "Page.stopLoading",
+		method == "Page.navigateToHistoryEntry":
+		return CallPriorityHigh
+	case method == "Runtime.evaluate", method == "Runtime.callFunctionOn",
+		strings.HasPrefix(method, "DOM.querySelector"), strings.HasPrefix(method, "DOM.getBoxModel"):
+		return CallPriorityLow
+	}
+	return CallPriorityNormal
+}
+
+// the calls that unblock the in-flight calls, such as an eval blocked by an alert or an infinite loop
+// 解除正在进行中的调用阻塞的调用，例如被 alert 或者死循环阻塞的 eval
+func isUnblockingCall(method string) bool {
+	return method == "Page.handleJavaScriptDialog" || method == "Runtime.terminateExecution"
+}
+
+// CallLimiterOptions for NewCallLimiter
+// NewCallLimiter 的选项
+type CallLimiterOptions struct {
+	// MaxInFlight is the max number of the in-flight calls of each session, default is 4.
+	// The calls to the browser itself are treated as a session.
+	// MaxInFlight 是每个 session 正在进行中的调用的最大数量，默认为 4。对浏览器本身的调用被视为一个 session。
+	MaxInFlight int
+
+	// Priority of the method, default is DefaultCallPriority
+	// Priority 是方法的优先级，默认为 DefaultCallPriority
+	Priority func(method string) CallPriority
+}
+
+// CallLimiterStats are the metrics of the CallLimiter
+// CallLimiterStats 是 CallLimiter 的指标
+type CallLimiterStats struct {
+	// InFlight is the number of the in-flight calls
+	// InFlight 是正在进行中的调用的数量
+	InFlight int
+
+	// Queued is the number of the waiting calls of each priority
+	// Queued 是每个优先级等待中的调用的数量
+	Queued map[CallPriority]int
+
+	// Calls is the total number of the calls
+	// Calls 是调用的总数
+	Calls int
+
+	// Waited is the number of the calls that have been queued
+	// Waited 是排过队的调用的数量
+	Waited int
+
+	// WaitTime is the total time the calls spent in the queues
+	// WaitTime 是调用在队列中花费的总时间
+	WaitTime time.Duration
+}
+
+// CallLimiter limits the in-flight cdp calls of each session, the calls over the limit will be queued by
+// their priorities, so that the heavily instrumented pages won't starve the input and navigation.
+// The calls that unblock the others, such as Page.handleJavaScriptDialog and Runtime.terminateExecution,
+// are never limited, or they may be queued behind the calls they are meant to unblock.
+// Use Browser.LimitCalls to apply it.
+// CallLimiter 限制每个 session 正在进行中的 cdp 调用，超出限制的调用会按照优先级排队，
+// 这样大量插桩的页面就不会让输入和导航处于饥饿状态。解除其他调用阻塞的调用，例如 Page.handleJavaScriptDialog
+// 和 Runtime.terminateExecution，永远不会被限制，否则它们可能会排在它们要解除阻塞的调用后面。使用 Browser.LimitCalls 应用它。
+type CallLimiter struct {
+	opts CallLimiterOptions
+
+	lock     sync.Mutex
+	sessions map[string]*callQueue
+	stats    CallLimiterStats
+}
+
+type callQueue struct {
+	inFlight int
+	waiters  map[CallPriority][]chan struct{}
+}
+
+// NewCallLimiter creates a CallLimiter
+// NewCallLimiter 创建一个 CallLimiter
+func NewCallLimiter(opts CallLimiterOptions) *CallLimiter {
+	if opts.MaxInFlight <= 0 {
+		opts.MaxInFlight = 4
+	}
+	if opts.Priority == nil {
+		opts.Priority = DefaultCallPriority
+	}
+	return &CallLimiter{opts: opts, sessions: map[string]*callQueue{}}
+}
+
+// LimitCalls returns a clone that uses the limiter for the cdp calls, see CallLimiter
+// LimitCalls 返回一个克隆，它的 cdp 调用会使用该限制器，查看 CallLimiter
+func (b *Browser) LimitCalls(l *CallLimiter) *Browser {
+	return b.UseCDPMiddleware(l.Middleware)
+}
+
+// Middleware to limit the calls, see Browser.UseCDPMiddleware
+// Middleware 用于限制调用，查看 Browser.UseCDPMiddleware
+func (l *CallLimiter) Middleware(next CallFunc) CallFunc {
+	return func(ctx context.Context, sessionID, method string, params interface{}) ([]byte, error) {
+		if isUnblockingCall(method) {
+			return next(ctx, sessionID, method, params)
+		}
+
+		err := l.acquire(ctx, sessionID, l.opts.Priority(method))
+		if err != nil {
+			return nil, err
+		}
+		defer l.release(sessionID)
+
+		return next(ctx, sessionID, method, params)
+	}
+}
+
+// Stats returns the current metrics
+// Stats 返回当前的指标
+func (l *CallLimiter) Stats() CallLimiterStats {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	s := l.stats
+	s.InFlight = 0
+	s.Queued = map[CallPriority]int{}
+	for _, q := range l.sessions {
+		s.InFlight += q.inFlight
+		for p, list := range q.waiters {
+			s.Queued[p] += len(list)
+		}
+	}
+	return s
+}
+
+func (l *CallLimiter) acquire(ctx context.Context, sessionID string, priority CallPriority) error {
+	if priority < CallPriorityLow {
+		priority = CallPriorityLow
+	} else if priority > CallPriorityHigh {
+		priority = CallPriorityHigh
+	}
+
+	l.lock.Lock()
+
+	l.stats.Calls++
+
+	q, has := l.sessions[sessionID]
+	if !has {
+		q = &callQueue{waiters: map[CallPriority][]chan struct{}{}}
+		l.sessions[sessionID] = q
+	}
+
+	if q.inFlight < l.opts.MaxInFlight {
+		q.inFlight++
+		l.lock.Unlock()
+		return nil
+	}
+
+	wait := make(chan struct{})
+	q.waiters[priority] = append(q.waiters[priority], wait)
+	l.stats.Waited++
+	l.lock.Unlock()
+
+	start := time.Now()
+	defer func() {
+		l.lock.Lock()
+		l.stats.WaitTime += time.Since(start)
+		l.lock.Unlock()
+	}()
+
+	select {
+	case <-wait:
+		return nil
+	case <-ctx.Done():
+	}
+
+	l.lock.Lock()
+	list := q.waiters[priority]
+	for i, w := range list {
+		if w == wait {
+			q.waiters[priority] = append(list[:i:i], list[i+1:]...)
+			l.lock.Unlock()
+			return ctx.Err()
+		}
+	}
+	l.lock.Unlock()
+
+	// the slot has been handed over to the call, give it to the next one
+	// 调用已经获得了位置，把它交给下一个调用
+	l.release(sessionID)
+	return ctx.Err()
+}
+
+// release the slot of the session, hand it over to the waiting call with the highest priority if there's one
+// 释放 session 的位置，如果有等待中的调用，将位置交给优先级最高的那个
+func (l *CallLimiter) release(sessionID string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	q := l.sessions[sessionID]
+
+	for p := CallPriorityHigh; p >= CallPriorityLow; p-- {
+		if list := q.waiters[p]; len(list) > 0 {
+			q.waiters[p] = list[1:]
+			close(list[0])
+			return
+		}
+	}
+
+	q.inFlight--
+	if q.inFlight == 0 {
+		delete(l.sessions, sessionID)
+	}
+}
@@ -0,0 +1,85 @@
+package rod_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+)
+
+func TestCallLimiter(t *testing.T) {
+	g := setup(t)
+
+	l := rod.NewCallLimiter(rod.CallLimiterOptions{MaxInFlight: 1})
+
+	lock := sync.Mutex{}
+	order := []string{}
+	block := make(chan struct{})
+
+	call := l.Middleware(func(ctx context.Context, sessionID, method string, params interface{}) ([]byte, error) {
+		if method == "Page.enable" {
+			<-block
+		}
+		lock.Lock()
+		order = append(order, method)
+		lock.Unlock()
+		return nil, nil
+	})
+
+	wg := sync.WaitGroup{}
+	run := func(method string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := call(g.Context(), "session", method, nil)
+			g.E(err)
+		}()
+	}
+
+	// wait until the number of the queued calls of the priority is n
+	waitQueued := func(p rod.CallPriority, n int) {
+		for l.Stats().Queued[p] != n {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	run("Page.enable")
+	for l.Stats().InFlight != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	run("Runtime.callFunctionOn")
+	waitQueued(rod.CallPriorityLow, 1)
+	run("Input.dispatchMouseEvent")
+	waitQueued(rod.CallPriorityHigh, 1)
+
+	// the other sessions aren't limited
+	_, err := call(g.Context(), "other", "DOM.getDocument", nil)
+	g.E(err)
+
+	// the dialog can be closed while the session is full
+	_, err = call(g.Context(), "session", "Page.handleJavaScriptDialog", nil)
+	g.E(err)
+
+	// the canceled call leaves the queue
+	ctx, cancel := context.WithCancel(g.Context())
+	cancel()
+	_, err = call(ctx, "session", "DOM.describeNode", nil)
+	g.Eq(err, context.Canceled)
+
+	close(block)
+	wg.Wait()
+
+	g.Eq(order, []string{"DOM.getDocument", "Page.handleJavaScriptDialog", "Page.enable", "Input.dispatchMouseEvent", "Runtime.callFunctionOn"})
+
+	s := l.Stats()
+	g.Eq(s.InFlight, 0)
+	g.Eq(s.Calls, 5)
+	g.Eq(s.Waited, 3)
+	g.Gt(s.WaitTime, time.Duration(0))
+
+	g.Eq(rod.DefaultCallPriority("Page.navigate"), rod.CallPriorityHigh)
+	g.Eq(rod.DefaultCallPriority("Network.enable"), rod.CallPriorityNormal)
+}
@@ -0,0 +1,309 @@
+package rod
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CDPLoggerOptions for Browser.CDPLogger
+// Browser.CDPLogger 的选项
+type CDPLoggerOptions struct {
+	// Writer to write the records to, if it's nil File will be used
+	// Writer 用于写入记录，如果为 nil 将使用 File
+	Writer io.Writer
+
+	// File to append the records to, it will be created if it doesn't exist
+	// File 用于追加记录，如果它不存在会被创建
+	File string
+
+	// Domains to log, such as "Page", "Network", empty means all domains
+	// Domains 是需要记录的 domain，例如 "Page"、"Network"，为空表示所有 domain
+	Domains []string
+
+	// ExcludeDomains won't be logged
+	// ExcludeDomains 中的 domain 不会被记录
+	ExcludeDomains []string
+
+	// RedactKeys are the json keys whose values will be replaced with "[REDACTED]", such as "cookies", "headers".
+	// The keys are matched case-insensitively at any depth.
+	// RedactKeys 是值会被替换为 "[REDACTED]" 的 json 键，例如 "cookies"、"headers"。键在任意深度都会被不区分大小写地匹配。
+	RedactKeys []string
+
+	// RedactPatterns are the patterns to be replaced with "[REDACTED]" in the string values, such as tokens
+	// RedactPatterns 是字符串值中需要被替换为 "[REDACTED]" 的模式，例如 token
+	RedactPatterns []*regexp.Regexp
+}
+
+// CDPRecord is a line of the NDJSON written by CDPLogger
+// CDPRecord 是 CDPLogger 写入的 NDJSON 中的一行
+type CDPRecord struct {
+	Time time.Time `json:"time"`
+
+	// Type is "call" for an outgoing call, "result" for its response, "event" for an incoming event
+	// Type 为 "call" 表示发出的调用，"result" 表示调用的响应，"event" 表示收到的事件
+	Type string `json:"type"`
+
+	// ID pairs the call and its result
+	// ID 用于配对调用和它的响应
+	ID int64 `json:"id,omitempty"`
+
+	SessionID string          `json:"sessionId,omitempty"`
+	Method    string          `json:"method"`
+	Params    json.RawMessage `json:"params,omitempty"`
+	Result    json.RawMessage `json:"result,omitempty"`
+	Error     string          `json:"error,omitempty"`
+
+	// Duration of the call in milliseconds
+	// Duration 是调用的耗时，单位为毫秒
+	Duration float64 `json:"duration,omitempty"`
+}
+
+// CDPLogger dumps the cdp traffic of a browser, use Browser.CDPLogger to create it
+// CDPLogger 输出浏览器的 cdp 通信，使用 Browser.CDPLogger 创建它
+type CDPLogger struct {
+	opts    CDPLoggerOptions
+	loggers *cdpLoggers
+	file    *os.File
+	enabled int32
+	count   int64
+
+	lock sync.Mutex
+	w    io.Writer
+}
+
+type cdpLoggersKey struct{}
+
+type cdpLoggers struct {
+	lock sync.Mutex
+	list []*CDPLogger
+}
+
+// CDPLogger starts to write every outgoing call and incoming event of the browser to opts.Writer or opts.File
+// as NDJSON, each line is a CDPRecord. It's useful to debug the protocol-level issues.
+// Use CDPLogger.Disable and CDPLogger.Enable to toggle it at runtime.
+// CDPLogger 开始将浏览器每个发出的调用和收到的事件以 NDJSON 的格式写入 opts.Writer 或 opts.File，每行是一个 CDPRecord。
+// 它可以用于调试协议层面的问题。使用 CDPLogger.Disable 和 CDPLogger.Enable 在运行时切换它。
+func (b *Browser) CDPLogger(opts CDPLoggerOptions) (*CDPLogger, error) {
+	l := &CDPLogger{opts: opts, w: opts.Writer, enabled: 1}
+
+	if l.w == nil {
+		f, err := os.OpenFile(opts.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0664)
+		if err != nil {
+			return nil, err
+		}
+		l.file = f
+		l.w = f
+	}
+
+	v, _ := b.states.LoadOrStore(cdpLoggersKey{}, &cdpLoggers{})
+	l.loggers = v.(*cdpLoggers)
+
+	l.loggers.lock.Lock()
+	l.loggers.list = append(l.loggers.list, l)
+	l.loggers.lock.Unlock()
+
+	return l, nil
+}
+
+// Enable the logger
+// 启用记录器
+func (l *CDPLogger) Enable() {
+	atomic.StoreInt32(&l.enabled, 1)
+}
+
+// Disable the logger, the records after it won't be written until it's enabled again
+// 禁用记录器，在再次启用之前，之后的记录都不会被写入
+func (l *CDPLogger) Disable() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	atomic.StoreInt32(&l.enabled, 0)
+}
+
+// Enabled tells if the logger is enabled
+// Enabled 用于判断记录器是否已启用
+func (l *CDPLogger) Enabled() bool {
+	return atomic.LoadInt32(&l.enabled) == 1
+}
+
+// Close the logger, it will be removed from the browser, the file will be closed if it's opened by the logger
+// 关闭记录器，它会从浏览器中移除，如果文件是由记录器打开的，文件会被关闭
+func (l *CDPLogger) Close() error {
+	l.loggers.lock.Lock()
+	for i, it := range l.loggers.list {
+		if it == l {
+			l.loggers.list = append(l.loggers.list[:i:i], l.loggers.list[i+1:]...)
+			break
+		}
+	}
+	l.loggers.lock.Unlock()
+
+	l.Disable()
+
+	if l.file != nil {
+		return l.file.Close()
+	}
+	return nil
+}
+
+func (l *CDPLogger) match(method string) bool {
+	domain := strings.Split(method, ".")[0]
+
+	for _, d := range l.opts.ExcludeDomains {
+		if d == domain {
+			return false
+		}
+	}
+
+	if len(l.opts.Domains) == 0 {
+		return true
+	}
+	for _, d := range l.opts.Domains {
+		if d == domain {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *CDPLogger) write(r *CDPRecord) {
+	r.Params = l.redact(r.Params)
+	r.Result = l.redact(r.Result)
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.Enabled() {
+		_, _ = l.w.Write(append(data, '\n'))
+	}
+}
+
+func (l *CDPLogger) redact(data json.RawMessage) json.RawMessage {
+	if len(data) == 0 || (len(l.opts.RedactKeys) == 0 && len(l.opts.RedactPatterns) == 0) {
+		return data
+	}
+
+	var v interface{}
+	if json.Unmarshal(data, &v) != nil {
+		return data
+	}
+
+	out, err := json.Marshal(l.redactValue(v))
+	if err != nil {
+		return data
+	}
+	return out
+}
+
+const cdpRedacted = "[REDACTED]"
+
+func (l *CDPLogger) redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			if l.redactKey(k) {
+				val[k] = cdpRedacted
+			} else {
+				val[k] = l.redactValue(child)
+			}
+		}
+	case []interface{}:
+		for i, child := range val {
+			val[i] = l.redactValue(child)
+		}
+	case string:
+		for _, reg := range l.opts.RedactPatterns {
+			val = reg.ReplaceAllString(val, cdpRedacted)
+		}
+		return val
+	}
+	return v
+}
+
+func (l *CDPLogger) redactKey(key string) bool {
+	for _, k := range l.opts.RedactKeys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// the enabled loggers that match the method
+// 与方法匹配的已启用的记录器
+func (b *Browser) cdpLoggers(method string) []*CDPLogger {
+	v, has := b.states.Load(cdpLoggersKey{})
+	if !has {
+		return nil
+	}
+	ls := v.(*cdpLoggers)
+
+	ls.lock.Lock()
+	defer ls.lock.Unlock()
+
+	var list []*CDPLogger
+	for _, l := range ls.list {
+		if l.Enabled() && l.match(method) {
+			list = append(list, l)
+		}
+	}
+	return list
+}
+
+// log the call and returns a function to log its result
+// 记录调用并返回一个用于记录其响应的函数
+func (b *Browser) logCall(sessionID, method string, params interface{}) func(res []byte, err error) {
+	list := b.cdpLoggers(method)
+	if len(list) == 0 {
+		return func([]byte, error) {}
+	}
+
+	var raw json.RawMessage
+	if params != nil {
+		raw, _ = json.Marshal(params)
+	}
+
+	start := time.Now()
+	ids := make([]int64, len(list))
+	for i, l := range list {
+		ids[i] = atomic.AddInt64(&l.count, 1)
+		l.write(&CDPRecord{
+			Time: start, Type: "call", ID: ids[i], SessionID: sessionID, Method: method, Params: raw,
+		})
+	}
+
+	return func(res []byte, err error) {
+		r := CDPRecord{
+			Time:      time.Now(),
+			Type:      "result",
+			SessionID: sessionID,
+			Method:    method,
+			Duration:  float64(time.Since(start)) / float64(time.Millisecond),
+		}
+		if err != nil {
+			r.Error = err.Error()
+		} else if json.Valid(res) {
+			r.Result = res
+		}
+		for i, l := range list {
+			rec := r
+			rec.ID = ids[i]
+			l.write(&rec)
+		}
+	}
+}
+
+func (b *Browser) logEvent(sessionID, method string, params json.RawMessage) {
+	for _, l := range b.cdpLoggers(method) {
+		l.write(&CDPRecord{Time: time.Now(), Type: "event", SessionID: sessionID, Method: method, Params: params})
+	}
+}
@@ -0,0 +1,124 @@
+package rod_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+type syncBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) records() []*rod.CDPRecord {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	list := []*rod.CDPRecord{}
+	for _, line := range strings.Split(strings.TrimSpace(b.buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		r := &rod.CDPRecord{}
+		utils.E(json.Unmarshal([]byte(line), r))
+		list = append(list, r)
+	}
+	return list
+}
+
+func TestCDPLogger(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank()).MustWaitLoad()
+
+	buf := &syncBuffer{}
+	l := g.browser.MustCDPLogger(rod.CDPLoggerOptions{
+		Writer:         buf,
+		Domains:        []string{"Runtime", "Page"},
+		RedactKeys:     []string{"expression"},
+		RedactPatterns: []*regexp.Regexp{regexp.MustCompile(`secret-\w+`)},
+	})
+	defer func() { g.E(l.Close()) }()
+
+	_, err := proto.RuntimeEvaluate{Expression: "'secret-token'", ReturnByValue: true}.Call(p)
+	g.E(err)
+	_, err = proto.BrowserGetVersion{}.Call(g.browser)
+	g.E(err)
+
+	var call, result *rod.CDPRecord
+	for _, r := range buf.records() {
+		g.Neq(r.Method, "Browser.getVersion")
+		if r.Method == "Runtime.evaluate" && r.Type == "call" {
+			call = r
+		}
+		if r.Method == "Runtime.evaluate" && r.Type == "result" {
+			result = r
+		}
+	}
+	g.NotNil(call)
+	g.NotNil(result)
+	g.Eq(call.ID, result.ID)
+	g.Eq(call.SessionID, string(p.SessionID))
+	g.Has(string(call.Params), `"expression":"[REDACTED]"`)
+	g.Has(string(result.Result), `"value":"[REDACTED]"`)
+
+	p.MustReload()
+	utils.Sleep(0.3)
+	has := false
+	for _, r := range buf.records() {
+		if r.Type == "event" && r.Method == "Page.frameNavigated" {
+			has = true
+		}
+	}
+	g.True(has)
+
+	l.Disable()
+	g.False(l.Enabled())
+	count := len(buf.records())
+	p.MustEval(`() => 1`)
+	g.Len(buf.records(), count)
+
+	l.Enable()
+	p.MustEval(`() => 1`)
+	g.Gt(len(buf.records()), count)
+}
+
+func TestCDPLoggerFile(t *testing.T) {
+	g := setup(t)
+
+	dir := filepath.Join(os.TempDir(), "rod", "cdp-logger")
+	g.E(os.MkdirAll(dir, 0755))
+	file := filepath.Join(dir, g.RandStr(8)+".ndjson")
+
+	l := g.browser.MustCDPLogger(rod.CDPLoggerOptions{File: file, ExcludeDomains: []string{"Target"}})
+	_, err := proto.BrowserGetVersion{}.Call(g.browser)
+	g.E(err)
+	g.E(l.Close())
+
+	_, err = proto.BrowserGetVersion{}.Call(g.browser)
+	g.E(err)
+
+	data, err := utils.ReadString(file)
+	g.E(err)
+	g.Eq(strings.Count(data, "Browser.getVersion"), 2)
+	g.Eq(strings.Count(data, "Target."), 0)
+
+	_, err = g.browser.CDPLogger(rod.CDPLoggerOptions{File: filepath.Join(file, "not-exists", "a")})
+	g.Err(err)
+}
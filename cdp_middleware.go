@@ -0,0 +1,29 @@
+package rod
+
+import "context"
+
+// CallFunc is the signature of the calls to the cdp client
+// CallFunc 是对 cdp 客户端调用的签名
+type CallFunc func(ctx context.Context, sessionID, method string, params interface{}) ([]byte, error)
+
+// CDPMiddleware wraps the next CallFunc, such as to log, rate limit, mutate the requests, or inject faults
+// CDPMiddleware 包装下一个 CallFunc，例如用于记录日志、限流、修改请求或者注入故障
+type CDPMiddleware func(next CallFunc) CallFunc
+
+// UseCDPMiddleware adds the middlewares around every call to the cdp client, the first one is the outermost.
+// Only the browser returned and the pages created by it will use them.
+// UseCDPMiddleware 在每次对 cdp 客户端的调用外围添加中间件，第一个中间件在最外层。
+// 只有返回的浏览器以及由它创建的页面会使用它们。
+func (b *Browser) UseCDPMiddleware(list ...CDPMiddleware) *Browser {
+	newObj := *b
+	newObj.cdpMiddlewares = append(append([]CDPMiddleware{}, b.cdpMiddlewares...), list...)
+	return &newObj
+}
+
+func (b *Browser) callClient(ctx context.Context, sessionID, method string, params interface{}) ([]byte, error) {
+	call := CallFunc(b.client.Call)
+	for i := len(b.cdpMiddlewares) - 1; i >= 0; i-- {
+		call = b.cdpMiddlewares[i](call)
+	}
+	return call(ctx, sessionID, method, params)
+}
@@ -0,0 +1,55 @@
+package rod_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestUseCDPMiddleware(t *testing.T) {
+	g := setup(t)
+
+	lock := sync.Mutex{}
+	order := []string{}
+	record := func(name string) rod.CDPMiddleware {
+		return func(next rod.CallFunc) rod.CallFunc {
+			return func(ctx context.Context, sessionID, method string, params interface{}) ([]byte, error) {
+				if method == (proto.PageNavigate{}).ProtoReq() {
+					lock.Lock()
+					order = append(order, name)
+					lock.Unlock()
+				}
+				return next(ctx, sessionID, method, params)
+			}
+		}
+	}
+
+	errFault := errors.New("fault")
+	fault := func(next rod.CallFunc) rod.CallFunc {
+		return func(ctx context.Context, sessionID, method string, params interface{}) ([]byte, error) {
+			if method == (proto.PageReload{}).ProtoReq() {
+				return nil, errFault
+			}
+			return next(ctx, sessionID, method, params)
+		}
+	}
+
+	b := g.browser.UseCDPMiddleware(record("a"), record("b")).UseCDPMiddleware(fault)
+
+	p := b.MustPage()
+	defer p.MustClose()
+
+	p.MustNavigate(g.blank())
+	g.Eq(order, []string{"a", "b"})
+
+	g.Eq(p.Reload(), errFault)
+
+	// the original browser isn't affected
+	p2 := g.browser.MustPage()
+	defer p2.MustClose()
+	g.E(p2.Reload())
+}
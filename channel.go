@@ -0,0 +1,134 @@
+package rod
+
+import (
+	"fmt"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+	"github.com/ysmood/gson"
+)
+
+// Channel is a long-lived message channel between the page js and Go, check Page.Channel for the details
+// Channel 是页面 js 和 Go 之间一个长期存在的消息通道，详情请查看 Page.Channel
+type Channel struct {
+	// Name of the channel object on the window of the page
+	// 页面 window 上通道对象的名称
+	Name string
+
+	page   *Page
+	bind   string
+	msgs   chan gson.JSON
+	remove func() error
+	cancel func()
+}
+
+// the js side of the channel, the messages from Go are queued until the first listener is registered
+// 通道的 js 端，来自 Go 的消息会被缓存，直到注册了第一个监听函数
+const channelDefinition = `(name, bind) => {
+	const send = window[bind]
+	const listeners = []
+	const queue = []
+	window[name] = {
+		send: (data) => send(JSON.stringify(data === undefined ? null : data)),
+		onMessage: (fn) => {
+			listeners.push(fn)
+			queue.splice(0).forEach((data) => fn(data))
+			return () => {
+				const i = listeners.indexOf(fn)
+				if (i > -1) listeners.splice(i, 1)
+			}
+		},
+		_push: (data) => {
+			if (listeners.length) listeners.forEach((fn) => fn(data))
+			else queue.push(data)
+		},
+	}
+}`
+
+// Channel creates a bidirectional message channel named name on the window of the page, it survives reloads.
+// In the page use `name.send(data)` to push a message to Go, and `name.onMessage(fn)` to receive the messages
+// from Go, onMessage returns a function to unsubscribe. In Go use Channel.Receive and Channel.Send.
+// Unlike Page.Expose, the page won't wait for Go to handle the messages, so it's suitable for a stream of events.
+// Channel 在页面的 window 上创建一个名为 name 的双向消息通道，它在重新加载后仍然有效。
+// 在页面中使用 `name.send(data)` 向 Go 推送消息，使用 `name.onMessage(fn)` 接收来自 Go 的消息，onMessage 返回一个用于取消订阅的函数。
+// 在 Go 中使用 Channel.Receive 和 Channel.Send。与 Page.Expose 不同，页面不会等待 Go 处理消息，所以它适用于事件流。
+func (p *Page) Channel(name string) (*Channel, error) {
+	bind := "_" + utils.RandString(8)
+
+	err := proto.RuntimeAddBinding{Name: bind}.Call(rodCall(p))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.Evaluate(Eval(channelDefinition, name, bind))
+	if err != nil {
+		_ = proto.RuntimeRemoveBinding{Name: bind}.Call(rodCall(p))
+		return nil, err
+	}
+
+	code := fmt.Sprintf(`(%s)(%s, %s)`, channelDefinition, utils.MustToJSON(name), utils.MustToJSON(bind))
+	remove, err := p.EvalOnNewDocument(code)
+	if err != nil {
+		_ = proto.RuntimeRemoveBinding{Name: bind}.Call(rodCall(p))
+		return nil, err
+	}
+
+	page, cancel := p.WithCancel()
+
+	c := &Channel{
+		Name:   name,
+		page:   page,
+		bind:   bind,
+		msgs:   make(chan gson.JSON),
+		remove: remove,
+		cancel: cancel,
+	}
+
+	wait := page.EachEvent(func(e *proto.RuntimeBindingCalled) {
+		if e.Name == bind {
+			select {
+			case <-page.ctx.Done():
+			case c.msgs <- gson.NewFrom(e.Payload):
+			}
+		}
+	})
+
+	go func() {
+		wait()
+		close(c.msgs)
+	}()
+
+	return c, nil
+}
+
+// Receive returns the messages sent by the page in order, it will be closed after the channel is closed
+// Receive 按顺序返回页面发送的消息，通道关闭后它也会被关闭
+func (c *Channel) Receive() <-chan gson.JSON {
+	return c.msgs
+}
+
+// Send the json-encoded v to the listeners of the channel in the page
+// 将 json 编码后的 v 发送给页面中该通道的监听函数
+func (c *Channel) Send(v interface{}) error {
+	_, err := c.page.Evaluate(Eval(`(name, data) => window[name]._push(data)`, c.Name, v))
+	return err
+}
+
+// Close the channel, the channel object will be removed from the page
+// 关闭通道，通道对象会从页面中移除
+func (c *Channel) Close() error {
+	defer c.cancel()
+
+	err := c.remove()
+	if err != nil {
+		return err
+	}
+
+	err = proto.RuntimeRemoveBinding{Name: c.bind}.Call(rodCall(c.page))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.page.Evaluate(Eval(`(name) => { delete window[name] }`, c.Name))
+	return err
+}
@@ -0,0 +1,76 @@
+package rod_test
+
+import (
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestPageChannel(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.blank()).MustWaitLoad()
+
+	c := page.MustChannel("events")
+
+	page.MustEval(`() => { events.send(1); events.send({ a: 2 }); events.send() }`)
+	g.Eq((<-c.Receive()).Int(), 1)
+	g.Eq((<-c.Receive()).Get("a").Int(), 2)
+	g.Nil((<-c.Receive()).Val())
+
+	// the messages are queued until there's a listener
+	c.MustSend("a").MustSend(map[string]int{"b": 1})
+	page.MustEval(`() => { window.got = []; window.unsubscribe = events.onMessage(d => got.push(d)) }`)
+	c.MustSend("c")
+	g.Eq(page.MustEval(`() => got`).Arr()[0].Str(), "a")
+	g.Eq(page.MustEval(`() => got`).Arr()[1].Get("b").Int(), 1)
+	g.Eq(page.MustEval(`() => got`).Arr()[2].Str(), "c")
+
+	page.MustEval(`() => unsubscribe()`)
+	c.MustSend("d")
+	g.Len(page.MustEval(`() => got`).Arr(), 3)
+
+	// survive the reload
+	page.MustReload().MustWaitLoad()
+	page.MustEval(`() => events.send('reloaded')`)
+	g.Eq((<-c.Receive()).Str(), "reloaded")
+
+	c.MustClose()
+	_, ok := <-c.Receive()
+	g.False(ok)
+	g.Eq(page.MustEval(`() => typeof events`).Str(), "undefined")
+
+	page.MustReload().MustWaitLoad()
+	g.Eq(page.MustEval(`() => typeof events`).Str(), "undefined")
+}
+
+func TestPageChannelErr(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.blank()).MustWaitLoad()
+
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeAddBinding{})
+		page.MustChannel("a")
+	})
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		page.MustChannel("a")
+	})
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.PageAddScriptToEvaluateOnNewDocument{})
+		page.MustChannel("a")
+	})
+
+	c := page.MustChannel("b")
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.PageRemoveScriptToEvaluateOnNewDocument{})
+		c.MustClose()
+	})
+
+	c = page.MustChannel("c")
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeRemoveBinding{})
+		c.MustClose()
+	})
+}
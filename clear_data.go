@@ -0,0 +1,166 @@
+package rod
+
+import (
+	"strings"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// ClearDataOptions for Browser.ClearData and Page.ClearData, use NewClearData to build it, such as:
+//     rod.NewClearData().Cookies().LocalStorage().Origin("https://example.com")
+// ClearDataOptions 是 Browser.ClearData 和 Page.ClearData 的选项，使用 NewClearData 来构建它。
+type ClearDataOptions struct {
+	// Origins to clear, if it's empty the http and https origins of the opened pages will be used
+	// 要清除的源，如果为空，将使用已打开页面的 http 和 https 源
+	Origins []string
+
+	// Types of the storages to clear
+	// 要清除的存储类型
+	Types []proto.StorageStorageType
+
+	// HTTPCache clears the http cache of the browser, it's not limited to the Origins
+	// HTTPCache 清除浏览器的 http 缓存，它不受 Origins 的限制
+	HTTPCache bool
+}
+
+// NewClearData creates an empty ClearDataOptions
+// NewClearData 创建一个空的 ClearDataOptions
+func NewClearData() *ClearDataOptions {
+	return &ClearDataOptions{Origins: []string{}, Types: []proto.StorageStorageType{}}
+}
+
+// Origin limits the clearing to the origins, such as "https://example.com"
+// Origin 将清除限制在这些源中，例如 "https://example.com"
+func (o *ClearDataOptions) Origin(origins ...string) *ClearDataOptions {
+	o.Origins = append(o.Origins, origins...)
+	return o
+}
+
+// Storage adds the types of the storages to clear
+// Storage 添加要清除的存储类型
+func (o *ClearDataOptions) Storage(types ...proto.StorageStorageType) *ClearDataOptions {
+	o.Types = append(o.Types, types...)
+	return o
+}
+
+// Cookies to clear
+// 清除 Cookie
+func (o *ClearDataOptions) Cookies() *ClearDataOptions {
+	return o.Storage(proto.StorageStorageTypeCookies)
+}
+
+// LocalStorage to clear
+// 清除 localStorage
+func (o *ClearDataOptions) LocalStorage() *ClearDataOptions {
+	return o.Storage(proto.StorageStorageTypeLocalStorage)
+}
+
+// IndexedDB to clear
+// 清除 IndexedDB
+func (o *ClearDataOptions) IndexedDB() *ClearDataOptions {
+	return o.Storage(proto.StorageStorageTypeIndexeddb)
+}
+
+// CacheStorage to clear
+// 清除 CacheStorage
+func (o *ClearDataOptions) CacheStorage() *ClearDataOptions {
+	return o.Storage(proto.StorageStorageTypeCacheStorage)
+}
+
+// ServiceWorkers to unregister
+// 注销 service worker
+func (o *ClearDataOptions) ServiceWorkers() *ClearDataOptions {
+	return o.Storage(proto.StorageStorageTypeServiceWorkers)
+}
+
+// All the storages to clear
+// 清除所有的存储
+func (o *ClearDataOptions) All() *ClearDataOptions {
+	return o.Storage(proto.StorageStorageTypeAll)
+}
+
+// Cache clears the http cache
+// Cache 清除 http 缓存
+func (o *ClearDataOptions) Cache() *ClearDataOptions {
+	o.HTTPCache = true
+	return o
+}
+
+func (o *ClearDataOptions) storageTypes() string {
+	list := []string{}
+	for _, t := range o.Types {
+		list = append(list, string(t))
+	}
+	return strings.Join(list, ",")
+}
+
+// ClearData clears the data of the browser selectively
+// ClearData 有选择地清除浏览器的数据
+func (b *Browser) ClearData(opts *ClearDataOptions) error {
+	origins := opts.Origins
+	if len(origins) == 0 && len(opts.Types) > 0 {
+		list, err := b.originPages(true)
+		if err != nil {
+			return err
+		}
+		for _, o := range list {
+			origins = append(origins, o.origin)
+		}
+	}
+
+	err := clearDataForOrigins(b, origins, opts)
+	if err != nil || !opts.HTTPCache {
+		return err
+	}
+
+	// the Network domain only works on a page
+	// Network 域只能在页面上工作
+	pages, err := b.Pages()
+	if err != nil {
+		return err
+	}
+	if !pages.Empty() {
+		return proto.NetworkClearBrowserCache{}.Call(pages.First())
+	}
+
+	p, err := b.Page(proto.TargetCreateTarget{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = p.Close() }()
+	return proto.NetworkClearBrowserCache{}.Call(p)
+}
+
+// ClearData is similar to Browser.ClearData, but if the opts.Origins is empty only the origin of the page will be used
+// ClearData 类似于 Browser.ClearData，但是如果 opts.Origins 为空，只会使用页面的源
+func (p *Page) ClearData(opts *ClearDataOptions) error {
+	origins := opts.Origins
+	if len(origins) == 0 && len(opts.Types) > 0 {
+		origin, err := p.origin()
+		if err != nil {
+			return err
+		}
+		origins = []string{origin}
+	}
+
+	err := clearDataForOrigins(p, origins, opts)
+	if err != nil || !opts.HTTPCache {
+		return err
+	}
+
+	return proto.NetworkClearBrowserCache{}.Call(p)
+}
+
+func clearDataForOrigins(c proto.Client, origins []string, opts *ClearDataOptions) error {
+	if len(opts.Types) == 0 {
+		return nil
+	}
+
+	for _, origin := range origins {
+		err := proto.StorageClearDataForOrigin{Origin: origin, StorageTypes: opts.storageTypes()}.Call(rodCall(c))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package rod_test
+
+import (
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestClearData(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/", ".html", `<html></html>`)
+
+	b := g.browser.MustIncognito()
+	defer b.MustClose()
+
+	page := b.MustPage(s.URL()).MustWaitLoad()
+	set := func() {
+		page.MustEval(`() => {
+			document.cookie = "a=1"
+			localStorage.setItem("l", "1")
+		}`)
+	}
+
+	set()
+	page.MustClearData(rod.NewClearData().LocalStorage())
+	g.Len(page.LocalStorage().MustKeys(), 0)
+	g.Eq(page.MustEval(`() => document.cookie`).Str(), "a=1")
+
+	set()
+	b.MustClearData(rod.NewClearData().Cookies().Cache())
+	g.Eq(page.MustEval(`() => document.cookie`).Str(), "")
+	g.Eq(page.LocalStorage().MustGet("l"), "1")
+
+	set()
+	b.MustClearData(rod.NewClearData().All().Origin("http://not-exists.com"))
+	g.Eq(page.LocalStorage().MustGet("l"), "1")
+
+	page.MustClearData(rod.NewClearData().IndexedDB().CacheStorage().ServiceWorkers().Cache())
+
+	opts := rod.NewClearData().Storage(proto.StorageStorageTypeWebsql).Origin("http://a.com", "http://b.com")
+	g.Len(opts.Origins, 2)
+	g.Eq(opts.Types, []proto.StorageStorageType{proto.StorageStorageTypeWebsql})
+
+	// nothing to clear
+	g.E(page.ClearData(rod.NewClearData()))
+
+	g.mc.stubErr(1, proto.StorageClearDataForOrigin{})
+	g.Err(page.ClearData(rod.NewClearData().Cookies()))
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(page.ClearData(rod.NewClearData().Cookies()))
+	g.mc.stubErr(1, proto.TargetGetTargets{})
+	g.Err(b.ClearData(rod.NewClearData().Cookies()))
+	g.mc.stubErr(1, proto.TargetGetTargets{})
+	g.Err(b.ClearData(rod.NewClearData().Cache()))
+}
@@ -0,0 +1,282 @@
+package rod
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// Cluster manages multiple browsers, local or remote, and schedules the page requests across them.
+// The pages it creates are the same as the ones created by Browser.Page, a page slot will be released
+// when the page is closed.
+// Cluster 管理多个本地或远程的浏览器，并在它们之间调度页面请求。
+// 它创建的页面与 Browser.Page 创建的页面相同，当页面关闭时会释放对应的页面名额。
+type Cluster struct {
+	ctx  context.Context
+	pool *clusterPool
+}
+
+type clusterPool struct {
+	sync.Mutex
+
+	nodes    []*ClusterNode
+	maxPages int
+
+	// closed and replaced when a page slot is released or a node becomes healthy
+	// 当页面名额被释放或者节点恢复健康时关闭并替换
+	changed chan struct{}
+}
+
+// ClusterNode is a browser in the cluster
+// ClusterNode 是集群中的一个浏览器
+type ClusterNode struct {
+	Browser *Browser
+
+	healthy  bool
+	pages    map[proto.TargetTargetID]struct{}
+	reserved int // the pages being created
+	cancel   func()
+}
+
+func (n *ClusterNode) load() int {
+	return len(n.pages) + n.reserved
+}
+
+// ClusterNodeState of a node
+// 节点的状态
+type ClusterNodeState struct {
+	Browser *Browser
+	Healthy bool
+	Pages   int
+}
+
+// NewCluster instance, maxPagesPerBrowser limits the number of pages each browser can open at the same time,
+// if it's not greater than 0 there will be no limit.
+// NewCluster 实例，maxPagesPerBrowser 限制每个浏览器同时可以打开的页面数量，如果它不大于 0 则没有限制。
+func NewCluster(maxPagesPerBrowser int) *Cluster {
+	return &Cluster{
+		ctx: context.Background(),
+		pool: &clusterPool{
+			maxPages: maxPagesPerBrowser,
+			changed:  make(chan struct{}),
+		},
+	}
+}
+
+// Context returns a clone with the specified ctx for chained sub-operations
+// Context 返回具有指定 ctx 的克隆，用于链式子操作
+func (c *Cluster) Context(ctx context.Context) *Cluster {
+	newObj := *c
+	newObj.ctx = ctx
+	return &newObj
+}
+
+// Timeout returns a clone with the specified total timeout of all chained sub-operations
+// Timeout 返回一个克隆，其中包含所有链接子操作的指定总超时
+func (c *Cluster) Timeout(d time.Duration) (*Cluster, func()) {
+	ctx, cancel := context.WithTimeout(c.ctx, d)
+	return c.Context(ctx), cancel
+}
+
+// Add a connected browser to the cluster
+// 将一个已连接的浏览器添加到集群中
+func (c *Cluster) Add(b *Browser) *Cluster {
+	b, cancel := b.WithCancel()
+	node := &ClusterNode{Browser: b, healthy: true, pages: map[proto.TargetTargetID]struct{}{}, cancel: cancel}
+
+	b.OnTargetDestroyed(func(id proto.TargetTargetID) {
+		c.pool.Lock()
+		defer c.pool.Unlock()
+
+		if _, has := node.pages[id]; has {
+			delete(node.pages, id)
+			c.pool.notify()
+		}
+	})
+
+	c.pool.Lock()
+	defer c.pool.Unlock()
+	c.pool.nodes = append(c.pool.nodes, node)
+	c.pool.notify()
+
+	return c
+}
+
+// AddURL connects to the control url and adds the browser to the cluster,
+// if the url is empty a local browser will be launched.
+// AddURL 连接控制 url 并将浏览器添加到集群中，如果 url 为空，将启动一个本地浏览器。
+func (c *Cluster) AddURL(u string) (*Browser, error) {
+	b := New().Context(c.ctx).ControlURL(u)
+	err := b.Connect()
+	if err != nil {
+		return nil, err
+	}
+	b = b.Context(context.Background())
+	c.Add(b)
+	return b, nil
+}
+
+// Page creates a page on the healthy browser that has the least pages.
+// If all the healthy browsers reach the page limit it will wait until a slot is released.
+// If there's no healthy browser ErrNoHealthyBrowser will be returned.
+// Page 在页面最少的健康浏览器上创建一个页面。
+// 如果所有健康的浏览器都达到了页面上限，它会等待直到有页面名额被释放。
+// 如果没有健康的浏览器，将返回 ErrNoHealthyBrowser。
+func (c *Cluster) Page(opts proto.TargetCreateTarget) (*Page, error) {
+	for {
+		node, changed, err := c.pool.pick()
+		if err != nil {
+			return nil, err
+		}
+
+		if node == nil {
+			select {
+			case <-c.ctx.Done():
+				return nil, c.ctx.Err()
+			case <-changed:
+				continue
+			}
+		}
+
+		p, err := node.Browser.Page(opts)
+
+		c.pool.Lock()
+		node.reserved--
+		if err != nil {
+			// release the reserved slot
+			// 释放预留的名额
+			c.pool.notify()
+			c.pool.Unlock()
+			return nil, err
+		}
+		node.pages[p.TargetID] = struct{}{}
+		c.pool.Unlock()
+
+		return p, nil
+	}
+}
+
+// pick the healthy node with the least pages and reserve a slot on it.
+// If all healthy nodes are full, node will be nil and the changed channel can be used to wait.
+// 选择页面最少的健康节点并在其上预留一个名额。
+// 如果所有健康节点都已满，node 将为 nil，可以使用 changed 通道来等待。
+func (pool *clusterPool) pick() (node *ClusterNode, changed chan struct{}, err error) {
+	pool.Lock()
+	defer pool.Unlock()
+
+	healthy := 0
+	for _, n := range pool.nodes {
+		if !n.healthy {
+			continue
+		}
+		healthy++
+
+		if pool.maxPages > 0 && n.load() >= pool.maxPages {
+			continue
+		}
+		if node == nil || n.load() < node.load() {
+			node = n
+		}
+	}
+
+	if healthy == 0 {
+		return nil, nil, &ErrNoHealthyBrowser{}
+	}
+
+	if node != nil {
+		node.reserved++
+	}
+
+	return node, pool.changed, nil
+}
+
+// must be called with the lock held
+// 必须在持有锁的时候调用
+func (pool *clusterPool) notify() {
+	close(pool.changed)
+	pool.changed = make(chan struct{})
+}
+
+// HealthCheck pings each browser within the timeout, the browsers that don't respond will be marked as unhealthy
+// and won't be scheduled until they pass the next check.
+// HealthCheck 在超时时间内 ping 每个浏览器，没有响应的浏览器将被标记为不健康，在通过下一次检查前不会被调度。
+func (c *Cluster) HealthCheck(timeout time.Duration) {
+	c.pool.Lock()
+	nodes := append([]*ClusterNode{}, c.pool.nodes...)
+	c.pool.Unlock()
+
+	wg := sync.WaitGroup{}
+	for _, node := range nodes {
+		wg.Add(1)
+		go func(node *ClusterNode) {
+			defer wg.Done()
+
+			_, err := node.Browser.Context(c.ctx).Timeout(timeout).Version()
+
+			c.pool.Lock()
+			defer c.pool.Unlock()
+			if node.healthy != (err == nil) {
+				node.healthy = err == nil
+				c.pool.notify()
+			}
+		}(node)
+	}
+	wg.Wait()
+}
+
+// StartHealthCheck runs Cluster.HealthCheck every interval in the background until the returned stop is called
+// StartHealthCheck 在后台每隔 interval 运行一次 Cluster.HealthCheck，直到调用返回的 stop
+func (c *Cluster) StartHealthCheck(interval, timeout time.Duration) (stop func()) {
+	ctx, stop := context.WithCancel(c.ctx)
+	c = c.Context(ctx)
+
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				c.HealthCheck(timeout)
+			}
+		}
+	}()
+
+	return
+}
+
+// States of the browsers in the cluster
+// 集群中浏览器的状态
+func (c *Cluster) States() []ClusterNodeState {
+	c.pool.Lock()
+	defer c.pool.Unlock()
+
+	list := []ClusterNodeState{}
+	for _, n := range c.pool.nodes {
+		list = append(list, ClusterNodeState{Browser: n.Browser, Healthy: n.healthy, Pages: len(n.pages)})
+	}
+	return list
+}
+
+// Close all the browsers in the cluster
+// 关闭集群中的所有浏览器
+func (c *Cluster) Close() error {
+	c.pool.Lock()
+	nodes := c.pool.nodes
+	c.pool.nodes = nil
+	c.pool.notify()
+	c.pool.Unlock()
+
+	var err error
+	for _, node := range nodes {
+		if e := node.Browser.Close(); e != nil {
+			err = e
+		}
+		node.cancel()
+	}
+	return err
+}
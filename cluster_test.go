@@ -0,0 +1,76 @@
+package rod_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestCluster(t *testing.T) {
+	g := setup(t)
+
+	c := rod.NewCluster(1)
+	defer func() { _ = c.Close() }()
+
+	for i := 0; i < 2; i++ {
+		l := launcher.New()
+		g.Cleanup(l.Kill)
+		c.MustAddURL(l.MustLaunch())
+	}
+
+	a := c.MustPage(g.blank())
+	b := c.MustPage(g.blank())
+
+	states := c.States()
+	g.Len(states, 2)
+	for _, s := range states {
+		g.True(s.Healthy)
+		g.Eq(s.Pages, 1)
+	}
+
+	// all the browsers are full
+	full, cancel := c.Timeout(300 * time.Millisecond)
+	defer cancel()
+	_, err := full.Page(proto.TargetCreateTarget{})
+	g.Err(err)
+
+	// the slot is released after the page is closed
+	wait := make(chan *rod.Page)
+	go func() { wait <- c.MustPage(g.blank()) }()
+	a.MustClose()
+	p := <-wait
+	g.Eq(p.MustEval(`() => 1`).Int(), 1)
+
+	b.MustClose()
+	c.HealthCheck(time.Second)
+	for _, s := range c.States() {
+		g.True(s.Healthy)
+	}
+
+	stop := c.StartHealthCheck(10*time.Millisecond, time.Second)
+	stop()
+
+	g.E(c.Close())
+	g.Len(c.States(), 0)
+}
+
+func TestClusterNoHealthyBrowser(t *testing.T) {
+	g := setup(t)
+
+	c := rod.NewCluster(0)
+	_, err := c.Page(proto.TargetCreateTarget{})
+	g.Is(err, &rod.ErrNoHealthyBrowser{})
+	g.Eq(err.Error(), "no healthy browser in the cluster")
+
+	c.Add(g.browser)
+	g.Len(c.States(), 1)
+
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.BrowserGetVersion{})
+		c.HealthCheck(time.Second)
+		c.MustPage()
+	})
+}
@@ -0,0 +1,111 @@
+package rod
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"sync"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+type concurrentUseKey struct{}
+
+type concurrentUse struct {
+	report func(*ErrConcurrentUse)
+
+	lock  sync.Mutex
+	calls map[concurrentCallKey]*concurrentCall
+}
+
+// the pages of different sessions may share the same context, such as the pages created from the same browser
+// 不同 session 的页面可能共享同一个 context，例如从同一个浏览器创建的页面
+type concurrentCallKey struct {
+	sessionID proto.TargetSessionID
+	ctx       context.Context
+}
+
+type concurrentCall struct {
+	goroutine uint64
+	method    string
+	stack     string
+}
+
+// DetectConcurrentUse reports the concurrent use of the same Page or Element from multiple goroutines, such as
+// two goroutines click the elements of the same page, it's a common source of the corrupted mouse state.
+// The clones created via Page.Context, Page.Timeout, etc. are treated as different callers, so they are fine.
+// The calls that unblock the others, such as handling a dialog from another goroutine while a click is waiting for it,
+// are the expected pattern and aren't reported.
+// The report receives the stacks of the conflicting callers, nil report disables it. Such as:
+//
+//     browser.DetectConcurrentUse(func(e *rod.ErrConcurrentUse) { panic(e) })
+//
+// It captures the stack of every cdp call, only enable it for debugging.
+// DetectConcurrentUse 报告多个 goroutine 对同一个 Page 或 Element 的并发使用，例如两个 goroutine 点击同一个页面的元素，
+// 这是鼠标状态被破坏的常见原因。通过 Page.Context、Page.Timeout 等创建的克隆会被当作不同的调用者，所以它们是没问题的。
+// 解除其他调用阻塞的调用，例如在点击等待对话框时从另一个 goroutine 处理该对话框，是预期的模式，不会被报告。
+// report 会收到冲突的调用者的调用栈，report 为 nil 则禁用它。它会捕获每次 cdp 调用的调用栈，只在调试时启用它。
+func (b *Browser) DetectConcurrentUse(report func(*ErrConcurrentUse)) *Browser {
+	if report == nil {
+		b.RemoveState(concurrentUseKey{})
+	} else {
+		b.states.Store(concurrentUseKey{}, &concurrentUse{
+			report: report,
+			calls:  map[concurrentCallKey]*concurrentCall{},
+		})
+	}
+	return b
+}
+
+// track the call of the page if the detector is enabled, returns the function to end the tracking
+// 如果启用了检测器，跟踪页面的调用，返回结束跟踪的函数
+func (p *Page) trackConcurrentUse(ctx context.Context, method string) func() {
+	v, has := p.browser.states.Load(concurrentUseKey{})
+	if !has || isUnblockingCall(method) {
+		return func() {}
+	}
+	u := v.(*concurrentUse)
+
+	call := &concurrentCall{goroutine: goroutineID(), method: method, stack: string(debug.Stack())}
+	key := concurrentCallKey{p.SessionID, ctx}
+
+	u.lock.Lock()
+	other, has := u.calls[key]
+	if !has {
+		u.calls[key] = call
+	}
+	u.lock.Unlock()
+
+	if has && other.goroutine != call.goroutine {
+		u.report(&ErrConcurrentUse{
+			TargetID:    p.TargetID,
+			Method:      method,
+			Stack:       call.stack,
+			OtherMethod: other.method,
+			OtherStack:  other.stack,
+		})
+	}
+
+	return func() {
+		u.lock.Lock()
+		defer u.lock.Unlock()
+		if u.calls[key] == call {
+			delete(u.calls, key)
+		}
+	}
+}
+
+// the id of the current goroutine, the first line of the stack is like "goroutine 123 [running]:"
+// 当前 goroutine 的 id，调用栈的第一行类似 "goroutine 123 [running]:"
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i > 0 {
+		buf = buf[:i]
+	}
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
@@ -0,0 +1,64 @@
+package rod_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+)
+
+func TestDetectConcurrentUse(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank())
+
+	reports := make(chan *rod.ErrConcurrentUse, 10)
+	g.browser.DetectConcurrentUse(func(e *rod.ErrConcurrentUse) { reports <- e })
+	defer g.browser.DetectConcurrentUse(nil)
+
+	slow := `() => new Promise(r => setTimeout(r, 300))`
+
+	// the clones are fine
+	done := make(chan struct{})
+	go func() {
+		p.Context(g.Context()).MustEval(slow)
+		close(done)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	p.MustEval(`() => 1`)
+	<-done
+	g.Len(reports, 0)
+
+	// the pages share the context of the browser, but they are different pages
+	other := g.newPage(g.blank())
+	done = make(chan struct{})
+	go func() {
+		other.MustEval(slow)
+		close(done)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	p.MustEval(`() => 1`)
+	<-done
+	g.Len(reports, 0)
+
+	// handling the dialog that blocks the eval is fine
+	wait, handle := p.MustHandleDialog()
+	done = make(chan struct{})
+	go func() {
+		p.MustEval(`() => alert('ok')`)
+		close(done)
+	}()
+	wait()
+	handle(true, "")
+	<-done
+	g.Len(reports, 0)
+
+	go p.MustEval(slow)
+	time.Sleep(100 * time.Millisecond)
+	p.MustEval(`() => 1`)
+
+	e := <-reports
+	g.Is(e, &rod.ErrConcurrentUse{})
+	g.Eq(e.TargetID, p.TargetID)
+	g.Has(e.OtherStack, "TestDetectConcurrentUse")
+}
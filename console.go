@@ -0,0 +1,140 @@
+package rod
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/ysmood/gson"
+)
+
+// ConsoleMessage is a message printed by the console api of the page, such as console.log
+// ConsoleMessage 是页面通过 console api 输出的消息，例如 console.log
+type ConsoleMessage struct {
+	// Level of the message, such as "log", "warning", "error"
+	// Level 是消息的级别，例如 "log"、"warning"、"error"
+	Level proto.RuntimeConsoleAPICalledType
+
+	// Text is the args joined by space, the strings are kept as they are, others are json-encoded
+	// Text 是以空格连接的参数，字符串保持原样，其他的会被 json 编码
+	Text string
+
+	// Args are the serialized args of the call
+	// Args 是调用的参数序列化后的值
+	Args []gson.JSON
+
+	// Location is the call frame that prints the message, it's nil if it's unknown
+	// Location 是输出该消息的调用帧，如果未知则为 nil
+	Location *proto.RuntimeCallFrame
+
+	StackTrace *proto.RuntimeStackTrace
+
+	Timestamp proto.RuntimeTimestamp
+}
+
+// String interface
+func (m *ConsoleMessage) String() string {
+	return fmt.Sprintf("[%s] %s", m.Level, m.Text)
+}
+
+// OnConsole calls fn for each message printed by the console api of the page, call stop to unsubscribe
+// OnConsole 对页面通过 console api 输出的每条消息调用 fn，调用 stop 取消订阅
+func (p *Page) OnConsole(fn func(*ConsoleMessage)) (stop func()) {
+	p, cancel := p.WithCancel()
+	go p.EachEvent(func(e *proto.RuntimeConsoleAPICalled) {
+		fn(p.consoleMessage(e))
+	})()
+	return cancel
+}
+
+// ConsoleCollector collects the console messages of a page, use Page.CollectConsole to create it
+// ConsoleCollector 收集页面的 console 消息，使用 Page.CollectConsole 创建它
+type ConsoleCollector struct {
+	collector
+}
+
+// CollectConsole starts to collect the console messages of the page
+// CollectConsole 开始收集页面的 console 消息
+func (p *Page) CollectConsole() *ConsoleCollector {
+	c := &ConsoleCollector{}
+	c.stop = p.OnConsole(func(m *ConsoleMessage) { c.add(m) })
+	return c
+}
+
+// Messages returns the collected messages in order
+// Messages 按顺序返回已收集的消息
+func (c *ConsoleCollector) Messages() []*ConsoleMessage {
+	list := []*ConsoleMessage{}
+	for _, m := range c.items() {
+		list = append(list, m.(*ConsoleMessage))
+	}
+	return list
+}
+
+// the shared part of the collectors of the page events
+// 页面事件收集器的共享部分
+type collector struct {
+	lock sync.Mutex
+	list []interface{}
+	stop func()
+}
+
+func (c *collector) add(item interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.list = append(c.list, item)
+}
+
+func (c *collector) items() []interface{} {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]interface{}{}, c.list...)
+}
+
+// Clear the collected items
+// 清空已收集的项
+func (c *collector) Clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.list = nil
+}
+
+// Stop collecting
+// 停止收集
+func (c *collector) Stop() {
+	c.stop()
+}
+
+func (p *Page) consoleMessage(e *proto.RuntimeConsoleAPICalled) *ConsoleMessage {
+	m := &ConsoleMessage{
+		Level:      e.Type,
+		Args:       []gson.JSON{},
+		StackTrace: e.StackTrace,
+		Timestamp:  e.Timestamp,
+	}
+
+	if e.StackTrace != nil && len(e.StackTrace.CallFrames) > 0 {
+		m.Location = e.StackTrace.CallFrames[0]
+	}
+
+	texts := []string{}
+	for _, arg := range e.Args {
+		// the page may have navigated and released the object, then use its description
+		// 页面可能已经导航并释放了该对象，此时使用它的描述
+		val, err := p.ObjectToJSON(arg)
+		if err != nil {
+			val = gson.New(arg.Description)
+		}
+		m.Args = append(m.Args, val)
+
+		if s, ok := val.Val().(string); ok {
+			texts = append(texts, s)
+		} else {
+			texts = append(texts, val.JSON("", ""))
+		}
+	}
+	m.Text = strings.Join(texts, " ")
+
+	return m
+}
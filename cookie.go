@@ -0,0 +1,100 @@
+package rod
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// CookieFormat for Browser.ExportCookies and Browser.ImportCookies
+// Browser.ExportCookies 和 Browser.ImportCookies 使用的 Cookie 格式
+type CookieFormat string
+
+const (
+	// CookieFormatNetscape is the Netscape cookies.txt format, the HttpOnly flag will be lost when exporting
+	// CookieFormatNetscape 是 Netscape cookies.txt 格式，导出时会丢失 HttpOnly 标记
+	CookieFormatNetscape CookieFormat = "netscape"
+
+	// CookieFormatCurl is the cookie jar format of curl, such as the file of "curl -c cookies.txt"
+	// CookieFormatCurl 是 curl 的 cookie jar 格式，例如 "curl -c cookies.txt" 生成的文件
+	CookieFormatCurl CookieFormat = "curl"
+
+	// CookieFormatPlaywright is the storage state JSON of Playwright
+	// CookieFormatPlaywright 是 Playwright 的 storage state JSON
+	CookieFormatPlaywright CookieFormat = "playwright"
+)
+
+// ExportCookies encodes all the cookies of the browser in the format
+// ExportCookies 以指定的格式编码浏览器的所有 Cookie
+func (b *Browser) ExportCookies(format CookieFormat) ([]byte, error) {
+	cookies, err := b.GetCookies()
+	if err != nil {
+		return nil, err
+	}
+	return encodeCookies(format, cookies)
+}
+
+// ImportCookies decodes the data in the format and sets the cookies to the browser
+// ImportCookies 以指定的格式解码 data 并将 Cookie 设置到浏览器中
+func (b *Browser) ImportCookies(format CookieFormat, data []byte) error {
+	cookies, err := decodeCookies(format, data)
+	if err != nil || len(cookies) == 0 {
+		return err
+	}
+	return b.SetCookies(cookies)
+}
+
+// CookieJar returns a net/http cookie jar that contains a snapshot of the browser's cookies,
+// so that a plain http.Client can send requests as the browser.
+// CookieJar 返回一个包含浏览器 Cookie 快照的 net/http cookie jar，这样普通的 http.Client 就可以像浏览器一样发送请求。
+func (b *Browser) CookieJar() (http.CookieJar, error) {
+	cookies, err := b.GetCookies()
+	if err != nil {
+		return nil, err
+	}
+	return proto.NewCookieJar(cookies), nil
+}
+
+// ExportCookies is similar to Browser.ExportCookies, but only for the cookies of the urls,
+// by default it's the url of the current page.
+// ExportCookies 类似于 Browser.ExportCookies，但只导出 urls 的 Cookie，默认为当前页面的 url。
+func (p *Page) ExportCookies(format CookieFormat, urls ...string) ([]byte, error) {
+	cookies, err := p.Cookies(urls)
+	if err != nil {
+		return nil, err
+	}
+	return encodeCookies(format, cookies)
+}
+
+// ImportCookies is similar to Browser.ImportCookies
+// ImportCookies 类似于 Browser.ImportCookies
+func (p *Page) ImportCookies(format CookieFormat, data []byte) error {
+	cookies, err := decodeCookies(format, data)
+	if err != nil || len(cookies) == 0 {
+		return err
+	}
+	return p.SetCookies(cookies)
+}
+
+func encodeCookies(format CookieFormat, cookies []*proto.NetworkCookie) ([]byte, error) {
+	switch format {
+	case CookieFormatNetscape:
+		return []byte(proto.CookiesToNetscape(cookies, false)), nil
+	case CookieFormatCurl:
+		return []byte(proto.CookiesToNetscape(cookies, true)), nil
+	case CookieFormatPlaywright:
+		return proto.CookiesToPlaywright(cookies)
+	}
+	return nil, fmt.Errorf("unknown cookie format: %s", format)
+}
+
+func decodeCookies(format CookieFormat, data []byte) ([]*proto.NetworkCookieParam, error) {
+	switch format {
+	case CookieFormatNetscape, CookieFormatCurl:
+		return proto.CookiesFromNetscape(string(data))
+	case CookieFormatPlaywright:
+		return proto.CookiesFromPlaywright(data)
+	}
+	return nil, fmt.Errorf("unknown cookie format: %s", format)
+}
@@ -0,0 +1,68 @@
+package rod_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestBrowserCookieFormats(t *testing.T) {
+	g := setup(t)
+
+	b := g.browser.MustIncognito()
+	defer b.MustClose()
+
+	b.MustSetCookies(&proto.NetworkCookie{
+		Name:     "a",
+		Value:    "1",
+		Domain:   "test.com",
+		Path:     "/",
+		Expires:  proto.TimeSinceEpoch(4102444800),
+		HTTPOnly: true,
+	})
+
+	for _, format := range []rod.CookieFormat{rod.CookieFormatNetscape, rod.CookieFormatCurl, rod.CookieFormatPlaywright} {
+		data := b.MustExportCookies(format)
+		b.MustSetCookies()
+		g.Len(b.MustGetCookies(), 0)
+
+		b.MustImportCookies(format, data)
+		cookies := b.MustGetCookies()
+		g.Len(cookies, 1)
+		g.Eq(cookies[0].Value, "1")
+		g.Eq(cookies[0].HTTPOnly, format != rod.CookieFormatNetscape)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
+	jar := b.MustCookieJar()
+	g.Eq(jar.Cookies(req.URL)[0].Name, "a")
+
+	_, err := b.ExportCookies("unknown")
+	g.Eq(err.Error(), "unknown cookie format: unknown")
+	g.Err(b.ImportCookies("unknown", nil))
+	g.Err(b.ImportCookies(rod.CookieFormatNetscape, []byte("invalid")))
+
+	g.mc.stubErr(1, proto.StorageGetCookies{})
+	g.Err(b.ExportCookies(rod.CookieFormatCurl))
+	g.mc.stubErr(1, proto.StorageGetCookies{})
+	g.Err(b.CookieJar())
+}
+
+func TestPageCookieFormats(t *testing.T) {
+	g := setup(t)
+
+	page := g.page.MustNavigate(g.srcFile("fixtures/click.html"))
+	page.MustImportCookies(rod.CookieFormatPlaywright, []byte(
+		`[{"name": "b", "value": "2", "domain": "test.com", "path": "/", "expires": -1}]`,
+	))
+
+	data := page.MustExportCookies(rod.CookieFormatNetscape, "http://test.com")
+	g.Has(string(data), "test.com\tFALSE\t/\tFALSE\t0\tb\t2")
+
+	g.E(page.ImportCookies(rod.CookieFormatCurl, []byte("# empty")))
+
+	g.mc.stubErr(1, proto.NetworkGetCookies{})
+	g.Err(page.ExportCookies(rod.CookieFormatCurl))
+}
@@ -0,0 +1,161 @@
+package rod
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/go-rod/rod/lib/cdp"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// CrashPolicy for Browser.CrashPolicy
+// Browser.CrashPolicy 的崩溃策略
+type CrashPolicy struct {
+	// OnCrash will be called when the browser or one of its targets crashes
+	// 当浏览器或者它的某个目标崩溃时会调用 OnCrash
+	OnCrash func(*ErrBrowserCrashed)
+
+	// Relaunch a new browser when the connection to the browser is lost and can't be re-dialed,
+	// it returns the control url of the new browser, such as launcher.New().Launch .
+	// The incognito contexts will be rebuilt on the new browser and the event subscriptions keep working,
+	// but the pages of the crashed browser are lost.
+	// 当与浏览器的连接断开并且无法重新连接时，启动一个新的浏览器，它返回新浏览器的控制 url，例如 launcher.New().Launch 。
+	// 无痕上下文会在新的浏览器上重建，事件订阅会继续工作，但是崩溃的浏览器中的页面会丢失。
+	Relaunch func() (string, error)
+}
+
+// the state key to mark the crash of a target, the TargetID is empty for the browser itself
+// 用于标记目标崩溃的 state key，浏览器本身崩溃时 TargetID 为空
+type crashKey struct {
+	TargetID proto.TargetTargetID
+}
+
+// the state key to mark the browser is closed on purpose
+// 用于标记浏览器是被主动关闭的 state key
+type closedKey struct{}
+
+// CrashPolicy sets the policy to handle the crashes of the browser and its targets.
+// Whether it's set or not, the calls after a crash will return ErrBrowserCrashed.
+// If the policy.Relaunch is set, the automatic reconnection will be enabled too, check Browser.Reconnect for details.
+// It only works when it's called before the Connect and the Client is not set.
+// CrashPolicy 设置处理浏览器及其目标崩溃的策略。
+// 无论是否设置，崩溃后的调用都会返回 ErrBrowserCrashed。
+// 如果设置了 policy.Relaunch，也会启用自动重连，详情请查看 Browser.Reconnect 。
+// 它只在 Connect 之前调用并且没有设置 Client 时有效。
+func (b *Browser) CrashPolicy(policy *CrashPolicy) *Browser {
+	b.crashPolicy = policy
+	if policy.Relaunch != nil && b.reconnect == nil {
+		b.reconnect = &ReconnectPolicy{}
+	}
+	return b
+}
+
+// watch the crash related events, it returns false if the message is not about crash
+// 监听与崩溃有关的事件，如果消息与崩溃无关则返回 false
+func (b *Browser) watchCrash(e *cdp.Event) bool {
+	var targetID proto.TargetTargetID
+
+	switch e.Method {
+	case (proto.TargetTargetCrashed{}).ProtoEvent():
+		var crashed proto.TargetTargetCrashed
+		if json.Unmarshal(e.Params, &crashed) != nil {
+			return false
+		}
+		targetID = crashed.TargetID
+
+	case (proto.TargetTargetDestroyed{}).ProtoEvent():
+		var destroyed proto.TargetTargetDestroyed
+		if json.Unmarshal(e.Params, &destroyed) == nil {
+			b.states.Delete(crashKey{destroyed.TargetID})
+		}
+		return false
+
+	case (proto.TargetDetachedFromTarget{}).ProtoEvent():
+		var detached proto.TargetDetachedFromTarget
+		if json.Unmarshal(e.Params, &detached) == nil && detached.TargetID != "" {
+			b.states.Delete(crashKey{detached.TargetID})
+		}
+		return false
+
+	case (proto.InspectorTargetCrashed{}).ProtoEvent():
+		b.states.Range(func(_, v interface{}) bool {
+			if p, ok := v.(*Page); ok && string(p.SessionID) == e.SessionID {
+				targetID = p.TargetID
+				return false
+			}
+			return true
+		})
+		if targetID == "" {
+			return false
+		}
+
+	default:
+		return false
+	}
+
+	b.crash(&ErrBrowserCrashed{TargetID: targetID})
+	return true
+}
+
+// the event stream of the cdp client ends
+// cdp 客户端的事件流结束
+func (b *Browser) lostConnection() {
+	if _, closed := b.states.Load(closedKey{}); closed {
+		return
+	}
+
+	var err error = io.EOF
+	if rc, ok := b.client.(*reconnectClient); ok {
+		if _, e := rc.connected(b.ctx, nil); e != nil {
+			err = e
+		}
+	}
+
+	b.crash(&ErrBrowserCrashed{Err: err})
+}
+
+func (b *Browser) crash(e *ErrBrowserCrashed) {
+	b.states.Store(crashKey{e.TargetID}, e)
+
+	if b.crashPolicy != nil && b.crashPolicy.OnCrash != nil {
+		b.crashPolicy.OnCrash(e)
+	}
+}
+
+// wrap the transport error after the browser crashed
+// 在浏览器崩溃后包装传输错误
+func (b *Browser) crashErr(err error) error {
+	if _, crashed := b.states.Load(crashKey{}); !crashed {
+		return err
+	}
+
+	var cdpErr *cdp.Error
+	if errors.As(err, &cdpErr) {
+		return err
+	}
+
+	return &ErrBrowserCrashed{Err: err}
+}
+
+// check if the target of the page has crashed, reload and navigate are allowed to recover the page
+// 检查页面的目标是否已崩溃，允许使用 reload 和 navigate 来恢复页面
+func (p *Page) crashed(methodName string) error {
+	if _, crashed := p.browser.states.Load(crashKey{p.TargetID}); !crashed {
+		return nil
+	}
+
+	switch methodName {
+	case (proto.PageReload{}).ProtoReq(), (proto.PageNavigate{}).ProtoReq():
+		return nil
+	}
+
+	return &ErrBrowserCrashed{TargetID: p.TargetID}
+}
+
+func (p *Page) recovered(methodName string) {
+	switch methodName {
+	case (proto.PageReload{}).ProtoReq(), (proto.PageNavigate{}).ProtoReq():
+		p.browser.states.Delete(crashKey{p.TargetID})
+	}
+}
@@ -0,0 +1,82 @@
+package rod_test
+
+import (
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+func TestPageCrash(t *testing.T) {
+	g := setup(t)
+
+	crashes := make(chan *rod.ErrBrowserCrashed, 1)
+	l := launcher.New()
+	g.Cleanup(l.Kill)
+	b := rod.New().ControlURL(l.MustLaunch()).CrashPolicy(&rod.CrashPolicy{
+		OnCrash: func(e *rod.ErrBrowserCrashed) { crashes <- e },
+	}).MustConnect()
+
+	p := b.MustPage(g.blank())
+
+	_ = p.Navigate("chrome://crash")
+	g.Eq((<-crashes).TargetID, p.TargetID)
+
+	_, err := p.Eval(`() => 1`)
+	g.Is(err, &rod.ErrBrowserCrashed{})
+	g.Eq(err.Error(), "target crashed: "+string(p.TargetID))
+
+	p.MustNavigate(g.blank())
+	g.Eq(p.MustEval(`() => 1`).Int(), 1)
+}
+
+func TestBrowserCrashErr(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New()
+	g.Cleanup(l.Kill)
+	b := rod.New().ControlURL(l.MustLaunch()).MustConnect()
+	p := b.MustPage(g.blank())
+
+	_ = proto.BrowserCrash{}.Call(b)
+
+	utils.Sleep(0.3)
+
+	_, err := p.Eval(`() => 1`)
+	g.Is(err, &rod.ErrBrowserCrashed{})
+	g.Has(err.Error(), "browser crashed: ")
+}
+
+func TestBrowserRelaunch(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New()
+	g.Cleanup(l.Kill)
+
+	relaunched := make(chan struct{}, 1)
+	b := rod.New().ControlURL(l.MustLaunch()).CrashPolicy(&rod.CrashPolicy{
+		Relaunch: func() (string, error) {
+			nl := launcher.New()
+			g.Cleanup(nl.Kill)
+			return nl.Launch()
+		},
+	}).Reconnect(&rod.ReconnectPolicy{
+		OnReconnect: func() { relaunched <- struct{}{} },
+	}).MustConnect()
+
+	incognito := b.MustIncognito()
+	incognito.MustPage(g.blank())
+
+	wait := b.WaitEvent(&proto.TargetTargetCreated{})
+
+	_ = proto.BrowserCrash{}.Call(b)
+	<-relaunched
+
+	p := incognito.MustPage(g.blank())
+	wait()
+
+	g.Eq(p.MustEval(`() => 1`).Int(), 1)
+	g.Len(b.MustTargets(proto.TargetTargetInfoTypePage), 1)
+}
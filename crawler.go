@@ -0,0 +1,324 @@
+package rod
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// CrawlTask to be processed by Crawler
+// 由 Crawler 处理的任务
+type CrawlTask struct {
+	URL string
+
+	// Data is attached by the caller, such as the depth of the url
+	// Data 由调用者附加，例如 url 的深度
+	Data interface{}
+}
+
+// CrawlResult of a CrawlTask
+// CrawlTask 的结果
+type CrawlResult struct {
+	Task *CrawlTask
+
+	// Value returned by the handler
+	// Value 是处理函数返回的值
+	Value interface{}
+
+	// Err of the last attempt
+	// Err 是最后一次尝试的错误
+	Err error
+
+	// Attempts of the task, including the retries
+	// Attempts 是任务的尝试次数，包括重试
+	Attempts int
+}
+
+// CrawlerOptions for NewCrawler
+// NewCrawler 的选项
+type CrawlerOptions struct {
+	// Get a page for a task, such as ManagedPagePool.Get or ManagedBrowserPool.Page
+	// Get 为任务获取一个页面，例如 ManagedPagePool.Get 或者 ManagedBrowserPool.Page
+	Get func() (*Page, error)
+
+	// Put the page back after the task, such as ManagedPagePool.Put or ManagedBrowserPool.Put
+	// Put 在任务结束后放回页面，例如 ManagedPagePool.Put 或者 ManagedBrowserPool.Put
+	Put func(*Page)
+
+	// Handler navigates the page to the url of the task and extracts the value, the panics of it will be
+	// converted to errors, so the Must functions can be used.
+	// Handler 将页面导航到任务的 url 并提取值，它的 panic 会被转换为错误，所以可以使用 Must 函数。
+	Handler func(p *Page, t *CrawlTask) (interface{}, error)
+
+	// Concurrency is the number of the tasks run at the same time, default is 1
+	// Concurrency 是同时运行的任务数量，默认为 1
+	Concurrency int
+
+	// Retries of a failed task
+	// Retries 是失败任务的重试次数
+	Retries int
+
+	// RetryDelay before each retry
+	// RetryDelay 是每次重试之前的等待时间
+	RetryDelay time.Duration
+
+	// HostInterval is the min interval between the starts of two tasks of the same host
+	// HostInterval 是同一个 host 的两个任务开始之间的最小间隔
+	HostInterval time.Duration
+
+	// Key to dedupe the tasks, default is the url without the fragment
+	// Key 用于任务去重，默认为去掉 fragment 的 url
+	Key func(t *CrawlTask) string
+
+	// Context to stop the crawler, the queued tasks will be dropped and the running ones will be canceled
+	// Context 用于停止爬虫，队列中的任务会被丢弃，正在运行的任务会被取消
+	Context context.Context
+}
+
+// Crawler schedules the tasks over a page pool or a browser pool with per-host rate limiting, retries and
+// deduplication, use NewCrawler to create it.
+// Crawler 在页面池或浏览器池上调度任务，支持按 host 限速、重试以及去重，使用 NewCrawler 创建它。
+type Crawler struct {
+	opts    CrawlerOptions
+	ctx     context.Context
+	cancel  func()
+	results chan *CrawlResult
+	wg      sync.WaitGroup
+
+	lock    sync.Mutex
+	cond    *sync.Cond
+	queue   []*crawlItem
+	seen    map[string]struct{}
+	pending int // the tasks queued or running
+	hosts   map[string]time.Time
+	wakeAt  time.Time // when the timer to wake the idle workers fires, zero if there's none
+	closed  bool
+}
+
+// a task in the queue, it won't start before the at
+// 队列中的任务，它不会在 at 之前开始
+type crawlItem struct {
+	res *CrawlResult
+	at  time.Time
+}
+
+// NewCrawler starts the workers, use Crawler.Add to feed the tasks and Crawler.Results to consume the results
+// NewCrawler 启动工作协程，使用 Crawler.Add 添加任务，使用 Crawler.Results 消费结果
+func NewCrawler(opts CrawlerOptions) *Crawler {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.Key == nil {
+		opts.Key = crawlKey
+	}
+	if opts.Context == nil {
+		opts.Context = context.Background()
+	}
+
+	ctx, cancel := context.WithCancel(opts.Context)
+	c := &Crawler{
+		opts:    opts,
+		ctx:     ctx,
+		cancel:  cancel,
+		results: make(chan *CrawlResult),
+		queue:   []*crawlItem{},
+		seen:    map[string]struct{}{},
+		hosts:   map[string]time.Time{},
+	}
+	c.cond = sync.NewCond(&c.lock)
+
+	go func() {
+		<-ctx.Done()
+		c.lock.Lock()
+		c.cond.Broadcast()
+		c.lock.Unlock()
+	}()
+
+	c.wg.Add(opts.Concurrency)
+	for i := 0; i < opts.Concurrency; i++ {
+		go c.work()
+	}
+
+	return c
+}
+
+// Add the task, it can be called in the handler to add the discovered urls.
+// It returns false if the task is a duplicate or the crawler is closed or canceled.
+// Add 添加任务，可以在处理函数中调用它来添加发现的 url。如果任务重复或者爬虫已关闭或已取消则返回 false。
+func (c *Crawler) Add(t *CrawlTask) bool {
+	key := c.opts.Key(t)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.closed || c.ctx.Err() != nil {
+		return false
+	}
+	if _, has := c.seen[key]; has {
+		return false
+	}
+	c.seen[key] = struct{}{}
+	c.queue = append(c.queue, &crawlItem{res: &CrawlResult{Task: t}})
+	c.pending++
+	c.cond.Broadcast()
+	return true
+}
+
+// AddURL adds the urls as tasks, returns the number of the added ones
+// AddURL 将 url 作为任务添加，返回被添加的数量
+func (c *Crawler) AddURL(urls ...string) int {
+	n := 0
+	for _, u := range urls {
+		if c.Add(&CrawlTask{URL: u}) {
+			n++
+		}
+	}
+	return n
+}
+
+// Results of the tasks, they must be consumed or the workers will be blocked.
+// The channel will be closed after Crawler.Close.
+// Results 是任务的结果，必须消费它们，否则工作协程会被阻塞。该 channel 会在 Crawler.Close 之后被关闭。
+func (c *Crawler) Results() <-chan *CrawlResult {
+	return c.results
+}
+
+// Close waits for all the tasks to be done, including the ones added by the handlers, then closes the results.
+// If the CrawlerOptions.Context is done, it won't wait for the queued tasks.
+// Consume the results in another goroutine, or it will block forever.
+// Close 等待所有任务完成，包括处理函数添加的任务，然后关闭结果 channel。如果 CrawlerOptions.Context 已结束，它不会等待队列中的任务。
+// 需要在另一个协程中消费结果，否则它会永远阻塞。
+func (c *Crawler) Close() {
+	c.lock.Lock()
+	for c.pending > 0 && c.ctx.Err() == nil {
+		c.cond.Wait()
+	}
+	c.closed = true
+	c.cond.Broadcast()
+	c.lock.Unlock()
+
+	c.wg.Wait()
+	c.cancel()
+	close(c.results)
+}
+
+func (c *Crawler) work() {
+	defer c.wg.Done()
+
+	for {
+		c.lock.Lock()
+		item := c.next()
+		c.lock.Unlock()
+		if item == nil {
+			return
+		}
+
+		res := item.res
+		res.Attempts++
+		res.Value, res.Err = c.handle(res.Task)
+
+		c.lock.Lock()
+		if res.Err != nil && res.Attempts <= c.opts.Retries && c.ctx.Err() == nil {
+			// retry it later without holding the worker
+			// 稍后重试，不占用工作协程
+			item.at = time.Now().Add(c.opts.RetryDelay)
+			c.queue = append(c.queue, item)
+			c.cond.Broadcast()
+			c.lock.Unlock()
+			continue
+		}
+		c.lock.Unlock()
+
+		select {
+		case <-c.ctx.Done():
+		case c.results <- res:
+		}
+
+		c.lock.Lock()
+		c.pending--
+		c.cond.Broadcast()
+		c.lock.Unlock()
+	}
+}
+
+// take the first task that is ready to start, it blocks until there's one or the crawler is done.
+// A task is ready when its retry delay and the interval of its host have passed, so a slow host won't hold the workers.
+// It must be called with the lock held, returns nil if the worker should exit.
+// 取出第一个可以开始的任务，它会阻塞直到有一个任务可以开始或者爬虫结束。
+// 当任务的重试延迟以及它的 host 的间隔都已经过去时任务才可以开始，这样一个慢的 host 不会占用工作协程。
+// 调用它时必须持有锁，如果工作协程应该退出则返回 nil。
+func (c *Crawler) next() *crawlItem {
+	for {
+		if c.ctx.Err() != nil || (c.closed && len(c.queue) == 0) {
+			return nil
+		}
+
+		now := time.Now()
+		var earliest time.Time
+		for i, item := range c.queue {
+			host := crawlHost(item.res.Task.URL)
+			at := item.at
+			if c.opts.HostInterval > 0 && c.hosts[host].After(at) {
+				at = c.hosts[host]
+			}
+
+			if !at.After(now) {
+				c.queue = append(c.queue[:i], c.queue[i+1:]...)
+				if c.opts.HostInterval > 0 {
+					c.hosts[host] = now.Add(c.opts.HostInterval)
+				}
+				return item
+			}
+
+			if earliest.IsZero() || at.Before(earliest) {
+				earliest = at
+			}
+		}
+
+		if !earliest.IsZero() && (c.wakeAt.IsZero() || earliest.Before(c.wakeAt)) {
+			c.wakeAt = earliest
+			time.AfterFunc(earliest.Sub(now), func() {
+				c.lock.Lock()
+				defer c.lock.Unlock()
+				if c.wakeAt.Equal(earliest) {
+					c.wakeAt = time.Time{}
+				}
+				c.cond.Broadcast()
+			})
+		}
+		c.cond.Wait()
+	}
+}
+
+func (c *Crawler) handle(t *CrawlTask) (val interface{}, err error) {
+	p, err := c.opts.Get()
+	if err != nil {
+		return nil, err
+	}
+	defer c.opts.Put(p)
+
+	tryErr := Try(func() {
+		val, err = c.opts.Handler(p.Context(c.ctx), t)
+	})
+	if tryErr != nil {
+		return nil, tryErr
+	}
+	return
+}
+
+func crawlHost(u string) string {
+	if parsed, err := url.Parse(u); err == nil {
+		return parsed.Host
+	}
+	return u
+}
+
+func crawlKey(t *CrawlTask) string {
+	u, err := url.Parse(t.URL)
+	if err != nil {
+		return t.URL
+	}
+	u.Fragment = ""
+	return u.String()
+}
@@ -0,0 +1,113 @@
+package rod_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestCrawler(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/a", ".html", `<html><a href="/b">b</a><a href="/a#top">a</a></html>`)
+	s.Route("/b", ".html", `<html><title>b</title></html>`)
+
+	pool := rod.NewManagedPagePool(rod.PagePoolOptions{
+		Limit:  2,
+		Create: func() (*rod.Page, error) { return g.browser.Page(proto.TargetCreateTarget{}) },
+	})
+	defer pool.Cleanup()
+
+	var c *rod.Crawler
+	c = rod.NewCrawler(rod.CrawlerOptions{
+		Get:          pool.Get,
+		Put:          pool.Put,
+		Concurrency:  2,
+		HostInterval: 10 * time.Millisecond,
+		Handler: func(p *rod.Page, t *rod.CrawlTask) (interface{}, error) {
+			p.MustNavigate(t.URL).MustWaitLoad()
+			for _, el := range p.MustElements("a") {
+				c.AddURL(el.MustProperty("href").String())
+			}
+			return p.MustInfo().Title, nil
+		},
+	})
+
+	g.Eq(c.AddURL(s.URL("/a"), s.URL("/a")), 1)
+
+	go c.Close()
+
+	titles := map[string]interface{}{}
+	for r := range c.Results() {
+		g.E(r.Err)
+		titles[r.Task.URL] = r.Value
+	}
+
+	g.Len(titles, 2)
+	g.Eq(titles[s.URL("/b")], "b")
+	g.False(c.AddURL(s.URL("/c")) == 1)
+}
+
+func TestCrawlerRetry(t *testing.T) {
+	g := setup(t)
+
+	c := rod.NewCrawler(rod.CrawlerOptions{
+		Get:     func() (*rod.Page, error) { return nil, errors.New("err") },
+		Put:     func(*rod.Page) {},
+		Retries: 2,
+	})
+	c.AddURL("http://a.com")
+	go c.Close()
+
+	r := <-c.Results()
+	g.Eq(r.Err.Error(), "err")
+	g.Eq(r.Attempts, 3)
+
+	_, ok := <-c.Results()
+	g.False(ok)
+}
+
+func TestCrawlerPanic(t *testing.T) {
+	g := setup(t)
+
+	c := rod.NewCrawler(rod.CrawlerOptions{
+		Get: func() (*rod.Page, error) { return g.page, nil },
+		Put: func(*rod.Page) {},
+		Handler: func(p *rod.Page, t *rod.CrawlTask) (interface{}, error) {
+			panic("boom")
+		},
+	})
+	c.AddURL("http://a.com")
+	go c.Close()
+
+	r := <-c.Results()
+	g.Is(r.Err, &rod.ErrTry{})
+}
+
+func TestCrawlerContext(t *testing.T) {
+	g := setup(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := rod.NewCrawler(rod.CrawlerOptions{
+		Get: func() (*rod.Page, error) { return g.page, nil },
+		Put: func(*rod.Page) {},
+		Handler: func(p *rod.Page, t *rod.CrawlTask) (interface{}, error) {
+			cancel()
+			<-p.GetContext().Done()
+			return nil, p.GetContext().Err()
+		},
+		Context: ctx,
+	})
+	g.Eq(c.AddURL("http://a.com", "http://b.com"), 2)
+
+	// the queued task is dropped
+	c.Close()
+	for range c.Results() {
+	}
+	g.Eq(c.AddURL("http://c.com"), 0)
+}
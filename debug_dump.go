@@ -0,0 +1,260 @@
+package rod
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// the max number of the trace entries that DebugRecorder keeps
+// DebugRecorder 保留的 trace 条目的最大数量
+const debugTraceLimit = 100
+
+type debugRecorderKey struct {
+	targetID proto.TargetTargetID
+}
+
+// DebugRecorder records the console messages, uncaught errors, pending requests and the recent actions of a page,
+// so that Page.DumpDebug can include them. Use Page.RecordDebug to create it.
+// DebugRecorder 记录页面的 console 消息、未被捕获的错误、未完成的请求以及最近的操作，这样 Page.DumpDebug 就可以包含它们。
+// 使用 Page.RecordDebug 创建它。
+type DebugRecorder struct {
+	page    *Page
+	console *ConsoleCollector
+	errors  *ErrorCollector
+	stop    func()
+
+	lock     sync.Mutex
+	requests map[proto.NetworkRequestID]*debugRequest
+	trace    []string
+}
+
+type debugRequest struct {
+	Method string    `json:"method"`
+	URL    string    `json:"url"`
+	Type   string    `json:"type"`
+	Since  time.Time `json:"since"`
+}
+
+// RecordDebug starts to record the debug info of the page for Page.DumpDebug, the previous recorder of the page
+// will be stopped
+// RecordDebug 开始为 Page.DumpDebug 记录页面的调试信息，页面之前的记录器会被停止
+func (p *Page) RecordDebug() *DebugRecorder {
+	if old, has := p.browser.states.Load(debugRecorderKey{p.TargetID}); has {
+		old.(*DebugRecorder).Stop()
+	}
+
+	r := &DebugRecorder{
+		page:     p,
+		console:  p.CollectConsole(),
+		errors:   p.CollectErrors(),
+		requests: map[proto.NetworkRequestID]*debugRequest{},
+		trace:    []string{},
+	}
+
+	page, cancel := p.WithCancel()
+	r.stop = cancel
+
+	go page.EachEvent(func(e *proto.NetworkRequestWillBeSent) {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		r.requests[e.RequestID] = &debugRequest{
+			Method: e.Request.Method,
+			URL:    e.Request.URL,
+			Type:   string(e.Type),
+			Since:  time.Now(),
+		}
+	}, func(e *proto.NetworkLoadingFinished) {
+		r.removeRequest(e.RequestID)
+	}, func(e *proto.NetworkLoadingFailed) {
+		r.removeRequest(e.RequestID)
+	})()
+
+	p.browser.states.Store(debugRecorderKey{p.TargetID}, r)
+
+	return r
+}
+
+// Stop recording
+// 停止记录
+func (r *DebugRecorder) Stop() {
+	r.stop()
+	r.console.Stop()
+	r.errors.Stop()
+
+	// only remove the state if it's not replaced by a newer recorder
+	// 只有在状态没有被更新的记录器替换时才删除它
+	key := debugRecorderKey{r.page.TargetID}
+	if cur, has := r.page.browser.states.Load(key); has && cur == r {
+		r.page.browser.RemoveState(key)
+	}
+}
+
+func (r *DebugRecorder) removeRequest(id proto.NetworkRequestID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.requests, id)
+}
+
+func (r *DebugRecorder) addTrace(entry string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.trace = append(r.trace, time.Now().Format(time.RFC3339Nano)+" "+entry)
+	if len(r.trace) > debugTraceLimit {
+		r.trace = r.trace[len(r.trace)-debugTraceLimit:]
+	}
+}
+
+// the pending requests sorted by the start time
+// 按开始时间排序的未完成请求
+func (r *DebugRecorder) pendingRequests() []*debugRequest {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	list := []*debugRequest{}
+	for _, req := range r.requests {
+		list = append(list, req)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Since.Before(list[j].Since) })
+	return list
+}
+
+func (r *DebugRecorder) traceEntries() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]string{}, r.trace...)
+}
+
+// record the action for the debug recorder, the monitor and the reporter of the page if there are,
+// the returned function should be called when the action is done
+// 如果页面有调试记录器、监控服务和报告器，为它们记录该操作，返回的函数应该在操作完成时被调用
+func (p *Page) recordTrace(typ TraceType, msg []interface{}, target interface{}) (done func()) {
+	p.recordMonitorTrace(typ, msg)
+
+	if r, has := p.browser.states.Load(debugRecorderKey{p.TargetID}); has {
+		list := append(append([]interface{}{typ}, msg...), target)
+		r.(*DebugRecorder).addTrace(strings.TrimSuffix(fmt.Sprintln(list...), "\n"))
+	}
+
+	return p.recordReport(typ, msg, target)
+}
+
+// DumpDebug writes the debug info of the page into dir to attach to the bug reports, if dir ends with ".zip" a zip
+// file will be created instead. The files are:
+//     info.json      the url, title and the time of the dump
+//     screenshot.png the screenshot of the viewport
+//     page.html      the html of the page
+// If Page.RecordDebug has been called for the page, these are also included:
+//     console.log    the console messages
+//     errors.log     the uncaught js errors
+//     requests.json  the pending network requests
+//     trace.log      the recent actions, such as the clicks and the waits on the elements
+// It writes as many files as it can, the first error will be returned.
+// DumpDebug 将页面的调试信息写入 dir 中以便附加到 bug 报告上，如果 dir 以 ".zip" 结尾，则会创建一个 zip 文件。
+// 如果页面调用过 Page.RecordDebug，还会包含 console 消息、未被捕获的错误、未完成的请求以及最近的操作。
+// 它会尽可能多地写入文件，并返回遇到的第一个错误。
+func (p *Page) DumpDebug(dir string) (err error) {
+	files := map[string][]byte{}
+	fail := func(e error) {
+		if err == nil {
+			err = e
+		}
+	}
+
+	info := map[string]interface{}{"time": time.Now()}
+	if ti, e := p.Info(); e == nil {
+		info["url"] = ti.URL
+		info["title"] = ti.Title
+	} else {
+		fail(e)
+	}
+	files["info.json"] = utils.MustToJSONBytes(info)
+
+	if bin, e := p.Screenshot(false, nil); e == nil {
+		files["screenshot.png"] = bin
+	} else {
+		fail(e)
+	}
+
+	if html, e := p.HTML(); e == nil {
+		files["page.html"] = []byte(html)
+	} else {
+		fail(e)
+	}
+
+	if v, has := p.browser.states.Load(debugRecorderKey{p.TargetID}); has {
+		r := v.(*DebugRecorder)
+
+		lines := []string{}
+		for _, m := range r.console.Messages() {
+			lines = append(lines, m.String())
+		}
+		files["console.log"] = []byte(strings.Join(lines, "\n"))
+
+		lines = []string{}
+		for _, e := range r.errors.Errors() {
+			lines = append(lines, e.Error())
+		}
+		files["errors.log"] = []byte(strings.Join(lines, "\n"))
+
+		files["requests.json"] = utils.MustToJSONBytes(r.pendingRequests())
+		files["trace.log"] = []byte(strings.Join(r.traceEntries(), "\n"))
+	}
+
+	if strings.HasSuffix(dir, ".zip") {
+		fail(writeZip(dir, files))
+	} else {
+		for name, data := range files {
+			fail(utils.OutputFile(filepath.Join(dir, name), data))
+		}
+	}
+
+	return
+}
+
+func writeZip(file string, files map[string][]byte) error {
+	err := os.MkdirAll(filepath.Dir(file), 0755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	names := []string{}
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	zw := zip.NewWriter(f)
+	for _, name := range names {
+		w, err := zw.Create(name)
+		if err == nil {
+			_, err = w.Write(files[name])
+		}
+		if err != nil {
+			_ = zw.Close()
+			_ = f.Close()
+			return err
+		}
+	}
+
+	err = zw.Close()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
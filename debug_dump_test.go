@@ -0,0 +1,95 @@
+package rod_test
+
+import (
+	"archive/zip"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+func TestDumpDebug(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Mux.HandleFunc("/pending", func(rw http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	s.Route("/", ".html", `<html><body><button>click me</button></body></html>`)
+
+	p := g.newPage(s.URL()).MustWaitLoad()
+	r := p.RecordDebug()
+	defer r.Stop()
+
+	p.MustEval(`() => console.log("hello")`)
+	p.MustEval(`() => setTimeout(() => { throw new Error("boom") })`)
+	p.MustEval(`() => { fetch('/pending') }`)
+	p.MustElement("button").MustClick()
+	utils.Sleep(0.3)
+
+	dir := filepath.Join(os.TempDir(), "rod", "debug-dump", g.RandStr(8))
+	p.MustDumpDebug(dir)
+
+	read := func(name string) string {
+		s, err := utils.ReadString(filepath.Join(dir, name))
+		g.E(err)
+		return s
+	}
+
+	g.Has(read("info.json"), s.URL())
+	g.Has(read("page.html"), "click me")
+	g.Gt(len(read("screenshot.png")), 0)
+	g.Has(read("console.log"), "[log] hello")
+	g.Has(read("errors.log"), "Error: boom")
+	g.Has(read("requests.json"), "/pending")
+	g.Has(read("trace.log"), "left click")
+
+	zipFile := dir + ".zip"
+	p.MustDumpDebug(zipFile)
+	zr, err := zip.OpenReader(zipFile)
+	g.E(err)
+	defer func() { _ = zr.Close() }()
+	names := []string{}
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	g.Eq(names, []string{
+		"console.log", "errors.log", "info.json", "page.html", "requests.json", "screenshot.png", "trace.log",
+	})
+
+	r.Stop()
+	other := filepath.Join(os.TempDir(), "rod", "debug-dump", g.RandStr(8))
+	p.MustDumpDebug(other)
+	_, err = os.Stat(filepath.Join(other, "console.log"))
+	g.True(os.IsNotExist(err))
+
+	g.mc.stubErr(1, proto.PageCaptureScreenshot{})
+	g.Err(p.DumpDebug(other))
+
+	g.Err(p.DumpDebug(filepath.Join(zipFile, "a.zip")))
+}
+
+func TestRecordDebugTwice(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank()).MustWaitLoad()
+	old := p.RecordDebug()
+	r := p.RecordDebug()
+	defer r.Stop()
+
+	old.Stop()
+
+	p.MustEval(`() => console.log("hello")`)
+	utils.Sleep(0.3)
+
+	dir := filepath.Join(os.TempDir(), "rod", "debug-dump", g.RandStr(8))
+	p.MustDumpDebug(dir)
+
+	s, err := utils.ReadString(filepath.Join(dir, "console.log"))
+	g.E(err)
+	g.Eq(strings.Count(s, "hello"), 1)
+}
@@ -6,12 +6,15 @@
 package rod
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"html"
+	"mime"
 	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-rod/rod/lib/assets"
@@ -50,15 +53,34 @@ const (
 // 启动一个监控服务
 // The reason why not to use "chrome://inspect/#devices" is one target cannot be driven by multiple controllers.
 // 不使用 "chrome://inspect/#devices "的原因是一个目标不能被多个控制器驱动。
+// Besides the web ui, it serves the json api for the dashboards and health checks:
+//     GET /api/health                 MonitorHealth, the status code is 503 if the browser is unreachable
+//     GET /api/pages                  []*proto.TargetTargetInfo of the pages
+//     GET /api/page/<id>              *proto.TargetTargetInfo of the page
+//     GET /api/screenshot/<id>        the screenshot, the query can be format=png|jpeg, quality=0-100, full=true
+//     GET /api/traces                 []*MonitorTrace, the query can be page=<id>, limit=100
+//     GET /api/network/<id>           MonitorNetwork of the page, the query can be since=<version>
+//     GET /api/body/<id>              the response body preview of the page as plain text, the query must have request=<request id>
+//     GET /api/logs/<id>              MonitorLogs of the page, the query can be since=<seq>
+// The errors are responded as json with the status code 400.
+// 除了 web 界面，它还为仪表盘和健康检查提供 json api，列表如上。错误会以 json 的形式响应，状态码为 400。
+// The server has no auth, use Browser.ServeMonitorWith to add auth and TLS.
+// 该服务没有鉴权，使用 Browser.ServeMonitorWith 来添加鉴权和 TLS。
 func (b *Browser) ServeMonitor(host string) string {
-	url, mux, close := serve(host)
-	go func() {
-		<-b.ctx.Done()
-		utils.E(close())
-	}()
+	url, err := b.ServeMonitorWith(MonitorOptions{Host: host})
+	utils.E(err)
+	return url
+}
+
+func (b *Browser) monitorMux(prefix, host string) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	b.serveMonitorAPI(mux)
+	b.serveMonitorNetwork(mux)
+	b.serveMonitorLogs(mux)
 
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		httHTML(w, assets.Monitor)
+		httHTML(w, monitorHTML(assets.Monitor, prefix))
 	})
 	mux.HandleFunc("/api/pages", func(w http.ResponseWriter, r *http.Request) {
 		res, err := proto.TargetGetTargets{}.Call(b)
@@ -71,18 +93,16 @@ func (b *Browser) ServeMonitor(host string) string {
 			}
 		}
 
-		w.WriteHeader(http.StatusOK)
-		utils.E(w.Write(utils.MustToJSONBytes(list)))
+		httJSON(w, list)
 	})
 	mux.HandleFunc("/page/", func(w http.ResponseWriter, r *http.Request) {
-		httHTML(w, assets.MonitorPage)
+		httHTML(w, monitorHTML(assets.MonitorPage, prefix))
 	})
 	mux.HandleFunc("/api/page/", func(w http.ResponseWriter, r *http.Request) {
 		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
 		info, err := b.pageInfo(proto.TargetTargetID(id))
 		utils.E(err)
-		w.WriteHeader(http.StatusOK)
-		utils.E(w.Write(utils.MustToJSONBytes(info)))
+		httJSON(w, info)
 	})
 	mux.HandleFunc("/screenshot/", func(w http.ResponseWriter, r *http.Request) {
 		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
@@ -92,8 +112,188 @@ func (b *Browser) ServeMonitor(host string) string {
 		w.Header().Add("Content-Type", "image/png;")
 		utils.E(w.Write(p.MustScreenshot()))
 	})
+	mux.HandleFunc("/screencast/", func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		target := proto.TargetTargetID(id)
+		p := b.MustPageFromTargetID(target)
 
-	return url
+		utils.E(serveScreencast(w, r, p))
+	})
+	mux.HandleFunc("/api/input/", func(w http.ResponseWriter, r *http.Request) {
+		// only accept the json posted by scripts, the forms of other sites can't post it without a cors preflight
+		// 只接受脚本 post 的 json，其他网站的表单在没有 cors 预检的情况下无法 post 它
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if t, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); t != "application/json" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		if !monitorHostAllowed(r.Host, host) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		target := proto.TargetTargetID(id)
+		p := b.MustPageFromTargetID(target)
+
+		var input monitorInput
+		utils.E(json.NewDecoder(r.Body).Decode(&input))
+		utils.E(input.dispatch(p))
+		w.WriteHeader(http.StatusOK)
+	})
+
+	return mux
+}
+
+// To prevent the dns rebinding, the host of the request must be an ip, "localhost", or the host the monitor listens on.
+// 为了防止 dns 重绑定，请求的 host 必须是 ip、"localhost"，或者监控服务监听的 host。
+func monitorHostAllowed(reqHost, listenHost string) bool {
+	hostname := func(h string) string {
+		if name, _, err := net.SplitHostPort(h); err == nil {
+			return name
+		}
+		return h
+	}
+
+	name := hostname(reqHost)
+	if name == "localhost" || net.ParseIP(strings.Trim(name, "[]")) != nil {
+		return true
+	}
+	return name != "" && name == hostname(listenHost)
+}
+
+// the urls of the web ui are relative, set the base url for them
+// web 界面的 url 是相对的，为它们设置基础 url
+func monitorHTML(page, prefix string) string {
+	return strings.Replace(page, "<head>", `<head><base href="`+html.EscapeString(prefix)+`/" />`, 1)
+}
+
+// the boundary of the parts of the mjpeg stream
+// mjpeg 流中各部分之间的分隔符
+const screencastBoundary = "rod-screencast-frame"
+
+// the number of the viewers of the screencast of a page
+// 页面 screencast 的观看者数量
+type screencastViewersKey struct {
+	targetID proto.TargetTargetID
+}
+
+var screencastViewersLock sync.Mutex
+
+// start the screencast for a new viewer of the page, starting it again makes the browser send the current frame
+// 为页面新的观看者启动 screencast，再次启动它会让浏览器发送当前的帧
+func (p *Page) joinScreencast() error {
+	screencastViewersLock.Lock()
+	defer screencastViewersLock.Unlock()
+
+	quality := 80
+	err := proto.PageStartScreencast{Format: proto.PageStartScreencastFormatJpeg, Quality: &quality}.Call(rodCall(p))
+	if err != nil {
+		return err
+	}
+
+	n := 0
+	if v, has := p.browser.states.Load(screencastViewersKey{p.TargetID}); has {
+		n = v.(int)
+	}
+	p.browser.states.Store(screencastViewersKey{p.TargetID}, n+1)
+	return nil
+}
+
+// stop the screencast if the viewer is the last one of the page
+// 如果观看者是页面的最后一个观看者，则停止 screencast
+func (p *Page) leaveScreencast() {
+	screencastViewersLock.Lock()
+	defer screencastViewersLock.Unlock()
+
+	key := screencastViewersKey{p.TargetID}
+	v, has := p.browser.states.Load(key)
+	if has && v.(int) > 1 {
+		p.browser.states.Store(key, v.(int)-1)
+		return
+	}
+
+	p.browser.states.Delete(key)
+	_ = proto.PageStopScreencast{}.Call(rodCall(p))
+}
+
+// Stream the screencast of the page as mjpeg until the request is done, the img tag can render it directly.
+// The screencast is shared by the viewers of the page, it's stopped when the last viewer disconnects.
+// 以 mjpeg 的格式推送页面的 screencast，直到请求结束，img 标签可以直接渲染它。
+// 页面的观看者共享同一个 screencast，当最后一个观看者断开连接时它才会被停止。
+func serveScreencast(w http.ResponseWriter, r *http.Request, p *Page) error {
+	page := p.Context(r.Context())
+	flusher, _ := w.(http.Flusher)
+
+	var writeErr error
+	wait := page.EachEvent(func(e *proto.PageScreencastFrame) bool {
+		_ = proto.PageScreencastFrameAck{SessionID: e.SessionID}.Call(rodCall(page))
+
+		_, writeErr = fmt.Fprintf(w, "--%s\r\nContent-Type: image/jpeg\r\nContent-Length: %d\r\n\r\n",
+			screencastBoundary, len(e.Data))
+		if writeErr == nil {
+			_, writeErr = w.Write(append(e.Data, '\r', '\n'))
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return writeErr != nil
+	})
+
+	err := page.joinScreencast()
+	if err != nil {
+		return err
+	}
+	// the request context is done when the stream ends, use the page's context to stop the screencast
+	// 流结束时请求的 context 已经结束，使用页面的 context 来停止 screencast
+	defer p.leaveScreencast()
+
+	w.Header().Set("Content-Type", "multipart/x-mixed-replace; boundary="+screencastBoundary)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if flusher != nil {
+		flusher.Flush()
+	}
+
+	wait()
+
+	return writeErr
+}
+
+// the input event forwarded from the monitor page to the remote page
+// 从监控页面转发到远程页面的输入事件
+type monitorInput struct {
+	// the X and Y are the ratio to the size of the screen image, such as 0.5 is the center
+	// X 和 Y 是相对于屏幕图像尺寸的比例，例如 0.5 表示中心
+	Mouse *proto.InputDispatchMouseEvent `json:"mouse,omitempty"`
+
+	Key *proto.InputDispatchKeyEvent `json:"key,omitempty"`
+}
+
+func (input monitorInput) dispatch(p *Page) error {
+	if input.Key != nil {
+		return input.Key.Call(p)
+	}
+
+	if input.Mouse == nil {
+		return nil
+	}
+
+	metrics, err := proto.PageGetLayoutMetrics{}.Call(p)
+	if err != nil {
+		return err
+	}
+	vp := metrics.CSSVisualViewport
+	if vp == nil {
+		vp = metrics.VisualViewport
+	}
+	input.Mouse.X *= vp.ClientWidth
+	input.Mouse.Y *= vp.ClientHeight
+	return input.Mouse.Call(p)
 }
 
 // check method and sleep if needed
@@ -119,35 +319,31 @@ func (p *Page) ExposeHelpers(list ...*js.Function) {
 // Overlay a rectangle on the main frame with specified message
 // 在主 Frame 上叠加一个带有指定消息的矩形
 func (p *Page) Overlay(left, top, width, height float64, msg string) (remove func()) {
-	id := utils.RandString(8)
-
-	_, _ = p.root.Evaluate(evalHelper(js.Overlay,
-		id,
-		left,
-		top,
-		width,
-		height,
-		msg,
-	).ByPromise())
-
-	remove = func() {
-		_, _ = p.root.Evaluate(evalHelper(js.RemoveOverlay, id))
-	}
-
-	return
+	return p.overlay(left, top, width, height, msg, &overlayStyle{})
 }
 
 func (p *Page) tryTrace(typ TraceType, msg ...interface{}) func() {
+	done := p.recordTrace(typ, msg, p)
+
 	if !p.browser.trace {
-		return func() {}
+		return done
 	}
 
+	detail := fmt.Sprint(msg...)
 	msg = append([]interface{}{typ}, msg...)
 	msg = append(msg, p)
 
-	p.browser.logger.Println(msg...)
+	p.browser.logTrace(msg, typ.String(), "detail", detail, "page", p)
 
-	return p.Overlay(0, 0, 500, 0, fmt.Sprint(msg))
+	if !p.browser.drawTrace(typ) {
+		return done
+	}
+
+	remove := p.traceOverlay(fmt.Sprint(msg))
+	return func() {
+		remove()
+		done()
+	}
 }
 
 func (p *Page) tryTraceQuery(opts *EvalOptions) func() {
@@ -155,10 +351,14 @@ func (p *Page) tryTraceQuery(opts *EvalOptions) func() {
 		return func() {}
 	}
 
-	p.browser.logger.Println(TraceTypeQuery, opts, p)
+	p.browser.logTrace([]interface{}{TraceTypeQuery, opts, p}, TraceTypeQuery.String(), "js", opts, "page", p)
+
+	if !p.browser.drawTrace(TraceTypeQuery) {
+		return func() {}
+	}
 
 	msg := fmt.Sprintf("<code>%s</code>", html.EscapeString(opts.String()))
-	return p.Overlay(0, 0, 500, 0, msg)
+	return p.traceOverlay(msg)
 }
 
 func (p *Page) tryTraceReq(includes, excludes []string) func(map[proto.NetworkRequestID]string) {
@@ -170,8 +370,12 @@ func (p *Page) tryTraceReq(includes, excludes []string) func(map[proto.NetworkRe
 		"includes": includes,
 		"excludes": excludes,
 	}
-	p.browser.logger.Println(TraceTypeWaitRequestsIdle, msg, p)
-	cleanup := p.Overlay(0, 0, 500, 0, utils.MustToJSON(msg))
+	p.browser.logTrace([]interface{}{TraceTypeWaitRequestsIdle, msg, p}, TraceTypeWaitRequestsIdle.String(),
+		"includes", includes, "excludes", excludes, "page", p)
+	cleanup := func() {}
+	if p.browser.drawTrace(TraceTypeWaitRequestsIdle) {
+		cleanup = p.traceOverlay(utils.MustToJSON(msg))
+	}
 
 	ch := make(chan map[string]string)
 	update := func(list map[proto.NetworkRequestID]string) {
@@ -193,7 +397,8 @@ func (p *Page) tryTraceReq(includes, excludes []string) func(map[proto.NetworkRe
 				return
 			case waitlist = <-ch:
 			case <-t.C:
-				p.browser.logger.Println(TraceTypeWaitRequests, p, waitlist)
+				p.browser.logTrace([]interface{}{TraceTypeWaitRequests, p, waitlist},
+					TraceTypeWaitRequests.String(), "page", p, "waitlist", waitlist)
 			}
 		}
 	}()
@@ -204,31 +409,31 @@ func (p *Page) tryTraceReq(includes, excludes []string) func(map[proto.NetworkRe
 // Overlay msg on the element
 // 在元素上叠加 msg
 func (el *Element) Overlay(msg string) (removeOverlay func()) {
-	id := utils.RandString(8)
-
-	_, _ = el.Evaluate(evalHelper(js.ElementOverlay,
-		id,
-		msg,
-	).ByPromise())
-
-	removeOverlay = func() {
-		_, _ = el.Evaluate(evalHelper(js.RemoveOverlay, id))
-	}
-
-	return
+	return el.overlay(msg, &overlayStyle{})
 }
 
 func (el *Element) tryTrace(typ TraceType, msg ...interface{}) func() {
+	done := el.page.recordTrace(typ, msg, el)
+
 	if !el.page.browser.trace {
-		return func() {}
+		return done
 	}
 
+	detail := fmt.Sprint(msg...)
 	msg = append([]interface{}{typ}, msg...)
 	msg = append(msg, el)
 
-	el.page.browser.logger.Println(msg...)
+	el.page.browser.logTrace(msg, typ.String(), "detail", detail, "element", el)
+
+	if !el.page.browser.drawTrace(typ) {
+		return done
+	}
 
-	return el.Overlay(fmt.Sprint(msg))
+	remove := el.overlay(fmt.Sprint(msg), el.page.browser.overlayStyle())
+	return func() {
+		remove()
+		done()
+	}
 }
 
 func (m *Mouse) initMouseTracer() {
@@ -243,15 +448,34 @@ func (m *Mouse) updateMouseTracer() bool {
 	return res.Value.Bool()
 }
 
-// Serve a port, if host is empty a random port will be used.
-// 为端口提供服务，如果主机为空，将使用随机端口。
-func serve(host string) (string, *http.ServeMux, func() error) {
+// Serve a port, if host is empty a random port will be used. If config isn't nil https will be used.
+// 为端口提供服务，如果主机为空，将使用随机端口。如果 config 不为 nil，将使用 https。
+func serve(host string, handler http.Handler, config *tls.Config) (string, func() error, error) {
 	if host == "" {
 		host = "127.0.0.1:0"
 	}
 
-	mux := http.NewServeMux()
-	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	l, err := net.Listen("tcp", host)
+	if err != nil {
+		return "", nil, err
+	}
+
+	scheme := "http://"
+	if config != nil {
+		l = tls.NewListener(l, config)
+		scheme = "https://"
+	}
+
+	srv := &http.Server{Handler: handler}
+	go func() { _ = srv.Serve(l) }()
+
+	return scheme + l.Addr().String(), srv.Close, nil
+}
+
+// respond the panics as json errors
+// 将 panic 以 json 错误的形式响应
+func recoverHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
 				w.WriteHeader(http.StatusBadRequest)
@@ -259,15 +483,6 @@ func serve(host string) (string, *http.ServeMux, func() error) {
 			}
 		}()
 
-		mux.ServeHTTP(w, r)
-	})}
-
-	l, err := net.Listen("tcp", host)
-	utils.E(err)
-
-	go func() { _ = srv.Serve(l) }()
-
-	url := "http://" + l.Addr().String()
-
-	return url, mux, srv.Close
+		h.ServeHTTP(w, r)
+	})
 }
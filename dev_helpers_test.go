@@ -1,6 +1,11 @@
 package rod_test
 
 import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +15,7 @@ import (
 	"github.com/go-rod/rod/lib/launcher"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
+	"github.com/ysmood/got"
 	"github.com/ysmood/gson"
 )
 
@@ -33,11 +39,187 @@ func TestMonitor(t *testing.T) {
 	img := g.Req("", host+"/screenshot").Bytes()
 	g.Gt(img.Len(), 10)
 
+	req, err := http.NewRequestWithContext(g.Timeout(5*time.Second), "", host+"/screencast/"+string(p.TargetID), nil)
+	g.E(err)
+	stream, err := http.DefaultClient.Do(req)
+	g.E(err)
+	defer func() { _ = stream.Body.Close() }()
+	g.Has(stream.Header.Get("Content-Type"), "multipart/x-mixed-replace")
+	line, err := bufio.NewReader(stream.Body).ReadString('\n')
+	g.E(err)
+	g.Has(line, "--rod-screencast-frame")
+
+	// the screencast keeps going for the other viewers when one of them disconnects
+	ctx := g.Timeout(5 * time.Second)
+	req, err = http.NewRequestWithContext(ctx, "", host+"/screencast/"+string(p.TargetID), nil)
+	g.E(err)
+	other, err := http.DefaultClient.Do(req)
+	g.E(err)
+	_, err = bufio.NewReader(other.Body).ReadString('\n')
+	g.E(err)
+	_ = other.Body.Close()
+	utils.Sleep(0.3)
+	p.MustEval(`() => document.body.style.background = 'red'`)
+	r := bufio.NewReader(stream.Body)
+	for {
+		line, err = r.ReadString('\n')
+		g.E(err)
+		if strings.Contains(line, "--rod-screencast-frame") {
+			break
+		}
+	}
+
+	p.MustEval(`() => { const el = document.createElement('input'); document.body.append(el); el.focus() }`)
+	input := host + "/api/input/" + string(p.TargetID)
+	g.Eq(200, g.Req("POST", input, got.ReqMIME(".json"), `{"key":{"type":"keyDown","key":"a","text":"a"}}`).StatusCode)
+	g.Eq(p.MustElement("input").MustText(), "a")
+	g.Eq(200, g.Req("POST", input, got.ReqMIME(".json"), `{"mouse":{"type":"mouseMoved","x":0.5,"y":0.5}}`).StatusCode)
+	g.Eq(405, g.Req("GET", input).StatusCode)
+	g.Eq(415, g.Req("POST", input, `{"key":{"type":"keyDown","key":"b","text":"b"}}`).StatusCode)
+
+	// the dns rebinding is blocked
+	rebind, err := http.NewRequest("POST", input, strings.NewReader(`{"key":{"type":"keyDown","key":"b","text":"b"}}`))
+	g.E(err)
+	rebind.Header.Set("Content-Type", "application/json")
+	rebind.Host = "rebind.example.com"
+	rebound, err := http.DefaultClient.Do(rebind)
+	g.E(err)
+	_ = rebound.Body.Close()
+	g.Eq(rebound.StatusCode, 403)
+	g.Eq(p.MustElement("input").MustText(), "a")
+
+	health := g.Req("", host+"/api/health")
+	g.Eq(200, health.StatusCode)
+	g.Eq(health.Header.Get("Content-Type"), "application/json")
+	g.Gt(gson.New(health.Body).Get("pages").Int(), 0)
+
+	p.MustElement("input").MustScrollIntoView()
+	traces := gson.New(g.Req("", host+"/api/traces?limit=1&page="+string(p.TargetID)).Body).Arr()
+	g.Len(traces, 1)
+	g.Eq(traces[0].Get("detail").Str(), "scroll into view")
+
+	jpeg := g.Req("", host+"/api/screenshot/"+string(p.TargetID)+"?format=jpeg&quality=10")
+	g.Eq(jpeg.Header.Get("Content-Type"), "image/jpeg")
+	g.Gt(jpeg.Bytes().Len(), 10)
+
+	network := host + "/api/network/" + string(p.TargetID)
+	g.Eq(g.Req("", network).StatusCode, 200)
+	s := g.Serve()
+	s.Route("/net", ".html", `<html>network</html>`)
+	p.MustNavigate(s.URL("/net")).MustWaitLoad()
+	var netReq gson.JSON
+	sleeper := utils.BackoffSleeper(30*time.Millisecond, time.Second, nil)
+	g.E(utils.Retry(g.Timeout(10*time.Second), sleeper, func() (bool, error) {
+		for _, r := range gson.New(g.Req("", network).Body).Get("requests").Arr() {
+			if r.Get("url").Str() == s.URL("/net") && r.Get("done").Bool() {
+				netReq = r
+				return true, nil
+			}
+		}
+		return false, nil
+	}))
+	g.Eq(netReq.Get("status").Int(), 200)
+	g.Eq(netReq.Get("type").Str(), "Document")
+	body := g.Req("", host+"/api/body/"+string(p.TargetID)+"?request="+netReq.Get("id").Str())
+	g.Eq(body.Header.Get("Content-Type"), "text/plain; charset=utf-8")
+	g.Eq(body.Header.Get("X-Content-Type-Options"), "nosniff")
+	g.Eq(body.String(), `<html>network</html>`)
+
+	logs := host + "/api/logs/" + string(p.TargetID)
+	g.Len(gson.New(g.Req("", logs).Body).Get("entries").Arr(), 1) // the trace of the scroll into view above
+	p.MustEval(`() => console.warn("monitor", 1)`)
+	var entries []gson.JSON
+	g.E(utils.Retry(g.Timeout(10*time.Second), sleeper, func() (bool, error) {
+		entries = gson.New(g.Req("", logs+"?since=1").Body).Get("entries").Arr()
+		return len(entries) == 1, nil
+	}))
+	g.Eq(entries[0].Get("level").Str(), "warning")
+	g.Eq(entries[0].Get("text").Str(), "monitor 1")
+	p.MustElement("html").MustScrollIntoView()
+	entries = gson.New(g.Req("", logs+"?since=2").Body).Get("entries").Arr()
+	g.Eq(entries[0].Get("level").Str(), "trace")
+
 	res := g.Req("", host+"/api/page/test")
 	g.Eq(400, res.StatusCode)
 	g.Eq(-32602, gson.New(res.Body).Get("code").Int())
 }
 
+func TestMonitorAuth(t *testing.T) {
+	g := setup(t)
+
+	b, cancel := g.browser.WithCancel()
+	defer cancel()
+
+	ts := httptest.NewUnstartedServer(nil)
+	ts.StartTLS()
+	defer ts.Close()
+
+	host, err := b.ServeMonitorWith(rod.MonitorOptions{
+		Username:  "user",
+		Password:  "pass",
+		Token:     "token",
+		TLSConfig: ts.TLS.Clone(),
+	})
+	g.E(err)
+	g.Has(host, "https://")
+
+	req := func(path string, set func(*http.Request)) *http.Response {
+		r, err := http.NewRequest("", host+path, nil)
+		g.E(err)
+		set(r)
+		res, err := ts.Client().Do(r)
+		g.E(err)
+		_ = res.Body.Close()
+		return res
+	}
+
+	g.Eq(req("/api/pages", func(r *http.Request) {}).StatusCode, 401)
+	g.Eq(req("/api/pages", func(r *http.Request) { r.SetBasicAuth("user", "wrong") }).StatusCode, 401)
+	g.Eq(req("/api/pages", func(r *http.Request) { r.SetBasicAuth("user", "pass") }).StatusCode, 200)
+	g.Eq(req("/api/pages", func(r *http.Request) { r.Header.Set("Authorization", "Bearer token") }).StatusCode, 200)
+
+	res := req("/?token=token", func(r *http.Request) {})
+	g.Eq(res.StatusCode, 200)
+	cookies := res.Cookies()
+	g.Len(cookies, 1)
+	g.True(cookies[0].Secure)
+	g.Eq(cookies[0].Path, "/")
+	g.Eq(req("/api/pages", func(r *http.Request) { r.AddCookie(cookies[0]) }).StatusCode, 200)
+
+	_, err = b.ServeMonitorWith(rod.MonitorOptions{CertFile: "not-exists", KeyFile: "not-exists"})
+	g.Err(err)
+}
+
+func TestMonitorHandler(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank())
+
+	mux := http.NewServeMux()
+	mux.Handle("/rod/", g.browser.MonitorHandler(rod.MonitorOptions{Prefix: "/rod/"}))
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	g.Has(g.Req("", ts.URL+"/rod").String(), `<base href="/rod/" />`)
+	g.Eq(g.Req("", ts.URL+"/rod/api/pages").Header.Get("Content-Type"), "application/json")
+	g.Eq(g.Req("", ts.URL+"/api/pages").StatusCode, 404)
+
+	tokenMux := http.NewServeMux()
+	tokenMux.Handle("/rod/", g.browser.MonitorHandler(rod.MonitorOptions{Prefix: "/rod/", Token: "token"}))
+	tokenTS := httptest.NewServer(tokenMux)
+	defer tokenTS.Close()
+
+	cookies := g.Req("", tokenTS.URL+"/rod/?token=token").Cookies()
+	g.Len(cookies, 1)
+	g.Eq(cookies[0].Path, "/rod")
+	g.False(cookies[0].Secure)
+
+	page := g.newPage(ts.URL + "/rod")
+	page.MustElement(fmt.Sprintf(`#targets a[href="page/%s"]`, p.TargetID)).MustClick()
+	page.MustWait(`(id) => document.title.includes(id)`, p.TargetID)
+	g.Eq(page.MustInfo().URL, ts.URL+"/rod/page/"+string(p.TargetID))
+}
+
 func TestMonitorErr(t *testing.T) {
 	g := setup(t)
 
@@ -80,6 +262,43 @@ func TestTrace(t *testing.T) {
 	_ = p.Mouse.Move(10, 10, 1)
 }
 
+func TestTraceOverlay(t *testing.T) {
+	g := setup(t)
+
+	g.browser.Logger(utils.LoggerQuiet)
+	g.browser.Trace(true).TraceOverlay(&rod.TraceOverlayOptions{
+		Types:            []rod.TraceType{rod.TraceTypeQuery},
+		BorderColor:      "blue",
+		FontSize:         20,
+		Position:         rod.TraceOverlayBottomRight,
+		AutoHide:         time.Minute,
+		HighlightQueries: true,
+	})
+	defer func() {
+		g.browser.Logger(rod.DefaultLogger)
+		g.browser.Trace(defaults.Trace).TraceOverlay(nil)
+	}()
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/click.html")).MustWaitLoad()
+	countBlue := `() => [...document.querySelectorAll('div')].filter(d => d.style.borderColor === 'blue').length`
+
+	// only the highlight of the query stays, the input trace isn't drawn
+	p.MustElement("button").MustClick()
+	g.Eq(p.MustEval(countBlue).Int(), 1)
+
+	p.MustElement("button")
+	g.Eq(p.MustEval(countBlue).Int(), 2)
+	g.Eq(p.MustEval(`() => [...document.querySelectorAll('div')].filter(d => d.style.fontSize === '20px').length`).Int(), 2)
+
+	p.MustElements("button")
+	g.Eq(p.MustEval(countBlue).Int(), 3)
+
+	// the style of the trace doesn't apply to the public overlays
+	p.Overlay(0, 0, 100, 30, "")
+	p.MustElement("button").Overlay("")
+	g.Eq(p.MustEval(countBlue).Int(), 4)
+}
+
 func TestTraceLogs(t *testing.T) {
 	g := setup(t)
 
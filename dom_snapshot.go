@@ -0,0 +1,397 @@
+package rod
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// DOMSnapshot of the flattened DOM of a page, including the iframes, use Page.DOMSnapshot to create it.
+// It's useful to extract hundreds of nodes without hundreds of evals.
+// DOMSnapshot 是页面的扁平化 DOM 的快照，包括 iframe，使用 Page.DOMSnapshot 创建它。
+// 它可以用于提取数百个节点，而不需要数百次 eval。
+type DOMSnapshot struct {
+	// Nodes of all the documents in document order, the root of an iframe document follows its iframe node
+	// Nodes 是所有文档中按文档顺序排列的节点，iframe 文档的根节点紧跟在其 iframe 节点之后
+	Nodes []*DOMSnapshotNode
+}
+
+// DOMSnapshotNode is a node of the DOMSnapshot
+// DOMSnapshotNode 是 DOMSnapshot 中的一个节点
+type DOMSnapshotNode struct {
+	// Document is the index of the document the node belongs to, 0 is the main document
+	// Document 是节点所属文档的索引，0 是主文档
+	Document int
+
+	Parent   *DOMSnapshotNode
+	Children []*DOMSnapshotNode
+
+	// Type of the node, such as 1 for element, 3 for text
+	// Type 是节点的类型，例如 1 表示元素，3 表示文本
+	Type int
+
+	// Name of the node, such as "DIV", "#text"
+	// Name 是节点的名称，例如 "DIV"、"#text"
+	Name string
+
+	Value      string
+	Attributes map[string]string
+
+	// InputValue of the input and textarea elements
+	// InputValue 是 input 和 textarea 元素的值
+	InputValue string
+
+	Checked   bool
+	Clickable bool
+
+	BackendNodeID proto.DOMBackendNodeID
+
+	// Layout is nil if the node is not rendered
+	// 如果节点没有被渲染，Layout 为 nil
+	Layout *DOMSnapshotLayout
+}
+
+// DOMSnapshotLayout of a rendered node
+// 已渲染节点的布局
+type DOMSnapshotLayout struct {
+	Bounds *proto.DOMRect
+
+	// Styles are the computed styles specified by the DOMSnapshotCaptureSnapshot.ComputedStyles
+	// Styles 是 DOMSnapshotCaptureSnapshot.ComputedStyles 指定的计算样式
+	Styles map[string]string
+
+	// Text of the layout object
+	// Text 是布局对象的文本
+	Text string
+}
+
+// DOMSnapshot captures the flattened DOM of the page with the computed styles and the layout in one call,
+// use opts.ComputedStyles to specify the styles to capture, such as "display", "color".
+// DOMSnapshot 在一次调用中捕获页面的扁平化 DOM 以及计算样式和布局，使用 opts.ComputedStyles 指定要捕获的样式，
+// 例如 "display"、"color"。
+func (p *Page) DOMSnapshot(opts proto.DOMSnapshotCaptureSnapshot) (*DOMSnapshot, error) {
+	if opts.ComputedStyles == nil {
+		opts.ComputedStyles = []string{}
+	}
+
+	res, err := opts.Call(rodCall(p))
+	if err != nil {
+		return nil, err
+	}
+
+	return newDOMSnapshot(res, opts.ComputedStyles), nil
+}
+
+func newDOMSnapshot(res *proto.DOMSnapshotCaptureSnapshotResult, styles []string) *DOMSnapshot {
+	str := func(i proto.DOMSnapshotStringIndex) string {
+		if i < 0 || int(i) >= len(res.Strings) {
+			return ""
+		}
+		return res.Strings[i]
+	}
+
+	docs := make([][]*DOMSnapshotNode, len(res.Documents))
+	owners := map[int]*DOMSnapshotNode{} // document index to its iframe node
+
+	for di, doc := range res.Documents {
+		if doc.Nodes == nil {
+			continue
+		}
+
+		list := newDOMSnapshotNodes(di, doc.Nodes, str)
+
+		if c := doc.Nodes.ContentDocumentIndex; c != nil {
+			for j, i := range c.Index {
+				if j < len(c.Value) && i < len(list) {
+					owners[c.Value[j]] = list[i]
+				}
+			}
+		}
+
+		if doc.Layout != nil {
+			setDOMSnapshotLayouts(list, doc.Layout, styles, str)
+		}
+
+		docs[di] = list
+	}
+
+	// attach the iframe documents to their iframe nodes
+	// 将 iframe 文档挂载到它们的 iframe 节点上
+	for di, owner := range owners {
+		if di < len(docs) && len(docs[di]) > 0 {
+			root := docs[di][0]
+			root.Parent = owner
+			owner.Children = append(owner.Children, root)
+		}
+	}
+
+	s := &DOMSnapshot{Nodes: []*DOMSnapshotNode{}}
+	if len(docs) > 0 && len(docs[0]) > 0 {
+		s.walk(docs[0][0])
+	}
+	return s
+}
+
+func newDOMSnapshotNodes(
+	doc int, tree *proto.DOMSnapshotNodeTreeSnapshot, str func(proto.DOMSnapshotStringIndex) string,
+) []*DOMSnapshotNode {
+	list := make([]*DOMSnapshotNode, len(tree.NodeType))
+	for i, typ := range tree.NodeType {
+		n := &DOMSnapshotNode{Document: doc, Type: typ, Attributes: map[string]string{}}
+		if i < len(tree.NodeName) {
+			n.Name = str(tree.NodeName[i])
+		}
+		if i < len(tree.NodeValue) {
+			n.Value = str(tree.NodeValue[i])
+		}
+		if i < len(tree.BackendNodeID) {
+			n.BackendNodeID = tree.BackendNodeID[i]
+		}
+		if i < len(tree.Attributes) {
+			attrs := tree.Attributes[i]
+			for j := 0; j+1 < len(attrs); j += 2 {
+				n.Attributes[str(attrs[j])] = str(attrs[j+1])
+			}
+		}
+		list[i] = n
+	}
+
+	for i, parent := range tree.ParentIndex {
+		if parent >= 0 && parent < len(list) && i < len(list) {
+			list[i].Parent = list[parent]
+			list[parent].Children = append(list[parent].Children, list[i])
+		}
+	}
+
+	get := func(i int) *DOMSnapshotNode {
+		if i < len(list) {
+			return list[i]
+		}
+		return &DOMSnapshotNode{}
+	}
+	eachRareString(tree.InputValue, func(i int, v proto.DOMSnapshotStringIndex) { get(i).InputValue = str(v) })
+	eachRareBool(tree.InputChecked, func(i int) { get(i).Checked = true })
+	eachRareBool(tree.IsClickable, func(i int) { get(i).Clickable = true })
+
+	return list
+}
+
+func setDOMSnapshotLayouts(
+	list []*DOMSnapshotNode, l *proto.DOMSnapshotLayoutTreeSnapshot, styles []string,
+	str func(proto.DOMSnapshotStringIndex) string,
+) {
+	for li, i := range l.NodeIndex {
+		if i >= len(list) {
+			continue
+		}
+		layout := &DOMSnapshotLayout{Styles: map[string]string{}}
+		if li < len(l.Bounds) && len(l.Bounds[li]) == 4 {
+			b := l.Bounds[li]
+			layout.Bounds = &proto.DOMRect{X: b[0], Y: b[1], Width: b[2], Height: b[3]}
+		}
+		if li < len(l.Styles) {
+			for j, v := range l.Styles[li] {
+				if j < len(styles) {
+					layout.Styles[styles[j]] = str(v)
+				}
+			}
+		}
+		if li < len(l.Text) {
+			layout.Text = str(l.Text[li])
+		}
+		list[i].Layout = layout
+	}
+}
+
+func (s *DOMSnapshot) walk(n *DOMSnapshotNode) {
+	s.Nodes = append(s.Nodes, n)
+	for _, c := range n.Children {
+		s.walk(c)
+	}
+}
+
+func eachRareString(data *proto.DOMSnapshotRareStringData, fn func(i int, v proto.DOMSnapshotStringIndex)) {
+	if data == nil {
+		return
+	}
+	for j, i := range data.Index {
+		if j < len(data.Value) {
+			fn(i, data.Value[j])
+		}
+	}
+}
+
+func eachRareBool(data *proto.DOMSnapshotRareBooleanData, fn func(i int)) {
+	if data == nil {
+		return
+	}
+	for _, i := range data.Index {
+		fn(i)
+	}
+}
+
+// Filter returns the nodes that make the fn return true, in document order
+// Filter 按文档顺序返回使 fn 返回 true 的节点
+func (s *DOMSnapshot) Filter(fn func(*DOMSnapshotNode) bool) []*DOMSnapshotNode {
+	list := []*DOMSnapshotNode{}
+	for _, n := range s.Nodes {
+		if fn(n) {
+			list = append(list, n)
+		}
+	}
+	return list
+}
+
+// Query the element nodes that match the selector, only a subset of the css selector is supported:
+// the compound selectors of tag, "#id", ".class", "[attr]", "[attr=value]", and the descendant combinator " ".
+// Such as "div.item a[href]".
+// Query 查询与选择器匹配的元素节点，只支持 css 选择器的一个子集：由标签、"#id"、".class"、"[attr]"、"[attr=value]"
+// 组成的复合选择器，以及后代组合器 " "。例如 "div.item a[href]"。
+func (s *DOMSnapshot) Query(selector string) ([]*DOMSnapshotNode, error) {
+	list, err := parseSnapshotSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return s.Filter(func(n *DOMSnapshotNode) bool {
+		return matchSnapshotSelector(n, list)
+	}), nil
+}
+
+// Attr returns the attribute of the node and whether it exists
+// Attr 返回节点的属性以及它是否存在
+func (n *DOMSnapshotNode) Attr(name string) (string, bool) {
+	v, has := n.Attributes[name]
+	return v, has
+}
+
+// Text returns the concatenated values of the text nodes under the node
+// Text 返回节点下所有文本节点的值拼接而成的文本
+func (n *DOMSnapshotNode) Text() string {
+	if n.Type == 3 {
+		return n.Value
+	}
+	var b strings.Builder
+	for _, c := range n.Children {
+		b.WriteString(c.Text())
+	}
+	return b.String()
+}
+
+// Visible returns true if the node is rendered with a non-empty box
+// Visible 如果节点被渲染并且其盒子不为空则返回 true
+func (n *DOMSnapshotNode) Visible() bool {
+	return n.Layout != nil && n.Layout.Bounds != nil && n.Layout.Bounds.Width > 0 && n.Layout.Bounds.Height > 0
+}
+
+// Element resolves the node to an element of the page
+// Element 将节点解析为页面的元素
+func (n *DOMSnapshotNode) Element(p *Page) (*Element, error) {
+	return p.ElementFromNode(&proto.DOMNode{BackendNodeID: n.BackendNodeID})
+}
+
+type snapshotCompound struct {
+	tag     string
+	id      string
+	classes []string
+	attrs   []snapshotAttr
+}
+
+type snapshotAttr struct {
+	name     string
+	value    string
+	hasValue bool // false means only the existence of the attribute is checked
+}
+
+var regSnapshotToken = regexp.MustCompile(`^(?:([a-zA-Z][\w-]*|\*)|#([\w-]+)|\.([\w-]+)|\[([\w-]+)(?:=(?:"([^"]*)"|'([^']*)'|([^\]]*)))?\])`)
+
+// the tokens are consumed from the whole selector, so the spaces in a quoted attribute value won't split a compound
+// token 是从整个选择器中消耗的，这样引号中属性值里的空格不会拆分复合选择器
+func parseSnapshotSelector(selector string) ([]*snapshotCompound, error) {
+	list := []*snapshotCompound{}
+	var c *snapshotCompound
+	for rest := selector; ; {
+		trimmed := strings.TrimLeft(rest, " \t\n\r\f")
+		if len(trimmed) != len(rest) {
+			c = nil
+		}
+		rest = trimmed
+		if rest == "" {
+			break
+		}
+
+		m := regSnapshotToken.FindStringSubmatch(rest)
+		if m == nil {
+			return nil, fmt.Errorf("unsupported selector: %s", selector)
+		}
+		rest = rest[len(m[0]):]
+
+		if c == nil {
+			c = &snapshotCompound{}
+			list = append(list, c)
+		}
+
+		switch {
+		case m[1] != "":
+			c.tag = strings.ToUpper(m[1])
+		case m[2] != "":
+			c.id = m[2]
+		case m[3] != "":
+			c.classes = append(c.classes, m[3])
+		default:
+			c.attrs = append(c.attrs, snapshotAttr{m[4], m[5] + m[6] + m[7], strings.Contains(m[0], "=")})
+		}
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("unsupported selector: %s", selector)
+	}
+	return list, nil
+}
+
+func matchSnapshotSelector(n *DOMSnapshotNode, list []*snapshotCompound) bool {
+	if !list[len(list)-1].match(n) {
+		return false
+	}
+
+	// match the rest of the compounds against the ancestors from the nearest one
+	// 从最近的祖先开始，用剩下的复合选择器匹配祖先
+	i := len(list) - 2
+	for p := n.Parent; p != nil && i >= 0; p = p.Parent {
+		if list[i].match(p) {
+			i--
+		}
+	}
+	return i < 0
+}
+
+func (c *snapshotCompound) match(n *DOMSnapshotNode) bool {
+	if n.Type != 1 {
+		return false
+	}
+	if c.tag != "" && c.tag != "*" && strings.ToUpper(n.Name) != c.tag {
+		return false
+	}
+	if c.id != "" && n.Attributes["id"] != c.id {
+		return false
+	}
+	for _, cls := range c.classes {
+		found := false
+		for _, it := range strings.Fields(n.Attributes["class"]) {
+			if it == cls {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, attr := range c.attrs {
+		v, has := n.Attributes[attr.name]
+		if !has || (attr.hasValue && v != attr.value) {
+			return false
+		}
+	}
+	return true
+}
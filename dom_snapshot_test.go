@@ -0,0 +1,49 @@
+package rod_test
+
+import (
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestDOMSnapshot(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.blank()).MustSetDocumentContent(`<div class="list">
+		<a class="item" href="/a">a</a>
+		<a class="item active" href="/b">b <b>bold</b></a>
+		<a style="display: none" href="/c" title="a b">c</a>
+		<input value="ok">
+	</div>`)
+
+	s := p.MustDOMSnapshot(proto.DOMSnapshotCaptureSnapshot{ComputedStyles: []string{"display"}})
+
+	list, err := s.Query("div.list a[href]")
+	g.E(err)
+	g.Len(list, 3)
+	g.Eq(list[1].Text(), "b bold")
+	g.Eq(list[1].Attributes["href"], "/b")
+	g.True(list[0].Visible())
+	g.False(list[2].Visible())
+
+	list, err = s.Query(`.item.active`)
+	g.E(err)
+	g.Len(list, 1)
+	g.Eq(list[0].Layout.Styles["display"], "inline")
+
+	list, err = s.Query(`input[value="ok"]`)
+	g.E(err)
+	g.Eq(list[0].InputValue, "ok")
+	g.Eq(g.page.MustElementFromNode(&proto.DOMNode{BackendNodeID: list[0].BackendNodeID}).MustProperty("value").String(), "ok")
+
+	g.Len(s.Filter(func(n *rod.DOMSnapshotNode) bool { return n.Name == "#text" && n.Value == "a" }), 1)
+
+	list, err = s.Query(`div [title="a b"]`)
+	g.E(err)
+	g.Len(list, 1)
+	g.Eq(list[0].Attributes["href"], "/c")
+
+	_, err = s.Query("a > b")
+	g.Eq(err.Error(), "unsupported selector: a > b")
+}
@@ -0,0 +1,409 @@
+package rod
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// DownloadManager tracks all the downloads of the browser, unlike Browser.WaitDownload it can handle
+// concurrent downloads. Use Browser.DownloadManager to create it.
+// DownloadManager 跟踪浏览器的所有下载，与 Browser.WaitDownload 不同，它可以处理并发的下载。
+// 使用 Browser.DownloadManager 创建它。
+type DownloadManager struct {
+	browser *Browser
+	ctx     context.Context
+	cancel  func()
+	dir     string
+
+	lock      sync.Mutex
+	downloads map[string]*Download
+	list      []*Download
+	onBegin   []func(*Download)
+	onUpdate  []func(*Download)
+	dest      func(*Download) string
+	closed    bool
+
+	// the download behavior before the manager is created
+	// 创建管理器之前的下载行为
+	restore proto.BrowserSetDownloadBehavior
+}
+
+// Download is a file that the browser is downloading
+// Download 是浏览器正在下载的一个文件
+type Download struct {
+	proto.PageDownloadWillBegin
+
+	manager *DownloadManager
+
+	lock       sync.Mutex
+	began      time.Time
+	total      float64
+	received   float64
+	state      proto.PageDownloadProgressState
+	dest       string
+	moved      bool
+	err        error
+	done       chan struct{}
+	onProgress []func(*Download)
+}
+
+// DownloadStatus is a snapshot of the progress of a download
+// DownloadStatus 是下载进度的快照
+type DownloadStatus struct {
+	// Received bytes
+	// 已接收的字节数
+	Received float64
+
+	// Total bytes, it's 0 if the size is unknown
+	// 总字节数，如果大小未知则为 0
+	Total float64
+
+	// Rate is the average bytes per second since the download began
+	// Rate 是从下载开始以来平均每秒的字节数
+	Rate float64
+
+	State proto.PageDownloadProgressState
+}
+
+// DownloadManager starts to track the downloads of the browser, the files will be saved to the dir first,
+// the name of each file is its GUID, then it will be moved to its destination if there is one.
+// Call DownloadManager.Close to stop tracking and restore the download behavior.
+// DownloadManager 开始跟踪浏览器的下载，文件会先被保存到 dir 中，每个文件的名字是它的 GUID，
+// 如果它有目标路径，完成后会被移动到目标路径。
+// 调用 DownloadManager.Close 停止跟踪并恢复下载行为。
+func (b *Browser) DownloadManager(dir string) (*DownloadManager, error) {
+	restore := proto.BrowserSetDownloadBehavior{
+		Behavior:         proto.BrowserSetDownloadBehaviorBehaviorDefault,
+		BrowserContextID: b.BrowserContextID,
+	}
+	b.LoadState("", &restore)
+
+	err := proto.BrowserSetDownloadBehavior{
+		Behavior:         proto.BrowserSetDownloadBehaviorBehaviorAllowAndName,
+		BrowserContextID: b.BrowserContextID,
+		DownloadPath:     dir,
+	}.Call(rodCall(b))
+	if err != nil {
+		return nil, err
+	}
+
+	events, cancel := b.WithCancel()
+
+	m := &DownloadManager{
+		browser:   b,
+		ctx:       events.ctx,
+		cancel:    cancel,
+		dir:       dir,
+		downloads: map[string]*Download{},
+		restore:   restore,
+	}
+
+	// track the manager so that Browser.CloseGracefully can wait for its downloads
+	// 记录管理器，以便 Browser.CloseGracefully 可以等待它的下载
+	b.states.Store(m, m)
+
+	go events.EachEvent(func(e *proto.PageDownloadWillBegin) {
+		m.begin(e)
+	}, func(e *proto.PageDownloadProgress) {
+		m.progress(e)
+	})()
+
+	return m, nil
+}
+
+// OnBegin calls fn when a download begins, it's the place to use Download.SaveAs to override the destination
+// OnBegin 在下载开始时调用 fn，可以在这里使用 Download.SaveAs 覆盖目标路径
+func (m *DownloadManager) OnBegin(fn func(*Download)) *DownloadManager {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.onBegin = append(m.onBegin, fn)
+	return m
+}
+
+// OnProgress calls fn each time the progress of a download is updated, including when it ends
+// OnProgress 每当下载的进度更新时调用 fn，包括下载结束时
+func (m *DownloadManager) OnProgress(fn func(*Download)) *DownloadManager {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.onUpdate = append(m.onUpdate, fn)
+	return m
+}
+
+// Destination sets the default destination of the downloads, return empty string to keep the file in the dir
+// Destination 设置下载的默认目标路径，返回空字符串则将文件保留在 dir 中
+func (m *DownloadManager) Destination(fn func(*Download) string) *DownloadManager {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.dest = fn
+	return m
+}
+
+// WaitBegin returns a wait function that waits for the next download to begin
+// WaitBegin 返回一个等待函数，它等待下一个下载开始
+func (m *DownloadManager) WaitBegin() (wait func() *Download) {
+	ch := make(chan *Download, 1)
+	once := sync.Once{}
+	m.OnBegin(func(d *Download) {
+		once.Do(func() { ch <- d })
+	})
+
+	return func() *Download {
+		select {
+		case <-m.ctx.Done():
+			return nil
+		case d := <-ch:
+			return d
+		}
+	}
+}
+
+// Downloads returns all the downloads that have begun, in the order they began
+// Downloads 按开始的顺序返回所有已开始的下载
+func (m *DownloadManager) Downloads() []*Download {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]*Download{}, m.list...)
+}
+
+// Get the download by its GUID
+// 通过 GUID 获取下载
+func (m *DownloadManager) Get(guid string) (*Download, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	d, has := m.downloads[guid]
+	return d, has
+}
+
+// Close stops tracking the downloads and restores the download behavior that was set before the manager is created
+// Close 停止跟踪下载并恢复创建管理器之前设置的下载行为
+func (m *DownloadManager) Close() error {
+	m.lock.Lock()
+	if m.closed {
+		m.lock.Unlock()
+		return nil
+	}
+	m.closed = true
+	m.lock.Unlock()
+
+	m.cancel()
+	m.browser.states.Delete(m)
+
+	return m.restore.Call(rodCall(m.browser))
+}
+
+func (m *DownloadManager) begin(e *proto.PageDownloadWillBegin) {
+	m.lock.Lock()
+	if _, has := m.downloads[e.GUID]; has {
+		m.lock.Unlock()
+		return
+	}
+
+	d := &Download{
+		PageDownloadWillBegin: *e,
+		manager:               m,
+		began:                 time.Now(),
+		state:                 proto.PageDownloadProgressStateInProgress,
+		done:                  make(chan struct{}),
+	}
+	if m.dest != nil {
+		d.dest = m.dest(d)
+	}
+	m.downloads[e.GUID] = d
+	m.list = append(m.list, d)
+	handlers := append([]func(*Download){}, m.onBegin...)
+	m.lock.Unlock()
+
+	for _, fn := range handlers {
+		fn(d)
+	}
+}
+
+func (m *DownloadManager) progress(e *proto.PageDownloadProgress) {
+	d, has := m.Get(e.GUID)
+	if !has {
+		return
+	}
+
+	d.lock.Lock()
+	ended := d.ended()
+	if !ended {
+		d.total = e.TotalBytes
+		d.received = e.ReceivedBytes
+		d.state = e.State
+	}
+	d.lock.Unlock()
+
+	if ended {
+		return
+	}
+
+	switch e.State {
+	case proto.PageDownloadProgressStateCompleted:
+		d.finish(d.move())
+	case proto.PageDownloadProgressStateCanceled:
+		d.finish(&ErrDownloadCanceled{e.GUID})
+	}
+
+	m.lock.Lock()
+	handlers := append([]func(*Download){}, m.onUpdate...)
+	m.lock.Unlock()
+
+	d.lock.Lock()
+	handlers = append(handlers, d.onProgress...)
+	d.lock.Unlock()
+
+	for _, fn := range handlers {
+		fn(d)
+	}
+}
+
+// SaveAs overrides the destination of the download, the file will be moved to the path after it completes
+// SaveAs 覆盖下载的目标路径，下载完成后文件将被移动到该路径
+func (d *Download) SaveAs(path string) *Download {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.dest = path
+	return d
+}
+
+// Progress of the download
+// 下载的进度
+func (d *Download) Progress() (received, total float64, state proto.PageDownloadProgressState) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.received, d.total, d.state
+}
+
+// Status returns the progress of the download with the transfer rate
+// Status 返回下载的进度以及传输速率
+func (d *Download) Status() DownloadStatus {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	s := DownloadStatus{Received: d.received, Total: d.total, State: d.state}
+	if elapsed := time.Since(d.began).Seconds(); elapsed > 0 {
+		s.Rate = d.received / elapsed
+	}
+	return s
+}
+
+// OnProgress calls fn each time the progress of the download is updated, including when it ends
+// OnProgress 每当该下载的进度更新时调用 fn，包括下载结束时
+func (d *Download) OnProgress(fn func(*Download)) *Download {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.onProgress = append(d.onProgress, fn)
+	return d
+}
+
+// Path of the downloaded file
+// 下载文件的路径
+func (d *Download) Path() string {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.moved {
+		return d.dest
+	}
+	return filepath.Join(d.manager.dir, d.GUID)
+}
+
+// Wait until the download completes or is canceled, ErrDownloadCanceled will be returned if it's canceled
+// 等待直到下载完成或者被取消，如果被取消将返回 ErrDownloadCanceled
+func (d *Download) Wait() error {
+	// the download may have ended before the manager is closed
+	// 下载可能在管理器关闭之前就已经结束了
+	select {
+	case <-d.done:
+	default:
+		select {
+		case <-d.manager.ctx.Done():
+			return d.manager.ctx.Err()
+		case <-d.done:
+		}
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.err
+}
+
+// Cancel the download
+// 取消下载
+func (d *Download) Cancel() error {
+	return proto.BrowserCancelDownload{
+		GUID:             d.GUID,
+		BrowserContextID: d.manager.browser.BrowserContextID,
+	}.Call(rodCall(d.manager.browser))
+}
+
+// must be called with the lock held
+// 必须在持有锁的时候调用
+func (d *Download) ended() bool {
+	return d.state == proto.PageDownloadProgressStateCompleted || d.state == proto.PageDownloadProgressStateCanceled
+}
+
+func (d *Download) finish(err error) {
+	d.lock.Lock()
+	d.err = err
+	d.moved = err == nil && d.dest != "" && d.state == proto.PageDownloadProgressStateCompleted
+	d.lock.Unlock()
+	close(d.done)
+}
+
+// move the file to the destination
+// 将文件移动到目标路径
+func (d *Download) move() error {
+	d.lock.Lock()
+	dest := d.dest
+	d.lock.Unlock()
+
+	if dest == "" {
+		return nil
+	}
+
+	src := filepath.Join(d.manager.dir, d.GUID)
+
+	err := os.MkdirAll(filepath.Dir(dest), 0755)
+	if err != nil {
+		return err
+	}
+
+	if os.Rename(src, dest) == nil {
+		return nil
+	}
+
+	// the dest may be on another device
+	// 目标路径可能在另一个设备上
+	err = copyFile(src, dest)
+	if err != nil {
+		return err
+	}
+	return os.Remove(src)
+}
+
+func copyFile(src, dest string) error {
+	from, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = from.Close() }()
+
+	to, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(to, from)
+	if err != nil {
+		_ = to.Close()
+		return err
+	}
+	return to.Close()
+}
@@ -0,0 +1,229 @@
+package rod
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+type downloadDirKey struct {
+	targetID proto.TargetTargetID
+}
+
+type downloadDir struct {
+	path string
+	temp bool
+}
+
+// the download behavior is per browser context, so the downloads of a context are saved to a shared staging dir
+// first, then moved to the dirs of the pages that trigger them
+// 下载行为是针对浏览器上下文的，所以一个上下文的下载会先被保存到一个共享的暂存目录，然后被移动到触发它们的页面的目录中
+type downloadRouterKey struct {
+	browserContextID proto.BrowserBrowserContextID
+}
+
+type downloadRouter struct {
+	manager *DownloadManager
+	staging string
+}
+
+type downloadRouterLockKey struct {
+	browserContextID proto.BrowserBrowserContextID
+}
+
+// the lock of the router of the browser context
+// 浏览器上下文的路由的锁
+func (b *Browser) downloadRouterLock() *sync.Mutex {
+	l, _ := b.states.LoadOrStore(downloadRouterLockKey{b.BrowserContextID}, &sync.Mutex{})
+	return l.(*sync.Mutex)
+}
+
+// SetDownloadDir saves the downloads of the page to the dir with their suggested file names. Unlike
+// Browser.WaitDownload it only affects the page, so the concurrent pages won't clobber each other's downloads.
+// If the dir is empty, a temp dir will be created and it will be removed when the page is closed.
+// It returns the absolute path of the dir.
+// The download behavior of Chrome is per browser context, so it's done by a DownloadManager of the context that moves
+// each download to the dir of the page whose frame triggers it. Don't use it with the other download helpers of the same
+// browser context, such as Browser.WaitDownload, or the downloads won't be moved until the other helper is done.
+// The downloads of the other pages of the browser context are saved to the download path set before the first
+// SetDownloadDir of the context, such as by proto.BrowserSetDownloadBehavior.
+// SetDownloadDir 将页面的下载以建议的文件名保存到 dir 中。与 Browser.WaitDownload 不同，它只影响该页面，
+// 所以并发的页面不会互相覆盖对方的下载。如果 dir 为空，将创建一个临时目录，并在页面关闭时删除它。
+// 它返回 dir 的绝对路径。
+// Chrome 的下载行为是针对浏览器上下文的，所以它是通过该上下文的一个 DownloadManager 实现的，它会把每个下载移动到触发它的 frame
+// 所属页面的目录中。不要在同一个浏览器上下文中同时使用其他的下载工具，例如 Browser.WaitDownload，否则在其他工具结束之前下载不会被移动。
+// 该浏览器上下文中其他页面的下载会被保存到该上下文第一次调用 SetDownloadDir 之前设置的下载路径中，例如通过 proto.BrowserSetDownloadBehavior 设置的。
+func (p *Page) SetDownloadDir(dir string) (string, error) {
+	temp := dir == ""
+	if temp {
+		d, err := ioutil.TempDir("", "rod-page-downloads-")
+		if err != nil {
+			return "", err
+		}
+		dir = d
+	}
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	err = p.browser.startDownloadRouter()
+	if err != nil {
+		if temp {
+			_ = os.RemoveAll(dir)
+		}
+		return "", err
+	}
+
+	p.dropDownloadDir()
+	p.browser.states.Store(downloadDirKey{p.TargetID}, &downloadDir{dir, temp})
+
+	return dir, nil
+}
+
+// DownloadDir returns the dir set by Page.SetDownloadDir, it's empty if it's not set
+// DownloadDir 返回通过 Page.SetDownloadDir 设置的目录，如果没有设置则为空
+func (p *Page) DownloadDir() string {
+	if d, has := p.browser.states.Load(downloadDirKey{p.TargetID}); has {
+		return d.(*downloadDir).path
+	}
+	return ""
+}
+
+// remove the download dir of the page, the router of the browser context will be stopped if no page uses it
+// 删除页面的下载目录，如果没有页面再使用浏览器上下文的路由，它将被停止
+func (p *Page) removeDownloadDir() {
+	if !p.dropDownloadDir() {
+		return
+	}
+
+	lock := p.browser.downloadRouterLock()
+	lock.Lock()
+	defer lock.Unlock()
+
+	if len(p.browser.downloadDirs()) > 0 {
+		return
+	}
+
+	key := downloadRouterKey{p.browser.BrowserContextID}
+	if r, has := p.browser.states.Load(key); has {
+		p.browser.states.Delete(key)
+		_ = r.(*downloadRouter).manager.Close()
+
+		// the staging dir may still have the unfinished downloads, only remove it when it's empty
+		// 暂存目录中可能还有未完成的下载，只在它为空时删除它
+		_ = os.Remove(r.(*downloadRouter).staging)
+	}
+}
+
+// remove the previous temp dir of the page
+// 删除页面之前的临时目录
+func (p *Page) dropDownloadDir() bool {
+	d, has := p.browser.states.Load(downloadDirKey{p.TargetID})
+	if !has {
+		return false
+	}
+	p.browser.states.Delete(downloadDirKey{p.TargetID})
+
+	if d := d.(*downloadDir); d.temp {
+		_ = os.RemoveAll(d.path)
+	}
+	return true
+}
+
+func (b *Browser) startDownloadRouter() error {
+	lock := b.downloadRouterLock()
+	lock.Lock()
+	defer lock.Unlock()
+
+	key := downloadRouterKey{b.BrowserContextID}
+	if _, has := b.states.Load(key); has {
+		return nil
+	}
+
+	staging, err := ioutil.TempDir("", "rod-downloads-")
+	if err != nil {
+		return err
+	}
+
+	m, err := b.DownloadManager(staging)
+	if err != nil {
+		_ = os.RemoveAll(staging)
+		return err
+	}
+	m.Destination(func(d *Download) string {
+		return b.downloadDest(d, m.restore)
+	})
+
+	b.states.Store(key, &downloadRouter{m, staging})
+	return nil
+}
+
+// the destination of the download in the dir of the page that triggers it, the downloads of the other pages are
+// saved to the download path of the browser context that was set before the router is started
+// 下载在触发它的页面的目录中的目标路径，其他页面的下载会被保存到路由启动之前为浏览器上下文设置的下载路径中
+func (b *Browser) downloadDest(d *Download, prev proto.BrowserSetDownloadBehavior) string {
+	dirs := b.downloadDirs()
+
+	if dir, has := dirs[proto.TargetTargetID(d.FrameID)]; has {
+		return filepath.Join(dir, d.SuggestedFilename)
+	}
+
+	// the download is triggered by an iframe
+	// 下载是由 iframe 触发的
+	for id, dir := range dirs {
+		if p := b.loadCachedPage(id); p != nil && p.hasFrame(d.FrameID) {
+			return filepath.Join(dir, d.SuggestedFilename)
+		}
+	}
+
+	if prev.DownloadPath == "" {
+		return ""
+	}
+	if prev.Behavior == proto.BrowserSetDownloadBehaviorBehaviorAllowAndName {
+		return filepath.Join(prev.DownloadPath, d.GUID)
+	}
+	return filepath.Join(prev.DownloadPath, d.SuggestedFilename)
+}
+
+// the download dirs of the pages in the browser context
+// 浏览器上下文中各个页面的下载目录
+func (b *Browser) downloadDirs() map[proto.TargetTargetID]string {
+	dirs := map[proto.TargetTargetID]string{}
+	b.states.Range(func(k, v interface{}) bool {
+		key, ok := k.(downloadDirKey)
+		if !ok {
+			return true
+		}
+		if p := b.loadCachedPage(key.targetID); p == nil || p.browser.BrowserContextID == b.BrowserContextID {
+			dirs[key.targetID] = v.(*downloadDir).path
+		}
+		return true
+	})
+	return dirs
+}
+
+func (p *Page) hasFrame(id proto.PageFrameID) bool {
+	tree, err := proto.PageGetFrameTree{}.Call(p)
+	if err != nil {
+		return false
+	}
+
+	var find func(t *proto.PageFrameTree) bool
+	find = func(t *proto.PageFrameTree) bool {
+		if t.Frame.ID == id {
+			return true
+		}
+		for _, child := range t.ChildFrames {
+			if find(child) {
+				return true
+			}
+		}
+		return false
+	}
+	return find(tree.FrameTree)
+}
@@ -0,0 +1,79 @@
+package rod_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+func TestPageSetDownloadDir(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/a.txt", ".txt", "file a")
+	s.Route("/b.txt", ".txt", "file b")
+	s.Route("/page", ".html", fmt.Sprintf(
+		`<html><a id="a" href="%s/a.txt" download>a</a><a id="b" href="%s/b.txt" download>b</a></html>`,
+		s.URL(), s.URL(),
+	))
+
+	// the download is moved to the dir of the page after it completes
+	download := func(p *rod.Page, selector, file string) {
+		p.MustElement(selector).MustClick()
+		g.E(utils.Retry(g.Context(), utils.BackoffSleeper(10*time.Millisecond, 100*time.Millisecond, nil),
+			func() (bool, error) { return utils.FileExists(file), nil }))
+	}
+
+	pa := g.newPage(s.URL("/page")).MustWaitLoad()
+	pb := g.newPage(s.URL("/page")).MustWaitLoad()
+
+	dirA := pa.MustSetDownloadDir("")
+	g.Eq(pa.DownloadDir(), dirA)
+	dirB := filepath.Join(os.TempDir(), "rod", "page-downloads", g.RandStr(8))
+	g.Eq(pb.MustSetDownloadDir(dirB), dirB)
+
+	download(pa, "#a", filepath.Join(dirA, "a.txt"))
+	download(pb, "#b", filepath.Join(dirB, "b.txt"))
+
+	read := func(p string) string {
+		s, err := utils.ReadString(p)
+		g.E(err)
+		return s
+	}
+	g.Eq(read(filepath.Join(dirA, "a.txt")), "file a")
+	g.Eq(read(filepath.Join(dirB, "b.txt")), "file b")
+	g.False(utils.FileExists(filepath.Join(dirA, "b.txt")))
+
+	// the temp dir will be removed when the page is closed
+	pa.MustClose()
+	_, err := os.Stat(dirA)
+	g.True(os.IsNotExist(err))
+
+	pb.MustClose()
+	g.True(utils.FileExists(filepath.Join(dirB, "b.txt")))
+	g.Eq(g.page.DownloadDir(), "")
+
+	// the downloads of the pages without a download dir are saved to the previous download path of the context
+	incognito := g.browser.MustIncognito()
+	defer incognito.MustClose()
+	prev := filepath.Join(os.TempDir(), "rod", "prev-downloads", g.RandStr(8))
+	g.E(proto.BrowserSetDownloadBehavior{
+		Behavior:         proto.BrowserSetDownloadBehaviorBehaviorAllow,
+		BrowserContextID: incognito.BrowserContextID,
+		DownloadPath:     prev,
+	}.Call(incognito))
+	routed := incognito.MustPage(s.URL("/page")).MustWaitLoad()
+	routed.MustSetDownloadDir("")
+	download(incognito.MustPage(s.URL("/page")).MustWaitLoad(), "#a", filepath.Join(prev, "a.txt"))
+	g.Eq(read(filepath.Join(prev, "a.txt")), "file a")
+
+	g.mc.stubErr(1, proto.BrowserSetDownloadBehavior{})
+	_, err = g.page.SetDownloadDir("")
+	g.Err(err)
+}
@@ -0,0 +1,163 @@
+package rod
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// DownloadReader reads the file of a download while the browser is still writing it, so the whole file
+// never needs to be held in memory. Use Browser.WaitDownloadReader to create it.
+// DownloadReader 在浏览器仍在写入文件时读取下载的文件，这样整个文件永远不需要被保存在内存中。
+// 使用 Browser.WaitDownloadReader 创建它。
+type DownloadReader struct {
+	*Download
+
+	dir    string
+	file   *os.File
+	size   int64
+	update chan struct{}
+}
+
+// WaitDownloadReader returns a wait function that waits for the next download to begin and returns a reader of it.
+// The file is saved to a temp dir, it will be removed when the reader is closed. If the reader is closed before
+// the download completes, the download will be canceled.
+// WaitDownloadReader 返回一个等待函数，它等待下一个下载开始并返回该下载的读取器。
+// 文件会被保存到一个临时目录中，当读取器关闭时它会被删除。如果在下载完成前关闭读取器，下载将被取消。
+func (b *Browser) WaitDownloadReader() (wait func() (*DownloadReader, error)) {
+	dir, err := ioutil.TempDir("", "rod-download-")
+	if err != nil {
+		return func() (*DownloadReader, error) { return nil, err }
+	}
+
+	m, err := b.DownloadManager(dir)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return func() (*DownloadReader, error) { return nil, err }
+	}
+
+	update := make(chan struct{}, 1)
+	m.OnProgress(func(*Download) {
+		select {
+		case update <- struct{}{}:
+		default:
+		}
+	})
+
+	waitBegin := m.WaitBegin()
+
+	return func() (*DownloadReader, error) {
+		d := waitBegin()
+		if d == nil {
+			_ = m.Close()
+			_ = os.RemoveAll(dir)
+			return nil, m.ctx.Err()
+		}
+		return &DownloadReader{Download: d, dir: dir, update: update}, nil
+	}
+}
+
+// WaitDownloadStream is similar to Browser.WaitDownloadReader, but the wait function copies the file to w
+// until the download completes. Use Download.Progress of the returned download to get the size of the file.
+// WaitDownloadStream 类似于 Browser.WaitDownloadReader，但是等待函数会将文件复制到 w 中直到下载完成。
+// 使用返回的下载的 Download.Progress 获取文件的大小。
+func (b *Browser) WaitDownloadStream(w io.Writer) (wait func() (*Download, error)) {
+	waitReader := b.WaitDownloadReader()
+
+	return func() (*Download, error) {
+		r, err := waitReader()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = io.Copy(w, r)
+		if err != nil {
+			_ = r.Close()
+			return r.Download, err
+		}
+		return r.Download, r.Close()
+	}
+}
+
+// Size returns the bytes that have been read
+// Size 返回已读取的字节数
+func (r *DownloadReader) Size() int64 {
+	return r.size
+}
+
+// Read the file, it blocks until there's more data or the download ends. If the download is canceled
+// ErrDownloadCanceled will be returned.
+// Read 读取文件，它会阻塞直到有更多的数据或者下载结束。如果下载被取消，将返回 ErrDownloadCanceled。
+func (r *DownloadReader) Read(p []byte) (int, error) {
+	for {
+		ended := r.finished()
+
+		if r.file == nil {
+			r.file = r.open()
+		}
+
+		if r.file != nil {
+			n, err := r.file.Read(p)
+			r.size += int64(n)
+			if n > 0 || (err != nil && err != io.EOF) {
+				return n, err
+			}
+		}
+
+		if ended {
+			err := r.Wait()
+			if err == nil {
+				err = io.EOF
+			}
+			return 0, err
+		}
+
+		select {
+		case <-r.manager.ctx.Done():
+			return 0, r.manager.ctx.Err()
+		case <-r.done:
+		case <-r.update:
+		}
+	}
+}
+
+// Close the reader and remove the temp dir, the download will be canceled if it hasn't completed
+// Close 关闭读取器并删除临时目录，如果下载还未完成，它将被取消
+func (r *DownloadReader) Close() error {
+	if !r.finished() {
+		_ = r.Cancel()
+	}
+
+	if r.file != nil {
+		_ = r.file.Close()
+	}
+
+	err := r.manager.Close()
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(r.dir)
+}
+
+func (r *DownloadReader) finished() bool {
+	select {
+	case <-r.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// The browser writes to the intermediate file first, then renames it to the GUID when it completes.
+// The opened file can still be read after it's renamed.
+// 浏览器首先写入中间文件，完成后再将其重命名为 GUID。文件被重命名后，已打开的文件仍然可以被读取。
+func (r *DownloadReader) open() *os.File {
+	for _, name := range []string{r.GUID, r.GUID + ".crdownload"} {
+		f, err := os.Open(filepath.Join(r.dir, name))
+		if err == nil {
+			return f
+		}
+	}
+	return nil
+}
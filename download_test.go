@@ -0,0 +1,270 @@
+package rod_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestDownloadManager(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/a", ".bin", "file a")
+	s.Route("/b", ".bin", "file b")
+	s.Route("/page", ".html", fmt.Sprintf(
+		`<html><a id="a" href="%s/a" download>a</a><a id="b" href="%s/b" download>b</a></html>`,
+		s.URL(), s.URL(),
+	))
+
+	dir := filepath.Join(os.TempDir(), "rod", "downloads")
+	dest := filepath.Join(dir, "dest", "b.bin")
+
+	m := g.browser.MustDownloadManager(dir)
+	defer func() { g.E(m.Close()) }()
+
+	progress := make(chan *rod.Download, 10)
+	m.OnBegin(func(d *rod.Download) {
+		if d.URL == s.URL("/b") {
+			d.SaveAs(dest)
+		}
+	}).OnProgress(func(d *rod.Download) { progress <- d })
+
+	page := g.page.MustNavigate(s.URL("/page"))
+
+	waitA := m.WaitBegin()
+	page.MustElement("#a").MustClick()
+	a := waitA()
+
+	waitB := m.WaitBegin()
+	page.MustElement("#b").MustClick()
+	b := waitB()
+
+	g.Eq(string(a.MustWait()), "file a")
+	g.Eq(string(b.MustWait()), "file b")
+	g.Eq(b.Path(), dest)
+	g.Eq(a.Path(), filepath.Join(dir, a.GUID))
+
+	received, total, state := b.Progress()
+	g.Eq(received, total)
+	g.Eq(state, proto.PageDownloadProgressStateCompleted)
+	g.Len(m.Downloads(), 2)
+	g.Gt(len(progress), 0)
+
+	got, has := m.Get(a.GUID)
+	g.True(has)
+	g.Eq(got, a)
+
+	data, err := ioutil.ReadFile(dest)
+	g.E(err)
+	g.Eq(string(data), "file b")
+}
+
+func TestDownloadManagerCancel(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Disposition", "attachment")
+		_, _ = w.Write([]byte("slow"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+	s.Route("/page", ".html", fmt.Sprintf(`<html><a href="%s/slow" download>slow</a></html>`, s.URL()))
+
+	m := g.browser.MustDownloadManager(filepath.Join(os.TempDir(), "rod", "downloads"))
+	defer func() { g.E(m.Close()) }()
+
+	page := g.page.MustNavigate(s.URL("/page"))
+
+	wait := m.WaitBegin()
+	page.MustElement("a").MustClick()
+	d := wait()
+
+	d.MustCancel()
+	g.Is(d.Wait(), &rod.ErrDownloadCanceled{})
+}
+
+func TestDownloadManagerErr(t *testing.T) {
+	g := setup(t)
+
+	g.mc.stubErr(1, proto.BrowserSetDownloadBehavior{})
+	_, err := g.browser.DownloadManager(os.TempDir())
+	g.Err(err)
+}
+
+func TestDownloadManagerRestore(t *testing.T) {
+	g := setup(t)
+
+	b := g.browser.MustIncognito()
+	defer b.MustClose()
+
+	dir := filepath.Join(os.TempDir(), "rod", "downloads")
+	g.E(proto.BrowserSetDownloadBehavior{
+		Behavior:         proto.BrowserSetDownloadBehaviorBehaviorAllow,
+		BrowserContextID: b.BrowserContextID,
+		DownloadPath:     dir,
+	}.Call(b))
+
+	g.E(b.MustDownloadManager(os.TempDir()).Close())
+
+	var behavior proto.BrowserSetDownloadBehavior
+	g.True(b.LoadState("", &behavior))
+	g.Eq(behavior.Behavior, proto.BrowserSetDownloadBehaviorBehaviorAllow)
+	g.Eq(behavior.DownloadPath, dir)
+}
+
+func TestWaitDownloadStream(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	content := strings.Repeat("rod", 100*1024)
+	s.Route("/file", ".bin", content)
+	s.Route("/page", ".html", fmt.Sprintf(`<html><a href="%s/file" download>file</a></html>`, s.URL()))
+
+	page := g.page.MustNavigate(s.URL("/page"))
+
+	buf := bytes.NewBuffer(nil)
+	wait := g.browser.MustWaitDownloadStream(buf)
+	page.MustElement("a").MustClick()
+	d := wait()
+
+	g.Eq(buf.String(), content)
+	received, total, state := d.Progress()
+	g.Eq(received, total)
+	g.Eq(int(total), len(content))
+	g.Eq(state, proto.PageDownloadProgressStateCompleted)
+}
+
+func TestWaitDownloadReader(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	next := make(chan struct{})
+	s.Mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Disposition", "attachment")
+		w.Header().Set("Content-Length", "6")
+		_, _ = w.Write([]byte("abc"))
+		w.(http.Flusher).Flush()
+		<-next
+		_, _ = w.Write([]byte("def"))
+	})
+	s.Route("/page", ".html", fmt.Sprintf(`<html><a href="%s/slow" download>slow</a></html>`, s.URL()))
+
+	page := g.page.MustNavigate(s.URL("/page"))
+
+	wait := g.browser.MustWaitDownloadReader()
+	page.MustElement("a").MustClick()
+	r := wait()
+	defer func() { g.E(r.Close()) }()
+
+	buf := make([]byte, 3)
+	_, err := io.ReadFull(r, buf)
+	g.E(err)
+	g.Eq(string(buf), "abc")
+
+	close(next)
+
+	rest, err := ioutil.ReadAll(r)
+	g.E(err)
+	g.Eq(string(rest), "def")
+	g.Eq(r.Size(), int64(6))
+}
+
+func TestWaitDownloadReaderCancel(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Disposition", "attachment")
+		_, _ = w.Write([]byte("slow"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+	s.Route("/page", ".html", fmt.Sprintf(`<html><a href="%s/slow" download>slow</a></html>`, s.URL()))
+
+	page := g.page.MustNavigate(s.URL("/page"))
+
+	wait := g.browser.MustWaitDownloadReader()
+	page.MustElement("a").MustClick()
+	r := wait()
+
+	r.MustCancel()
+	_, err := ioutil.ReadAll(r)
+	g.Is(err, &rod.ErrDownloadCanceled{})
+	g.E(r.Close())
+
+	g.mc.stubErr(1, proto.BrowserSetDownloadBehavior{})
+	_, err = g.browser.WaitDownloadStream(ioutil.Discard)()
+	g.Err(err)
+}
+
+func TestWaitDownloadBegin(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	content := strings.Repeat("rod", 100*1024)
+	s.Route("/file", ".bin", content)
+	s.Route("/page", ".html", fmt.Sprintf(`<html><a href="%s/file" download>file</a></html>`, s.URL()))
+
+	page := g.page.MustNavigate(s.URL("/page"))
+
+	dir := filepath.Join(os.TempDir(), "rod", "downloads")
+	wait := g.browser.MustWaitDownloadBegin(dir)
+	page.MustElement("a").MustClick()
+	d := wait()
+
+	updates := make(chan rod.DownloadStatus, 100)
+	d.OnProgress(func(d *rod.Download) { updates <- d.Status() })
+
+	g.Eq(string(d.MustWait()), content)
+
+	status := d.Status()
+	g.Eq(status.Received, status.Total)
+	g.Gt(status.Rate, 0)
+	g.Eq(status.State, proto.PageDownloadProgressStateCompleted)
+
+	if len(updates) > 0 {
+		var last rod.DownloadStatus
+		for len(updates) > 0 {
+			last = <-updates
+		}
+		g.Eq(last.State, proto.PageDownloadProgressStateCompleted)
+	}
+}
+
+func TestWaitDownloadBeginCancel(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Disposition", "attachment")
+		_, _ = w.Write([]byte("slow"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+	s.Route("/page", ".html", fmt.Sprintf(`<html><a href="%s/slow" download>slow</a></html>`, s.URL()))
+
+	page := g.page.MustNavigate(s.URL("/page"))
+
+	wait := g.browser.MustWaitDownloadBegin(filepath.Join(os.TempDir(), "rod", "downloads"))
+	page.MustElement("a").MustClick()
+	d := wait()
+
+	d.MustCancel()
+	g.Is(d.Wait(), &rod.ErrDownloadCanceled{})
+	g.Eq(d.Status().State, proto.PageDownloadProgressStateCanceled)
+
+	g.mc.stubErr(1, proto.BrowserSetDownloadBehavior{})
+	_, err := g.browser.WaitDownloadBegin(os.TempDir())()
+	g.Err(err)
+}
@@ -4,6 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -73,7 +77,7 @@ func (el *Element) ScrollIntoView() error {
 		return err
 	}
 
-	return proto.DOMScrollIntoViewIfNeeded{ObjectID: el.id()}.Call(el)
+	return proto.DOMScrollIntoViewIfNeeded{ObjectID: el.id()}.Call(rodCall(el))
 }
 
 // Hover 将鼠标停在元素的中心
@@ -99,8 +103,13 @@ func (el *Element) MoveMouseOut() error {
 
 // Click 会像人一样按下然后释放按钮。
 // 在执行操作之前，它将尝试滚动到元素，将鼠标悬停在该元素上，等待该元素可交互并启用。
-func (el *Element) Click(button proto.InputMouseButton) error {
-	err := el.Hover()
+func (el *Element) Click(button proto.InputMouseButton) (err error) {
+	el, end := el.startSpan("Click", func() map[string]interface{} {
+		return map[string]interface{}{"button": string(button)}
+	})
+	defer func() { end(err) }()
+
+	err = el.Hover()
 	if err != nil {
 		return err
 	}
@@ -198,7 +207,7 @@ func (el *Element) Interactable() (pt *proto.Point, err error) {
 //     /________/                                   /________/
 //
 func (el *Element) Shape() (*proto.DOMGetContentQuadsResult, error) {
-	return proto.DOMGetContentQuads{ObjectID: el.id()}.Call(el)
+	return proto.DOMGetContentQuads{ObjectID: el.id()}.Call(rodCall(el))
 }
 
 // Type 与Keyboard.Type类似。
@@ -276,6 +285,29 @@ func (el *Element) Input(text string) error {
 	return err
 }
 
+// InputIME 类似于 Element.Input，但是它通过 Page.InsertTextIME 模拟输入法输入文本，
+// 适用于依赖 compositionstart、compositionupdate 和 compositionend 事件的组件。
+func (el *Element) InputIME(text string) error {
+	err := el.Focus()
+	if err != nil {
+		return err
+	}
+
+	err = el.WaitEnabled()
+	if err != nil {
+		return err
+	}
+
+	err = el.WaitWritable()
+	if err != nil {
+		return err
+	}
+
+	err = el.page.InsertTextIME(text)
+	_, _ = el.Evaluate(evalHelper(js.InputEvent).ByUser())
+	return err
+}
+
 // InputTime 聚焦该元素及其输入时间。
 // 在执行操作之前，它将滚动到元素，等待其可见、启用和可写。
 // 它将等待元素可见、启用和可写。
@@ -385,6 +417,88 @@ func (el *Element) SetFiles(paths []string) error {
 	return err
 }
 
+// DropFile 是 Element.DropFileReaders 中要拖放的文件
+type DropFile struct {
+	// Name 文件名
+	Name string
+
+	// Type 文件的 MIME 类型，为空时根据 Name 的扩展名推断
+	Type string
+
+	// Content 文件的内容
+	Content io.Reader
+}
+
+// DropFiles 将 paths 对应的文件拖放到当前元素上，它会构造一个包含这些文件的 DataTransfer，
+// 并在元素上依次派发 dragenter、dragover 和 drop 事件。适用于没有 <input type=file> 的拖放上传区域。
+func (el *Element) DropFiles(paths []string) error {
+	files := []*DropFile{}
+	for _, p := range paths {
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+
+		files = append(files, &DropFile{Name: filepath.Base(p), Content: f})
+	}
+
+	return el.DropFileReaders(files)
+}
+
+// DropFileReaders 类似于 Element.DropFiles，但是文件的内容从 reader 中读取
+func (el *Element) DropFileReaders(files []*DropFile) error {
+	type dropFile struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+		Data []byte `json:"data"`
+	}
+
+	list := []*dropFile{}
+	names := []string{}
+	for _, f := range files {
+		data, err := ioutil.ReadAll(f.Content)
+		if err != nil {
+			return err
+		}
+
+		t := f.Type
+		if t == "" {
+			t = mime.TypeByExtension(filepath.Ext(f.Name))
+		}
+
+		list = append(list, &dropFile{Name: f.Name, Type: t, Data: data})
+		names = append(names, f.Name)
+	}
+
+	defer el.tryTrace(TraceTypeInput, fmt.Sprintf("drop files: %v", names))()
+	el.page.browser.trySlowmotion()
+
+	_, err := el.Evaluate(Eval(`(files) => {
+		const dt = new DataTransfer()
+		for (const f of files) {
+			const bin = atob(f.data || '')
+			const buf = new Uint8Array(bin.length)
+			for (let i = 0; i < bin.length; i++) buf[i] = bin.charCodeAt(i)
+			dt.items.add(new File([buf], f.name, { type: f.type }))
+		}
+
+		const rect = this.getBoundingClientRect()
+		const opts = {
+			bubbles: true,
+			cancelable: true,
+			composed: true,
+			dataTransfer: dt,
+			clientX: rect.x + rect.width / 2,
+			clientY: rect.y + rect.height / 2,
+		}
+		for (const type of ['dragenter', 'dragover', 'drop']) {
+			this.dispatchEvent(new DragEvent(type, opts))
+		}
+	}`, list))
+	return err
+}
+
 // Describe 描述当前元素。深度是应检索子级的最大深度，默认为1，对整个子树使用-1，或提供大于0的整数。
 // pierce决定在返回子树时是否要遍历iframes和影子根。
 // 返回的proto.DOMNode。NodeID将始终为空，因为NodeID不稳定（当proto.DOMDocumentUpdated被触发时，
@@ -407,7 +521,7 @@ func (el *Element) ShadowRoot() (*Element, error) {
 	// 虽然现在它是一个数组，但w3c将其规范更改为单个数组。
 	id := node.ShadowRoots[0].BackendNodeID
 
-	shadowNode, err := proto.DOMResolveNode{BackendNodeID: id}.Call(el)
+	shadowNode, err := el.page.Context(el.ctx).resolveNode(proto.DOMResolveNode{BackendNodeID: id})
 	if err != nil {
 		return nil, err
 	}
@@ -425,6 +539,9 @@ func (el *Element) Frame() (*Page, error) {
 	clone := *el.page
 	clone.FrameID = node.FrameID
 	clone.jsCtxID = new(proto.RuntimeRemoteObjectID)
+	if clone.world != nil {
+		clone.world = &isolatedWorld{name: clone.world.name}
+	}
 	clone.element = el
 	clone.sleeper = el.sleeper
 
@@ -689,6 +806,15 @@ func (el *Element) Eval(js string, params ...interface{}) (*proto.RuntimeRemoteO
 	return el.Evaluate(Eval(js, params...).ByPromise())
 }
 
+// EvalInto 类似于 Element.Eval，但是会将结果通过 json 解码到 v 中
+func (el *Element) EvalInto(v interface{}, js string, params ...interface{}) error {
+	res, err := el.Eval(js, params...)
+	if err != nil {
+		return err
+	}
+	return res.Into(v)
+}
+
 // Evaluate 只是Page.Evaluate的一个快捷方式，This设置为当前元素。
 func (el *Element) Evaluate(opts *EvalOptions) (*proto.RuntimeRemoteObject, error) {
 	return el.page.Context(el.ctx).Evaluate(opts.This(el.Object))
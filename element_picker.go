@@ -0,0 +1,257 @@
+package rod
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+	"github.com/ysmood/gson"
+)
+
+// PickedElement is the result of Page.PickElement
+// PickedElement 是 Page.PickElement 的结果
+type PickedElement struct {
+	// CSS selector that only matches the element. The test id, the id and the name attributes are preferred,
+	// then the shortest path of ":nth-of-type" from the nearest ancestor that has a unique test id or id.
+	// CSS 是只匹配该元素的 css 选择器。优先使用 test id、id 和 name 属性，
+	// 然后是从最近的具有唯一 test id 或 id 的祖先元素开始的、最短的 ":nth-of-type" 路径。
+	CSS string
+
+	// XPath that only matches the element
+	// XPath 是只匹配该元素的 xpath
+	XPath string
+
+	// TestID is the selector of the test id attribute, such as `[data-testid="submit"]`, empty if there's none
+	// TestID 是 test id 属性的选择器，例如 `[data-testid="submit"]`，如果没有则为空
+	TestID string
+
+	// Element that is picked
+	// Element 是被选中的元素
+	Element *Element
+}
+
+// the attributes that are checked in order for the test id
+// 按顺序检查的 test id 属性
+var pickElementTestIDs = []string{"data-testid", "data-test-id", "data-test", "data-qa", "data-cy"}
+
+// the js of the picker, it highlights the hovered element, and sends the selectors of the clicked element to
+// the binding, the Escape key cancels it. The window[bind + '_stop'] removes the picker without calling the binding.
+// 选择器的 js，它高亮鼠标悬停的元素，并将被点击元素的选择器发送给 binding，按 Escape 键取消。
+// window[bind + '_stop'] 会移除选择器，但不会调用 binding。
+const jsPickElement = `(bind, testIDs, style) => {
+	const unique = (sel) => {
+		try {
+			return document.querySelectorAll(sel).length === 1
+		} catch (e) {
+			return false
+		}
+	}
+	const attr = (name, val) => '[' + name + '="' + CSS.escape(val) + '"]'
+	// xpath 1.0 has no escapes, so a literal with both kinds of quotes has to be built with concat()
+	const literal = (val) => {
+		if (!val.includes('"')) return '"' + val + '"'
+		if (!val.includes("'")) return "'" + val + "'"
+		return 'concat(' + val.split('"').map((s) => '"' + s + '"').join(", '\"', ") + ')'
+	}
+	const xpathUnique = (xp) =>
+		document.evaluate(xp, document, null, XPathResult.ORDERED_NODE_SNAPSHOT_TYPE, null).snapshotLength === 1
+
+	const testID = (el) => {
+		for (const name of testIDs) {
+			const val = el.getAttribute(name)
+			if (val && unique(attr(name, val))) return [name, val]
+		}
+		return null
+	}
+
+	const anchor = (el) => {
+		const t = testID(el)
+		if (t) return attr(t[0], t[1])
+		if (el.id && unique('#' + CSS.escape(el.id))) return '#' + CSS.escape(el.id)
+		return null
+	}
+
+	const index = (el) => {
+		const same = el.parentElement
+			? Array.from(el.parentElement.children).filter((c) => c.tagName === el.tagName)
+			: [el]
+		return same.length > 1 ? same.indexOf(el) + 1 : 0
+	}
+
+	const css = (el) => {
+		const a = anchor(el)
+		if (a) return a
+
+		const tag = el.tagName.toLowerCase()
+		const name = el.getAttribute('name')
+		if (name && unique(tag + attr('name', name))) return tag + attr('name', name)
+
+		const path = []
+		for (let cur = el; cur && cur.nodeType === Node.ELEMENT_NODE; cur = cur.parentElement) {
+			const a = cur === el ? null : anchor(cur)
+			if (a) {
+				path.unshift(a)
+				break
+			}
+			const i = index(cur)
+			path.unshift(cur.tagName.toLowerCase() + (i ? ':nth-of-type(' + i + ')' : ''))
+
+			const sel = path.join(' > ')
+			if (unique(sel) && document.querySelector(sel) === el) return sel
+		}
+		return path.join(' > ')
+	}
+
+	const xpath = (el) => {
+		const t = testID(el)
+		if (t && xpathUnique('//*[@' + t[0] + '=' + literal(t[1]) + ']')) {
+			return '//*[@' + t[0] + '=' + literal(t[1]) + ']'
+		}
+		if (el.id && xpathUnique('//*[@id=' + literal(el.id) + ']')) {
+			return '//*[@id=' + literal(el.id) + ']'
+		}
+
+		const path = []
+		for (let cur = el; cur && cur.nodeType === Node.ELEMENT_NODE; cur = cur.parentElement) {
+			const i = index(cur)
+			path.unshift(cur.tagName.toLowerCase() + (i ? '[' + i + ']' : ''))
+		}
+		return '/' + path.join('/')
+	}
+
+	const box = document.createElement('div')
+	box.style = 'position: fixed; z-index: 2147483647; pointer-events: none; box-sizing: border-box;' +
+		'border: 2px dashed ' + (style.border || 'red') + '; border-radius: 3px; box-shadow: #5f3232 0 0 3px;' +
+		'display: none;'
+	const label = document.createElement('div')
+	label.style = 'position: absolute; top: 100%; white-space: nowrap; padding: 2px 5px; border-radius: 3px;' +
+		'box-shadow: #333 0 0 3px; font-family: monospace;' +
+		'color: ' + (style.color || '#cc26d6') + '; background: ' + (style.background || '#ffffffeb') + ';' +
+		'font-size: ' + (style.fontSize || 12) + 'px;'
+	box.appendChild(label)
+	document.documentElement.appendChild(box)
+
+	const target = (e) => {
+		const el = document.elementFromPoint(e.clientX, e.clientY)
+		return el && el !== box && !box.contains(el) ? el : null
+	}
+
+	const onMove = (e) => {
+		const el = target(e)
+		if (!el) return
+		const rect = el.getBoundingClientRect()
+		box.style.display = 'block'
+		box.style.left = rect.left + 'px'
+		box.style.top = rect.top + 'px'
+		box.style.width = rect.width + 'px'
+		box.style.height = rect.height + 'px'
+		label.textContent = css(el)
+	}
+
+	// prevent the page from handling the events of the pick
+	// 阻止页面处理选择时的事件
+	const block = (e) => {
+		e.preventDefault()
+		e.stopImmediatePropagation()
+	}
+
+	const events = ['mousedown', 'mouseup', 'pointerdown', 'pointerup', 'dblclick', 'contextmenu']
+
+	const stop = () => {
+		document.removeEventListener('mousemove', onMove, true)
+		document.removeEventListener('click', onClick, true)
+		document.removeEventListener('keydown', onKey, true)
+		for (const name of events) document.removeEventListener(name, block, true)
+		box.remove()
+		delete window[bind + '_stop']
+	}
+
+	const done = (res) => {
+		stop()
+		window[bind](res)
+	}
+
+	const onClick = (e) => {
+		block(e)
+		const el = target(e)
+		if (!el) return
+		const t = testID(el)
+		done({ css: css(el), xpath: xpath(el), testID: t ? attr(t[0], t[1]) : '' })
+	}
+
+	const onKey = (e) => {
+		if (e.key !== 'Escape') return
+		block(e)
+		done({ canceled: true })
+	}
+
+	document.addEventListener('mousemove', onMove, true)
+	document.addEventListener('click', onClick, true)
+	document.addEventListener('keydown', onKey, true)
+	for (const name of events) document.addEventListener(name, block, true)
+	window[bind + '_stop'] = stop
+}`
+
+// PickElement lets the user hover and click an element in the headful browser, then returns the generated selectors
+// of the clicked element, such as for writing the selectors of a new script. It blocks until an element is clicked,
+// the Escape key is pressed, or the page's context is done. It only works for the current document of the main frame,
+// the colors of Browser.TraceOverlay are used to highlight the hovered element.
+// PickElement 让用户在有界面的浏览器中悬停并点击一个元素，然后返回被点击元素生成的选择器，例如用于为新脚本编写选择器。
+// 它会一直阻塞，直到某个元素被点击、Escape 键被按下，或者页面的 context 结束。它只对主 frame 当前的文档有效，
+// 悬停元素的高亮会使用 Browser.TraceOverlay 的颜色。
+func (p *Page) PickElement() (*PickedElement, error) {
+	bind := "_" + utils.RandString(8)
+	picked := make(chan gson.JSON, 1)
+
+	// the cleanup must still work after the page's context is done
+	// 在页面的 context 结束之后，清理仍然需要能够执行
+	bg := p.Context(p.browser.ctx)
+
+	stop, err := bg.Expose(bind, func(res gson.JSON) (interface{}, error) {
+		select {
+		case picked <- res:
+		default:
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = stop() }()
+
+	_, err = p.Evaluate(Eval(jsPickElement, bind, pickElementTestIDs, p.browser.overlayStyle()))
+	if err != nil {
+		return nil, err
+	}
+
+	var res gson.JSON
+	select {
+	case <-p.ctx.Done():
+		_, _ = bg.Evaluate(Eval(`(bind) => window[bind + '_stop'] && window[bind + '_stop']()`, bind))
+		return nil, p.ctx.Err()
+	case res = <-picked:
+	}
+
+	if res.Get("canceled").Bool() {
+		return nil, &ErrPickCanceled{}
+	}
+
+	result := &PickedElement{
+		CSS:    res.Get("css").Str(),
+		XPath:  res.Get("xpath").Str(),
+		TestID: res.Get("testID").Str(),
+	}
+
+	obj, err := p.Evaluate(Eval(`(s) => document.querySelector(s)`, result.CSS).ByObject())
+	if err != nil {
+		return nil, err
+	}
+	if obj.Subtype != proto.RuntimeRemoteObjectSubtypeNode {
+		return nil, &ErrElementNotFound{}
+	}
+
+	result.Element, err = p.ElementFromObject(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
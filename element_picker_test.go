@@ -0,0 +1,94 @@
+package rod_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/input"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+func TestPickElement(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank()).MustSetDocumentContent(`<div>
+		<ul><li>a</li><li>b</li></ul>
+		<button data-testid="submit">submit</button>
+		<input name="q">
+		<a data-testid="a&#10;b">nl</a>
+		<p data-testid="it's">quote</p>
+		<span id="a&quot;b'c">both</span>
+	</div>`)
+
+	// keep clicking until the picker is ready
+	pick := func(selector string) *rod.PickedElement {
+		done := make(chan *rod.PickedElement)
+		go func() { done <- p.MustPickElement() }()
+		for {
+			select {
+			case el := <-done:
+				return el
+			default:
+				p.MustElement(selector).MustClick()
+				utils.Sleep(0.1)
+			}
+		}
+	}
+
+	el := pick("button")
+	g.Eq(el.CSS, `[data-testid="submit"]`)
+	g.Eq(el.XPath, `//*[@data-testid="submit"]`)
+	g.Eq(el.TestID, `[data-testid="submit"]`)
+	g.Eq(el.Element.MustText(), "submit")
+
+	el = pick("li:nth-child(2)")
+	g.Eq(el.CSS, "li:nth-of-type(2)")
+	g.Eq(el.XPath, "/html/body/div/ul/li[2]")
+	g.Eq(el.TestID, "")
+	g.Eq(el.Element.MustText(), "b")
+
+	el = pick("input")
+	g.Eq(el.CSS, `input[name="q"]`)
+
+	el = pick("a")
+	g.Eq(el.CSS, `[data-testid="a\a b"]`)
+	g.Eq(el.Element.MustText(), "nl")
+
+	el = pick("p")
+	g.Eq(el.XPath, `//*[@data-testid="it's"]`)
+
+	el = pick("span")
+	g.Eq(el.XPath, `//*[@id=concat("a", '"', "b'c")]`)
+	g.Eq(el.Element.MustText(), "both")
+
+	// the picker is removed when the context is done
+	picker := `() => document.documentElement.lastElementChild.tagName === 'DIV'`
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error)
+	go func() {
+		_, err := p.Context(ctx).PickElement()
+		stopped <- err
+	}()
+	p.MustWait(picker)
+	cancel()
+	g.True(errors.Is(<-stopped, context.Canceled))
+	g.False(p.MustEval(picker).Bool())
+
+	canceled := make(chan error)
+	go func() {
+		_, err := p.PickElement()
+		canceled <- err
+	}()
+	for {
+		select {
+		case err := <-canceled:
+			g.True(errors.Is(err, &rod.ErrPickCanceled{}))
+			return
+		default:
+			p.Keyboard.MustType(input.Escape)
+			utils.Sleep(0.1)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"image/png"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -500,6 +501,28 @@ func TestSetFiles(t *testing.T) {
 	g.Eq("alert.html", list[1].String())
 }
 
+func TestDropFiles(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/drop-zone.html"))
+	el := p.MustElement("#zone")
+
+	el.MustDropFiles(slash("fixtures/click.html"))
+	g.Eq(p.MustEval(`() => events`).Arr()[2].Str(), "drop")
+	g.Eq(p.MustEval(`() => dropped[0].name`).Str(), "click.html")
+	g.Has(p.MustEval(`() => dropped[0].type`).Str(), "text/html")
+	g.Has(p.MustEval(`() => dropped[0].text()`).Str(), "click me")
+
+	g.E(el.DropFileReaders([]*rod.DropFile{
+		{Name: "a.txt", Content: strings.NewReader("abc")},
+		{Name: "b", Type: "application/x-test", Content: strings.NewReader("")},
+	}))
+	g.Eq(p.MustEval(`() => dropped[1].text()`).Str(), "abc")
+	g.Eq(p.MustEval(`() => dropped[2].type`).Str(), "application/x-test")
+
+	g.Err(el.DropFiles([]string{"not-exists"}))
+}
+
 func TestEnter(t *testing.T) {
 	g := setup(t)
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
@@ -200,3 +202,268 @@ type ErrPageNotFound struct {
 func (e *ErrPageNotFound) Error() string {
 	return "cannot find page"
 }
+
+// ErrMainThreadBusy error
+type ErrMainThreadBusy struct {
+	Budget time.Duration
+}
+
+func (e *ErrMainThreadBusy) Error() string {
+	return fmt.Sprintf("main thread is still busy after %v", e.Budget)
+}
+
+// Is interface
+func (e *ErrMainThreadBusy) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrBrowserCrashed error, the browser or one of its targets crashed
+type ErrBrowserCrashed struct {
+	// TargetID of the crashed target, it's empty when the connection to the browser is lost
+	TargetID proto.TargetTargetID
+
+	// Err is the transport error when the connection to the browser is lost
+	Err error
+}
+
+func (e *ErrBrowserCrashed) Error() string {
+	if e.TargetID != "" {
+		return fmt.Sprintf("target crashed: %s", e.TargetID)
+	}
+	return fmt.Sprintf("browser crashed: %v", e.Err)
+}
+
+// Is interface
+func (e *ErrBrowserCrashed) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// Unwrap stdlib interface
+func (e *ErrBrowserCrashed) Unwrap() error {
+	return e.Err
+}
+
+// ErrNoHealthyBrowser error
+type ErrNoHealthyBrowser struct {
+}
+
+func (e *ErrNoHealthyBrowser) Error() string {
+	return "no healthy browser in the cluster"
+}
+
+// Is interface
+func (e *ErrNoHealthyBrowser) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrExtensionNotFound error
+type ErrExtensionNotFound struct {
+	ID string
+}
+
+func (e *ErrExtensionNotFound) Error() string {
+	return "cannot find the background page or service worker of extension: " + e.ID
+}
+
+// Is interface
+func (e *ErrExtensionNotFound) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrDownloadCanceled error
+type ErrDownloadCanceled struct {
+	GUID string
+}
+
+func (e *ErrDownloadCanceled) Error() string {
+	return "download canceled: " + e.GUID
+}
+
+// Is interface
+func (e *ErrDownloadCanceled) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrCacheNotFound error
+type ErrCacheNotFound struct {
+	Name string
+}
+
+func (e *ErrCacheNotFound) Error() string {
+	return "cannot find cache: " + e.Name
+}
+
+// Is interface
+func (e *ErrCacheNotFound) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrFileChooserNotOpened error
+type ErrFileChooserNotOpened struct{}
+
+func (e *ErrFileChooserNotOpened) Error() string {
+	return "the file chooser hasn't been opened, call the wait function first"
+}
+
+// Is interface
+func (e *ErrFileChooserNotOpened) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrResourceStatus error
+type ErrResourceStatus struct {
+	URL    string
+	Status int
+}
+
+func (e *ErrResourceStatus) Error() string {
+	return fmt.Sprintf("failed to fetch the resource %s, status code: %d", e.URL, e.Status)
+}
+
+// Is interface
+func (e *ErrResourceStatus) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrResourceLoad error, the browser failed to load the resource, such as the dns or the connection failed
+type ErrResourceLoad struct {
+	URL      string
+	NetError string
+}
+
+func (e *ErrResourceLoad) Error() string {
+	return fmt.Sprintf("failed to load the resource %s: %s", e.URL, e.NetError)
+}
+
+// Is interface
+func (e *ErrResourceLoad) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrSessionMisuse error, the session isn't attached via the connection of the browser or has been detached,
+// check Browser.StrictSessions
+type ErrSessionMisuse struct {
+	SessionID proto.TargetSessionID
+	Method    string
+}
+
+func (e *ErrSessionMisuse) Error() string {
+	return fmt.Sprintf("session %s isn't attached via the connection of the browser, method: %s", e.SessionID, e.Method)
+}
+
+// Is interface
+func (e *ErrSessionMisuse) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrProtocolMismatch error, the connected browser doesn't match the Browser.PinProtocol
+type ErrProtocolMismatch struct {
+	Product  string
+	Problems []string
+}
+
+func (e *ErrProtocolMismatch) Error() string {
+	return fmt.Sprintf("protocol mismatch with %s: %s", e.Product, strings.Join(e.Problems, "; "))
+}
+
+// Is interface
+func (e *ErrProtocolMismatch) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrUnstableMethod error, the method is experimental or deprecated, check Browser.GateMethods
+type ErrUnstableMethod struct {
+	Method    string
+	Stability proto.Stability
+}
+
+func (e *ErrUnstableMethod) Error() string {
+	return fmt.Sprintf("method %s is %s", e.Method, e.Stability)
+}
+
+// Is interface
+func (e *ErrUnstableMethod) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrProtocolValidation error, the params of the call don't match the schema, check Browser.ValidateProtocol
+type ErrProtocolValidation struct {
+	Method   string
+	Problems []string
+}
+
+func (e *ErrProtocolValidation) Error() string {
+	return fmt.Sprintf("invalid params of %s: %s", e.Method, strings.Join(e.Problems, "; "))
+}
+
+// Is interface
+func (e *ErrProtocolValidation) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrConcurrentUse error, the same Page or Element is used by multiple goroutines at the same time,
+// check Browser.DetectConcurrentUse
+type ErrConcurrentUse struct {
+	TargetID proto.TargetTargetID
+
+	Method string
+	Stack  string
+
+	// OtherMethod is the method of the call that was in progress in another goroutine
+	OtherMethod string
+	OtherStack  string
+}
+
+func (e *ErrConcurrentUse) Error() string {
+	return fmt.Sprintf(
+		"page %s is used by multiple goroutines at the same time: %s and %s, use a clone via Page.Context for each goroutine",
+		e.TargetID, e.Method, e.OtherMethod,
+	)
+}
+
+// Is interface
+func (e *ErrConcurrentUse) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrBatchFrame error, the element of the batch action doesn't belong to the frame of the Batch,
+// such as an element in an iframe, use a separate Batch of the iframe for it
+type ErrBatchFrame struct {
+	Op      int
+	Element *Element
+}
+
+func (e *ErrBatchFrame) Error() string {
+	return fmt.Sprintf("batch op %d: the element %s doesn't belong to the frame of the batch, "+
+		"use the Batch of the element's frame", e.Op, e.Element)
+}
+
+// Is interface
+func (e *ErrBatchFrame) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrWaitAborted error, the events stopped before the wait was satisfied, such as the page was closed
+// or the connection was lost
+type ErrWaitAborted struct{}
+
+func (e *ErrWaitAborted) Error() string {
+	return "the wait is aborted because the events stopped, such as the page is closed"
+}
+
+// Is interface
+func (e *ErrWaitAborted) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrPickCanceled error, the Escape key is pressed during Page.PickElement
+type ErrPickCanceled struct{}
+
+func (e *ErrPickCanceled) Error() string {
+	return "the element picking is canceled"
+}
+
+// Is interface
+func (e *ErrPickCanceled) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
@@ -0,0 +1,173 @@
+package rod
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ysmood/goob"
+)
+
+// EventPolicy decides what to do when the event buffer of a subscriber is full
+// EventPolicy 决定当订阅者的事件缓冲区满时该怎么做
+type EventPolicy int
+
+const (
+	// EventDropOldest drops the oldest event in the buffer to make room for the new one
+	// EventDropOldest 丢弃缓冲区中最旧的事件，为新事件腾出空间
+	EventDropOldest EventPolicy = iota
+
+	// EventBlock blocks the connection until the subscriber consumes an event. Be careful, the responses of the
+	// calls are also blocked, a subscriber that never consumes will stall the browser.
+	// EventBlock 阻塞连接，直到订阅者消费了一个事件。注意，调用的响应也会被阻塞，一个从不消费的订阅者会使浏览器停顿。
+	EventBlock
+)
+
+// EventBufferOptions for Browser.EventBuffer
+// Browser.EventBuffer 的选项
+type EventBufferOptions struct {
+	// Size of the buffer of each subscriber, 0 means unlimited, which is the default
+	// Size 是每个订阅者的缓冲区大小，0 表示没有限制，这是默认值
+	Size int
+
+	// Policy when the buffer is full
+	// Policy 是缓冲区满时的策略
+	Policy EventPolicy
+}
+
+// EventStats of the event subscribers
+// 事件订阅者的统计
+type EventStats struct {
+	// Dropped events in total
+	// Dropped 是丢弃的事件总数
+	Dropped int64
+
+	// DroppedByMethod is the number of the dropped events of each method
+	// DroppedByMethod 是每个方法被丢弃的事件数量
+	DroppedByMethod map[string]int64
+}
+
+type eventStats struct {
+	lock    sync.Mutex
+	dropped map[string]int64
+}
+
+func newEventStats() *eventStats {
+	return &eventStats{dropped: map[string]int64{}}
+}
+
+func (s *eventStats) drop(method string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.dropped[method]++
+}
+
+// EventBuffer sets how the events are buffered for each subscriber of Browser.Event and Browser.EventOf,
+// it only affects the subscriptions created after it. By default the buffers are unlimited, so a slow consumer
+// can balloon the memory. The EachEvent functions are not affected, because the waits built on them
+// can't afford to miss any event.
+// EventBuffer 设置如何为 Browser.Event 和 Browser.EventOf 的每个订阅者缓冲事件，它只影响之后创建的订阅。
+// 默认情况下缓冲区没有限制，所以一个慢的消费者会使内存膨胀。EachEvent 系列函数不受影响，因为基于它们的等待不能错过任何事件。
+func (b *Browser) EventBuffer(opts EventBufferOptions) *Browser {
+	b.router.lock.Lock()
+	defer b.router.lock.Unlock()
+	b.router.buffer = opts
+	return b
+}
+
+// EventStats returns the stats of the event subscribers, such as the dropped events
+// EventStats 返回事件订阅者的统计，例如被丢弃的事件
+func (b *Browser) EventStats() EventStats {
+	s := b.router.stats
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stats := EventStats{DroppedByMethod: map[string]int64{}}
+	for m, n := range s.dropped {
+		stats.Dropped += n
+		stats.DroppedByMethod[m] = n
+	}
+	return stats
+}
+
+// EventOf is similar to Browser.Event, but only the events of the methods are buffered for the subscriber,
+// such as "Network.requestWillBeSent"
+// EventOf 类似于 Browser.Event，但是只有这些方法的事件会为订阅者缓冲，例如 "Network.requestWillBeSent"
+func (b *Browser) EventOf(methods ...string) <-chan *Message {
+	if len(methods) == 0 {
+		ch := make(chan *Message)
+		close(ch)
+		return ch
+	}
+	return b.messages(b.router.subscribeMethods(b.ctx, "", methods, true))
+}
+
+// newEventPipe is similar to goob.NewPipe, but the buffer is limited by the opts
+// newEventPipe 类似于 goob.NewPipe，但是缓冲区受 opts 限制
+func newEventPipe(ctx context.Context, opts EventBufferOptions, stats *eventStats) (func(*Message), goob.Events) {
+	events := make(chan goob.Event)
+	lock := sync.Mutex{}
+	buf := []*Message{}
+	wait := make(chan struct{}, 1)  // notifies the new events
+	space := make(chan struct{}, 1) // notifies the consumed events
+
+	notify := func(ch chan struct{}) {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+
+	write := func(msg *Message) {
+		lock.Lock()
+		for opts.Size > 0 && len(buf) >= opts.Size {
+			if opts.Policy == EventDropOldest {
+				stats.drop(buf[0].Method)
+				buf = buf[1:]
+				break
+			}
+
+			lock.Unlock()
+			select {
+			case <-ctx.Done():
+				return
+			case <-space:
+			}
+			lock.Lock()
+		}
+		buf = append(buf, msg)
+		lock.Unlock()
+
+		notify(wait)
+	}
+
+	go func() {
+		defer close(events)
+
+		for {
+			lock.Lock()
+			if len(buf) == 0 {
+				lock.Unlock()
+				select {
+				case <-ctx.Done():
+					return
+				case <-wait:
+				}
+				continue
+			}
+			msg := buf[0]
+			buf = buf[1:]
+			lock.Unlock()
+
+			notify(space)
+
+			select {
+			case <-ctx.Done():
+				return
+			case events <- msg:
+			}
+		}
+	}()
+
+	return write, events
+}
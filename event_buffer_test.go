@@ -0,0 +1,45 @@
+package rod_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestEventBuffer(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank())
+	defer p.EnableDomain(&proto.RuntimeEnable{})()
+
+	g.browser.EventBuffer(rod.EventBufferOptions{Size: 1, Policy: rod.EventDropOldest})
+	defer g.browser.EventBuffer(rod.EventBufferOptions{})
+
+	before := g.browser.EventStats().DroppedByMethod["Runtime.consoleAPICalled"]
+
+	b, cancel := g.browser.WithCancel()
+	defer cancel()
+	events := b.EventOf("Runtime.consoleAPICalled")
+
+	p.MustEval(`() => { for (let i = 0; i < 10; i++) console.log(i) }`)
+	time.Sleep(300 * time.Millisecond)
+
+	var last *rod.Message
+	timeout := time.After(time.Second)
+loop:
+	for {
+		select {
+		case last = <-events:
+		case <-timeout:
+			break loop
+		}
+	}
+
+	// only the newest events are kept
+	g.Gt(g.browser.EventStats().DroppedByMethod["Runtime.consoleAPICalled"], before)
+	e := proto.RuntimeConsoleAPICalled{}
+	g.True(last.Load(&e))
+	g.Eq(e.Args[0].Value.Int(), 9)
+}
@@ -0,0 +1,190 @@
+package rod
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-rod/rod/lib/utils"
+	"github.com/ysmood/gson"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// ExposeFunc is similar to Page.Expose, but the fn can be any Go func, such as:
+//     page.ExposeFunc("add", func(a, b int) (int, error) { return a + b, nil })
+// In the page it can be called with multiple args, such as `await add(1, 2)`. The args and the result will be
+// json-encoded automatically. The fn can return nothing, a value, an error, or a value and an error,
+// if the error is not nil the promise in the page will be rejected with the error message.
+// The name can be a path like "api.add", the parent objects will be created automatically.
+// ExposeFunc 类似于 Page.Expose，但是 fn 可以是任意的 Go 函数。在页面中可以使用多个参数调用它，例如 `await add(1, 2)`。
+// 参数和结果会自动通过 json 编码。fn 可以没有返回值，或者返回一个值、一个 error、一个值和一个 error，
+// 如果 error 不为 nil，页面中的 promise 将以该 error 的信息被 reject。
+// name 可以是类似 "api.add" 的路径，父级对象会被自动创建。
+func (p *Page) ExposeFunc(name string, fn interface{}) (stop func() error, err error) {
+	handler, err := exposeHandler(name, reflect.ValueOf(fn))
+	if err != nil {
+		return nil, err
+	}
+
+	bind := "_" + utils.RandString(8)
+
+	stopBind, err := p.Expose(bind, handler)
+	if err != nil {
+		return nil, err
+	}
+
+	// spread the args of the js call as an array to the binding
+	// 将 js 调用的参数作为数组传递给 binding
+	def := `(path, bind) => {
+		const fn = window[bind]
+		const keys = path.split('.')
+		let obj = window
+		for (const k of keys.slice(0, -1)) obj = obj[k] = obj[k] || {}
+		obj[keys[keys.length - 1]] = (...args) => fn(args)
+	}`
+
+	_, err = p.Evaluate(Eval(def, name, bind))
+	if err != nil {
+		_ = stopBind()
+		return nil, err
+	}
+
+	remove, err := p.EvalOnNewDocument(fmt.Sprintf(`(%s)(%s, %s)`, def, utils.MustToJSON(name), utils.MustToJSON(bind)))
+	if err != nil {
+		_ = stopBind()
+		return nil, err
+	}
+
+	return func() error {
+		err := remove()
+		if err != nil {
+			return err
+		}
+		return stopBind()
+	}, nil
+}
+
+// ExposeMethods exposes all the exported methods of the obj under the namespace via Page.ExposeFunc,
+// the first letter of the method names will be lowercased, such as the method "Add" will be exposed as "namespace.add".
+// ExposeMethods 通过 Page.ExposeFunc 将 obj 所有导出的方法暴露到 namespace 下，
+// 方法名的首字母会被转为小写，例如方法 "Add" 会被暴露为 "namespace.add"。
+func (p *Page) ExposeMethods(namespace string, obj interface{}) (stop func() error, err error) {
+	v := reflect.ValueOf(obj)
+	t := v.Type()
+
+	stops := []func() error{}
+	stop = func() error {
+		for _, s := range stops {
+			err := s()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+		name := namespace + "." + strings.ToLower(m.Name[:1]) + m.Name[1:]
+
+		s, err := p.ExposeFunc(name, v.Method(i).Interface())
+		if err != nil {
+			_ = stop()
+			return nil, err
+		}
+		stops = append(stops, s)
+	}
+
+	return stop, nil
+}
+
+// convert the fn to the handler of Page.Expose, the req of the handler is the array of the js args
+// 将 fn 转换为 Page.Expose 的 handler，handler 的 req 是 js 参数的数组
+func exposeHandler(name string, fn reflect.Value) (func(gson.JSON) (interface{}, error), error) {
+	if fn.Kind() != reflect.Func {
+		return nil, fmt.Errorf("the exposed %s must be a func, but got %s", name, fn.Kind())
+	}
+
+	t := fn.Type()
+
+	switch {
+	case t.NumOut() == 2 && t.Out(1) == errorType:
+	case t.NumOut() < 2:
+	default:
+		return nil, fmt.Errorf("the exposed %s can only return (value, error), value, error or nothing", name)
+	}
+
+	return func(req gson.JSON) (interface{}, error) {
+		args, err := exposeArgs(t, req.Arr())
+		if err != nil {
+			return nil, &exposeError{err}
+		}
+
+		out := fn.Call(args)
+
+		if len(out) > 0 && t.Out(len(out)-1) == errorType {
+			if err, _ := out[len(out)-1].Interface().(error); err != nil {
+				return nil, &exposeError{err}
+			}
+			out = out[:len(out)-1]
+		}
+
+		if len(out) == 0 {
+			return nil, nil
+		}
+		return out[0].Interface(), nil
+	}, nil
+}
+
+// decode the js args to the params of the fn, the missing args will be zero values
+// 将 js 参数解码为 fn 的参数，缺失的参数将使用零值
+func exposeArgs(t reflect.Type, list []gson.JSON) ([]reflect.Value, error) {
+	args := []reflect.Value{}
+
+	for i := 0; i < t.NumIn() || (t.IsVariadic() && i < len(list)); i++ {
+		var pt reflect.Type
+		if t.IsVariadic() && i >= t.NumIn()-1 {
+			pt = t.In(t.NumIn() - 1).Elem()
+		} else {
+			pt = t.In(i)
+		}
+
+		if i >= len(list) {
+			if t.IsVariadic() && i >= t.NumIn()-1 {
+				break
+			}
+			args = append(args, reflect.Zero(pt))
+			continue
+		}
+
+		b, err := list[i].MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+
+		v := reflect.New(pt)
+		err = json.Unmarshal(b, v.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode arg %d into %s: %w", i, pt, err)
+		}
+		args = append(args, v.Elem())
+	}
+
+	return args, nil
+}
+
+// exposeError will be json-encoded as its message, so that the page can receive it
+// exposeError 会被 json 编码为它的信息，这样页面就能收到它
+type exposeError struct {
+	err error
+}
+
+func (e *exposeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exposeError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.err.Error())
+}
@@ -0,0 +1,95 @@
+package rod_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+type exposeCalc struct {
+	base int
+}
+
+func (c *exposeCalc) Add(a, b int) int {
+	return c.base + a + b
+}
+
+func (c *exposeCalc) Div(a, b int) (int, error) {
+	if b == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return a / b, nil
+}
+
+func TestPageExposeFunc(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.blank()).MustWaitLoad()
+
+	type user struct {
+		Name string `json:"name"`
+	}
+
+	stop := page.MustExposeFunc("api.greet", func(u user, greetings ...string) (string, error) {
+		return strings.Join(greetings, " ") + " " + u.Name, nil
+	})
+
+	g.Eq(page.MustEval(`() => api.greet({ name: "jack" }, "hi", "hello")`).Str(), "hi hello jack")
+
+	var called bool
+	page.MustExposeFunc("noop", func(n int) { called = n == 0 })
+	g.Nil(page.MustEval(`() => noop()`).Val())
+	g.True(called)
+
+	page.MustExposeFunc("fail", func() error { return errors.New("err") })
+	g.Eq(page.MustEval(`() => fail().catch(e => e)`).Str(), "err")
+
+	g.Has(page.MustEval(`() => api.greet(1).catch(e => e)`).Str(), "failed to decode arg 0 into rod_test.user")
+
+	// survive the reload
+	page.MustReload().MustWaitLoad()
+	g.Eq(page.MustEval(`() => api.greet({ name: "ok" })`).Str(), " ok")
+
+	stop()
+	page.MustReload().MustWaitLoad()
+	g.Eq(page.MustEval(`() => typeof api`).Str(), "undefined")
+
+	_, err := page.ExposeFunc("x", 1)
+	g.Eq(err.Error(), "the exposed x must be a func, but got int")
+
+	_, err = page.ExposeFunc("x", func() (int, int) { return 0, 0 })
+	g.Eq(err.Error(), "the exposed x can only return (value, error), value, error or nothing")
+
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeAddBinding{})
+		page.MustExposeFunc("x", func() {})
+	})
+	g.Panic(func() {
+		g.mc.stubErr(2, proto.RuntimeCallFunctionOn{})
+		page.MustExposeFunc("x", func() {})
+	})
+	g.Panic(func() {
+		g.mc.stubErr(2, proto.PageAddScriptToEvaluateOnNewDocument{})
+		page.MustExposeFunc("x", func() {})
+	})
+}
+
+func TestPageExposeMethods(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.blank()).MustWaitLoad()
+
+	stop := page.MustExposeMethods("calc", &exposeCalc{base: 1})
+	defer stop()
+
+	g.Eq(page.MustEval(`() => calc.add(2, 3)`).Int(), 6)
+	g.Eq(page.MustEval(`() => calc.div(6, 3)`).Int(), 2)
+	g.Eq(page.MustEval(`() => calc.div(1, 0).catch(e => e)`).Str(), "division by zero")
+
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeAddBinding{})
+		page.MustExposeMethods("calc2", &exposeCalc{})
+	})
+}
@@ -0,0 +1,207 @@
+package rod
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+	"github.com/ysmood/gson"
+)
+
+// Extension is the background page or service worker of a browser extension
+// Extension 是浏览器扩展的后台页面或 service worker
+type Extension struct {
+	// ID of the extension, such as the host of chrome-extension://<id>/
+	// 扩展的 ID，即 chrome-extension://<id>/ 中的 host
+	ID string
+
+	// Target of the background page or service worker
+	// 后台页面或 service worker 的目标
+	Target *proto.TargetTargetInfo
+
+	// the session attached to the target, only the Runtime related methods work on it
+	// 附加到目标的会话，只有 Runtime 相关的方法能在它上面工作
+	page *Page
+}
+
+// ExtensionTargets returns the targets that belong to browser extensions, such as background pages,
+// service workers, popups and option pages. If the extensionID is not empty, only the targets of it will be returned.
+// ExtensionTargets 返回属于浏览器扩展的目标，例如后台页面、service worker、弹出窗口和选项页面。
+// 如果 extensionID 不为空，则只返回该扩展的目标。
+func (b *Browser) ExtensionTargets(extensionID string) ([]*proto.TargetTargetInfo, error) {
+	list, err := b.Targets()
+	if err != nil {
+		return nil, err
+	}
+
+	targets := []*proto.TargetTargetInfo{}
+	for _, info := range list {
+		id := extensionIDOf(info.URL)
+		if id == "" || (extensionID != "" && id != extensionID) {
+			continue
+		}
+		targets = append(targets, info)
+	}
+	return targets, nil
+}
+
+// Extension attaches to the background page or service worker of the extension,
+// if the extensionID is empty the first extension found will be used.
+// Extension 附加到扩展的后台页面或 service worker，如果 extensionID 为空，将使用找到的第一个扩展。
+func (b *Browser) Extension(extensionID string) (*Extension, error) {
+	targets, err := b.ExtensionTargets(extensionID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range targets {
+		switch info.Type {
+		case proto.TargetTargetInfoTypeBackgroundPage, proto.TargetTargetInfoTypeServiceWorker:
+		default:
+			continue
+		}
+
+		page, err := b.attachRuntime(info.TargetID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Extension{ID: extensionIDOf(info.URL), Target: info, page: page}, nil
+	}
+
+	return nil, &ErrExtensionNotFound{extensionID}
+}
+
+// attach a target that may not have the Page domain, such as a service worker
+// 附加一个可能没有 Page 域的目标，例如 service worker
+func (b *Browser) attachRuntime(targetID proto.TargetTargetID) (*Page, error) {
+	session, err := proto.TargetAttachToTarget{
+		TargetID: targetID,
+		Flatten:  true,
+	}.Call(b)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionCtx, cancel := context.WithCancel(b.ctx)
+
+	page := &Page{
+		e:             b.e,
+		ctx:           sessionCtx,
+		sessionCancel: cancel,
+		sleeper:       b.sleeper,
+		browser:       b,
+		TargetID:      targetID,
+		SessionID:     session.SessionID,
+		jsCtxLock:     &sync.Mutex{},
+		jsCtxID:       new(proto.RuntimeRemoteObjectID),
+		helpersLock:   &sync.Mutex{},
+
+		inputRecording: &inputRecording{},
+	}
+	page.root = page
+
+	page.initEvents()
+
+	return page, nil
+}
+
+func extensionIDOf(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil || parsed.Scheme != "chrome-extension" {
+		return ""
+	}
+	return parsed.Host
+}
+
+// Context returns a clone with the specified ctx for chained sub-operations
+// Context 返回具有指定 ctx 的克隆，用于链式子操作
+func (e *Extension) Context(ctx context.Context) *Extension {
+	newObj := *e
+	newObj.page = e.page.Context(ctx)
+	return &newObj
+}
+
+// Eval is similar to Page.Eval, the js runs in the background page or service worker of the extension
+// Eval 类似于 Page.Eval，js 在扩展的后台页面或 service worker 中运行
+func (e *Extension) Eval(js string, args ...interface{}) (*proto.RuntimeRemoteObject, error) {
+	return e.page.Eval(js, args...)
+}
+
+// Evaluate is similar to Page.Evaluate
+// Evaluate 类似于 Page.Evaluate
+func (e *Extension) Evaluate(opts *EvalOptions) (*proto.RuntimeRemoteObject, error) {
+	return e.page.Evaluate(opts)
+}
+
+// SendMessage sends the msg via chrome.runtime.sendMessage from the extension and returns the response,
+// it can be received by the other pages of the extension, such as the popup.
+// SendMessage 从扩展中通过 chrome.runtime.sendMessage 发送 msg 并返回响应，
+// 它可以被扩展的其他页面接收，例如弹出窗口。
+func (e *Extension) SendMessage(msg interface{}) (gson.JSON, error) {
+	res, err := e.Eval(`msg => new Promise((resolve, reject) => {
+		chrome.runtime.sendMessage(msg, (res) => {
+			const err = chrome.runtime.lastError
+			err ? reject(new Error(err.message)) : resolve(res)
+		})
+	})`, msg)
+	if err != nil {
+		return gson.New(nil), err
+	}
+	return res.Value, nil
+}
+
+// OnMessage calls fn with the messages that the extension receives via chrome.runtime.onMessage,
+// such as the ones sent by its content scripts. Call stop to unsubscribe.
+// OnMessage 使用扩展通过 chrome.runtime.onMessage 接收到的消息调用 fn，例如它的内容脚本发送的消息。
+// 调用 stop 取消订阅。
+func (e *Extension) OnMessage(fn func(msg gson.JSON)) (stop func() error, err error) {
+	bind := "_" + utils.RandString(8)
+
+	err = proto.RuntimeAddBinding{Name: bind}.Call(rodCall(e.page))
+	if err != nil {
+		return
+	}
+
+	_, err = e.Eval(fmt.Sprintf(`() => {
+		const listener = (msg) => { %s(JSON.stringify(msg === undefined ? null : msg)) }
+		globalThis.%s_listener = listener
+		chrome.runtime.onMessage.addListener(listener)
+	}`, bind, bind))
+	if err != nil {
+		return
+	}
+
+	p, cancel := e.page.WithCancel()
+
+	stop = func() error {
+		defer cancel()
+		_, err := e.Eval(fmt.Sprintf(`() => chrome.runtime.onMessage.removeListener(globalThis.%s_listener)`, bind))
+		if err != nil {
+			return err
+		}
+		return proto.RuntimeRemoveBinding{Name: bind}.Call(rodCall(p))
+	}
+
+	go p.EachEvent(func(ev *proto.RuntimeBindingCalled) {
+		if ev.Name == bind {
+			fn(gson.NewFrom(ev.Payload))
+		}
+	})()
+
+	return
+}
+
+// Close detaches from the target of the extension, the extension itself keeps running
+// Close 从扩展的目标上分离，扩展本身会继续运行
+func (e *Extension) Close() error {
+	err := proto.TargetDetachFromTarget{SessionID: e.page.SessionID}.Call(e.page.browser)
+	if err != nil {
+		return err
+	}
+	e.page.sessionCancel()
+	return nil
+}
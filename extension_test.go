@@ -0,0 +1,51 @@
+package rod_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/launcher/flags"
+	"github.com/go-rod/rod/lib/utils"
+	"github.com/ysmood/gson"
+)
+
+func TestExtension(t *testing.T) {
+	g := setup(t)
+
+	extPath, err := filepath.Abs("fixtures/chrome-extension")
+	g.E(err)
+
+	l := launcher.New().Set("load-extension", extPath).Set(flags.Headless, "new")
+	g.Cleanup(l.Kill)
+	b := rod.New().ControlURL(l.MustLaunch()).MustConnect()
+
+	// wait for the background page to be loaded
+	var ext *rod.Extension
+	for i := 0; ext == nil && i < 30; i++ {
+		ext, err = b.Extension("")
+		utils.Sleep(0.1)
+	}
+	g.E(err)
+
+	g.Len(b.MustExtensionTargets(ext.ID), 1)
+	g.Len(b.MustExtensionTargets("not-exists"), 0)
+	g.Eq(ext.MustEval(`() => window.rodExtension`).Str(), "ok")
+	g.Eq(ext.MustEval(`() => chrome.runtime.id`).Str(), ext.ID)
+
+	msg := make(chan gson.JSON, 1)
+	stop := ext.MustOnMessage(func(m gson.JSON) { msg <- m })
+	b.MustPage(g.blank())
+	g.Eq((<-msg).Get("title").Str(), "test-extension")
+	stop()
+
+	_, err = ext.SendMessage("no receiver")
+	g.Err(err)
+
+	g.E(ext.Close())
+
+	_, err = b.Extension("not-exists")
+	g.Is(err, &rod.ErrExtensionNotFound{})
+	g.Has(err.Error(), "not-exists")
+}
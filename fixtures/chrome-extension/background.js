@@ -0,0 +1 @@
+window.rodExtension = 'ok'
@@ -1 +1,2 @@
 window.document.title = 'test-extension'
+chrome.runtime.sendMessage({ title: document.title })
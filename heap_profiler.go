@@ -0,0 +1,197 @@
+package rod
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// HeapSnapshot takes a heap snapshot of the page and streams the chunks to the file, the whole snapshot is never
+// held in memory. The file can be loaded by the memory panel of the devtools.
+// HeapSnapshot 为页面拍摄一个堆快照并将数据块以流的方式写入文件，整个快照永远不会被保存在内存中。
+// 该文件可以被 devtools 的 memory 面板加载。
+func (p *Page) HeapSnapshot(file string) error {
+	err := os.MkdirAll(filepath.Dir(file), 0755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	w := &jsonEndWriter{w: f}
+
+	page, cancel := p.WithCancel()
+	defer cancel()
+
+	var writeErr error
+	wait := page.EachEvent(func(e *proto.HeapProfilerAddHeapSnapshotChunk) bool {
+		_, writeErr = w.Write([]byte(e.Chunk))
+		return writeErr != nil || w.ended
+	})
+
+	done := make(chan struct{})
+	go func() {
+		wait()
+		close(done)
+	}()
+
+	err = proto.HeapProfilerTakeHeapSnapshot{}.Call(rodCall(p))
+	if err != nil {
+		cancel()
+		<-done
+		_ = f.Close()
+		return err
+	}
+
+	// the chunks may arrive after the response, wait until the snapshot is complete
+	// 数据块可能在响应之后到达，等待直到快照完整
+	<-done
+	if writeErr == nil && !w.ended {
+		writeErr = p.ctx.Err()
+	}
+	if writeErr != nil {
+		_ = f.Close()
+		return writeErr
+	}
+	return f.Close()
+}
+
+// the snapshot is a json object, track its depth to know when it's complete
+// 快照是一个 json 对象，跟踪它的深度以得知它何时完整
+type jsonEndWriter struct {
+	w io.Writer
+
+	depth   int
+	inStr   bool
+	escaped bool
+	ended   bool
+}
+
+func (j *jsonEndWriter) Write(p []byte) (int, error) {
+	for _, c := range p {
+		if j.ended {
+			break
+		}
+
+		switch {
+		case j.escaped:
+			j.escaped = false
+		case j.inStr && c == '\\':
+			j.escaped = true
+		case c == '"':
+			j.inStr = !j.inStr
+		case j.inStr:
+		case c == '{' || c == '[':
+			j.depth++
+		case c == '}' || c == ']':
+			j.depth--
+			j.ended = j.depth == 0
+		}
+	}
+	return j.w.Write(p)
+}
+
+// CollectGarbage forces a garbage collection of the js heap of the page
+// CollectGarbage 强制对页面的 js 堆进行一次垃圾回收
+func (p *Page) CollectGarbage() error {
+	return proto.HeapProfilerCollectGarbage{}.Call(rodCall(p))
+}
+
+// HeapUsage of the js heap
+// js 堆的使用情况
+type HeapUsage struct {
+	// Used size in bytes
+	// Used 是已使用的字节数
+	Used float64
+
+	// Total size in bytes
+	// Total 是总的字节数
+	Total float64
+
+	Time time.Time
+}
+
+// HeapUsage returns the current usage of the js heap of the page
+// HeapUsage 返回页面 js 堆当前的使用情况
+func (p *Page) HeapUsage() (*HeapUsage, error) {
+	res, err := proto.RuntimeGetHeapUsage{}.Call(rodCall(p))
+	if err != nil {
+		return nil, err
+	}
+	return &HeapUsage{Used: res.UsedSize, Total: res.TotalSize, Time: time.Now()}, nil
+}
+
+// MonitorHeap samples the heap usage of the page every interval until stop is called or the page's context is done,
+// stop returns the samples in order. Compare the samples to detect the memory leaks of long-running pages.
+// MonitorHeap 每隔 interval 对页面的堆使用情况进行一次采样，直到 stop 被调用或者页面的 context 结束，
+// stop 按顺序返回采样结果。比较这些采样结果可以检测长时间运行的页面的内存泄漏。
+func (p *Page) MonitorHeap(interval time.Duration) (stop func() []*HeapUsage) {
+	page, cancel := p.WithCancel()
+
+	lock := sync.Mutex{}
+	list := []*HeapUsage{}
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		for {
+			if u, err := page.HeapUsage(); err == nil {
+				lock.Lock()
+				list = append(list, u)
+				lock.Unlock()
+			}
+
+			select {
+			case <-page.ctx.Done():
+				return
+			case <-t.C:
+			}
+		}
+	}()
+
+	return func() []*HeapUsage {
+		cancel()
+		<-done
+
+		lock.Lock()
+		defer lock.Unlock()
+		return list
+	}
+}
+
+// StartHeapSampling starts to sample the allocations of the js heap, the interval is the average bytes between
+// the samples, 0 means the default of the browser. Use Page.StopHeapSampling to get the profile.
+// StartHeapSampling 开始对 js 堆的分配进行采样，interval 是采样之间的平均字节数，0 表示使用浏览器的默认值。
+// 使用 Page.StopHeapSampling 获取结果。
+func (p *Page) StartHeapSampling(interval float64) error {
+	err := proto.HeapProfilerEnable{}.Call(rodCall(p))
+	if err != nil {
+		return err
+	}
+
+	req := proto.HeapProfilerStartSampling{}
+	if interval > 0 {
+		req.SamplingInterval = &interval
+	}
+	return req.Call(rodCall(p))
+}
+
+// StopHeapSampling stops the sampling and returns the profile of the allocations
+// StopHeapSampling 停止采样并返回分配的结果
+func (p *Page) StopHeapSampling() (*proto.HeapProfilerSamplingHeapProfile, error) {
+	res, err := proto.HeapProfilerStopSampling{}.Call(rodCall(p))
+	if err != nil {
+		return nil, err
+	}
+	return res.Profile, nil
+}
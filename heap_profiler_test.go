@@ -0,0 +1,68 @@
+package rod_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+	"github.com/ysmood/gson"
+)
+
+func TestHeapSnapshot(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank()).MustWaitLoad()
+
+	file := filepath.Join(os.TempDir(), "rod", "heap", g.RandStr(8)+".heapsnapshot")
+	p.MustHeapSnapshot(file)
+
+	data, err := utils.ReadString(file)
+	g.E(err)
+	g.True(gson.NewFrom(data).Has("snapshot.meta"))
+
+	g.mc.stubErr(1, proto.HeapProfilerTakeHeapSnapshot{})
+	g.Err(p.HeapSnapshot(file))
+
+	g.Err(p.HeapSnapshot(filepath.Join(file, "a")))
+}
+
+func TestHeapUsage(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank()).MustWaitLoad()
+
+	p.MustCollectGarbage()
+	u := p.MustHeapUsage()
+	g.Gt(u.Used, 0.0)
+	g.Gte(u.Total, u.Used)
+
+	stop := p.MonitorHeap(10 * time.Millisecond)
+	p.MustEval(`() => { window.leak = new Array(1e6).fill(1) }`)
+	utils.Sleep(0.1)
+	list := stop()
+	g.Gt(len(list), 1)
+	g.Gt(list[len(list)-1].Used, list[0].Used)
+
+	g.mc.stubErr(1, proto.RuntimeGetHeapUsage{})
+	g.Err(p.HeapUsage())
+}
+
+func TestHeapSampling(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank()).MustWaitLoad()
+
+	p.MustStartHeapSampling(1024)
+	p.MustEval(`() => { window.list = []; for (let i = 0; i < 1e4; i++) window.list.push({ i }) }`)
+	profile := p.MustStopHeapSampling()
+	g.NotNil(profile.Head)
+
+	g.mc.stubErr(1, proto.HeapProfilerEnable{})
+	g.Err(p.StartHeapSampling(0))
+
+	g.mc.stubErr(1, proto.HeapProfilerStopSampling{})
+	g.Err(p.StopHeapSampling())
+}
@@ -61,11 +61,21 @@ func (r *HijackRouter) initEvents() *HijackRouter {
 	eventCtx, cancel := context.WithCancel(ctx)
 	r.stop = cancel
 
+	// track the router so that Browser.CloseGracefully can stop it
+	// 记录路由器，以便 Browser.CloseGracefully 可以停止它
+	r.browser.states.Store(r, r)
+
 	_ = r.enable.Call(r.client)
 
 	r.run = r.browser.Context(eventCtx).eachEvent(sessionID, func(e *proto.FetchRequestPaused) bool {
 		go func() {
 			ctx := r.new(eventCtx, e)
+			log := r.browser.log(LogHijack)
+			onError := func(action string, err error) {
+				log.Warn(action, "url", e.Request.URL, "err", err)
+				ctx.OnError(err)
+			}
+
 			for _, h := range r.handlers {
 				if !h.regexp.MatchString(e.Request.URL) {
 					continue
@@ -74,29 +84,33 @@ func (r *HijackRouter) initEvents() *HijackRouter {
 				h.handler(ctx)
 
 				if ctx.continueRequest != nil {
+					log.Debug("continue", "url", e.Request.URL)
 					ctx.continueRequest.RequestID = e.RequestID
 					err := ctx.continueRequest.Call(r.client)
 					if err != nil {
-						ctx.OnError(err)
+						onError("continue", err)
 					}
 					return
 				}
 
 				if ctx.Skip {
+					log.Debug("skip", "url", e.Request.URL)
 					continue
 				}
 
 				if ctx.Response.fail.ErrorReason != "" {
+					log.Debug("fail", "url", e.Request.URL, "reason", ctx.Response.fail.ErrorReason)
 					err := ctx.Response.fail.Call(r.client)
 					if err != nil {
-						ctx.OnError(err)
+						onError("fail", err)
 					}
 					return
 				}
 
+				log.Debug("fulfill", "url", e.Request.URL, "status", ctx.Response.payload.ResponseCode)
 				err := ctx.Response.payload.Call(r.client)
 				if err != nil {
-					ctx.OnError(err)
+					onError("fulfill", err)
 					return
 				}
 			}
@@ -144,18 +158,13 @@ func (r *HijackRouter) Remove(pattern string) error {
 
 // new 新建一个ctx
 func (r *HijackRouter) new(ctx context.Context, e *proto.FetchRequestPaused) *Hijack {
-	headers := http.Header{}
-	for k, v := range e.Request.Headers {
-		headers[k] = []string{v.String()}
-	}
-
 	u, _ := url.Parse(e.Request.URL)
 
 	req := &http.Request{
 		Method: e.Request.Method,
 		URL:    u,
 		Body:   ioutil.NopCloser(strings.NewReader(e.Request.PostData)),
-		Header: headers,
+		Header: e.Request.Headers.HTTPHeader(),
 	}
 
 	return &Hijack{
@@ -187,6 +196,7 @@ func (r *HijackRouter) Run() {
 // Stop 停止router
 func (r *HijackRouter) Stop() error {
 	r.stop()
+	r.browser.states.Delete(r)
 	return proto.FetchDisable{}.Call(r.client)
 }
 
@@ -273,10 +283,10 @@ func (ctx *HijackRequest) URL() *url.URL {
 	return u
 }
 
-// Header via a key
-// 通过key获得相应的请求头的值
+// Header via a key, the key is case-insensitive
+// 通过key获得相应的请求头的值，key不区分大小写
 func (ctx *HijackRequest) Header(key string) string {
-	return ctx.event.Request.Headers[key].String()
+	return ctx.event.Request.Headers.Get(key)
 }
 
 // Headers of request
@@ -0,0 +1,132 @@
+package rod
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// InitScriptOptions for Page.AddInitScript
+// Page.AddInitScript 的选项
+type InitScriptOptions struct {
+	// Args for the js function, they will be encoded as JSON
+	// js 函数的参数，它们会被编码为 JSON
+	Args []interface{}
+
+	// WorldName runs the js in an isolated world with the name, so the js of the page can't access its variables
+	// WorldName 在指定名称的隔离环境中运行 js，这样页面的 js 就无法访问它的变量
+	WorldName string
+
+	// MainFrameOnly skips the iframes
+	// MainFrameOnly 跳过 iframe
+	MainFrameOnly bool
+}
+
+// InitScript is a script that will be evaluated in every new document of the page before the document's scripts
+// InitScript 是一个会在页面的每个新文档中，先于文档自身的脚本执行的脚本
+type InitScript struct {
+	ID        proto.PageScriptIdentifier
+	Source    string
+	WorldName string
+
+	page *Page
+}
+
+type initScriptsKey struct {
+	targetID proto.TargetTargetID
+}
+
+// the active init scripts of a target, they are shared by all the Page instances of the target
+// 一个 target 中生效的 init script，它们由该 target 的所有 Page 实例共享
+type initScripts struct {
+	lock sync.Mutex
+	list []*InitScript
+}
+
+// AddInitScript is similar to Page.EvalOnNewDocument, but the js is a function definition like Page.Eval,
+// it will be called with the opts.Args, such as:
+//     page.AddInitScript(`(name) => { window.user = name }`, &rod.InitScriptOptions{Args: []interface{}{"jack"}})
+// AddInitScript 类似于 Page.EvalOnNewDocument，但是 js 是一个类似 Page.Eval 的函数定义，它会以 opts.Args 为参数被调用。
+func (p *Page) AddInitScript(js string, opts *InitScriptOptions) (*InitScript, error) {
+	if opts == nil {
+		opts = &InitScriptOptions{}
+	}
+
+	args := opts.Args
+	if args == nil {
+		args = []interface{}{}
+	}
+
+	source := fmt.Sprintf("(%s).apply(undefined, %s)", strings.Trim(js, "\t\n\v\f\r ;"), utils.MustToJSON(args))
+	if opts.MainFrameOnly {
+		source = fmt.Sprintf("if (window === window.top) %s", source)
+	}
+
+	return p.addInitScript(proto.PageAddScriptToEvaluateOnNewDocument{Source: source, WorldName: opts.WorldName})
+}
+
+// InitScripts returns the active init scripts of the page in the order they are added,
+// including the ones added by Page.EvalOnNewDocument and Page.Expose .
+// InitScripts 按添加的顺序返回页面中生效的 init script，包括通过 Page.EvalOnNewDocument 和 Page.Expose 添加的。
+func (p *Page) InitScripts() []*InitScript {
+	s := p.initScripts()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]*InitScript{}, s.list...)
+}
+
+// ClearInitScripts removes all the active init scripts of the page, it's useful before reusing a page
+// ClearInitScripts 删除页面中所有生效的 init script，在复用页面之前很有用
+func (p *Page) ClearInitScripts() error {
+	for _, s := range p.InitScripts() {
+		err := s.Remove()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove the init script, the documents that are already loaded won't be affected
+// 删除该 init script，已经加载的文档不会受影响
+func (s *InitScript) Remove() error {
+	err := proto.PageRemoveScriptToEvaluateOnNewDocument{Identifier: s.ID}.Call(s.page)
+	if err != nil {
+		return err
+	}
+
+	list := s.page.initScripts()
+	list.lock.Lock()
+	defer list.lock.Unlock()
+	for i, item := range list.list {
+		if item == s {
+			list.list = append(list.list[:i], list.list[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (p *Page) addInitScript(req proto.PageAddScriptToEvaluateOnNewDocument) (*InitScript, error) {
+	res, err := req.Call(p)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &InitScript{ID: res.Identifier, Source: req.Source, WorldName: req.WorldName, page: p}
+
+	list := p.initScripts()
+	list.lock.Lock()
+	list.list = append(list.list, s)
+	list.lock.Unlock()
+
+	return s, nil
+}
+
+func (p *Page) initScripts() *initScripts {
+	s, _ := p.browser.states.LoadOrStore(initScriptsKey{p.TargetID}, &initScripts{})
+	return s.(*initScripts)
+}
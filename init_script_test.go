@@ -0,0 +1,66 @@
+package rod_test
+
+import (
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestPageInitScripts(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/iframe", ".html", `<html></html>`)
+	s.Route("/", ".html", `<html><iframe src="/iframe"></iframe></html>`)
+
+	page := g.newPage()
+
+	user := page.MustAddInitScript(`(name, age) => { window.user = name + age }`, &rod.InitScriptOptions{
+		Args: []interface{}{"jack", 10},
+	})
+	page.MustAddInitScript(`() => { window.main = true }`, &rod.InitScriptOptions{MainFrameOnly: true})
+	page.MustAddInitScript(`() => { window.isolated = true }`, &rod.InitScriptOptions{WorldName: "rod-test"})
+	page.MustEvalOnNewDocument(`window.raw = true`)
+
+	list := page.InitScripts()
+	g.Len(list, 4)
+	g.Eq(list[0], user)
+	g.Eq(list[2].WorldName, "rod-test")
+	g.Eq(list[3].Source, `window.raw = true`)
+
+	page.MustNavigate(s.URL()).MustWaitLoad()
+
+	g.Eq(page.MustEval(`() => window.user`).Str(), "jack10")
+	g.True(page.MustEval(`() => window.main`).Bool())
+	g.True(page.MustEval(`() => window.raw`).Bool())
+	g.Nil(page.MustEval(`() => window.isolated`).Val())
+
+	frame := page.MustElement("iframe").MustFrame().MustWaitLoad()
+	g.Eq(frame.MustEval(`() => window.user`).Str(), "jack10")
+	g.Nil(frame.MustEval(`() => window.main`).Val())
+
+	// the same target shares the init scripts
+	same := g.browser.MustPageFromTargetID(page.TargetID)
+	g.Len(same.InitScripts(), 4)
+
+	user.MustRemove()
+	g.Len(page.InitScripts(), 3)
+
+	page.MustClearInitScripts()
+	g.Len(page.InitScripts(), 0)
+
+	page.MustReload().MustWaitLoad()
+	g.Nil(page.MustEval(`() => window.user`).Val())
+	g.Nil(page.MustEval(`() => window.raw`).Val())
+
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.PageAddScriptToEvaluateOnNewDocument{})
+		page.MustAddInitScript(`() => {}`, nil)
+	})
+	g.Panic(func() {
+		page.MustAddInitScript(`() => {}`, nil)
+		g.mc.stubErr(1, proto.PageRemoveScriptToEvaluateOnNewDocument{})
+		page.MustClearInitScripts()
+	})
+}
@@ -1,8 +1,12 @@
 package rod
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/go-rod/rod/lib/input"
 	"github.com/go-rod/rod/lib/proto"
@@ -20,13 +24,31 @@ type Keyboard struct {
 	// pressed keys must be released before it can be pressed again
 	// 必须释放以后，才能再次按下按键
 	pressed map[input.Key]struct{}
+
+	layout *input.Layout
 }
 
 func (p *Page) newKeyboard() *Page {
-	p.Keyboard = &Keyboard{page: p, pressed: map[input.Key]struct{}{}}
+	p.Keyboard = &Keyboard{page: p, pressed: map[input.Key]struct{}{}, layout: input.LayoutUS}
 	return p
 }
 
+// SetLayout sets the keyboard layout used to encode the keys, such as input.LayoutDE .
+// The default is input.LayoutUS .
+// SetLayout 设置用于编码按键的键盘布局，例如 input.LayoutDE 。
+// 默认是 input.LayoutUS 。
+func (k *Keyboard) SetLayout(layout *input.Layout) {
+	k.Lock()
+	defer k.Unlock()
+	k.layout = layout
+}
+
+func (k *Keyboard) getLayout() *input.Layout {
+	k.Lock()
+	defer k.Unlock()
+	return k.layout
+}
+
 func (k *Keyboard) getModifiers() int {
 	k.Lock()
 	defer k.Unlock()
@@ -47,7 +69,7 @@ func (k *Keyboard) modifiers() int {
 // 按下按键
 // 要输入键盘上没有的字符，如中文或日文，你应该使用类似Page.InsertText的方法。
 func (k *Keyboard) Press(key input.Key) error {
-	defer k.page.tryTrace(TraceTypeInput, "press key: "+key.Info().Code)()
+	defer k.page.tryTrace(TraceTypeInput, "press key: "+k.getLayout().Info(key).Code)()
 	k.page.browser.trySlowmotion()
 
 	k.Lock()
@@ -55,13 +77,13 @@ func (k *Keyboard) Press(key input.Key) error {
 
 	k.pressed[key] = struct{}{}
 
-	return key.Encode(proto.InputDispatchKeyEventTypeKeyDown, k.modifiers()).Call(k.page)
+	return k.layout.Encode(key, proto.InputDispatchKeyEventTypeKeyDown, k.modifiers()).Call(k.page)
 }
 
 // Release the key
 // 释放按键
 func (k *Keyboard) Release(key input.Key) error {
-	defer k.page.tryTrace(TraceTypeInput, "release key: "+key.Info().Code)()
+	defer k.page.tryTrace(TraceTypeInput, "release key: "+k.getLayout().Info(key).Code)()
 
 	k.Lock()
 	defer k.Unlock()
@@ -72,7 +94,7 @@ func (k *Keyboard) Release(key input.Key) error {
 
 	delete(k.pressed, key)
 
-	return key.Encode(proto.InputDispatchKeyEventTypeKeyUp, k.modifiers()).Call(k.page)
+	return k.layout.Encode(key, proto.InputDispatchKeyEventTypeKeyUp, k.modifiers()).Call(k.page)
 }
 
 // Type releases the key after the press
@@ -202,10 +224,51 @@ func (p *Page) InsertText(text string) error {
 	defer p.tryTrace(TraceTypeInput, "insert text "+text)()
 	p.browser.trySlowmotion()
 
-	err := proto.InputInsertText{Text: text}.Call(p)
+	err := proto.InputInsertText{Text: text}.Call(rodCall(p))
 	return err
 }
 
+// IMEComposition simulates the typing via an IME (input method editor), such as typing "nihao" to get "你好" with a pinyin IME.
+// Each of the compositions will be set as the candidate text in order, which emits compositionstart and compositionupdate,
+// then the commit text will be inserted to end the composition, which emits compositionend.
+// Unlike Page.InsertText, it works with the widgets that depend on composition events, such as autocomplete.
+// IMEComposition 模拟通过 IME（输入法）输入，例如用拼音输入法输入 "nihao" 得到 "你好"。
+// compositions 中的每一项会按顺序被设置为候选文本，它会触发 compositionstart 和 compositionupdate，
+// 然后插入 commit 文本来结束输入，它会触发 compositionend。
+// 与 Page.InsertText 不同，它适用于依赖输入法事件的组件，例如自动补全。
+func (p *Page) IMEComposition(compositions []string, commit string) error {
+	defer p.tryTrace(TraceTypeInput, "ime composition "+commit)()
+	p.browser.trySlowmotion()
+
+	for _, c := range compositions {
+		l := utf8.RuneCountInString(c)
+		err := proto.InputImeSetComposition{Text: c, SelectionStart: l, SelectionEnd: l}.Call(rodCall(p))
+		if err != nil {
+			return err
+		}
+	}
+
+	return proto.InputInsertText{Text: commit}.Call(rodCall(p))
+}
+
+// IMECancel cancels the current composition of the IME
+// IMECancel 取消 IME 当前的输入
+func (p *Page) IMECancel() error {
+	return proto.InputImeSetComposition{}.Call(rodCall(p))
+}
+
+// InsertTextIME is like Page.IMEComposition, it composes the text character by character before committing it,
+// useful when the composition steps are not important.
+// InsertTextIME 类似于 Page.IMEComposition，它在提交文本之前逐字符地组合文本，在不关心输入过程时很有用。
+func (p *Page) InsertTextIME(text string) error {
+	compositions := []string{}
+	runes := []rune(text)
+	for i := range runes {
+		compositions = append(compositions, string(runes[:i+1]))
+	}
+	return p.IMEComposition(compositions, text)
+}
+
 // Mouse represents the mouse on a page, it's always related the main frame
 // 代表一个在页面中的鼠标，总是依赖于主frame
 type Mouse struct {
@@ -280,12 +343,25 @@ func (m *Mouse) Move(x, y float64, steps int) error {
 // Scroll the relative offset with specified steps
 // 以指定的步骤滚动相对偏移量
 func (m *Mouse) Scroll(offsetX, offsetY float64, steps int) error {
-	m.Lock()
-	defer m.Unlock()
-
 	defer m.page.tryTrace(TraceTypeInput, fmt.Sprintf("scroll (%.2f, %.2f)", offsetX, offsetY))()
 	m.page.browser.trySlowmotion()
 
+	return m.wheel(offsetX, offsetY, steps, 0)
+}
+
+// WheelZoom zooms the page like holding the ctrl key while rolling the wheel, negative delta zooms in.
+// WheelZoom 像按住 ctrl 键滚动滚轮一样缩放页面，负数的 delta 表示放大。
+func (m *Mouse) WheelZoom(delta float64, steps int) error {
+	defer m.page.tryTrace(TraceTypeInput, fmt.Sprintf("wheel zoom %.2f", delta))()
+	m.page.browser.trySlowmotion()
+
+	return m.wheel(0, delta, steps, input.ModifierControl)
+}
+
+func (m *Mouse) wheel(offsetX, offsetY float64, steps int, modifiers int) error {
+	m.Lock()
+	defer m.Unlock()
+
 	if steps < 1 {
 		steps = 1
 	}
@@ -302,7 +378,7 @@ func (m *Mouse) Scroll(offsetX, offsetY float64, steps int) error {
 			Y:         m.y,
 			Button:    button,
 			Buttons:   gson.Int(buttons),
-			Modifiers: m.page.Keyboard.getModifiers(),
+			Modifiers: m.page.Keyboard.getModifiers() | modifiers,
 			DeltaX:    stepX,
 			DeltaY:    stepY,
 		}.Call(m.page)
@@ -314,6 +390,84 @@ func (m *Mouse) Scroll(offsetX, offsetY float64, steps int) error {
 	return nil
 }
 
+// ScrollGestureOptions for Mouse.ScrollGesture and Page.ScrollGesture
+// Mouse.ScrollGesture 和 Page.ScrollGesture 的选项
+type ScrollGestureOptions struct {
+	// Speed in pixels per second, default is 800
+	// 以像素每秒为单位的速度，默认是 800
+	Speed int
+
+	// Fling allows the momentum scrolling after the gesture
+	// Fling 允许手势结束后的惯性滚动
+	Fling bool
+
+	// RepeatCount is the number of times to repeat the gesture
+	// RepeatCount 是重复手势的次数
+	RepeatCount int
+
+	// RepeatDelay between each repeat, default is 250ms
+	// 每次重复之间的延迟，默认是 250ms
+	RepeatDelay time.Duration
+
+	// Source of the input events to generate, default is decided by the platform
+	// 要生成的输入事件的类型，默认由平台决定
+	Source proto.InputGestureSourceType
+}
+
+// ScrollGesture scrolls the relative offset from the current position of the mouse with a native scroll gesture,
+// unlike Mouse.Scroll it can trigger the momentum scrolling.
+// ScrollGesture 以原生滚动手势从鼠标当前位置滚动相对偏移量，与 Mouse.Scroll 不同，它可以触发惯性滚动。
+func (m *Mouse) ScrollGesture(offsetX, offsetY float64, opts *ScrollGestureOptions) error {
+	m.Lock()
+	x, y := m.x, m.y
+	m.Unlock()
+
+	return m.page.ScrollGesture(x, y, offsetX, offsetY, opts)
+}
+
+// ScrollGesture scrolls the relative offset from the point (x, y) with a native scroll gesture
+// ScrollGesture 以原生滚动手势从点 (x, y) 滚动相对偏移量
+func (p *Page) ScrollGesture(x, y, offsetX, offsetY float64, opts *ScrollGestureOptions) error {
+	defer p.tryTrace(TraceTypeInput, fmt.Sprintf("scroll gesture (%.2f, %.2f)", offsetX, offsetY))()
+	p.browser.trySlowmotion()
+
+	if opts == nil {
+		opts = &ScrollGestureOptions{}
+	}
+
+	req := scrollGesture{
+		InputSynthesizeScrollGesture: proto.InputSynthesizeScrollGesture{
+			X: x,
+			Y: y,
+			// the distance of the protocol is positive to scroll left and up
+			// 协议中的距离为正数时表示向左和向上滚动
+			XDistance:         gson.Num(-offsetX),
+			YDistance:         gson.Num(-offsetY),
+			RepeatCount:       opts.RepeatCount,
+			GestureSourceType: opts.Source,
+		},
+		PreventFling: !opts.Fling,
+	}
+	if opts.Speed > 0 {
+		req.Speed = gson.Int(opts.Speed)
+	}
+	if opts.RepeatDelay > 0 {
+		req.RepeatDelayMs = gson.Int(int(opts.RepeatDelay.Milliseconds()))
+	}
+
+	_, err := p.Call(context.WithValue(p.ctx, rodCallKey{}, true), string(p.SessionID), req.ProtoReq(), req)
+	return err
+}
+
+// scrollGesture overrides the PreventFling, because its default value is true,
+// it will be omitted by the proto when it's false.
+// scrollGesture 覆盖了 PreventFling，因为它的默认值是 true，当它为 false 时会被 proto 忽略。
+type scrollGesture struct {
+	proto.InputSynthesizeScrollGesture
+
+	PreventFling bool `json:"preventFling"`
+}
+
 // Down holds the button down
 // 向下按住按钮
 func (m *Mouse) Down(button proto.InputMouseButton, clicks int) error {
@@ -385,6 +539,301 @@ func (m *Mouse) Click(button proto.InputMouseButton) error {
 	return m.Up(button, 1)
 }
 
+// DragOptions for Mouse.Drag
+// Mouse.Drag 的选项
+type DragOptions struct {
+	// Button to hold during the drag, default is left
+	// 拖拽过程中按住的按钮，默认是左键
+	Button proto.InputMouseButton
+
+	// Steps of the movement between the start and end point, default is 1
+	// 从起点到终点的移动步数，默认是 1
+	Steps int
+
+	// Curve maps the linear progress of each step (0 to 1) to the progress along the path,
+	// such as an easing function. Default is linear.
+	// Curve 把每一步的线性进度（0 到 1）映射为路径上的进度，例如一个缓动函数。默认是线性的。
+	Curve func(progress float64) float64
+}
+
+// Drag presses the button at (fromX, fromY), moves to (toX, toY) and releases it.
+// If the element under the start point is draggable, the native drag events (dragenter, dragover, drop)
+// will be emitted on the way too, which can't be triggered by the mouse events alone.
+// Drag 在 (fromX, fromY) 按下按钮，移动到 (toX, toY) 然后释放。
+// 如果起点下的元素是可拖拽的，沿途还会触发原生的拖拽事件（dragenter、dragover、drop），仅靠鼠标事件无法触发它们。
+func (m *Mouse) Drag(fromX, fromY, toX, toY float64, opts *DragOptions) error {
+	defer m.page.tryTrace(TraceTypeInput, fmt.Sprintf("drag (%.2f, %.2f) to (%.2f, %.2f)", fromX, fromY, toX, toY))()
+
+	if opts == nil {
+		opts = &DragOptions{}
+	}
+
+	button := opts.Button
+	if button == "" {
+		button = proto.InputMouseButtonLeft
+	}
+
+	steps := opts.Steps
+	if steps < 1 {
+		steps = 1
+	}
+
+	curve := opts.Curve
+	if curve == nil {
+		curve = func(progress float64) float64 { return progress }
+	}
+
+	err := proto.InputSetInterceptDrags{Enabled: true}.Call(rodCall(m.page))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = proto.InputSetInterceptDrags{Enabled: false}.Call(rodCall(m.page)) }()
+
+	p, cancel := m.page.WithCancel()
+	defer cancel()
+
+	intercepted := make(chan *proto.InputDragData, 1)
+	go p.EachEvent(func(e *proto.InputDragIntercepted) bool {
+		intercepted <- e.Data
+		return true
+	})()
+
+	err = m.Move(fromX, fromY, 1)
+	if err != nil {
+		return err
+	}
+
+	err = m.Down(button, 1)
+	if err != nil {
+		return err
+	}
+
+	var data *proto.InputDragData
+
+	for i := 1; i <= steps; i++ {
+		progress := curve(float64(i) / float64(steps))
+		x := fromX + (toX-fromX)*progress
+		y := fromY + (toY-fromY)*progress
+
+		err = m.Move(x, y, 1)
+		if err != nil {
+			return err
+		}
+
+		if data == nil {
+			select {
+			case data = <-intercepted:
+				err = m.dispatchDrag(proto.InputDispatchDragEventTypeDragEnter, x, y, data)
+				if err != nil {
+					return err
+				}
+			default:
+				continue
+			}
+		}
+
+		err = m.dispatchDrag(proto.InputDispatchDragEventTypeDragOver, x, y, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	if data != nil {
+		err = m.dispatchDrag(proto.InputDispatchDragEventTypeDrop, toX, toY, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return m.Up(button, 1)
+}
+
+func (m *Mouse) dispatchDrag(t proto.InputDispatchDragEventType, x, y float64, data *proto.InputDragData) error {
+	return proto.InputDispatchDragEvent{
+		Type:      t,
+		X:         x,
+		Y:         y,
+		Data:      data,
+		Modifiers: m.page.Keyboard.getModifiers(),
+	}.Call(rodCall(m.page))
+}
+
+// Pen represents a pen (stylus) on a page, it's always related the main frame.
+// It dispatches pointer events with pointerType "pen", useful to test the drawing or signature canvases.
+// Pen 代表一个页面上的笔（触控笔），它总是与主frame相关。
+// 它触发 pointerType 为 "pen" 的指针事件，对于测试绘图或签名画布很有用。
+type Pen struct {
+	sync.Mutex
+
+	page *Page
+
+	x float64
+	y float64
+
+	down bool
+}
+
+func (p *Page) newPen() *Page {
+	p.Pen = &Pen{page: p}
+	return p
+}
+
+// PenState of the pen when the event is dispatched
+// 触发事件时笔的状态
+type PenState struct {
+	// Pressure in the range of [0,1]
+	// 压力，范围是 [0,1]
+	Pressure float64
+
+	// TangentialPressure in the range of [-1,1]
+	// 切向压力，范围是 [-1,1]
+	TangentialPressure float64
+
+	// TiltX in degrees of the range [-90,90], a positive TiltX is to the right
+	// TiltX 的角度范围是 [-90,90]，正数表示向右倾斜
+	TiltX int
+
+	// TiltY in degrees of the range [-90,90], a positive TiltY is towards the user
+	// TiltY 的角度范围是 [-90,90]，正数表示朝向用户倾斜
+	TiltY int
+
+	// Twist is the clockwise rotation around the pen's own axis, in degrees of the range [0,359]
+	// Twist 是围绕笔自身轴线的顺时针旋转，角度范围是 [0,359]
+	Twist int
+}
+
+// the state used when the pen is down and no state is specified
+// 当笔按下且没有指定状态时使用的状态
+var defaultPenState = &PenState{Pressure: 0.5}
+
+// Move the pen to the absolute position, if the pen is down it draws a line
+// 将笔移动到绝对位置，如果笔已按下，则会画出一条线
+func (pen *Pen) Move(x, y float64, state *PenState) error {
+	pen.Lock()
+	defer pen.Unlock()
+
+	pen.page.browser.trySlowmotion()
+
+	err := pen.dispatch(proto.InputDispatchMouseEventTypeMouseMoved, x, y, state)
+	if err != nil {
+		return err
+	}
+
+	pen.x = x
+	pen.y = y
+	return nil
+}
+
+// Down puts the pen onto the surface at the current position
+// Down 在当前位置将笔放到平面上
+func (pen *Pen) Down(state *PenState) error {
+	pen.Lock()
+	defer pen.Unlock()
+
+	pen.down = true
+
+	err := pen.dispatch(proto.InputDispatchMouseEventTypeMousePressed, pen.x, pen.y, state)
+	if err != nil {
+		pen.down = false
+	}
+	return err
+}
+
+// Up lifts the pen from the surface
+// Up 将笔从平面上抬起
+func (pen *Pen) Up() error {
+	pen.Lock()
+	defer pen.Unlock()
+
+	pen.down = false
+
+	err := pen.dispatch(proto.InputDispatchMouseEventTypeMouseReleased, pen.x, pen.y, &PenState{})
+	if err != nil {
+		pen.down = true
+	}
+	return err
+}
+
+// Stroke puts the pen down at the first point, draws through the rest of the points, then lifts it.
+// The states are applied to the points in order, the last state is used for the rest of the points, it can be empty.
+// Stroke 在第一个点放下笔，经过其余的点画线，然后抬起笔。
+// states 按顺序应用于各个点，最后一个状态用于剩余的点，它可以为空。
+func (pen *Pen) Stroke(points []proto.Point, states ...*PenState) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	defer pen.page.tryTrace(TraceTypeInput, "pen stroke")()
+
+	state := func(i int) *PenState {
+		if len(states) == 0 {
+			return nil
+		}
+		if i < len(states) {
+			return states[i]
+		}
+		return states[len(states)-1]
+	}
+
+	err := pen.Move(points[0].X, points[0].Y, nil)
+	if err != nil {
+		return err
+	}
+
+	err = pen.Down(state(0))
+	if err != nil {
+		return err
+	}
+
+	for i, pt := range points[1:] {
+		err = pen.Move(pt.X, pt.Y, state(i+1))
+		if err != nil {
+			return err
+		}
+	}
+
+	return pen.Up()
+}
+
+func (pen *Pen) dispatch(t proto.InputDispatchMouseEventType, x, y float64, state *PenState) error {
+	button := proto.InputMouseButtonNone
+	buttons := 0
+	if pen.down {
+		button = proto.InputMouseButtonLeft
+		buttons = 1
+		if state == nil {
+			state = defaultPenState
+		}
+	}
+	if t == proto.InputDispatchMouseEventTypeMouseReleased {
+		button = proto.InputMouseButtonLeft
+	}
+	if state == nil {
+		state = &PenState{}
+	}
+
+	clicks := 0
+	if t != proto.InputDispatchMouseEventTypeMouseMoved {
+		clicks = 1
+	}
+
+	return proto.InputDispatchMouseEvent{
+		Type:               t,
+		X:                  x,
+		Y:                  y,
+		Button:             button,
+		Buttons:            gson.Int(buttons),
+		ClickCount:         clicks,
+		Modifiers:          pen.page.Keyboard.getModifiers(),
+		Force:              state.Pressure,
+		TangentialPressure: state.TangentialPressure,
+		TiltX:              state.TiltX,
+		TiltY:              state.TiltY,
+		Twist:              state.Twist,
+		PointerType:        proto.InputDispatchMouseEventPointerTypePen,
+	}.Call(pen.page)
+}
+
 // Touch presents a touch device, such as a hand with fingers, each finger is a proto.InputTouchPoint.
 // Touch events is stateless, we use the struct here only as a namespace to make the API style unified.
 // Touch 代表一个触摸设备，例如带手指的手，每个手指都是一个原型输入点。
@@ -458,3 +907,90 @@ func (t *Touch) Tap(x, y float64) error {
 
 	return t.End()
 }
+
+// the interval between two touch moves of a gesture, about 60 fps
+// 手势中两次触摸移动的间隔，大约 60 fps
+const touchFrame = 16 * time.Millisecond
+
+// the distance from each finger to the center when pinch or rotate starts
+// 捏合或旋转开始时每个手指到中心的距离
+const touchGestureRadius = 50.0
+
+// Swipe a finger from one point to another in the duration
+// 在 duration 时间内将一个手指从一个点滑动到另一个点
+func (t *Touch) Swipe(from, to proto.Point, duration time.Duration) error {
+	defer t.page.tryTrace(TraceTypeInput, "swipe")()
+	t.page.browser.trySlowmotion()
+
+	return t.gesture(duration, func(progress float64) []*proto.InputTouchPoint {
+		return []*proto.InputTouchPoint{{
+			X:  from.X + (to.X-from.X)*progress,
+			Y:  from.Y + (to.Y-from.Y)*progress,
+			ID: gson.Num(0),
+		}}
+	})
+}
+
+// Pinch two fingers around the center in the duration, scale greater than 1 zooms in, less than 1 zooms out.
+// Pinch 在 duration 时间内以 center 为中心捏合两个手指，scale 大于 1 放大，小于 1 缩小。
+func (t *Touch) Pinch(center proto.Point, scale float64, duration time.Duration) error {
+	defer t.page.tryTrace(TraceTypeInput, fmt.Sprintf("pinch %.2f", scale))()
+	t.page.browser.trySlowmotion()
+
+	return t.gesture(duration, func(progress float64) []*proto.InputTouchPoint {
+		r := touchGestureRadius * (1 + (scale-1)*progress)
+		return twoFingers(center, r, 0)
+	})
+}
+
+// Rotate two fingers around the center by the angle in degrees in the duration, positive angle rotates clockwise.
+// Rotate 在 duration 时间内以 center 为中心将两个手指旋转 angle 度，正数为顺时针旋转。
+func (t *Touch) Rotate(center proto.Point, angle float64, duration time.Duration) error {
+	defer t.page.tryTrace(TraceTypeInput, fmt.Sprintf("rotate %.2f", angle))()
+	t.page.browser.trySlowmotion()
+
+	return t.gesture(duration, func(progress float64) []*proto.InputTouchPoint {
+		return twoFingers(center, touchGestureRadius, angle*progress*math.Pi/180)
+	})
+}
+
+// gesture starts the touch with the points at progress 0, then moves them frame by frame till progress 1 and ends the touch.
+// gesture 以进度 0 时的触摸点开始触摸，然后逐帧移动它们直到进度 1，最后结束触摸。
+func (t *Touch) gesture(duration time.Duration, points func(progress float64) []*proto.InputTouchPoint) error {
+	err := t.Start(points(0)...)
+	if err != nil {
+		return err
+	}
+
+	frames := int(duration / touchFrame)
+	if frames < 1 {
+		frames = 1
+	}
+	interval := duration / time.Duration(frames)
+	sleeper := utils.BackoffSleeper(interval, interval, nil)
+
+	for i := 1; i <= frames; i++ {
+		err = sleeper(t.page.ctx)
+		if err != nil {
+			return err
+		}
+
+		err = t.Move(points(float64(i) / float64(frames))...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return t.End()
+}
+
+// twoFingers returns two points on opposite sides of the center with the distance r, rotated by the radian
+// twoFingers 返回中心两侧距离为 r 的两个点，并旋转 radian 弧度
+func twoFingers(center proto.Point, r, radian float64) []*proto.InputTouchPoint {
+	dx := r * math.Cos(radian)
+	dy := r * math.Sin(radian)
+	return []*proto.InputTouchPoint{
+		{X: center.X - dx, Y: center.Y - dy, ID: gson.Num(0)},
+		{X: center.X + dx, Y: center.Y + dy, ID: gson.Num(1)},
+	}
+}
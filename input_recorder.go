@@ -0,0 +1,137 @@
+package rod
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// InputScript is a serializable list of input actions recorded by InputRecorder
+// InputScript 是由 InputRecorder 录制的可序列化的输入操作列表
+type InputScript struct {
+	Actions []*InputAction `json:"actions"`
+}
+
+// InputAction is a CDP Input domain call, such as Input.dispatchMouseEvent
+// InputAction 是一次 CDP Input 域的调用，例如 Input.dispatchMouseEvent
+type InputAction struct {
+	// Time since the recording started
+	// 从开始录制到执行该操作的时间
+	Time time.Duration `json:"time"`
+
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// InputRecorder records all the Mouse, Keyboard, Touch and Pen actions performed through rod on a page
+// InputRecorder 录制通过 rod 在页面上执行的所有 Mouse、Keyboard、Touch 和 Pen 操作
+type InputRecorder struct {
+	lock sync.Mutex
+
+	page  *Page
+	start time.Time
+
+	script *InputScript
+}
+
+type inputRecording struct {
+	sync.Mutex
+
+	recorder *InputRecorder
+}
+
+// RecordInput starts to record the input actions of the page, it will replace the previous recorder.
+// Use InputRecorder.Stop to get the script, then use Page.ReplayInput to replay it on another page.
+// RecordInput 开始录制页面的输入操作，它会替换之前的录制器。
+// 使用 InputRecorder.Stop 获取脚本，然后使用 Page.ReplayInput 在另一个页面上重放它。
+func (p *Page) RecordInput() *InputRecorder {
+	r := &InputRecorder{page: p, start: time.Now(), script: &InputScript{Actions: []*InputAction{}}}
+
+	p.inputRecording.Lock()
+	defer p.inputRecording.Unlock()
+	p.inputRecording.recorder = r
+
+	return r
+}
+
+// Stop the recording and return the recorded script
+// 停止录制并返回录制的脚本
+func (r *InputRecorder) Stop() *InputScript {
+	rec := r.page.inputRecording
+	rec.Lock()
+	if rec.recorder == r {
+		rec.recorder = nil
+	}
+	rec.Unlock()
+
+	return r.Script()
+}
+
+// Script returns a copy of the actions recorded so far
+// Script 返回到目前为止录制的操作的副本
+func (r *InputRecorder) Script() *InputScript {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return &InputScript{Actions: append([]*InputAction{}, r.script.Actions...)}
+}
+
+func (r *InputRecorder) record(method string, params interface{}) {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.script.Actions = append(r.script.Actions, &InputAction{
+		Time:   time.Since(r.start),
+		Method: method,
+		Params: b,
+	})
+}
+
+func (p *Page) recordInput(method string, params interface{}) {
+	if !strings.HasPrefix(method, "Input.") {
+		return
+	}
+
+	p.inputRecording.Lock()
+	r := p.inputRecording.recorder
+	p.inputRecording.Unlock()
+
+	if r != nil {
+		r.record(method, params)
+	}
+}
+
+// ReplayInput replays the script on the page. The speed is relative to the recording,
+// such as 2 replays twice as fast, if it's not greater than 0 the actions will be replayed without waiting.
+// ReplayInput 在页面上重放脚本。speed 是相对于录制时的速度，
+// 例如 2 表示以两倍的速度重放，如果它不大于 0，操作将不等待地重放。
+func (p *Page) ReplayInput(script *InputScript, speed float64) error {
+	defer p.tryTrace(TraceTypeInput, "replay input")()
+
+	start := time.Now()
+
+	for _, a := range script.Actions {
+		if speed > 0 {
+			wait := time.Duration(float64(a.Time)/speed) - time.Since(start)
+			err := utils.BackoffSleeper(wait, wait, nil)(p.ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := p.Call(p.ctx, string(p.SessionID), a.Method, a.Params)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package rod_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/input"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestInputRecorder(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.srcFile("fixtures/input.html"))
+	el := p.MustElement("[type=text]")
+	el.MustFocus()
+
+	r := p.RecordInput()
+	p.Keyboard.MustType(input.KeyA, input.KeyB)
+	p.Mouse.MustMove(10, 10)
+	p.MustInsertText("c")
+	script := r.Stop()
+
+	p.Keyboard.MustType(input.KeyD)
+	g.Len(script.Actions, 6)
+	g.Eq(script.Actions[0].Method, "Input.dispatchKeyEvent")
+	g.Eq(script.Actions[4].Method, "Input.dispatchMouseEvent")
+	g.Eq(script.Actions[5].Method, "Input.insertText")
+	g.Len(r.Script().Actions, 6)
+
+	b, err := json.Marshal(script)
+	g.E(err)
+
+	var loaded rod.InputScript
+	g.E(json.Unmarshal(b, &loaded))
+
+	other := g.newPage(g.srcFile("fixtures/input.html"))
+	other.MustElement("[type=text]").MustFocus()
+	other.MustReplayInput(&loaded)
+	g.Eq(other.MustElement("[type=text]").MustText(), "abc")
+
+	loaded.Actions[2].Time = time.Hour
+	g.Err(other.Timeout(100*time.Millisecond).ReplayInput(&loaded, 1))
+
+	g.mc.stubErr(1, proto.InputDispatchKeyEvent{})
+	g.Err(other.ReplayInput(&loaded, 0))
+
+	r = p.RecordInput()
+	p.RecordInput().Stop()
+	g.Len(r.Stop().Actions, 0)
+}
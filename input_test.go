@@ -2,7 +2,9 @@ package rod_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/devices"
 	"github.com/go-rod/rod/lib/input"
 	"github.com/go-rod/rod/lib/proto"
@@ -53,6 +55,25 @@ func TestKeyType(t *testing.T) {
 	g.Eq("1 A b test", el.MustText())
 }
 
+func TestKeyboardLayout(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.srcFile("fixtures/input.html"))
+	p.MustEval(`() => {
+		window.codeTrack = []
+		document.addEventListener('keydown', (e) => codeTrack.push(e.code))
+	}`)
+	el := p.MustElement("[type=text]")
+
+	p.Keyboard.SetLayout(input.LayoutDE)
+	defer p.Keyboard.SetLayout(input.LayoutUS)
+
+	el.MustType('z', 'ä', 'ß', '@')
+
+	g.Eq(el.MustText(), "zäß@")
+	g.Eq(p.MustEval(`() => codeTrack.join(',')`).Str(), "KeyY,Quote,Minus,KeyQ")
+}
+
 func TestKeyTypeErr(t *testing.T) {
 	g := setup(t)
 
@@ -112,6 +133,41 @@ func TestInput(t *testing.T) {
 	})
 }
 
+func TestInputIME(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.srcFile("fixtures/input.html"))
+	p.MustEval(`() => {
+		window.imeTrack = []
+		let ta = document.querySelector('textarea')
+		for (let t of ['compositionstart', 'compositionupdate', 'compositionend']) {
+			ta.addEventListener(t, (e) => imeTrack.push(t + ' ' + e.data))
+		}
+	}`)
+
+	el := p.MustElement("textarea")
+	el.MustFocus()
+	p.MustIMEComposition([]string{"ni", "nihao"}, "你好")
+	g.Eq(el.MustText(), "你好")
+	g.Eq(p.MustEval(`() => imeTrack.join(',')`).Str(),
+		"compositionstart ,compositionupdate ni,compositionupdate nihao,compositionend 你好")
+
+	el.MustSelectAllText().MustInputIME("雲")
+	g.Eq(el.MustText(), "雲")
+
+	p.MustIMECancel()
+	g.Eq(el.MustText(), "雲")
+
+	g.mc.stubErr(1, proto.InputImeSetComposition{})
+	g.Err(p.InsertTextIME("a"))
+
+	g.mc.stubErr(1, proto.InputInsertText{})
+	g.Err(el.InputIME("a"))
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(el.InputIME("a"))
+}
+
 func TestMouse(t *testing.T) {
 	g := setup(t)
 
@@ -184,6 +240,41 @@ func TestMouseDrag(t *testing.T) {
 	g.Eq(page.MustEval(`() => dragTrack`).Str(), " move 3 3 down 3 3 move 22 28 move 41 54 move 60 80 up 60 80")
 }
 
+func TestMouseDragHelper(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage().MustNavigate(g.srcFile("fixtures/drag.html")).MustWaitLoad()
+	mouse := page.Mouse
+
+	g.E(mouse.Drag(3, 3, 60, 80, &rod.DragOptions{Steps: 3}))
+
+	utils.Sleep(0.3)
+	g.Eq(page.MustEval(`() => dragTrack`).Str(), " move 3 3 down 3 3 move 22 28 move 41 54 move 60 80 up 60 80")
+
+	pt := page.MustElement("#draggable").MustShape().OnePointInside()
+	to := page.MustElement(".dropzone:nth-child(2)").MustShape().OnePointInside()
+
+	g.E(mouse.Drag(pt.X, pt.Y, to.X, to.Y, &rod.DragOptions{
+		Steps: 5,
+		Curve: func(p float64) float64 { return p * p },
+	}))
+	page.MustElement(".dropzone:nth-child(2) #draggable")
+
+	g.mc.stubErr(1, proto.InputSetInterceptDrags{})
+	g.Err(mouse.Drag(0, 0, 1, 1, nil))
+
+	g.mc.stubErr(1, proto.InputDispatchMouseEvent{})
+	g.Err(mouse.Drag(0, 0, 1, 1, nil))
+
+	g.mc.stubErr(2, proto.InputDispatchMouseEvent{})
+	g.Err(mouse.Drag(0, 0, 1, 1, nil))
+
+	g.mc.stubErr(3, proto.InputDispatchMouseEvent{})
+	g.Err(mouse.Drag(0, 0, 1, 1, nil))
+
+	mouse.MustDrag(0, 0, 1, 1)
+}
+
 func TestMouseScroll(t *testing.T) {
 	g := setup(t)
 
@@ -199,6 +290,41 @@ func TestMouseScroll(t *testing.T) {
 	p.MustWait(`() => pageXOffset > 200 && pageYOffset > 300`)
 }
 
+func TestMouseScrollGesture(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.srcFile("fixtures/scroll.html")).MustWaitLoad()
+
+	p.Mouse.MustMove(30, 30)
+	p.Mouse.MustScrollGesture(0, 100)
+	p.MustWait(`() => pageYOffset >= 100`)
+
+	g.E(p.ScrollGesture(30, 30, 100, 0, &rod.ScrollGestureOptions{
+		Speed:       2000,
+		Fling:       true,
+		RepeatCount: 1,
+		RepeatDelay: 10 * time.Millisecond,
+		Source:      proto.InputGestureSourceTypeMouse,
+	}))
+	p.MustWait(`() => pageXOffset >= 200`)
+
+	p.MustScrollGesture(30, 30, 0, -100)
+
+	p.MustEval(`() => {
+		window.zoomTrack = []
+		window.addEventListener('wheel', (e) => { zoomTrack.push(e.ctrlKey + ' ' + e.deltaY); e.preventDefault() }, { passive: false })
+	}`)
+	p.Mouse.MustWheelZoom(-100)
+	g.E(p.Mouse.WheelZoom(100, 2))
+	p.MustWait(`() => zoomTrack.join(',') === 'true -100,true 50,true 50'`)
+
+	g.mc.stubErr(1, proto.InputSynthesizeScrollGesture{})
+	g.Err(p.Mouse.ScrollGesture(0, 10, nil))
+
+	g.mc.stubErr(1, proto.InputDispatchMouseEvent{})
+	g.Err(p.Mouse.WheelZoom(1, 1))
+}
+
 func TestMouseMoveErr(t *testing.T) {
 	g := setup(t)
 
@@ -229,6 +355,44 @@ func TestNativeDrag(t *testing.T) { // devtools doesn't support to use mouse eve
 	page.MustElement(".dropzone:nth-child(2) #draggable")
 }
 
+func TestPen(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.blank())
+	page.MustEval(`() => {
+		window.penTrack = []
+		for (let t of ['pointerdown', 'pointermove', 'pointerup']) {
+			document.addEventListener(t, (e) => penTrack.push([t, e.pointerType, e.clientX, e.clientY, e.pressure, e.tiltX]))
+		}
+	}`)
+
+	pen := page.Pen
+
+	pen.MustStroke([]proto.Point{{X: 10, Y: 10}, {X: 20, Y: 30}, {X: 40, Y: 50}},
+		&rod.PenState{Pressure: 0.2}, &rod.PenState{Pressure: 0.8, TiltX: 30})
+
+	track := page.MustEval(`() => penTrack.map(t => t.join(' ')).join(',')`).Str()
+	g.Has(track, "pointerdown pen 10 10 0.2 0")
+	g.Has(track, "pointermove pen 20 30 0.8 30")
+	g.Has(track, "pointermove pen 40 50 0.8 30")
+	g.Has(track, "pointerup pen 40 50 0 0")
+
+	pen.MustMove(1, 1).MustDown().MustUp()
+	g.E(pen.Stroke(nil))
+
+	g.mc.stubErr(1, proto.InputDispatchMouseEvent{})
+	g.Err(pen.Stroke([]proto.Point{{}}))
+
+	g.mc.stubErr(2, proto.InputDispatchMouseEvent{})
+	g.Err(pen.Stroke([]proto.Point{{}}))
+
+	g.mc.stubErr(3, proto.InputDispatchMouseEvent{})
+	g.Err(pen.Stroke([]proto.Point{{}, {}}))
+
+	g.mc.stubErr(1, proto.InputDispatchMouseEvent{})
+	g.Err(pen.Up())
+}
+
 func TestTouch(t *testing.T) {
 	g := setup(t)
 
@@ -256,3 +420,43 @@ func TestTouch(t *testing.T) {
 		touch.MustTap(1, 2)
 	})
 }
+
+func TestTouchGestures(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage().MustEmulate(devices.IPad)
+
+	wait := page.WaitNavigation(proto.PageLifecycleEventNameLoad)
+	page.MustNavigate(g.srcFile("fixtures/touch.html"))
+	wait()
+
+	touch := page.Touch
+
+	touch.MustSwipe(proto.Point{X: 10, Y: 20}, proto.Point{X: 50, Y: 60})
+	page.MustWait(`() => touchTrack.startsWith(' start 10 20 move') && touchTrack.endsWith(' move 50 60 end')`)
+
+	page.MustEval(`() => {
+		window.gestureTrack = []
+		document.body.addEventListener('touchmove', (e) => {
+			let [a, b] = e.touches
+			gestureTrack.push([Math.round(Math.hypot(b.clientX - a.clientX, b.clientY - a.clientY)), Math.round(b.clientY - a.clientY)])
+		})
+	}`)
+
+	touch.MustPinch(proto.Point{X: 100, Y: 100}, 0.5)
+	g.Eq(page.MustEval(`() => gestureTrack.pop()[0]`).Int(), 50)
+
+	g.E(touch.Rotate(proto.Point{X: 100, Y: 100}, 90, 0))
+	g.Eq(page.MustEval(`() => gestureTrack.pop()`).Arr()[1].Int(), 100)
+
+	touch.MustRotate(proto.Point{X: 100, Y: 100}, 45)
+
+	g.mc.stubErr(1, proto.InputDispatchTouchEvent{})
+	g.Err(touch.Swipe(proto.Point{}, proto.Point{}, 0))
+
+	g.mc.stubErr(2, proto.InputDispatchTouchEvent{})
+	g.Err(touch.Swipe(proto.Point{}, proto.Point{}, 0))
+
+	g.mc.stubErr(3, proto.InputDispatchTouchEvent{})
+	g.Err(touch.Swipe(proto.Point{}, proto.Point{}, 0))
+}
@@ -0,0 +1,103 @@
+package rod
+
+import (
+	"context"
+	"time"
+)
+
+// Tracer creates the spans for the operations of rod, such as Page.Navigate, Page.Element, Element.Click,
+// Page.Evaluate and the cdp calls. The interface has no dependency, it can be easily adapted to OpenTelemetry,
+// set it via Browser.Tracer.
+// Tracer 为 rod 的操作创建 span，例如 Page.Navigate、Page.Element、Element.Click、Page.Evaluate 以及 cdp 调用。
+// 该接口没有任何依赖，可以很容易地适配到 OpenTelemetry，通过 Browser.Tracer 设置它。
+type Tracer interface {
+	// Start a span named name. The returned context must be derived from ctx, the sub-operations will use it,
+	// so their spans will be the children of the span.
+	// Start 开始一个名为 name 的 span。返回的 context 必须派生自 ctx，子操作会使用它，所以子操作的 span 会成为该 span 的子级。
+	Start(ctx context.Context, name string, attrs map[string]interface{}) (context.Context, Span)
+}
+
+// Span of an operation
+// 一个操作的 span
+type Span interface {
+	// SetAttributes adds the attributes to the span
+	// SetAttributes 为 span 添加属性
+	SetAttributes(attrs map[string]interface{})
+
+	// End the span, err is the error of the operation, it's nil if the operation succeeded
+	// End 结束 span，err 是操作的错误，如果操作成功则为 nil
+	End(err error)
+}
+
+// Tracer sets the tracer for the operations of the browser and its pages, nil to disable it
+// Tracer 为浏览器及其页面的操作设置 tracer，设置为 nil 则禁用
+func (b *Browser) Tracer(t Tracer) *Browser {
+	b.tracer = t
+	return b
+}
+
+// start a span for ctx, attrs is only called when the tracer is set, so it costs nothing when tracing is disabled.
+// The end function will add the "duration_ms" attribute before it ends the span.
+// 为 ctx 开始一个 span，attrs 只有在设置了 tracer 时才会被调用，所以禁用追踪时没有任何开销。
+// end 函数在结束 span 之前会添加 "duration_ms" 属性。
+func (b *Browser) startSpan(
+	ctx context.Context, name string, attrs func() map[string]interface{},
+) (context.Context, func(err error)) {
+	if b.tracer == nil {
+		return ctx, func(error) {}
+	}
+
+	ctx, span := b.tracer.Start(ctx, name, attrs())
+	start := time.Now()
+
+	return ctx, func(err error) {
+		span.SetAttributes(map[string]interface{}{
+			"duration_ms": float64(time.Since(start)) / float64(time.Millisecond),
+		})
+		span.End(err)
+	}
+}
+
+func (p *Page) startSpan(name string, attrs func() map[string]interface{}) (*Page, func(err error)) {
+	if p.browser.tracer == nil {
+		return p, func(error) {}
+	}
+
+	ctx, end := p.browser.startSpan(p.ctx, name, func() map[string]interface{} {
+		m := attrs()
+		m["session_id"] = string(p.SessionID)
+		return m
+	})
+	return p.Context(ctx), end
+}
+
+func (el *Element) startSpan(name string, attrs func() map[string]interface{}) (*Element, func(err error)) {
+	if el.page.browser.tracer == nil {
+		return el, func(error) {}
+	}
+
+	ctx, end := el.page.browser.startSpan(el.ctx, name, func() map[string]interface{} {
+		m := attrs()
+		m["element"] = el.String()
+		m["session_id"] = string(el.page.SessionID)
+		return m
+	})
+	return el.Context(ctx), end
+}
+
+// detach the element from the ctx of the span that created it, so the later operations of the element won't use the
+// ended span as their parent
+// 将元素与创建它的 span 的 ctx 分离，这样元素之后的操作就不会把已经结束的 span 当作父级
+func (el *Element) detachSpan(ctx context.Context) *Element {
+	if el.ctx == ctx {
+		return el
+	}
+
+	page := *el.page
+	page.ctx = ctx
+
+	clone := *el
+	clone.ctx = ctx
+	clone.page = &page
+	return &clone
+}
@@ -0,0 +1,99 @@
+package rod_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+type spanKey struct{}
+
+type fakeSpan struct {
+	name   string
+	parent *fakeSpan
+	attrs  map[string]interface{}
+	err    error
+	ended  bool
+}
+
+func (s *fakeSpan) SetAttributes(attrs map[string]interface{}) {
+	for k, v := range attrs {
+		s.attrs[k] = v
+	}
+}
+
+func (s *fakeSpan) End(err error) {
+	s.err = err
+	s.ended = true
+}
+
+type fakeTracer struct {
+	lock  sync.Mutex
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string, attrs map[string]interface{}) (context.Context, rod.Span) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	s := &fakeSpan{name: name, attrs: attrs}
+	s.parent, _ = ctx.Value(spanKey{}).(*fakeSpan)
+	t.spans = append(t.spans, s)
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// the last span named name
+func (t *fakeTracer) find(name string) *fakeSpan {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for i := len(t.spans) - 1; i >= 0; i-- {
+		if t.spans[i].name == name {
+			return t.spans[i]
+		}
+	}
+	return nil
+}
+
+func TestTracer(t *testing.T) {
+	g := setup(t)
+
+	tracer := &fakeTracer{}
+	b := g.browser.Context(g.Context()).Tracer(tracer)
+
+	u := g.srcFile("fixtures/click.html")
+	p := b.MustPage()
+	defer p.MustClose()
+
+	p.MustNavigate(u).MustWaitLoad()
+	nav := tracer.find("Navigate")
+	g.Eq(nav.attrs["url"], u)
+	g.True(nav.ended)
+	g.Nil(nav.err)
+	g.Gte(nav.attrs["duration_ms"].(float64), 0.0)
+
+	cdp := tracer.find("cdp Page.navigate")
+	g.Eq(cdp.parent, nav)
+	g.Eq(cdp.attrs["method"], "Page.navigate")
+	g.Eq(cdp.attrs["session_id"], string(p.SessionID))
+
+	p.MustElement("button").MustClick()
+	el := tracer.find("Element")
+	g.Eq(el.attrs["selector"], "button")
+	click := tracer.find("Click")
+	g.Eq(click.attrs["button"], "left")
+	g.Has(click.attrs["element"].(string), "button")
+	// the element isn't bound to the ended span of the query
+	g.Nil(click.parent)
+
+	p.MustEval(`() => 1`)
+	eval := tracer.find("Eval")
+	g.Has(eval.attrs["js"].(string), "() => 1")
+
+	g.mc.stubErr(1, proto.PageNavigate{})
+	g.Err(p.Navigate(u))
+	g.Err(tracer.find("Navigate").err)
+}
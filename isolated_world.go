@@ -0,0 +1,86 @@
+package rod
+
+import (
+	"sync"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+type isolatedWorld struct {
+	name  string
+	ctxID proto.RuntimeExecutionContextID
+}
+
+// Isolated returns a clone of the page that runs the js helpers of rod and the evals in an isolated world named
+// worldName, it shares the DOM with the page but not the js variables. So the js of the page can't tamper with or
+// detect the injected functions, and the overridden globals of the page such as document.querySelector won't
+// affect rod. The elements found by the clone also use the isolated world. The world will be recreated
+// automatically after navigation.
+// Isolated 返回页面的一个克隆，它在名为 worldName 的隔离环境中运行 rod 的 js 辅助函数和 eval，
+// 它与页面共享 DOM，但不共享 js 变量。这样页面的 js 就无法篡改或检测到注入的函数，页面覆盖的全局变量，
+// 例如 document.querySelector 也不会影响 rod。通过克隆找到的元素也会使用该隔离环境。导航后隔离环境会被自动重新创建。
+func (p *Page) Isolated(worldName string) *Page {
+	clone := *p
+	clone.world = &isolatedWorld{name: worldName}
+	clone.jsCtxLock = &sync.Mutex{}
+	clone.jsCtxID = new(proto.RuntimeRemoteObjectID)
+	clone.helpersLock = &sync.Mutex{}
+	clone.helpers = nil
+	return &clone
+}
+
+// IsIsolated tells if the page runs the js in an isolated world
+// IsIsolated 用于判断页面是否在隔离环境中运行 js
+func (p *Page) IsIsolated() bool {
+	return p.world != nil
+}
+
+// create the isolated world for the frame of the page, the jsCtxLock must be held
+// 为页面的 frame 创建隔离环境，调用时必须持有 jsCtxLock
+func (p *Page) createIsolatedWorld() (proto.RuntimeRemoteObjectID, error) {
+	world, err := proto.PageCreateIsolatedWorld{FrameID: p.FrameID, WorldName: p.world.name}.Call(p)
+	if err != nil {
+		return "", err
+	}
+
+	obj, err := proto.RuntimeEvaluate{Expression: "globalThis", ContextID: world.ExecutionContextID}.Call(p)
+	if err != nil {
+		return "", err
+	}
+
+	p.world.ctxID = world.ExecutionContextID
+	*p.jsCtxID = obj.Result.ObjectID
+	p.helpersLock.Lock()
+	p.helpers = nil
+	p.helpersLock.Unlock()
+	return *p.jsCtxID, nil
+}
+
+// resolve the node in the js context of the page, if the page is isolated the node will be resolved in the
+// isolated world, and the world will be recreated once if it's destroyed by navigation
+// 在页面的 js ctx 中解析节点，如果页面是隔离的，节点将在隔离环境中被解析，如果隔离环境因导航被销毁，会重新创建一次
+func (p *Page) resolveNode(req proto.DOMResolveNode) (*proto.DOMResolveNodeResult, error) {
+	if p.world == nil {
+		return req.Call(p)
+	}
+
+	resolve := func() (*proto.DOMResolveNodeResult, error) {
+		_, err := p.getJSCtxID()
+		if err != nil {
+			return nil, err
+		}
+
+		p.jsCtxLock.Lock()
+		req.ExecutionContextID = p.world.ctxID
+		p.jsCtxLock.Unlock()
+
+		return req.Call(p)
+	}
+
+	res, err := resolve()
+	if err != nil {
+		p.unsetJSCtxID()
+		return resolve()
+	}
+	return res, nil
+}
@@ -0,0 +1,67 @@
+package rod_test
+
+import (
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestPageIsolated(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.srcFile("fixtures/click.html")).MustWaitLoad()
+	g.False(page.IsIsolated())
+
+	page.MustEval(`() => {
+		window.secret = 1
+		document.querySelector = () => null
+	}`)
+
+	iso := page.Isolated("rod")
+	g.True(iso.IsIsolated())
+
+	g.Eq(iso.MustEval(`() => typeof window.secret`).Str(), "undefined")
+	iso.MustEval(`() => { window.injected = 1 }`)
+	g.Eq(page.MustEval(`() => typeof window.injected`).Str(), "undefined")
+
+	// the overridden globals of the page won't affect the helpers
+	iso.MustElement("button").MustClick()
+	g.True(page.MustHas("[a=ok]"))
+	g.Eq(iso.MustElement("button").MustEval(`() => typeof window.secret`).Str(), "undefined")
+
+	// the world will be recreated after navigation
+	page.MustReload().MustWaitLoad()
+	g.Eq(iso.MustElement("button").MustText(), "click me")
+	g.Eq(iso.MustEval(`() => typeof window.injected`).Str(), "undefined")
+}
+
+func TestPageIsolatedIframe(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.srcFile("fixtures/click-iframe.html")).MustWaitLoad()
+	iso := page.Isolated("rod")
+
+	frame := iso.MustElement("iframe").MustFrame()
+	g.True(frame.IsIsolated())
+
+	frame.MustEval(`() => { window.injected = 1 }`)
+	g.Eq(page.MustElement("iframe").MustFrame().MustEval(`() => typeof window.injected`).Str(), "undefined")
+
+	frame.MustElement("button").MustClick()
+	g.True(frame.MustHas("[a=ok]"))
+}
+
+func TestPageIsolatedErr(t *testing.T) {
+	g := setup(t)
+
+	iso := g.newPage(g.blank()).MustWaitLoad().Isolated("rod")
+
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.PageCreateIsolatedWorld{})
+		iso.MustEval(`() => 1`)
+	})
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeEvaluate{})
+		iso.MustEval(`() => 1`)
+	})
+}
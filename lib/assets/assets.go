@@ -68,13 +68,18 @@ const Monitor = `<html>
 
     <script>
       async function update() {
-        const list = await (await fetch('/api/pages')).json()
-        let html = ''
-        list.forEach((el) => {
-          html += ` + "`" + `<a href='/page/${el.targetId}' title="${el.url}">${el.title}</a>` + "`" + `
+        const list = await (await fetch('api/pages')).json()
+
+        // the title and url are from the pages, never parse them as html
+        const links = list.map((el) => {
+          const a = document.createElement('a')
+          a.setAttribute('href', 'page/' + encodeURIComponent(el.targetId))
+          a.setAttribute('title', el.url)
+          a.textContent = el.title
+          return a
         })
 
-        window.targets.innerHTML = html
+        window.targets.replaceChildren(...links)
 
         setTimeout(update, 1000)
       }
@@ -127,6 +132,113 @@ const MonitorPage = `<html>
       .rate {
         flex: 1;
       }
+      .control {
+        font-size: 14px;
+        margin: 5px;
+        display: flex;
+        align-items: center;
+        white-space: nowrap;
+      }
+      .screen.controlled {
+        cursor: crosshair;
+        outline: 1px solid #4f475a;
+      }
+      .network {
+        display: none;
+        font-family: monospace;
+        font-size: 12px;
+        border-top: 1px solid #1413158c;
+      }
+      .network table {
+        width: 100%;
+        border-collapse: collapse;
+      }
+      .network th {
+        text-align: left;
+        font-weight: normal;
+        color: #a09aa8;
+      }
+      .network td {
+        max-width: 400px;
+        overflow: hidden;
+        text-overflow: ellipsis;
+        white-space: nowrap;
+        padding: 2px 5px;
+      }
+      .network tr.req {
+        cursor: pointer;
+      }
+      .network tr.req:hover {
+        background: #4f475a;
+      }
+      .network tr.failed {
+        color: #ff3f3f;
+      }
+      .log-panel {
+        display: none;
+        font-family: monospace;
+        font-size: 12px;
+        border-top: 1px solid #1413158c;
+      }
+      .levels {
+        font-family: sans-serif;
+        display: flex;
+        flex-direction: row;
+      }
+      .logs {
+        max-height: 300px;
+        overflow: auto;
+        padding: 5px 10px;
+        background: #1f1e21;
+      }
+      .logs div {
+        white-space: pre-wrap;
+        word-break: break-all;
+        border-bottom: 1px solid #2d2c2f;
+      }
+      .logs .location {
+        float: right;
+        color: #a09aa8;
+      }
+      .level-debug {
+        color: #a09aa8;
+      }
+      .level-warning {
+        color: #ffd36b;
+      }
+      .level-error {
+        color: #ff3f3f;
+      }
+      .level-trace {
+        color: #7fc7ff;
+      }
+      .logs.hide-debug .level-debug {
+        display: none;
+      }
+      .logs.hide-log .level-log {
+        display: none;
+      }
+      .logs.hide-info .level-info {
+        display: none;
+      }
+      .logs.hide-warning .level-warning {
+        display: none;
+      }
+      .logs.hide-error .level-error {
+        display: none;
+      }
+      .logs.hide-trace .level-trace {
+        display: none;
+      }
+      .preview {
+        max-height: 300px;
+        overflow: auto;
+        margin: 0;
+        padding: 10px;
+        background: #1f1e21;
+        white-space: pre-wrap;
+        word-break: break-all;
+      }
     </style>
   </head>
   <body>
@@ -144,11 +256,69 @@ const MonitorPage = `<html>
         value="0.5"
         min="0"
         step="0.1"
-        title="refresh rate (second)"
+        title="refresh rate of the title, url, network and logs (second)"
       />
+      <label class="control" title="forward the mouse and keyboard to the remote page">
+        <input type="checkbox" class="control-switch" />
+        control
+      </label>
+      <label class="control" title="show the network requests of the remote page">
+        <input type="checkbox" class="network-switch" />
+        network
+      </label>
+      <label class="control" title="show the console messages and the trace entries of the remote page">
+        <input type="checkbox" class="logs-switch" />
+        logs
+      </label>
     </div>
     <pre class="error"></pre>
-    <img class="screen" />
+    <img class="screen" tabindex="0" />
+    <div class="network">
+      <table>
+        <thead>
+          <tr>
+            <th>method</th>
+            <th>status</th>
+            <th>type</th>
+            <th>url</th>
+            <th>initiator</th>
+            <th>size</th>
+            <th>time</th>
+          </tr>
+        </thead>
+        <tbody></tbody>
+      </table>
+      <pre class="preview"></pre>
+    </div>
+    <div class="log-panel">
+      <div class="levels">
+        <label class="control">
+          <input type="checkbox" class="level" value="debug" checked />
+          debug
+        </label>
+        <label class="control">
+          <input type="checkbox" class="level" value="log" checked />
+          log
+        </label>
+        <label class="control">
+          <input type="checkbox" class="level" value="info" checked />
+          info
+        </label>
+        <label class="control">
+          <input type="checkbox" class="level" value="warning" checked />
+          warning
+        </label>
+        <label class="control">
+          <input type="checkbox" class="level" value="error" checked />
+          error
+        </label>
+        <label class="control">
+          <input type="checkbox" class="level" value="trace" checked />
+          trace
+        </label>
+      </div>
+      <div class="logs"></div>
+    </div>
   </body>
   <script>
     const id = location.pathname.split('/').slice(-1)[0]
@@ -157,28 +327,203 @@ const MonitorPage = `<html>
     const elUrl = document.querySelector('.url')
     const elRate = document.querySelector('.rate')
     const elErr = document.querySelector('.error')
+    const elControl = document.querySelector('.control-switch')
+    const elNetworkSwitch = document.querySelector('.network-switch')
+    const elNetwork = document.querySelector('.network')
+    const elRequests = document.querySelector('.network tbody')
+    const elPreview = document.querySelector('.preview')
+    const elLogsSwitch = document.querySelector('.logs-switch')
+    const elLogPanel = document.querySelector('.log-panel')
+    const elLogs = document.querySelector('.logs')
 
     document.title = ` + "`" + `Rod Monitor - ${id}` + "`" + `
 
+    // the screencast is a mjpeg stream, the browser renders each frame as it arrives
+    function connect() {
+      elImg.src = ` + "`" + `screencast/${id}?t=${Date.now()}` + "`" + `
+    }
+    elImg.onload = () => elErr.attributeStyleMap.delete('display')
+    elImg.onerror = () => {
+      elErr.style.display = 'block'
+      elErr.textContent = 'error loading the screencast, reconnecting...'
+      setTimeout(connect, 1000)
+    }
+    connect()
+
+    // forward the mouse and keyboard events on the screen to the remote page
+    function send(input) {
+      if (!elControl.checked) return
+      fetch(` + "`" + `api/input/${id}` + "`" + `, {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify(input),
+      }).catch((err) => {
+        elErr.style.display = 'block'
+        elErr.textContent = err + ''
+      })
+    }
+
+    function modifiers(e) {
+      return (e.altKey ? 1 : 0) | (e.ctrlKey ? 2 : 0) | (e.metaKey ? 4 : 0) | (e.shiftKey ? 8 : 0)
+    }
+
+    function mouse(e, type, extra) {
+      const rect = elImg.getBoundingClientRect()
+      send({
+        mouse: Object.assign(
+          {
+            type,
+            x: (e.clientX - rect.left) / rect.width,
+            y: (e.clientY - rect.top) / rect.height,
+            modifiers: modifiers(e),
+            deltaX: 0,
+            deltaY: 0,
+          },
+          extra
+        ),
+      })
+    }
+
+    const buttons = ['left', 'middle', 'right']
+
+    elControl.onchange = () => elImg.classList.toggle('controlled', elControl.checked)
+    elImg.ondragstart = (e) => e.preventDefault()
+    elImg.oncontextmenu = (e) => elControl.checked && e.preventDefault()
+    elImg.onmousedown = (e) =>
+      mouse(e, 'mousePressed', { button: buttons[e.button], clickCount: e.detail })
+    elImg.onmouseup = (e) =>
+      mouse(e, 'mouseReleased', { button: buttons[e.button], clickCount: e.detail })
+    elImg.onmousemove = (e) => e.buttons && mouse(e, 'mouseMoved', { button: buttons[0] })
+    elImg.onwheel = (e) => {
+      if (!elControl.checked) return
+      e.preventDefault()
+      mouse(e, 'mouseWheel', { deltaX: e.deltaX, deltaY: e.deltaY })
+    }
+
+    function key(e, type) {
+      if (!elControl.checked) return
+      e.preventDefault()
+      const text = type === 'keyDown' && e.key.length === 1 ? e.key : ''
+      send({
+        key: {
+          type,
+          key: e.key,
+          code: e.code,
+          text,
+          unmodifiedText: text,
+          modifiers: modifiers(e),
+          windowsVirtualKeyCode: e.keyCode,
+          autoRepeat: e.repeat,
+        },
+      })
+    }
+    elImg.onkeydown = (e) => key(e, 'keyDown')
+    elImg.onkeyup = (e) => key(e, 'keyUp')
+
+    // the rows of the network panel, the key is the request id
+    const rows = {}
+    let networkVersion = 0
+
+    elNetworkSwitch.onchange = () => {
+      elNetwork.style.display = elNetworkSwitch.checked ? 'block' : 'none'
+    }
+
+    async function preview(req) {
+      if (!req.done || req.error) {
+        elPreview.textContent = req.error || 'the request is not finished'
+        return
+      }
+      if (!/^text\/|json|javascript|xml/.test(req.mimeType)) {
+        elPreview.textContent = ` + "`" + `[${req.mimeType}] ${req.size} bytes` + "`" + `
+        return
+      }
+      const res = await fetch(` + "`" + `api/body/${id}?request=${encodeURIComponent(req.id)}` + "`" + `)
+      elPreview.textContent = await res.text()
+    }
+
+    function renderRequest(req) {
+      let row = rows[req.id]
+      if (!row) {
+        row = rows[req.id] = document.createElement('tr')
+        row.className = 'req'
+        elRequests.append(row)
+      }
+      row.classList.toggle('failed', !!req.error)
+      row.onclick = () => preview(req).catch((err) => (elPreview.textContent = err + ''))
+      row.replaceChildren(
+        ...[
+          req.method,
+          req.error || req.status || '',
+          req.type,
+          req.url,
+          req.initiator,
+          req.done ? req.size : '',
+          req.done ? req.duration.toFixed(0) + 'ms' : 'pending',
+        ].map((text) => {
+          const td = document.createElement('td')
+          td.textContent = text
+          td.title = text
+          return td
+        })
+      )
+    }
+
+    async function updateNetwork() {
+      const res = await fetch(` + "`" + `api/network/${id}?since=${networkVersion}` + "`" + `)
+      const network = await res.json()
+      network.requests.forEach(renderRequest)
+      networkVersion = network.version
+    }
+
+    let logSeq = 0
+
+    elLogsSwitch.onchange = () => {
+      elLogPanel.style.display = elLogsSwitch.checked ? 'block' : 'none'
+    }
+
+    document.querySelectorAll('.level').forEach((el) => {
+      el.onchange = () => elLogs.classList.toggle(` + "`" + `hide-${el.value}` + "`" + `, !el.checked)
+    })
+
+    async function updateLogs() {
+      const res = await fetch(` + "`" + `api/logs/${id}?since=${logSeq}` + "`" + `)
+      const logs = await res.json()
+
+      // only follow the new entries when it's scrolled to the bottom
+      const follow = elLogs.scrollTop + elLogs.clientHeight >= elLogs.scrollHeight - 5
+
+      logs.entries.forEach((entry) => {
+        const el = document.createElement('div')
+        el.className = ` + "`" + `level-${entry.level}` + "`" + `
+        el.textContent = ` + "`" + `${new Date(entry.time).toLocaleTimeString()} [${entry.level}] ${entry.text}` + "`" + `
+        if (entry.location) {
+          const loc = document.createElement('span')
+          loc.className = 'location'
+          loc.textContent = entry.location
+          el.prepend(loc)
+        }
+        elLogs.append(el)
+      })
+      while (elLogs.childElementCount > 1000) elLogs.firstChild.remove()
+      logSeq = logs.seq
+
+      if (follow) elLogs.scrollTop = elLogs.scrollHeight
+    }
+
     async function update() {
-      const res = await fetch(` + "`" + `/api/page/${id}` + "`" + `)
+      const res = await fetch(` + "`" + `api/page/${id}` + "`" + `)
       const info = await res.json()
       elTitle.value = info.title
       elUrl.value = info.url
+      elImg.style.maxWidth = innerWidth + 'px'
 
-      await new Promise((resolve, reject) => {
-        const now = new Date()
-        elImg.src = ` + "`" + `/screenshot/${id}?t=${now.getTime()}` + "`" + `
-        elImg.style.maxWidth = innerWidth + 'px'
-        elImg.onload = resolve
-        elImg.onerror = () => reject(new Error('error loading screenshots'))
-      })
+      if (elNetworkSwitch.checked) await updateNetwork()
+      if (elLogsSwitch.checked) await updateLogs()
     }
 
     async function mainLoop() {
       try {
         await update()
-        elErr.attributeStyleMap.delete('display')
       } catch (err) {
         elErr.style.display = 'block'
         elErr.textContent = err + ''
@@ -191,3 +536,160 @@ const MonitorPage = `<html>
   </script>
 </html>
 `
+
+// Report for rod
+// 定义 rod 的 Report
+const Report = `<!DOCTYPE html>
+<html>
+  <head>
+    <meta charset="utf-8" />
+    <title>{{.Title}}</title>
+    <style>
+      body {
+        margin: 0;
+        padding: 20px;
+        font-family: sans-serif;
+        font-size: 14px;
+        background: #2d2c2f;
+        color: #ffffff;
+      }
+      h1 {
+        margin: 0 0 10px;
+        font-size: 20px;
+      }
+      h2 {
+        margin: 30px 0 10px;
+        font-size: 16px;
+      }
+      .summary span {
+        display: inline-block;
+        margin-right: 20px;
+        color: #a09aa8;
+      }
+      .summary b {
+        color: #ffffff;
+      }
+      .timeline {
+        border-left: 2px solid #4f475a;
+        margin-left: 80px;
+      }
+      .entry {
+        position: relative;
+        padding: 5px 10px;
+      }
+      .entry .offset {
+        position: absolute;
+        left: -90px;
+        width: 75px;
+        text-align: right;
+        color: #a09aa8;
+        font-family: monospace;
+      }
+      .entry .type {
+        display: inline-block;
+        min-width: 80px;
+        color: #7fc7ff;
+      }
+      .entry .duration {
+        color: #a09aa8;
+        margin-left: 10px;
+      }
+      .entry.error .type,
+      .entry.error .detail {
+        color: #ff3f3f;
+      }
+      .entry.console .type {
+        color: #ffd36b;
+      }
+      .entry .detail {
+        font-family: monospace;
+        white-space: pre-wrap;
+        word-break: break-all;
+      }
+      .entry img {
+        display: block;
+        max-width: 480px;
+        margin-top: 5px;
+        border: 1px solid #4f475a;
+        cursor: zoom-in;
+      }
+      .entry img.zoom {
+        max-width: 100%;
+      }
+      table {
+        border-collapse: collapse;
+        width: 100%;
+        font-family: monospace;
+        font-size: 12px;
+      }
+      th {
+        text-align: left;
+        font-weight: normal;
+        color: #a09aa8;
+      }
+      td {
+        max-width: 600px;
+        overflow: hidden;
+        text-overflow: ellipsis;
+        white-space: nowrap;
+        padding: 2px 5px;
+        border-bottom: 1px solid #1413158c;
+      }
+      tr.failed {
+        color: #ff3f3f;
+      }
+    </style>
+  </head>
+  <body>
+    <h1>{{.Title}}</h1>
+    <div class="summary">
+      <span>start <b>{{.Start.Format "2006-01-02 15:04:05"}}</b></span>
+      <span>duration <b>{{.Duration}}</b></span>
+      <span>actions <b>{{.Actions}}</b></span>
+      <span>errors <b>{{.Errors}}</b></span>
+      <span>requests <b>{{len .Requests}}</b></span>
+      <span>failed requests <b>{{.FailedRequests}}</b></span>
+      <span>received <b>{{.Received}}</b></span>
+    </div>
+
+    <h2>Timeline</h2>
+    <div class="timeline">
+      {{range .Entries}}
+      <div class="entry {{.Kind}}">
+        <span class="offset">+{{.Offset}}</span>
+        <span class="type">{{.Type}}</span>
+        <span class="detail">{{.Detail}}</span>
+        {{if .Duration}}<span class="duration">{{.Duration}}</span>{{end}}
+        {{if .Image}}<img src="{{.Image}}" onclick="this.classList.toggle('zoom')" />{{end}}
+      </div>
+      {{end}}
+    </div>
+
+    <h2>Network</h2>
+    <table>
+      <thead>
+        <tr>
+          <th>method</th>
+          <th>status</th>
+          <th>type</th>
+          <th>url</th>
+          <th>size</th>
+          <th>time</th>
+        </tr>
+      </thead>
+      <tbody>
+        {{range .Requests}}
+        <tr {{if .Error}}class="failed"{{end}}>
+          <td>{{.Method}}</td>
+          <td>{{if .Error}}{{.Error}}{{else}}{{.Status}}{{end}}</td>
+          <td>{{.Type}}</td>
+          <td title="{{.URL}}">{{.URL}}</td>
+          <td>{{.Size}}</td>
+          <td>{{.Duration}}</td>
+        </tr>
+        {{end}}
+      </tbody>
+    </table>
+  </body>
+</html>
+`
@@ -21,10 +21,14 @@ const Monitor = {{.monitor}}
 
 // MonitorPage for rod
 const MonitorPage = {{.monitorPage}}
+
+// Report for rod
+const Report = {{.report}}
 `,
 		"mousePointer", get("../../fixtures/mouse-pointer.svg"),
 		"monitor", get("monitor.html"),
 		"monitorPage", get("monitor-page.html"),
+		"report", get("report.html"),
 	)
 
 	utils.E(utils.OutputFile(slash("lib/assets/assets.go"), build))
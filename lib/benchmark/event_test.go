@@ -0,0 +1,111 @@
+package main_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/cdp"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+var requestWillBeSent = []byte(`{
+	"requestId": "1000.1",
+	"loaderId": "1000",
+	"documentURL": "https://example.com/",
+	"request": {
+		"url": "https://example.com/app.js",
+		"method": "GET",
+		"headers": {"Accept": "*/*", "User-Agent": "Mozilla/5.0"},
+		"initialPriority": "High",
+		"referrerPolicy": "strict-origin-when-cross-origin"
+	},
+	"timestamp": 1000.5,
+	"wallTime": 1600000000.5,
+	"initiator": {"type": "parser", "url": "https://example.com/"},
+	"type": "Script"
+}`)
+
+var domEvents = map[string][]byte{
+	"DOM.childNodeCountUpdated": []byte(`{"nodeId": 10, "childNodeCount": 3}`),
+	"DOM.attributeModified":     []byte(`{"nodeId": 10, "name": "class", "value": "active"}`),
+}
+
+func BenchmarkDecodeEvent(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, err := proto.DecodeEvent("Network.requestWillBeSent", requestWillBeSent)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for method, data := range domEvents {
+			_, err = proto.DecodeEvent(method, data)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// the decoding via GetType that was used before the generated constructors
+func BenchmarkDecodeEventReflect(b *testing.B) {
+	b.ReportAllocs()
+
+	decode := func(method string, data []byte) {
+		e := reflect.New(proto.GetType(method)).Interface()
+		if err := json.Unmarshal(data, e); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	for i := 0; i < b.N; i++ {
+		decode("Network.requestWillBeSent", requestWillBeSent)
+		for method, data := range domEvents {
+			decode(method, data)
+		}
+	}
+}
+
+var loadingFinished = []byte(`{"requestId": "1000.1", "timestamp": 1001.5, "encodedDataLength": 1024}`)
+
+type eventClient struct {
+	event chan *cdp.Event
+}
+
+func (c *eventClient) Event() <-chan *cdp.Event { return c.event }
+
+func (c *eventClient) Call(context.Context, string, string, interface{}) ([]byte, error) {
+	return []byte("{}"), nil
+}
+
+// a page with heavy network traffic, most of the subscribers don't listen to the network events
+func BenchmarkEachEventHeavyNetwork(b *testing.B) {
+	b.ReportAllocs()
+
+	c := &eventClient{make(chan *cdp.Event)}
+	browser := rod.New().Client(c).MustConnect()
+
+	for i := 0; i < 10; i++ {
+		go browser.EachEvent(func(e *proto.PageLoadEventFired) {})()
+	}
+
+	count := 0
+	wait := browser.EachEvent(func(e *proto.NetworkLoadingFinished) bool {
+		count++
+		return count == b.N
+	})
+
+	b.ResetTimer()
+
+	go func() {
+		for i := 0; i < b.N; i++ {
+			c.event <- &cdp.Event{Method: "Network.requestWillBeSent", Params: requestWillBeSent}
+			c.event <- &cdp.Event{Method: "Network.loadingFinished", Params: loadingFinished}
+		}
+	}()
+
+	wait()
+}
@@ -0,0 +1,170 @@
+package bidi_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/go-rod/rod/lib/bidi"
+	"github.com/ysmood/got"
+	"github.com/ysmood/gson"
+)
+
+var setup = got.Setup(nil)
+
+// MockBrowser responds the requests with the results of the handlers
+type MockBrowser struct {
+	req      chan []byte
+	handlers map[string]func(params gson.JSON) interface{}
+	sent     []gson.JSON
+}
+
+func newMockBrowser(t *testing.T, handlers map[string]func(params gson.JSON) interface{}) *MockBrowser {
+	m := &MockBrowser{req: make(chan []byte, 10), handlers: handlers}
+	t.Cleanup(func() { close(m.req) })
+	return m
+}
+
+func (m *MockBrowser) Send(data []byte) error {
+	m.req <- append([]byte{}, data...)
+	return nil
+}
+
+func (m *MockBrowser) Read() ([]byte, error) {
+	data, ok := <-m.req
+	if !ok {
+		return nil, io.EOF
+	}
+
+	req := gson.New(data)
+	m.sent = append(m.sent, req)
+
+	method := req.Get("method").Str()
+	if method == "event" {
+		return json.Marshal(map[string]interface{}{"type": "event", "method": "log.entryAdded", "params": map[string]int{}})
+	}
+
+	h, has := m.handlers[method]
+	if !has {
+		return json.Marshal(map[string]interface{}{
+			"type": "error", "id": req.Get("id").Int(), "error": "unknown command", "message": method,
+		})
+	}
+	return json.Marshal(map[string]interface{}{
+		"type": "success", "id": req.Get("id").Int(), "result": h(req.Get("params")),
+	})
+}
+
+func TestClient(t *testing.T) {
+	g := setup(t)
+
+	c := bidi.New().Start(newMockBrowser(t, map[string]func(gson.JSON) interface{}{
+		"session.status": func(gson.JSON) interface{} { return map[string]bool{"ready": true} },
+	}))
+
+	res, err := c.Call(g.Context(), "session.status", nil)
+	g.E(err)
+	g.True(gson.New(res).Get("ready").Bool())
+
+	_, err = c.Call(g.Context(), "not.exists", nil)
+	g.Eq(err.Error(), "unknown command: not.exists")
+
+	go func() { _, _ = c.Call(context.Background(), "event", nil) }()
+	g.Eq((<-c.Event()).Method, "log.entryAdded")
+}
+
+type closedWS struct{}
+
+func (closedWS) Send([]byte) error     { return nil }
+func (closedWS) Read() ([]byte, error) { return nil, io.ErrUnexpectedEOF }
+
+func TestClientClosed(t *testing.T) {
+	g := setup(t)
+
+	c := bidi.New().Start(closedWS{})
+
+	_, err := c.Call(g.Context(), "session.status", nil)
+	g.Eq(err, io.ErrUnexpectedEOF)
+
+	_, err = c.Call(g.Context(), "session.status", nil)
+	g.Eq(err, io.ErrUnexpectedEOF)
+}
+
+func TestBrowser(t *testing.T) {
+	g := setup(t)
+
+	png := []byte("png")
+
+	m := newMockBrowser(t, map[string]func(gson.JSON) interface{}{
+		"browsingContext.create":   func(gson.JSON) interface{} { return map[string]string{"context": "ctx"} },
+		"browsingContext.navigate": func(gson.JSON) interface{} { return map[string]string{} },
+		"browsingContext.close":    func(gson.JSON) interface{} { return map[string]string{} },
+		"browsingContext.getTree": func(gson.JSON) interface{} {
+			return map[string]interface{}{"contexts": []interface{}{map[string]string{"context": "ctx"}}}
+		},
+		"browsingContext.captureScreenshot": func(gson.JSON) interface{} {
+			return map[string]string{"data": base64.StdEncoding.EncodeToString(png)}
+		},
+		"browsingContext.locateNodes": func(p gson.JSON) interface{} {
+			if p.Get("locator.value").Str() == "none" {
+				return map[string]interface{}{"nodes": []interface{}{}}
+			}
+			return map[string]interface{}{"nodes": []interface{}{map[string]string{"sharedId": "node"}}}
+		},
+		"script.callFunction": func(p gson.JSON) interface{} {
+			switch p.Get("functionDeclaration").Str() {
+			case "throw":
+				return map[string]interface{}{"type": "exception", "exceptionDetails": map[string]string{"text": "err"}}
+			case "function() { return this.innerText }":
+				return map[string]interface{}{"type": "success", "result": map[string]string{"type": "string", "value": "text"}}
+			}
+			// echo the args
+			return map[string]interface{}{"type": "success", "result": map[string]interface{}{
+				"type": "array", "value": p.Get("arguments").Val(),
+			}}
+		},
+		"input.performActions": func(gson.JSON) interface{} { return map[string]string{} },
+	})
+
+	b := bidi.NewBrowser(g.Context(), bidi.New().Start(m))
+
+	p, err := b.Page("http://a.com")
+	g.E(err)
+	g.Eq(p.Context, "ctx")
+
+	list, err := b.Pages()
+	g.E(err)
+	g.Len(list, 1)
+
+	v, err := p.Eval("echo", 1, "a", nil, map[string]interface{}{"k": []int{1}})
+	g.E(err)
+	g.Eq(v, []interface{}{1.0, "a", nil, map[string]interface{}{"k": []interface{}{1.0}}})
+
+	_, err = p.Eval("throw")
+	g.Eq(err.Error(), "eval js error: err")
+
+	bin, err := p.Screenshot()
+	g.E(err)
+	g.Eq(bin, png)
+
+	el, err := p.Element("button")
+	g.E(err)
+	g.Eq(el.SharedID, "node")
+
+	_, err = p.Element("none")
+	g.Eq(err, bidi.ErrElementNotFound)
+
+	text, err := el.Text()
+	g.E(err)
+	g.Eq(text, "text")
+
+	g.E(el.Click())
+	g.Eq(m.sent[len(m.sent)-1].Get("params.actions.0.actions.0.origin.element.sharedId").Str(), "node")
+
+	g.E(el.Input("ab"))
+	g.Eq(m.sent[len(m.sent)-1].Get("params.actions.0.actions.2.value").Str(), "b")
+
+	g.E(p.Close())
+}
@@ -0,0 +1,295 @@
+package bidi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-rod/rod/lib/cdp"
+)
+
+// ErrElementNotFound is returned when no element matches the selector
+var ErrElementNotFound = errors.New("cannot find element")
+
+// Browser represents the browser, it's similar to rod.Browser
+type Browser struct {
+	ctx    context.Context
+	client *Client
+}
+
+// Connect to the BiDi websocket url of the browser, such as "ws://127.0.0.1:9222/session" of Firefox,
+// then create a new session.
+func Connect(ctx context.Context, wsURL string, header http.Header) (*Browser, error) {
+	ws := &cdp.WebSocket{}
+	err := ws.Connect(ctx, wsURL, header)
+	if err != nil {
+		return nil, err
+	}
+
+	b := NewBrowser(ctx, New().Start(ws))
+	err = b.Call("session.new", map[string]interface{}{"capabilities": map[string]interface{}{}}, nil)
+	if err != nil {
+		_ = ws.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+// NewBrowser creates a Browser with the started client, the events of the client will be discarded
+func NewBrowser(ctx context.Context, client *Client) *Browser {
+	go func() {
+		for range client.Event() {
+		}
+	}()
+	return &Browser{ctx: ctx, client: client}
+}
+
+// Call the method, the result will be decoded into the into if it's not nil
+func (b *Browser) Call(method string, params interface{}, into interface{}) error {
+	res, err := b.client.Call(b.ctx, method, params)
+	if err != nil || into == nil {
+		return err
+	}
+	return json.Unmarshal(res, into)
+}
+
+// Page creates a new tab, then navigates to the url if it's not empty
+func (b *Browser) Page(url string) (*Page, error) {
+	var res struct {
+		Context string `json:"context"`
+	}
+	err := b.Call("browsingContext.create", map[string]interface{}{"type": "tab"}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Page{browser: b, Context: res.Context}
+	if url != "" {
+		return p, p.Navigate(url)
+	}
+	return p, nil
+}
+
+// Pages returns the top-level browsing contexts
+func (b *Browser) Pages() ([]*Page, error) {
+	var res struct {
+		Contexts []struct {
+			Context string `json:"context"`
+		} `json:"contexts"`
+	}
+	err := b.Call("browsingContext.getTree", map[string]interface{}{"maxDepth": 0}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*Page{}
+	for _, c := range res.Contexts {
+		list = append(list, &Page{browser: b, Context: c.Context})
+	}
+	return list, nil
+}
+
+// Close the browser
+func (b *Browser) Close() error {
+	return b.Call("browser.close", nil, nil)
+}
+
+// Page is a top-level browsing context, it's similar to rod.Page
+type Page struct {
+	browser *Browser
+
+	// Context id of the browsing context
+	Context string
+}
+
+// Navigate to the url and wait for the page to be loaded
+func (p *Page) Navigate(url string) error {
+	return p.browser.Call("browsingContext.navigate", map[string]interface{}{
+		"context": p.Context,
+		"url":     url,
+		"wait":    "complete",
+	}, nil)
+}
+
+// Eval the js function declaration with the args on the page, the returned promise will be awaited,
+// the args can be the json values or the *Element
+func (p *Page) Eval(js string, args ...interface{}) (interface{}, error) {
+	return p.callFunction(js, nil, args)
+}
+
+// Screenshot of the viewport as png
+func (p *Page) Screenshot() ([]byte, error) {
+	var res struct {
+		Data string `json:"data"`
+	}
+	err := p.browser.Call("browsingContext.captureScreenshot", map[string]interface{}{"context": p.Context}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(res.Data)
+}
+
+// Element returns the first element that matches the css selector
+func (p *Page) Element(selector string) (*Element, error) {
+	list, err := p.locate(selector, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, ErrElementNotFound
+	}
+	return list[0], nil
+}
+
+// Elements returns all the elements that match the css selector
+func (p *Page) Elements(selector string) ([]*Element, error) {
+	return p.locate(selector, 0)
+}
+
+func (p *Page) locate(selector string, max int) ([]*Element, error) {
+	params := map[string]interface{}{
+		"context": p.Context,
+		"locator": map[string]interface{}{"type": "css", "value": selector},
+	}
+	if max > 0 {
+		params["maxNodeCount"] = max
+	}
+
+	var res struct {
+		Nodes []struct {
+			SharedID string `json:"sharedId"`
+		} `json:"nodes"`
+	}
+	err := p.browser.Call("browsingContext.locateNodes", params, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*Element{}
+	for _, n := range res.Nodes {
+		list = append(list, &Element{page: p, SharedID: n.SharedID})
+	}
+	return list, nil
+}
+
+// Close the page
+func (p *Page) Close() error {
+	return p.browser.Call("browsingContext.close", map[string]interface{}{"context": p.Context}, nil)
+}
+
+func (p *Page) callFunction(js string, this *Element, args []interface{}) (interface{}, error) {
+	list := []interface{}{}
+	for _, arg := range args {
+		list = append(list, localValue(arg))
+	}
+
+	params := map[string]interface{}{
+		"functionDeclaration": js,
+		"target":              map[string]interface{}{"context": p.Context},
+		"arguments":           list,
+		"awaitPromise":        true,
+	}
+	if this != nil {
+		params["this"] = this.reference()
+	}
+
+	var res struct {
+		Type             string          `json:"type"`
+		Result           json.RawMessage `json:"result"`
+		ExceptionDetails struct {
+			Text string `json:"text"`
+		} `json:"exceptionDetails"`
+	}
+	err := p.browser.Call("script.callFunction", params, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Type == "exception" {
+		return nil, &ErrEval{Text: res.ExceptionDetails.Text}
+	}
+	return remoteValue(res.Result)
+}
+
+// ErrEval is the exception thrown by the js
+type ErrEval struct {
+	Text string
+}
+
+func (e *ErrEval) Error() string {
+	return "eval js error: " + e.Text
+}
+
+// Element is a node of the page, it's similar to rod.Element
+type Element struct {
+	page *Page
+
+	// SharedID of the node
+	SharedID string
+}
+
+func (el *Element) reference() map[string]interface{} {
+	return map[string]interface{}{"sharedId": el.SharedID}
+}
+
+// Eval the js function declaration on the element, the "this" of the function is the element
+func (el *Element) Eval(js string, args ...interface{}) (interface{}, error) {
+	return el.page.callFunction(js, el, args)
+}
+
+// Text of the element
+func (el *Element) Text() (string, error) {
+	v, err := el.Eval(`function() { return this.innerText }`)
+	if err != nil {
+		return "", err
+	}
+	s, _ := v.(string)
+	return s, nil
+}
+
+// Click the center of the element with the left mouse button
+func (el *Element) Click() error {
+	return el.performActions(map[string]interface{}{
+		"type": "pointer",
+		"id":   "mouse",
+		"actions": []interface{}{
+			map[string]interface{}{
+				"type": "pointerMove", "x": 0, "y": 0,
+				"origin": map[string]interface{}{"type": "element", "element": el.reference()},
+			},
+			map[string]interface{}{"type": "pointerDown", "button": 0},
+			map[string]interface{}{"type": "pointerUp", "button": 0},
+		},
+	})
+}
+
+// Input focuses the element and types the text
+func (el *Element) Input(text string) error {
+	_, err := el.Eval(`function() { this.focus() }`)
+	if err != nil {
+		return err
+	}
+
+	actions := []interface{}{}
+	for _, r := range text {
+		actions = append(actions,
+			map[string]interface{}{"type": "keyDown", "value": string(r)},
+			map[string]interface{}{"type": "keyUp", "value": string(r)},
+		)
+	}
+
+	return el.performActions(map[string]interface{}{
+		"type":    "key",
+		"id":      "keyboard",
+		"actions": actions,
+	})
+}
+
+func (el *Element) performActions(source map[string]interface{}) error {
+	return el.page.browser.Call("input.performActions", map[string]interface{}{
+		"context": el.page.Context,
+		"actions": []interface{}{source},
+	}, nil)
+}
@@ -0,0 +1,172 @@
+// Package bidi is an experimental client of the WebDriver BiDi protocol, ref: https://w3c.github.io/webdriver-bidi/
+// It's a standalone client with its own small Browser and Page types, such as for Firefox,
+// it doesn't share code or types with the cdp based surface of rod.
+package bidi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-rod/rod/lib/cdp"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// Request to send to browser
+type Request struct {
+	ID     int         `json:"id"`
+	Method string      `json:"method"`
+	Params interface{} `json:"params"`
+}
+
+// Event from browser
+type Event struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// Error of the response
+type Error struct {
+	Code    string `json:"error"`
+	Message string `json:"message"`
+}
+
+// Error stdlib interface
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// the message is a success response, an error response, or an event
+type message struct {
+	Type   string          `json:"type"`
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Error
+}
+
+type result struct {
+	msg json.RawMessage
+	err error
+}
+
+// Client is a BiDi connection instance, the transport is the same as the cdp.Client
+type Client struct {
+	count uint64
+
+	ws cdp.WebSocketable
+
+	pending sync.Map    // pending requests
+	event   chan *Event // events from browser
+
+	closed chan struct{} // closed when the connection is closed
+	err    error         // why the connection is closed
+}
+
+// New creates a BiDi connection, all messages from Client.Event must be received or they will block the client.
+func New() *Client {
+	return &Client{event: make(chan *Event), closed: make(chan struct{})}
+}
+
+// Start to browser
+func (c *Client) Start(ws cdp.WebSocketable) *Client {
+	c.ws = ws
+	go c.consumeMessages()
+	return c
+}
+
+// Call a method and wait for its response, the params can't be nil, use an empty map instead
+func (c *Client) Call(ctx context.Context, method string, params interface{}) ([]byte, error) {
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+
+	req := &Request{
+		ID:     int(atomic.AddUint64(&c.count, 1)),
+		Method: method,
+		Params: params,
+	}
+
+	data, err := json.Marshal(req)
+	utils.E(err)
+
+	done := make(chan result, 1)
+	c.pending.Store(req.ID, done)
+	defer c.pending.Delete(req.ID)
+
+	select {
+	case <-c.closed:
+		return nil, c.err
+	default:
+	}
+
+	err = c.ws.Send(data)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-done:
+		return res.msg, res.err
+	case <-c.closed:
+		// the response may be received right before the connection is closed
+		select {
+		case res := <-done:
+			return res.msg, res.err
+		default:
+			return nil, c.err
+		}
+	}
+}
+
+// Event returns a channel that will emit the BiDi events. Must be consumed or will block producer.
+func (c *Client) Event() <-chan *Event {
+	return c.event
+}
+
+func (c *Client) consumeMessages() {
+	defer close(c.event)
+
+	for {
+		data, err := c.ws.Read()
+		if err != nil {
+			// fail the pending calls and the following ones with the read error
+			c.err = err
+			close(c.closed)
+			return
+		}
+
+		var msg message
+		err = json.Unmarshal(data, &msg)
+		utils.E(err)
+
+		if msg.Type == "event" {
+			c.event <- &Event{Method: msg.Method, Params: msg.Params}
+			continue
+		}
+
+		val, ok := c.pending.Load(msg.ID)
+		if !ok {
+			continue
+		}
+		if msg.Type == "error" {
+			e := msg.Error
+			deliver(val, result{err: &e})
+		} else {
+			deliver(val, result{msg: msg.Result})
+		}
+	}
+}
+
+// the channel is buffered, drop the result if the call already has one
+func deliver(done interface{}, res result) {
+	select {
+	case done.(chan result) <- res:
+	default:
+	}
+}
@@ -0,0 +1,148 @@
+package bidi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// localValue serializes the go value to the BiDi LocalValue
+func localValue(v interface{}) interface{} {
+	if el, ok := v.(*Element); ok {
+		return el.reference()
+	}
+
+	// normalize the go value to the json types
+	data, err := json.Marshal(v)
+	if err != nil {
+		return map[string]interface{}{"type": "undefined"}
+	}
+	var val interface{}
+	_ = json.Unmarshal(data, &val)
+
+	return jsonValue(val)
+}
+
+func jsonValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case nil:
+		return map[string]interface{}{"type": "null"}
+	case string:
+		return map[string]interface{}{"type": "string", "value": val}
+	case bool:
+		return map[string]interface{}{"type": "boolean", "value": val}
+	case float64:
+		return map[string]interface{}{"type": "number", "value": val}
+	case []interface{}:
+		list := []interface{}{}
+		for _, item := range val {
+			list = append(list, jsonValue(item))
+		}
+		return map[string]interface{}{"type": "array", "value": list}
+	default:
+		m := v.(map[string]interface{})
+		keys := []string{}
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		list := []interface{}{}
+		for _, k := range keys {
+			list = append(list, []interface{}{k, jsonValue(m[k])})
+		}
+		return map[string]interface{}{"type": "object", "value": list}
+	}
+}
+
+type remote struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// remoteValue deserializes the BiDi RemoteValue to the go value. The primitives, arrays and objects are converted to
+// the json types, the other values such as the nodes are returned as the raw map.
+func remoteValue(data json.RawMessage) (interface{}, error) {
+	var r remote
+	err := json.Unmarshal(data, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch r.Type {
+	case "undefined", "null":
+		return nil, nil
+
+	case "string", "boolean", "bigint", "date", "regexp":
+		var v interface{}
+		err = json.Unmarshal(r.Value, &v)
+		return v, err
+
+	case "number":
+		var s string
+		if json.Unmarshal(r.Value, &s) == nil {
+			return specialNumber(s), nil
+		}
+		var f float64
+		err = json.Unmarshal(r.Value, &f)
+		return f, err
+
+	case "array", "set":
+		var list []json.RawMessage
+		err = json.Unmarshal(r.Value, &list)
+		if err != nil {
+			return nil, err
+		}
+		out := []interface{}{}
+		for _, item := range list {
+			v, err := remoteValue(item)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+
+	case "object", "map":
+		var pairs [][2]json.RawMessage
+		err = json.Unmarshal(r.Value, &pairs)
+		if err != nil {
+			return nil, err
+		}
+		out := map[string]interface{}{}
+		for _, pair := range pairs {
+			var key string
+			if json.Unmarshal(pair[0], &key) != nil {
+				k, err := remoteValue(pair[0])
+				if err != nil {
+					return nil, err
+				}
+				key = fmt.Sprint(k)
+			}
+
+			v, err := remoteValue(pair[1])
+			if err != nil {
+				return nil, err
+			}
+			out[key] = v
+		}
+		return out, nil
+	}
+
+	var raw map[string]interface{}
+	err = json.Unmarshal(data, &raw)
+	return raw, err
+}
+
+func specialNumber(s string) float64 {
+	switch s {
+	case "Infinity":
+		return math.Inf(1)
+	case "-Infinity":
+		return math.Inf(-1)
+	case "-0":
+		return math.Copysign(0, -1)
+	}
+	return math.NaN()
+}
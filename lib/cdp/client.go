@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/go-rod/rod/lib/defaults"
 	"github.com/go-rod/rod/lib/utils"
@@ -48,17 +49,25 @@ type Client struct {
 
 	ws WebSocketable
 
+	reconnect *ReconnectOptions
+	lock      sync.Mutex
+	conn      uint64        // increased when the connection is established or lost
+	ready     chan struct{} // closed when the ws is connected or the reconnection gave up
+	err       error         // why the reconnection gave up
+
 	pending sync.Map    // pending requests
 	event   chan *Event // events from browser
 
-	logger utils.Logger
+	logger  utils.Logger
+	metrics Metrics
 }
 
 // New creates a cdp connection, all messages from Client.Event must be received or they will block the client.
 func New() *Client {
 	return &Client{
-		event:  make(chan *Event),
-		logger: defaults.CDP,
+		event:   make(chan *Event),
+		logger:  defaults.CDP,
+		metrics: nopMetrics{},
 	}
 }
 
@@ -72,19 +81,53 @@ func (cdp *Client) Logger(l utils.Logger) *Client {
 // Start to browser
 func (cdp *Client) Start(ws WebSocketable) *Client {
 	cdp.ws = ws
+	cdp.conn = 1
+	cdp.ready = make(chan struct{})
+	close(cdp.ready)
 
-	go cdp.consumeMessages()
+	go cdp.consumeMessages(ws)
 
 	return cdp
 }
 
+// WebSocket returns the current connection of the client, it's nil while the client is reconnecting
+func (cdp *Client) WebSocket() WebSocketable {
+	cdp.lock.Lock()
+	defer cdp.lock.Unlock()
+	return cdp.ws
+}
+
 type result struct {
-	msg json.RawMessage
-	err error
+	msg  json.RawMessage
+	err  error
+	lost bool // the request failed because the connection is lost
 }
 
-// Call a method and wait for its response
+// Call a method and wait for its response.
+// If Client.Reconnect is enabled and the connection drops, the idempotent calls will be retried after the reconnection.
 func (cdp *Client) Call(ctx context.Context, sessionID, method string, params interface{}) ([]byte, error) {
+	start := time.Now()
+
+	var broken uint64
+	for attempt := 1; ; attempt++ {
+		res, conn, lost, err := cdp.call(ctx, sessionID, method, params, broken)
+		if !lost || !cdp.retry(ctx, method, attempt) {
+			cdp.metrics.Call(method, time.Since(start), err)
+			return res, err
+		}
+		broken = conn
+	}
+}
+
+// call sends the request once, lost reports if the error is caused by the loss of the connection
+func (cdp *Client) call(
+	ctx context.Context, sessionID, method string, params interface{}, broken uint64,
+) (res []byte, conn uint64, lost bool, err error) {
+	ws, conn, err := cdp.connected(ctx, broken)
+	if err != nil {
+		return nil, conn, false, err
+	}
+
 	req := &Request{
 		ID:        int(atomic.AddUint64(&cdp.count, 1)),
 		SessionID: sessionID,
@@ -97,7 +140,8 @@ func (cdp *Client) Call(ctx context.Context, sessionID, method string, params in
 	data, err := json.Marshal(req)
 	utils.E(err)
 
-	done := make(chan result)
+	// buffered, so the consumer won't be blocked if the call has returned
+	done := make(chan result, 1)
 	once := sync.Once{}
 	cdp.pending.Store(req.ID, func(res result) {
 		once.Do(func() {
@@ -109,16 +153,23 @@ func (cdp *Client) Call(ctx context.Context, sessionID, method string, params in
 	})
 	defer cdp.pending.Delete(req.ID)
 
-	err = cdp.ws.Send(data)
+	// the pending requests of the lost connection may have been cleared before the request is stored
+	if cdp.lost(conn) {
+		return nil, conn, true, ErrDisconnected
+	}
+
+	size := len(data)
+	err = ws.Send(data)
 	if err != nil {
-		return nil, err
+		return nil, conn, cdp.lost(conn), err
 	}
+	cdp.metrics.Bytes(true, size)
 
 	select {
 	case <-ctx.Done():
-		return nil, ctx.Err()
-	case res := <-done:
-		return res.msg, res.err
+		return nil, conn, false, ctx.Err()
+	case r := <-done:
+		return r.msg, conn, r.lost, r.err
 	}
 }
 
@@ -128,48 +179,51 @@ func (cdp *Client) Event() <-chan *Event {
 }
 
 // Consume messages coming from the browser via the websocket.
-func (cdp *Client) consumeMessages() {
+func (cdp *Client) consumeMessages(ws WebSocketable) {
 	defer close(cdp.event)
 
 	for {
-		data, err := cdp.ws.Read()
+		data, err := ws.Read()
 		if err != nil {
-			cdp.pending.Range(func(_, val interface{}) bool {
-				val.(func(result))(result{err: err})
-				return true
-			})
+			if cdp.reconnect != nil {
+				ws, err = cdp.redial(err)
+				if err == nil {
+					continue
+				}
+			}
+			cdp.failPending(err)
 			return
 		}
 
-		var id struct {
-			ID int `json:"id"`
+		cdp.metrics.Bytes(false, len(data))
+
+		// decode the message in a single pass, a message without id is an event
+		var msg struct {
+			Response
+			Event
 		}
-		err = json.Unmarshal(data, &id)
+		err = json.Unmarshal(data, &msg)
 		utils.E(err)
 
-		if id.ID == 0 {
-			var evt Event
-			err := json.Unmarshal(data, &evt)
-			utils.E(err)
+		if msg.ID == 0 {
+			evt := msg.Event
 			cdp.logger.Println(&evt)
+			cdp.metrics.Event(evt.Method)
 			cdp.event <- &evt
 			continue
 		}
 
-		var res Response
-		err = json.Unmarshal(data, &res)
-		utils.E(err)
-
+		res := msg.Response
 		cdp.logger.Println(&res)
 
-		val, ok := cdp.pending.Load(id.ID)
+		val, ok := cdp.pending.Load(res.ID)
 		if !ok {
 			continue
 		}
 		if res.Error == nil {
-			val.(func(result))(result{res.Result, nil})
+			val.(func(result))(result{msg: res.Result})
 		} else {
-			val.(func(result))(result{nil, res.Error})
+			val.(func(result))(result{err: res.Error})
 		}
 	}
 }
@@ -3,6 +3,7 @@ package cdp_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -124,6 +125,13 @@ func TestError(t *testing.T) {
 	cdpErr := cdp.Error{10, "err", "data"}
 	g.Eq(cdpErr.Error(), "{10 err data}")
 	g.True(cdpErr.Is(&cdpErr))
+	g.False(cdpErr.Is(errors.New("err")))
+
+	g.True(errors.Is(&cdp.Error{Code: -32601, Message: "'Not.exists' wasn't found"}, cdp.ErrMethodNotFound))
+	g.False(errors.Is(&cdp.Error{Code: -32601}, cdp.ErrInvalidParams))
+	g.True(errors.Is(cdp.ErrCtxNotFound, cdp.ErrServer))
+	g.True(errors.Is(cdp.ErrSessionNotFound, cdp.ErrSessionClosed))
+	g.Eq(cdp.ErrInvalidParams.Error(), "invalid params (-32602)")
 
 	g.Panic(func() {
 		cdp.MustStartWithURL(context.Background(), "", nil)
@@ -325,6 +333,191 @@ func TestConcurrentCall(t *testing.T) {
 	}
 }
 
+func TestReconnect(t *testing.T) {
+	g := setup(t)
+
+	// the first connection drops once it receives a request
+	drop := make(chan struct{})
+	broken := &MockWebSocket{
+		send: func([]byte) error {
+			close(drop)
+			return nil
+		},
+		read: func() ([]byte, error) {
+			<-drop
+			return nil, io.EOF
+		},
+	}
+
+	req := make(chan []byte, 10)
+	t.Cleanup(func() { close(req) })
+	echo := &MockWebSocket{
+		send: func(data []byte) error {
+			req <- data
+			return nil
+		},
+		read: func() ([]byte, error) {
+			data, ok := <-req
+			if !ok {
+				return nil, io.EOF
+			}
+
+			var req cdp.Request
+			g.E(json.Unmarshal(data, &req))
+			return json.Marshal(cdp.Response{ID: req.ID, Result: json.RawMessage(`"` + req.Method + `"`)})
+		},
+	}
+
+	states := make(chan cdp.ConnState, 10)
+	c := cdp.New().Reconnect(g.Context(), cdp.ReconnectOptions{
+		Dial: func(context.Context) (cdp.WebSocketable, error) {
+			return echo, nil
+		},
+		Sleeper: func() utils.Sleeper { return utils.CountSleeper(1) },
+		OnState: func(s cdp.ConnState, _ error) { states <- s },
+	}).Start(broken)
+
+	res, err := c.Call(g.Context(), "", "DOM.getDocument", nil)
+	g.E(err)
+	g.Eq(string(res), `"DOM.getDocument"`)
+	g.Eq(<-states, cdp.ConnDisconnected)
+	g.Eq(<-states, cdp.ConnConnected)
+
+	res, err = c.Call(g.Context(), "", "Page.navigate", nil)
+	g.E(err)
+	g.Eq(string(res), `"Page.navigate"`)
+
+	g.True(cdp.IsIdempotent("Page.enable"))
+	g.False(cdp.IsIdempotent("Input.dispatchMouseEvent"))
+	g.Eq(cdp.ConnClosed.String(), "closed")
+}
+
+func TestReconnectNotIdempotent(t *testing.T) {
+	g := setup(t)
+
+	drop := make(chan struct{})
+	broken := &MockWebSocket{
+		send: func([]byte) error {
+			close(drop)
+			return nil
+		},
+		read: func() ([]byte, error) {
+			<-drop
+			return nil, io.EOF
+		},
+	}
+
+	c := cdp.New().Reconnect(g.Context(), cdp.ReconnectOptions{
+		Dial: func(context.Context) (cdp.WebSocketable, error) {
+			return nil, errors.New("dial err")
+		},
+		Sleeper: func() utils.Sleeper { return utils.CountSleeper(2) },
+	}).Start(broken)
+
+	_, err := c.Call(g.Context(), "", "Page.navigate", nil)
+	g.Eq(err, io.EOF)
+
+	_, err = c.Call(g.Context(), "", "DOM.getDocument", nil)
+	g.Is(err, &utils.ErrMaxSleepCount{})
+}
+
+func TestReconnectSendErr(t *testing.T) {
+	g := setup(t)
+
+	// the connection is healthy, only the send fails
+	sent := 0
+	done := make(chan struct{})
+	t.Cleanup(func() { close(done) })
+	ws := &MockWebSocket{
+		send: func([]byte) error {
+			sent++
+			return errors.New("send err")
+		},
+		read: func() ([]byte, error) {
+			<-done
+			return nil, io.EOF
+		},
+	}
+
+	c := cdp.New().Reconnect(g.Context(), cdp.ReconnectOptions{
+		Dial: func(context.Context) (cdp.WebSocketable, error) {
+			return nil, errors.New("dial err")
+		},
+	}).Start(ws)
+
+	_, err := c.Call(g.Context(), "", "DOM.getDocument", nil)
+	g.Eq(err.Error(), "send err")
+	g.Eq(sent, 1)
+}
+
+func TestMetrics(t *testing.T) {
+	g := setup(t)
+
+	req := make(chan []byte, 10)
+	t.Cleanup(func() { close(req) })
+
+	ws := &MockWebSocket{
+		send: func(data []byte) error {
+			req <- append([]byte{}, data...)
+			return nil
+		},
+		read: func() ([]byte, error) {
+			data, ok := <-req
+			if !ok {
+				return nil, io.EOF
+			}
+
+			var r cdp.Request
+			g.E(json.Unmarshal(data, &r))
+
+			if r.Method == "event" {
+				return json.Marshal(cdp.Event{Method: "Page.loadEventFired"})
+			}
+
+			res := cdp.Response{ID: r.ID, Result: json.RawMessage("1")}
+			if r.Method == "fail" {
+				res = cdp.Response{ID: r.ID, Error: &cdp.Error{Code: -1}}
+			}
+			return json.Marshal(res)
+		},
+	}
+
+	m := cdp.NewMetricsCollector(time.Hour)
+	c := cdp.New().Metrics(m).Start(ws)
+
+	go func() {
+		for range c.Event() {
+		}
+	}()
+
+	_, err := c.Call(g.Context(), "", "ok", nil)
+	g.E(err)
+	_, err = c.Call(g.Context(), "", "fail", nil)
+	g.Err(err)
+	_, err = c.Call(g.Context(), "", "fail", nil)
+	g.Err(err)
+
+	// the event has no response, so the call waits for nothing
+	ctx, cancel := context.WithCancel(g.Context())
+	go func() {
+		for m.Snapshot().Events["Page.loadEventFired"] == 0 {
+			utils.Sleep(0.001)
+		}
+		cancel()
+	}()
+	_, _ = c.Call(ctx, "", "event", nil)
+
+	s := m.Snapshot()
+	g.Eq(s.Buckets, []time.Duration{time.Hour})
+	g.Eq(s.Methods["ok"].Calls, 1)
+	g.Eq(s.Methods["ok"].Latency, []int{1, 0})
+	g.Eq(s.Methods["fail"].Calls, 2)
+	g.Eq(s.Methods["fail"].Errors, 2)
+	g.Eq(s.Events["Page.loadEventFired"], 1)
+	g.Gt(s.BytesSent, 0)
+	g.Gt(s.BytesReceived, 0)
+}
+
 func TestMassBrowserClose(t *testing.T) {
 	t.Skip()
 
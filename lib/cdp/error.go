@@ -16,12 +16,50 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("%v", *e)
 }
 
-// Is stdlib interface
+// Is stdlib interface, the target can be an *Error or an *ErrorClass
 func (e Error) Is(target error) bool {
-	err, ok := target.(*Error)
-	return ok && e == *err
+	switch err := target.(type) {
+	case *Error:
+		return e == *err
+	case *ErrorClass:
+		return e.Code == err.Code
+	}
+	return false
 }
 
+// ErrorClass is the class of the errors that have the same Code, such as:
+//     if errors.Is(err, cdp.ErrMethodNotFound) {}
+type ErrorClass struct {
+	Code int
+	Name string
+}
+
+// Error stdlib interface
+func (c *ErrorClass) Error() string {
+	return fmt.Sprintf("%s (%d)", c.Name, c.Code)
+}
+
+// ErrParse class, the message isn't valid json
+var ErrParse = &ErrorClass{Code: -32700, Name: "parse error"}
+
+// ErrInvalidRequest class, the message isn't a valid request
+var ErrInvalidRequest = &ErrorClass{Code: -32600, Name: "invalid request"}
+
+// ErrMethodNotFound class, the method doesn't exist in the browser
+var ErrMethodNotFound = &ErrorClass{Code: -32601, Name: "method not found"}
+
+// ErrInvalidParams class, the params don't match the method
+var ErrInvalidParams = &ErrorClass{Code: -32602, Name: "invalid params"}
+
+// ErrInternal class, the browser failed to handle the request
+var ErrInternal = &ErrorClass{Code: -32603, Name: "internal error"}
+
+// ErrServer class, most of the failures of the methods, such as ErrCtxNotFound and ErrObjNotFound
+var ErrServer = &ErrorClass{Code: -32000, Name: "server error"}
+
+// ErrSessionClosed class, the session doesn't exist or has been detached, such as ErrSessionNotFound
+var ErrSessionClosed = &ErrorClass{Code: -32001, Name: "session closed"}
+
 // ErrCtxNotFound type
 var ErrCtxNotFound = &Error{
 	Code:    -32000,
@@ -0,0 +1,165 @@
+package cdp
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Metrics receives the measurements of the client, implement it to export them to a monitoring system,
+// such as Prometheus. The methods are called synchronously, so they shouldn't block.
+type Metrics interface {
+	// Call is called when a call is done, the duration includes the retries after the reconnections
+	Call(method string, duration time.Duration, err error)
+
+	// Event is called for each event from the browser
+	Event(method string)
+
+	// Bytes is called for each message on the wire, sent is true for the outgoing ones
+	Bytes(sent bool, size int)
+}
+
+// Metrics sets the metrics to receive the measurements of the client, nil to disable it
+func (cdp *Client) Metrics(m Metrics) *Client {
+	if m == nil {
+		m = nopMetrics{}
+	}
+	cdp.metrics = m
+	return cdp
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) Call(string, time.Duration, error) {}
+func (nopMetrics) Event(string)                      {}
+func (nopMetrics) Bytes(bool, int)                   {}
+
+// DefaultLatencyBuckets for NewMetricsCollector
+var DefaultLatencyBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+var _ Metrics = &MetricsCollector{}
+
+// MetricsCollector is a Metrics that aggregates the measurements in memory, use Snapshot to read them
+type MetricsCollector struct {
+	buckets []time.Duration
+
+	lock     sync.Mutex
+	methods  map[string]*MethodMetrics
+	events   map[string]int
+	sent     int64
+	received int64
+}
+
+// MethodMetrics of a method
+type MethodMetrics struct {
+	Calls  int
+	Errors int
+
+	// Latency is the histogram of the durations, Latency[i] is the number of the calls that are not longer
+	// than the Buckets[i], the last one is for the calls longer than all the buckets.
+	Latency []int
+
+	// TotalLatency of the calls
+	TotalLatency time.Duration
+}
+
+// MetricsSnapshot of the MetricsCollector
+type MetricsSnapshot struct {
+	// Buckets of the latency histograms
+	Buckets []time.Duration
+
+	// Methods are the metrics of the calls of each method
+	Methods map[string]MethodMetrics
+
+	// Events is the number of the events of each method
+	Events map[string]int
+
+	BytesSent     int64
+	BytesReceived int64
+}
+
+// NewMetricsCollector creates a MetricsCollector, the buckets are the upper bounds of the latency histograms,
+// if it's empty DefaultLatencyBuckets will be used.
+func NewMetricsCollector(buckets ...time.Duration) *MetricsCollector {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]time.Duration{}, buckets...)
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i] < buckets[j] })
+
+	return &MetricsCollector{
+		buckets: buckets,
+		methods: map[string]*MethodMetrics{},
+		events:  map[string]int{},
+	}
+}
+
+// Call interface
+func (c *MetricsCollector) Call(method string, duration time.Duration, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	m, has := c.methods[method]
+	if !has {
+		m = &MethodMetrics{Latency: make([]int, len(c.buckets)+1)}
+		c.methods[method] = m
+	}
+
+	m.Calls++
+	if err != nil {
+		m.Errors++
+	}
+	m.Latency[sort.Search(len(c.buckets), func(i int) bool { return duration <= c.buckets[i] })]++
+	m.TotalLatency += duration
+}
+
+// Event interface
+func (c *MetricsCollector) Event(method string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.events[method]++
+}
+
+// Bytes interface
+func (c *MetricsCollector) Bytes(sent bool, size int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if sent {
+		c.sent += int64(size)
+	} else {
+		c.received += int64(size)
+	}
+}
+
+// Snapshot returns a copy of the current metrics
+func (c *MetricsCollector) Snapshot() MetricsSnapshot {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	s := MetricsSnapshot{
+		Buckets:       append([]time.Duration{}, c.buckets...),
+		Methods:       map[string]MethodMetrics{},
+		Events:        map[string]int{},
+		BytesSent:     c.sent,
+		BytesReceived: c.received,
+	}
+	for k, m := range c.methods {
+		cp := *m
+		cp.Latency = append([]int{}, m.Latency...)
+		s.Methods[k] = cp
+	}
+	for k, n := range c.events {
+		s.Events[k] = n
+	}
+	return s
+}
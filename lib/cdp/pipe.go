@@ -0,0 +1,57 @@
+package cdp
+
+import (
+	"bufio"
+	"io"
+	"sync"
+)
+
+var _ WebSocketable = &Pipe{}
+
+// Pipe is the transport of the browser's --remote-debugging-pipe mode, each message is terminated by a null byte.
+// It avoids the debugging port, so there's no port conflict or websocket proxy issue.
+// Both the Send and Read are thread-safe.
+type Pipe struct {
+	lock sync.Mutex
+	w    io.WriteCloser
+	r    *bufio.Reader
+	rc   io.Closer
+
+	readLock sync.Mutex
+}
+
+// NewPipe creates a Pipe, w is the browser's fd 3 that it reads the messages from,
+// r is the browser's fd 4 that it writes the messages to.
+func NewPipe(w io.WriteCloser, r io.ReadCloser) *Pipe {
+	return &Pipe{w: w, r: bufio.NewReader(r), rc: r}
+}
+
+// Send a message
+func (p *Pipe) Send(msg []byte) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	_, err := p.w.Write(append(msg, 0))
+	return err
+}
+
+// Read a message
+func (p *Pipe) Read() ([]byte, error) {
+	p.readLock.Lock()
+	defer p.readLock.Unlock()
+
+	msg, err := p.r.ReadBytes(0)
+	if err != nil {
+		return nil, err
+	}
+	return msg[:len(msg)-1], nil
+}
+
+// Close both ends of the pipe
+func (p *Pipe) Close() error {
+	err := p.w.Close()
+	if e := p.rc.Close(); err == nil {
+		err = e
+	}
+	return err
+}
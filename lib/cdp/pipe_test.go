@@ -0,0 +1,38 @@
+package cdp_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/go-rod/rod/lib/cdp"
+)
+
+func TestPipe(t *testing.T) {
+	g := setup(t)
+
+	browserIn, w := io.Pipe()
+	r, browserOut := io.Pipe()
+	p := cdp.NewPipe(w, r)
+
+	go func() {
+		buf := make([]byte, 6)
+		_, _ = io.ReadFull(browserIn, buf)
+		_, _ = browserOut.Write(append(buf[:5], 0, 'b', 0))
+	}()
+
+	g.E(p.Send([]byte(`{"a"}`)))
+
+	msg, err := p.Read()
+	g.E(err)
+	g.Eq(string(msg), `{"a"}`)
+
+	msg, err = p.Read()
+	g.E(err)
+	g.Eq(string(msg), "b")
+
+	g.E(p.Close())
+
+	_, err = p.Read()
+	g.Err(err)
+	g.Err(p.Send(nil))
+}
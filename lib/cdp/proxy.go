@@ -0,0 +1,192 @@
+package cdp
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// proxyDialer tunnels the connections through the proxy
+type proxyDialer struct {
+	proxy  *url.URL
+	dialer Dialer
+}
+
+func (d *proxyDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	var tunnel func(conn net.Conn, address string) error
+	port := "80"
+
+	switch d.proxy.Scheme {
+	case "http":
+		tunnel = d.connect
+	case "https":
+		tunnel = d.connect
+		port = "443"
+	case "socks5":
+		tunnel = d.socks5
+		port = "1080"
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme: %s", d.proxy.Scheme)
+	}
+
+	host := d.proxy.Host
+	if d.proxy.Port() == "" {
+		host = net.JoinHostPort(d.proxy.Hostname(), port)
+	}
+
+	var dialer Dialer = d.dialer
+	if d.proxy.Scheme == "https" {
+		dialer = &tlsDialer{dialer: d.dialer}
+	}
+
+	conn, err := dialer.DialContext(ctx, network, host)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tunnel(conn, address)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// connect uses the http CONNECT method to create the tunnel
+func (d *proxyDialer) connect(conn net.Conn, address string) error {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: http.Header{},
+	}
+
+	if user := d.proxy.User; user != nil {
+		password, _ := user.Password()
+		auth := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+auth)
+	}
+
+	err := req.Write(conn)
+	if err != nil {
+		return err
+	}
+
+	res, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		return err
+	}
+	_ = res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("proxy connect failed: %s", res.Status)
+	}
+	return nil
+}
+
+// socks5 creates the tunnel via the socks5 protocol, ref: https://tools.ietf.org/html/rfc1928
+func (d *proxyDialer) socks5(conn net.Conn, address string) error {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return err
+	}
+
+	// 0x00 is no authentication, 0x02 is username/password
+	methods := []byte{0x00}
+	if d.proxy.User != nil {
+		methods = append(methods, 0x02)
+	}
+
+	_, err = conn.Write(append([]byte{5, byte(len(methods))}, methods...))
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(conn, buf[:2])
+	if err != nil {
+		return err
+	}
+
+	switch buf[1] {
+	case 0x00:
+	case 0x02:
+		if d.proxy.User == nil {
+			return fmt.Errorf("socks5 proxy requires authentication")
+		}
+		err = d.socks5Auth(conn)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("socks5 proxy doesn't support the authentication methods")
+	}
+
+	req := append([]byte{5, 1, 0, 3, byte(len(host))}, host...)
+	req = append(req, 0, 0)
+	binary.BigEndian.PutUint16(req[len(req)-2:], uint16(port))
+
+	_, err = conn.Write(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.ReadFull(conn, buf)
+	if err != nil {
+		return err
+	}
+	if buf[1] != 0 {
+		return fmt.Errorf("socks5 proxy connect failed with code: %d", buf[1])
+	}
+
+	// skip the bound address and port
+	size := 0
+	switch buf[3] {
+	case 1:
+		size = net.IPv4len
+	case 4:
+		size = net.IPv6len
+	case 3:
+		_, err = io.ReadFull(conn, buf[:1])
+		if err != nil {
+			return err
+		}
+		size = int(buf[0])
+	}
+	_, err = io.ReadFull(conn, make([]byte, size+2))
+	return err
+}
+
+func (d *proxyDialer) socks5Auth(conn net.Conn) error {
+	username := d.proxy.User.Username()
+	password, _ := d.proxy.User.Password()
+
+	req := append([]byte{1, byte(len(username))}, username...)
+	req = append(append(req, byte(len(password))), password...)
+
+	_, err := conn.Write(req)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, 2)
+	_, err = io.ReadFull(conn, buf)
+	if err != nil {
+		return err
+	}
+	if buf[1] != 0 {
+		return fmt.Errorf("socks5 proxy authentication failed")
+	}
+	return nil
+}
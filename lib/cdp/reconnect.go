@@ -0,0 +1,201 @@
+package cdp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// ErrDisconnected is returned when the connection is lost before the request is sent
+var ErrDisconnected = errors.New("cdp connection is lost")
+
+// the max number of the attempts of an idempotent call when the connection keeps being lost
+const retryLimit = 5
+
+// ConnState of the connection, see ReconnectOptions.OnState
+type ConnState int
+
+const (
+	// ConnConnected means the connection is re-established
+	ConnConnected ConnState = iota
+	// ConnDisconnected means the connection is lost, the client is reconnecting
+	ConnDisconnected
+	// ConnClosed means the client gave up reconnecting
+	ConnClosed
+)
+
+// String interface
+func (s ConnState) String() string {
+	switch s {
+	case ConnConnected:
+		return "connected"
+	case ConnDisconnected:
+		return "disconnected"
+	default:
+		return "closed"
+	}
+}
+
+// ReconnectOptions for Client.Reconnect
+type ReconnectOptions struct {
+	// Dial a new connection to the browser, such as:
+	//
+	//     func(ctx context.Context) (cdp.WebSocketable, error) {
+	//         ws := &cdp.WebSocket{}
+	//         return ws, ws.Connect(ctx, u, nil)
+	//     }
+	Dial func(ctx context.Context) (WebSocketable, error)
+
+	// Sleeper to wait before each dial, the client gives up when it returns an error.
+	// Default is a backoff sleeper from 100ms to 5s that never gives up.
+	Sleeper func() utils.Sleeper
+
+	// Idempotent reports if a call can be safely sent again after the reconnection. Default is IsIdempotent.
+	Idempotent func(method string) bool
+
+	// OnState is called when the state of the connection changes, err is why the connection is lost or closed.
+	// It's called on the goroutine that reads the messages, so it shouldn't block.
+	OnState func(state ConnState, err error)
+
+	ctx context.Context
+}
+
+// Reconnect enables the client to re-dial with opts.Dial when the connection drops, the in-flight and following
+// calls will wait for the reconnection, the idempotent ones will be retried, the others return the error.
+// The sessions are bound to the connection, so the calls to them will fail after the reconnection,
+// re-attach the targets to get the new sessions. The ctx stops the reconnection. It must be called before Start.
+func (cdp *Client) Reconnect(ctx context.Context, opts ReconnectOptions) *Client {
+	if opts.Sleeper == nil {
+		opts.Sleeper = func() utils.Sleeper {
+			return utils.BackoffSleeper(100*time.Millisecond, 5*time.Second, nil)
+		}
+	}
+	if opts.Idempotent == nil {
+		opts.Idempotent = IsIdempotent
+	}
+	if opts.OnState == nil {
+		opts.OnState = func(ConnState, error) {}
+	}
+	opts.ctx = ctx
+
+	cdp.reconnect = &opts
+	return cdp
+}
+
+// IsIdempotent reports if the method only reads or sets the states of the browser,
+// such as "DOM.getDocument", "Page.enable", "Emulation.setDeviceMetricsOverride".
+func IsIdempotent(method string) bool {
+	name := method[strings.LastIndex(method, ".")+1:]
+	for _, prefix := range []string{"get", "query", "describe", "resolve", "capture", "enable", "disable", "set"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// connected waits until a connection other than the broken one is established
+func (cdp *Client) connected(ctx context.Context, broken uint64) (WebSocketable, uint64, error) {
+	if cdp.reconnect == nil {
+		return cdp.ws, 0, nil
+	}
+
+	for {
+		cdp.lock.Lock()
+		ws, conn, ready, err := cdp.ws, cdp.conn, cdp.ready, cdp.err
+		cdp.lock.Unlock()
+
+		if err != nil {
+			return nil, conn, err
+		}
+		if ws != nil && conn != broken {
+			return ws, conn, nil
+		}
+
+		if ws != nil {
+			// the disconnection hasn't been noticed yet
+			ready = nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, conn, ctx.Err()
+		case <-ready:
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// lost tells if the conn has been lost
+func (cdp *Client) lost(conn uint64) bool {
+	if cdp.reconnect == nil {
+		return false
+	}
+
+	cdp.lock.Lock()
+	defer cdp.lock.Unlock()
+	return cdp.conn != conn
+}
+
+// retry tells if the call that failed because of the lost connection should be sent again
+func (cdp *Client) retry(ctx context.Context, method string, attempt int) bool {
+	if cdp.reconnect == nil || ctx.Err() != nil || attempt >= retryLimit || !cdp.reconnect.Idempotent(method) {
+		return false
+	}
+
+	cdp.lock.Lock()
+	defer cdp.lock.Unlock()
+	return cdp.err == nil
+}
+
+// redial until a new connection is established or the sleeper gives up
+func (cdp *Client) redial(cause error) (WebSocketable, error) {
+	opts := cdp.reconnect
+
+	cdp.lock.Lock()
+	cdp.ws = nil
+	cdp.conn++
+	cdp.ready = make(chan struct{})
+	cdp.lock.Unlock()
+
+	cdp.failPending(cause)
+	opts.OnState(ConnDisconnected, cause)
+
+	sleep := opts.Sleeper()
+	for {
+		err := sleep(opts.ctx)
+		if err != nil {
+			cdp.lock.Lock()
+			cdp.err = err
+			close(cdp.ready)
+			cdp.lock.Unlock()
+
+			opts.OnState(ConnClosed, err)
+			return nil, err
+		}
+
+		ws, err := opts.Dial(opts.ctx)
+		if err != nil {
+			continue
+		}
+
+		cdp.lock.Lock()
+		cdp.ws = ws
+		cdp.conn++
+		close(cdp.ready)
+		cdp.lock.Unlock()
+
+		opts.OnState(ConnConnected, nil)
+		return ws, nil
+	}
+}
+
+func (cdp *Client) failPending(err error) {
+	cdp.pending.Range(func(_, val interface{}) bool {
+		val.(func(result))(result{err: err, lost: true})
+		return true
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/go-rod/rod/lib/utils"
 )
@@ -14,11 +15,43 @@ type Dialer interface {
 	DialContext(ctx context.Context, network, address string) (net.Conn, error)
 }
 
-// TODO: replace it with tls.Dialer once golang v1.15 is widely used.
-type tlsDialer struct{}
+// tlsDialer does the tls handshake over the connection of the dialer
+type tlsDialer struct {
+	dialer Dialer
+	config *tls.Config
+}
 
 func (d *tlsDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
-	return tls.Dial(network, address, nil)
+	var dialer Dialer = &net.Dialer{}
+	if d.dialer != nil {
+		dialer = d.dialer
+	}
+
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{}
+	if d.config != nil {
+		config = d.config.Clone()
+	}
+	if config.ServerName == "" {
+		config.ServerName, _, _ = net.SplitHostPort(address)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+		defer func() { _ = conn.SetDeadline(time.Time{}) }()
+	}
+
+	tlsConn := tls.Client(conn, config)
+	err = tlsConn.Handshake()
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
 }
 
 // MustConnectWS helper to make a websocket connection
@@ -37,8 +70,21 @@ func MustStartWithURL(ctx context.Context, u string, h http.Header) *Client {
 
 // StartWithURL helper to connect to the u with the default websocket lib.
 func StartWithURL(ctx context.Context, u string, h http.Header) (*Client, error) {
-	ws := &WebSocket{}
-	err := ws.Connect(ctx, u, h)
+	return StartWithOptions(ctx, u, WebSocketOptions{Header: h})
+}
+
+// MustStartWithOptions helper for StartWithOptions
+func MustStartWithOptions(ctx context.Context, u string, opts WebSocketOptions) *Client {
+	c, err := StartWithOptions(ctx, u, opts)
+	utils.E(err)
+	return c
+}
+
+// StartWithOptions is similar to StartWithURL, but the opts can tune the default websocket lib,
+// such as the buffer sizes, max message size, compression and ping interval.
+func StartWithOptions(ctx context.Context, u string, opts WebSocketOptions) (*Client, error) {
+	ws := &WebSocket{Options: opts}
+	err := ws.Connect(ctx, u, nil)
 	if err != nil {
 		return nil, err
 	}
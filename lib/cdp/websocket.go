@@ -2,7 +2,11 @@ package cdp
 
 import (
 	"bufio"
+	"bytes"
+	"compress/flate"
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -10,6 +14,7 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
+	"time"
 )
 
 var _ WebSocketable = &WebSocket{}
@@ -22,12 +27,66 @@ type WebSocket struct {
 	// Dialer is usually used for proxy
 	Dialer Dialer
 
-	lock sync.Mutex
-	conn net.Conn
-	r    *bufio.Reader
+	// Options of the connection, they must be set before Connect
+	Options WebSocketOptions
+
+	lock  sync.Mutex
+	conn  net.Conn
+	r     *bufio.Reader
+	wLock sync.Mutex
+	w     *bufio.Writer
+	wsURL string
 }
 
-// Connect to browser
+// WebSocketOptions for WebSocket, the zero value is the default
+type WebSocketOptions struct {
+	// Header of the handshake request, the "Host" key will override the host of the request
+	Header http.Header
+
+	// ReadBufferSize in bytes, default is 4096
+	ReadBufferSize int
+
+	// WriteBufferSize in bytes, default is 4096
+	WriteBufferSize int
+
+	// MaxMessageSize in bytes, if a message from the browser is larger than it, the Read will return
+	// ErrMessageTooLarge and the connection will be closed. 0 means no limit.
+	MaxMessageSize int
+
+	// Compression negotiates the permessage-deflate extension with the browser,
+	// it can greatly reduce the traffic of the large payloads such as the screenshots over a remote connection.
+	Compression bool
+
+	// PingInterval to send the ping frames to keep the connection alive, such as through the proxies
+	// that close the idle connections. 0 means no ping.
+	PingInterval time.Duration
+
+	// Dialer to dial the tcp connection, such as a *net.Dialer with a local address, or the dialer of a service mesh.
+	// The Proxy and TLSConfig still apply on it. It's ignored if the WebSocket.Dialer is set.
+	Dialer Dialer
+
+	// Proxy to connect to the browser through, such as a bastion host. The schemes "http", "https" (the CONNECT
+	// method) and "socks5" are supported, the credentials in the url will be used for the authentication.
+	// It only proxies the devtools connection itself, not the traffic of the pages.
+	Proxy *url.URL
+
+	// TLSConfig for the "wss" scheme
+	TLSConfig *tls.Config
+}
+
+// ErrMessageTooLarge is returned when a message from the browser exceeds the WebSocketOptions.MaxMessageSize
+var ErrMessageTooLarge = errors.New("websocket message exceeds the max size")
+
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+// Connect to browser, the header will be merged into the Options.Header
 func (ws *WebSocket) Connect(ctx context.Context, wsURL string, header http.Header) error {
 	if ws.conn != nil {
 		panic("duplicated connection: " + wsURL)
@@ -46,8 +105,63 @@ func (ws *WebSocket) Connect(ctx context.Context, wsURL string, header http.Head
 	}
 
 	ws.conn = conn
-	ws.r = bufio.NewReader(conn)
-	return ws.handshake(ctx, u, header)
+	ws.wsURL = wsURL
+	ws.r = bufio.NewReaderSize(conn, ws.bufferSize(ws.Options.ReadBufferSize))
+	ws.w = bufio.NewWriterSize(conn, ws.bufferSize(ws.Options.WriteBufferSize))
+
+	h := http.Header{}
+	for k, vs := range ws.Options.Header {
+		h[k] = vs
+	}
+	for k, vs := range header {
+		h[k] = vs
+	}
+
+	err = ws.handshake(ctx, u, h)
+	if err != nil {
+		return err
+	}
+
+	if ws.Options.PingInterval > 0 {
+		go ws.ping(ws.Options.PingInterval)
+	}
+	return nil
+}
+
+// URL of the connection, it's empty before Connect
+func (ws *WebSocket) URL() string {
+	return ws.wsURL
+}
+
+// HTTPClient returns a client that dials the browser the same way as the websocket, such as via the
+// Options.Dialer, Options.Proxy and Options.TLSConfig, so the http endpoints of the devtools can be fetched,
+// such as "/json/protocol". It must be called after Connect.
+func (ws *WebSocket) HTTPClient() *http.Client {
+	// the dialer of a "wss" url has done the tls handshake
+	d := ws.Dialer
+	return &http.Client{Transport: &http.Transport{
+		DialContext:    d.DialContext,
+		DialTLSContext: d.DialContext,
+	}}
+}
+
+func (ws *WebSocket) bufferSize(size int) int {
+	if size > 0 {
+		return size
+	}
+	return 4096
+}
+
+// the loop stops once the connection is closed
+func (ws *WebSocket) ping(interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for range t.C {
+		if ws.write(opPing, nil) != nil {
+			return
+		}
+	}
 }
 
 // Close the underlying connection.
@@ -60,14 +174,23 @@ func (ws *WebSocket) initDialer(u *url.URL) {
 		return
 	}
 
+	var d Dialer = &net.Dialer{}
+	if ws.Options.Dialer != nil {
+		d = ws.Options.Dialer
+	}
+
+	if ws.Options.Proxy != nil {
+		d = &proxyDialer{proxy: ws.Options.Proxy, dialer: d}
+	}
+
 	if u.Scheme == "wss" {
-		ws.Dialer = &tlsDialer{}
+		d = &tlsDialer{dialer: d, config: ws.Options.TLSConfig}
 		if u.Port() == "" {
 			u.Host += ":443"
 		}
-	} else {
-		ws.Dialer = &net.Dialer{}
 	}
+
+	ws.Dialer = d
 }
 
 // Send a message to browser.
@@ -82,8 +205,12 @@ func (ws *WebSocket) Send(msg []byte) error {
 }
 
 func (ws *WebSocket) send(msg []byte) error {
-	// FIN is alway true, Opcode is always text frame.
-	header := [18]byte{0b1000_0001, 0b1000_0000}
+	return ws.write(opText, msg)
+}
+
+// write a frame, FIN is always true
+func (ws *WebSocket) write(opcode byte, msg []byte) error {
+	header := [18]byte{0b1000_0000 | opcode, 0b1000_0000}
 	mask := []byte{0, 1, 2, 3}
 
 	size := len(msg)
@@ -111,12 +238,18 @@ func (ws *WebSocket) send(msg []byte) error {
 		msg[i] = msg[i] ^ mask[i%4]
 	}
 
-	data := make([]byte, i+6+len(msg))
-	copy(data, header[:i+6])
-	copy(data[i+6:], msg)
+	ws.wLock.Lock()
+	defer ws.wLock.Unlock()
 
-	_, err := ws.conn.Write(data)
-	return err
+	_, err := ws.w.Write(header[:i+6])
+	if err != nil {
+		return err
+	}
+	_, err = ws.w.Write(msg)
+	if err != nil {
+		return err
+	}
+	return ws.w.Flush()
 }
 
 // Read a message from browser
@@ -133,23 +266,67 @@ func (ws *WebSocket) read() ([]byte, error) {
 	ws.lock.Lock()
 	defer ws.lock.Unlock()
 
-	_, err := ws.r.ReadByte()
-	if err != nil {
-		return nil, err
+	var msg []byte
+	compressed := false
+
+	for {
+		fin, rsv1, opcode, data, err := ws.readFrame(len(msg))
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case opPing:
+			err = ws.write(opPong, data)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		case opPong:
+			continue
+		case opClose:
+			return nil, io.EOF
+		case opContinuation:
+			msg = append(msg, data...)
+		default:
+			// the browser only sets the rsv1 when the permessage-deflate is negotiated
+			msg = data
+			compressed = rsv1
+		}
+
+		if fin {
+			break
+		}
+	}
+
+	if compressed {
+		return ws.inflate(msg)
 	}
+	return msg, nil
+}
 
+// read a frame, the size is the size of the message that has been read
+func (ws *WebSocket) readFrame(size int) (fin, rsv1 bool, opcode byte, data []byte, err error) {
 	b, err := ws.r.ReadByte()
 	if err != nil {
-		return nil, err
+		return
 	}
+	fin = b&0b1000_0000 != 0
+	rsv1 = b&0b0100_0000 != 0
+	opcode = b & 0x0f
 
-	size := 0
+	b, err = ws.r.ReadByte()
+	if err != nil {
+		return
+	}
+
+	length := 0
 	fieldLen := 0
 
 	b &= 0x7f
 	switch {
 	case b <= 125:
-		size = int(b)
+		length = int(b)
 	case b == 126:
 		fieldLen = 2
 	case b == 127:
@@ -157,17 +334,40 @@ func (ws *WebSocket) read() ([]byte, error) {
 	}
 
 	for i := 0; i < fieldLen; i++ {
-		b, err := ws.r.ReadByte()
+		b, err = ws.r.ReadByte()
 		if err != nil {
-			return nil, err
+			return
 		}
 
-		size = size<<8 + int(b)
+		length = length<<8 + int(b)
 	}
 
-	data := make([]byte, size)
+	if max := ws.Options.MaxMessageSize; max > 0 && (length < 0 || size+length > max) {
+		err = ErrMessageTooLarge
+		return
+	}
+
+	data = make([]byte, length)
 	_, err = io.ReadFull(ws.r, data)
-	return data, err
+	return
+}
+
+// the tail to append to a permessage-deflate message, the last part is an empty final block to end the stream
+var deflateTail = []byte{0x00, 0x00, 0xff, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff}
+
+func (ws *WebSocket) inflate(msg []byte) ([]byte, error) {
+	r := flate.NewReader(io.MultiReader(bytes.NewReader(msg), bytes.NewReader(deflateTail)))
+	defer func() { _ = r.Close() }()
+
+	if max := ws.Options.MaxMessageSize; max > 0 {
+		data, err := ioutil.ReadAll(io.LimitReader(r, int64(max)+1))
+		if err == nil && len(data) > max {
+			err = ErrMessageTooLarge
+		}
+		return data, err
+	}
+
+	return ioutil.ReadAll(r)
 }
 
 // ErrBadHandshake type
@@ -191,6 +391,12 @@ func (ws *WebSocket) handshake(ctx context.Context, u *url.URL, header http.Head
 		"Sec-WebSocket-Version": {"13"},
 	}}).WithContext(ctx)
 
+	if ws.Options.Compression {
+		// the contexts are not kept, so each message can be inflated independently
+		req.Header.Set("Sec-WebSocket-Extensions",
+			"permessage-deflate; client_no_context_takeover; server_no_context_takeover")
+	}
+
 	for k, vs := range header {
 		if k == "Host" && len(vs) > 0 {
 			req.Host = vs[0]
@@ -2,9 +2,14 @@ package cdp
 
 import (
 	"bufio"
+	"bytes"
+	"compress/flate"
 	"context"
 	"errors"
+	"io"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"sync"
 	"testing"
@@ -31,6 +36,7 @@ func TestWebSocketErr(t *testing.T) {
 
 	mc := &MockConn{}
 	ws.conn = mc
+	ws.w = bufio.NewWriter(mc)
 	g.Err(ws.Send([]byte("test")))
 
 	mc.errOnCount = 1
@@ -52,6 +58,191 @@ func TestWebSocketErr(t *testing.T) {
 	g.Err(tls.DialContext(context.Background(), "", ""))
 }
 
+func TestWebSocketFrames(t *testing.T) {
+	frame := func(b0 byte, payload []byte) []byte {
+		return append([]byte{b0, byte(len(payload))}, payload...)
+	}
+
+	deflate := func(s string) []byte {
+		buf := bytes.NewBuffer(nil)
+		w, _ := flate.NewWriter(buf, flate.BestSpeed)
+		_, _ = w.Write([]byte(s))
+		_ = w.Flush()
+		return bytes.TrimSuffix(buf.Bytes(), []byte{0x00, 0x00, 0xff, 0xff})
+	}
+
+	newWS := func(opts WebSocketOptions, frames ...[]byte) (*WebSocket, *bytes.Buffer) {
+		out := bytes.NewBuffer(nil)
+		return &WebSocket{
+			Options: opts,
+			r:       bufio.NewReader(bytes.NewReader(bytes.Join(frames, nil))),
+			w:       bufio.NewWriter(out),
+		}, out
+	}
+
+	t.Run("fragments and control frames", func(t *testing.T) {
+		g := setup(t)
+
+		ws, out := newWS(WebSocketOptions{},
+			frame(0x01, []byte("a")),
+			frame(0x89, []byte("p")),
+			frame(0x8a, nil),
+			frame(0x80, []byte("b")),
+			frame(0x88, nil),
+		)
+
+		msg, err := ws.read()
+		g.E(err)
+		g.Eq(string(msg), "ab")
+		g.Eq(out.Bytes()[0], byte(0x8a))
+
+		_, err = ws.read()
+		g.Eq(err, io.EOF)
+	})
+
+	t.Run("compression", func(t *testing.T) {
+		g := setup(t)
+
+		ws, _ := newWS(WebSocketOptions{}, frame(0xc1, deflate("hello")))
+
+		msg, err := ws.read()
+		g.E(err)
+		g.Eq(string(msg), "hello")
+	})
+
+	t.Run("max message size", func(t *testing.T) {
+		g := setup(t)
+
+		ws, _ := newWS(WebSocketOptions{MaxMessageSize: 5},
+			frame(0x01, []byte("abc")),
+			frame(0x80, []byte("def")),
+		)
+		_, err := ws.read()
+		g.Eq(err, ErrMessageTooLarge)
+
+		ws, _ = newWS(WebSocketOptions{MaxMessageSize: 5}, frame(0xc1, deflate("hello world")))
+		_, err = ws.read()
+		g.Eq(err, ErrMessageTooLarge)
+	})
+}
+
+func TestProxyDialer(t *testing.T) {
+	// serve one connection with the handshake of the proxy, then echo the data
+	serve := func(g got.G, handshake func(conn net.Conn, r *bufio.Reader)) *url.URL {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		g.E(err)
+		g.Cleanup(func() { _ = l.Close() })
+
+		go func() {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer func() { _ = conn.Close() }()
+
+			r := bufio.NewReader(conn)
+			handshake(conn, r)
+			_, _ = io.Copy(conn, r)
+		}()
+
+		return &url.URL{Host: l.Addr().String()}
+	}
+
+	echo := func(g got.G, d Dialer) {
+		conn, err := d.DialContext(g.Context(), "tcp", "browser.internal:9222")
+		g.E(err)
+		defer func() { _ = conn.Close() }()
+
+		_, err = conn.Write([]byte("ok"))
+		g.E(err)
+		buf := make([]byte, 2)
+		_, err = io.ReadFull(conn, buf)
+		g.E(err)
+		g.Eq(string(buf), "ok")
+	}
+
+	t.Run("http", func(t *testing.T) {
+		g := setup(t)
+
+		u := serve(g, func(conn net.Conn, r *bufio.Reader) {
+			req, err := http.ReadRequest(r)
+			g.E(err)
+			g.Eq(req.Method, http.MethodConnect)
+			g.Eq(req.Host, "browser.internal:9222")
+			g.Eq(req.Header.Get("Proxy-Authorization"), "Basic dXNlcjpwYXNz")
+			_, _ = conn.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n"))
+		})
+		u.Scheme = "http"
+		u.User = url.UserPassword("user", "pass")
+
+		echo(g, &proxyDialer{proxy: u, dialer: &net.Dialer{}})
+	})
+
+	t.Run("socks5", func(t *testing.T) {
+		g := setup(t)
+
+		u := serve(g, func(conn net.Conn, r *bufio.Reader) {
+			buf := make([]byte, 4)
+			_, _ = io.ReadFull(r, buf)
+			g.Eq(buf, []byte{5, 2, 0, 2})
+			_, _ = conn.Write([]byte{5, 2})
+
+			auth := make([]byte, 11)
+			_, _ = io.ReadFull(r, auth)
+			g.Eq(auth, append([]byte{1, 4}, "user\x04pass"...))
+			_, _ = conn.Write([]byte{1, 0})
+
+			req := make([]byte, 5+len("browser.internal")+2)
+			_, _ = io.ReadFull(r, req)
+			g.Eq(string(req[5:len(req)-2]), "browser.internal")
+			_, _ = conn.Write([]byte{5, 0, 0, 1, 127, 0, 0, 1, 0, 80})
+		})
+		u.Scheme = "socks5"
+		u.User = url.UserPassword("user", "pass")
+
+		echo(g, &proxyDialer{proxy: u, dialer: &net.Dialer{}})
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		g := setup(t)
+
+		d := &proxyDialer{proxy: &url.URL{Scheme: "ftp", Host: "a.com"}, dialer: &net.Dialer{}}
+		_, err := d.DialContext(g.Context(), "tcp", "a.com:80")
+		g.Eq(err.Error(), "unsupported proxy scheme: ftp")
+	})
+}
+
+type countDialer struct {
+	net.Dialer
+	count int
+}
+
+func (d *countDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d.count++
+	return d.Dialer.DialContext(ctx, network, address)
+}
+
+func TestWebSocketHTTPClient(t *testing.T) {
+	g := setup(t)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer s.Close()
+
+	d := &countDialer{}
+	ws := &WebSocket{Dialer: d}
+
+	res, err := ws.HTTPClient().Get(s.URL)
+	g.E(err)
+	defer func() { _ = res.Body.Close() }()
+
+	b, err := io.ReadAll(res.Body)
+	g.E(err)
+	g.Eq(string(b), "ok")
+	g.Eq(d.count, 1)
+}
+
 type MockConn struct {
 	sync.Mutex
 	errOnCount int
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/launcher"
@@ -120,3 +121,28 @@ func TestDuplicatedConnectErr(t *testing.T) {
 		_ = ws.Connect(g.Context(), u, nil)
 	})
 }
+
+func TestWebSocketOptions(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New()
+	g.Cleanup(l.Kill)
+
+	client := cdp.MustStartWithOptions(g.Context(), l.MustLaunch(), cdp.WebSocketOptions{
+		ReadBufferSize:  1024 * 1024,
+		WriteBufferSize: 1024 * 1024,
+		Compression:     true,
+		PingInterval:    100 * time.Millisecond,
+	})
+
+	go func() {
+		for range client.Event() {
+		}
+	}()
+
+	time.Sleep(300 * time.Millisecond)
+
+	res, err := client.Call(g.Context(), "", "Browser.getVersion", nil)
+	g.E(err)
+	g.Has(string(res), "product")
+}
@@ -1,6 +1,8 @@
 package input
 
 import (
+	"unicode/utf8"
+
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/ysmood/gson"
 )
@@ -82,7 +84,12 @@ func (k Key) Printable() bool {
 
 // Modifier returns the modifier value of the key
 func (k Key) Modifier() int {
-	switch k.Info().KeyCode {
+	info, has := keyMap[k]
+	if !has {
+		return 0
+	}
+
+	switch info.KeyCode {
 	case 18:
 		return ModifierAlt
 	case 17:
@@ -97,12 +104,17 @@ func (k Key) Modifier() int {
 
 // Encode general key event
 func (k Key) Encode(t proto.InputDispatchKeyEventType, modifiers int) *proto.InputDispatchKeyEvent {
+	return encode(k.Info(), t, modifiers)
+}
+
+func encode(info KeyInfo, t proto.InputDispatchKeyEventType, modifiers int) *proto.InputDispatchKeyEvent {
+	printable := utf8.RuneCountInString(info.Key) == 1
+
 	tp := t
-	if t == proto.InputDispatchKeyEventTypeKeyDown && !k.Printable() {
+	if t == proto.InputDispatchKeyEventTypeKeyDown && !printable {
 		tp = proto.InputDispatchKeyEventTypeRawKeyDown
 	}
 
-	info := k.Info()
 	l := gson.Int(info.Location)
 	keypad := false
 	if info.Location == 3 {
@@ -111,7 +123,7 @@ func (k Key) Encode(t proto.InputDispatchKeyEventType, modifiers int) *proto.Inp
 	}
 
 	txt := ""
-	if k.Printable() {
+	if printable {
 		txt = info.Key
 	}
 
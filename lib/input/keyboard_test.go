@@ -125,6 +125,48 @@ func TestKeyEncode(t *testing.T) {
 	})
 }
 
+func TestLayout(t *testing.T) {
+	g := got.T(t)
+
+	l, has := input.GetLayout("de")
+	g.True(has)
+	g.Eq(l, input.LayoutDE)
+
+	g.Eq(input.LayoutDE.Info('z'), input.KeyInfo{Key: "z", Code: "KeyY", KeyCode: 90})
+	g.Eq(input.LayoutDE.Info('Ä'), input.KeyInfo{Key: "Ä", Code: "Quote", KeyCode: 222})
+	g.Eq(input.LayoutDE.Info('@'), input.KeyInfo{Key: "@", Code: "KeyQ", KeyCode: 81})
+	g.True(input.LayoutDE.AltGr('@'))
+	g.False(input.LayoutDE.AltGr('z'))
+	g.Eq(input.LayoutFR.Info('@').Code, "Digit0")
+	g.Eq(input.LayoutFR.Info('a'), input.KeyInfo{Key: "a", Code: "KeyQ", KeyCode: 65})
+	g.Eq(input.LayoutUS.Info('a'), input.KeyA.Info())
+	g.Eq(input.LayoutDE.Info(input.Enter), input.Enter.Info())
+
+	g.True(input.LayoutDE.Has('ö'))
+	g.True(input.LayoutDE.Has('A'))
+	g.False(input.LayoutUS.Has('ö'))
+
+	g.Eq(input.LayoutDE.Encode('ö', proto.InputDispatchKeyEventTypeKeyDown, 0), &proto.InputDispatchKeyEvent{
+		Type:                  "keyDown",
+		Text:                  "ö",
+		UnmodifiedText:        "ö",
+		Code:                  "Semicolon",
+		Key:                   "ö",
+		WindowsVirtualKeyCode: 192,
+		Location:              gson.Int(0),
+	})
+
+	custom := input.NewLayout("custom").AddKey("ñ", "Ñ", "Semicolon", 186)
+	l, has = input.GetLayout("custom")
+	g.True(has)
+	g.Eq(l, custom)
+	g.Eq(custom.Info('Ñ').Code, "Semicolon")
+	g.Eq(input.Key('ñ').Modifier(), 0)
+
+	_, has = input.GetLayout("not-exists")
+	g.False(has)
+}
+
 func TestMac(t *testing.T) {
 	g := got.T(t)
 
@@ -0,0 +1,176 @@
+package input
+
+import (
+	"sync"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// Layout of a keyboard, it maps the characters to the physical keys that produce them.
+// The characters that are not defined in a layout fall back to the US layout.
+type Layout struct {
+	Name string
+
+	keys  map[Key]KeyInfo
+	altGr map[Key]bool
+}
+
+var layouts = map[string]*Layout{}
+var layoutsLock sync.Mutex
+
+// NewLayout creates an empty layout and registers it with the name, use Layout.AddKey to define its keys.
+// If a layout with the same name exists it will be replaced.
+func NewLayout(name string) *Layout {
+	l := &Layout{Name: name, keys: map[Key]KeyInfo{}, altGr: map[Key]bool{}}
+
+	layoutsLock.Lock()
+	defer layoutsLock.Unlock()
+	layouts[name] = l
+
+	return l
+}
+
+// GetLayout returns the registered layout with the name
+func GetLayout(name string) (*Layout, bool) {
+	layoutsLock.Lock()
+	defer layoutsLock.Unlock()
+	l, has := layouts[name]
+	return l, has
+}
+
+// AddKey defines the characters that the physical key produces on the layout, without and with the shift key.
+// The code is the KeyboardEvent.code of the physical key, such as "KeyY" for the "z" on a German keyboard.
+// Leave the shiftedKey empty if the key doesn't produce a character with shift.
+func (l *Layout) AddKey(key string, shiftedKey string, code string, keyCode int) *Layout {
+	if r := []rune(key); len(r) == 1 {
+		l.keys[Key(r[0])] = KeyInfo{key, code, keyCode, 0}
+	}
+	if r := []rune(shiftedKey); len(r) == 1 {
+		l.keys[Key(r[0])] = KeyInfo{shiftedKey, code, keyCode, 0}
+	}
+	return l
+}
+
+// AddAltGrKey defines the character that the physical key produces with the AltGr key, such as "@" with "KeyQ"
+// on a German keyboard. The AltGr key itself isn't sent, only the code of the physical key is used.
+func (l *Layout) AddAltGrKey(key string, code string, keyCode int) *Layout {
+	if r := []rune(key); len(r) == 1 {
+		l.keys[Key(r[0])] = KeyInfo{key, code, keyCode, 0}
+		l.altGr[Key(r[0])] = true
+	}
+	return l
+}
+
+// AltGr returns true if the key is produced with the AltGr key on the layout
+func (l *Layout) AltGr(k Key) bool {
+	return l.altGr[k]
+}
+
+// Has returns true if the key can be encoded with the layout, including the fallback to the US layout
+func (l *Layout) Has(k Key) bool {
+	if _, has := l.keys[k]; has {
+		return true
+	}
+	if _, has := keyMap[k]; has {
+		return true
+	}
+	_, has := keyMapShifted[k]
+	return has
+}
+
+// Info of the key on the layout
+func (l *Layout) Info(k Key) KeyInfo {
+	if info, has := l.keys[k]; has {
+		return info
+	}
+	return k.Info()
+}
+
+// Encode key event with the layout, it's the same as Key.Encode except the key info comes from the layout.
+func (l *Layout) Encode(k Key, t proto.InputDispatchKeyEventType, modifiers int) *proto.InputDispatchKeyEvent {
+	return encode(l.Info(k), t, modifiers)
+}
+
+// Keyboard layouts
+var (
+	// LayoutUS is the default layout
+	LayoutUS = NewLayout("us")
+
+	// LayoutDE is the German QWERTZ layout
+	LayoutDE = NewLayout("de").
+			AddKey("^", "°", "Backquote", 220).
+			AddKey("1", "!", "Digit1", 49).
+			AddKey("2", `"`, "Digit2", 50).
+			AddKey("3", "§", "Digit3", 51).
+			AddKey("4", "$", "Digit4", 52).
+			AddKey("5", "%", "Digit5", 53).
+			AddKey("6", "&", "Digit6", 54).
+			AddKey("7", "/", "Digit7", 55).
+			AddKey("8", "(", "Digit8", 56).
+			AddKey("9", ")", "Digit9", 57).
+			AddKey("0", "=", "Digit0", 48).
+			AddKey("ß", "?", "Minus", 219).
+			AddKey("´", "`", "Equal", 221).
+			AddKey("z", "Z", "KeyY", 90).
+			AddKey("ü", "Ü", "BracketLeft", 186).
+			AddKey("+", "*", "BracketRight", 187).
+			AddKey("ö", "Ö", "Semicolon", 192).
+			AddKey("ä", "Ä", "Quote", 222).
+			AddKey("#", "'", "Backslash", 191).
+			AddKey("<", ">", "IntlBackslash", 226).
+			AddKey("y", "Y", "KeyZ", 89).
+			AddKey(",", ";", "Comma", 188).
+			AddKey(".", ":", "Period", 190).
+			AddKey("-", "_", "Slash", 189).
+			AddAltGrKey("@", "KeyQ", 81).
+			AddAltGrKey("€", "KeyE", 69).
+			AddAltGrKey("µ", "KeyM", 77).
+			AddAltGrKey("{", "Digit7", 55).
+			AddAltGrKey("[", "Digit8", 56).
+			AddAltGrKey("]", "Digit9", 57).
+			AddAltGrKey("}", "Digit0", 48).
+			AddAltGrKey(`\`, "Minus", 219).
+			AddAltGrKey("~", "BracketRight", 187).
+			AddAltGrKey("|", "IntlBackslash", 226)
+
+	// LayoutFR is the French AZERTY layout
+	LayoutFR = NewLayout("fr").
+			AddKey("²", "", "Backquote", 222).
+			AddKey("&", "1", "Digit1", 49).
+			AddKey("é", "2", "Digit2", 50).
+			AddKey(`"`, "3", "Digit3", 51).
+			AddKey("'", "4", "Digit4", 52).
+			AddKey("(", "5", "Digit5", 53).
+			AddKey("-", "6", "Digit6", 54).
+			AddKey("è", "7", "Digit7", 55).
+			AddKey("_", "8", "Digit8", 56).
+			AddKey("ç", "9", "Digit9", 57).
+			AddKey("à", "0", "Digit0", 48).
+			AddKey(")", "°", "Minus", 219).
+			AddKey("=", "+", "Equal", 187).
+			AddKey("a", "A", "KeyQ", 65).
+			AddKey("z", "Z", "KeyW", 90).
+			AddKey("$", "£", "BracketRight", 186).
+			AddKey("q", "Q", "KeyA", 81).
+			AddKey("m", "M", "Semicolon", 77).
+			AddKey("ù", "%", "Quote", 192).
+			AddKey("*", "µ", "Backslash", 220).
+			AddKey("<", ">", "IntlBackslash", 226).
+			AddKey("w", "W", "KeyZ", 87).
+			AddKey(",", "?", "KeyM", 188).
+			AddKey(";", ".", "Comma", 190).
+			AddKey(":", "/", "Period", 191).
+			AddKey("!", "§", "Slash", 223).
+			AddAltGrKey("~", "Digit2", 50).
+			AddAltGrKey("#", "Digit3", 51).
+			AddAltGrKey("{", "Digit4", 52).
+			AddAltGrKey("[", "Digit5", 53).
+			AddAltGrKey("|", "Digit6", 54).
+			AddAltGrKey("`", "Digit7", 55).
+			AddAltGrKey(`\`, "Digit8", 56).
+			AddAltGrKey("^", "Digit9", 57).
+			AddAltGrKey("@", "Digit0", 48).
+			AddAltGrKey("]", "Minus", 219).
+			AddAltGrKey("}", "Equal", 187).
+			AddAltGrKey("€", "KeyE", 69)
+)
@@ -37,6 +37,13 @@ var ElementR = &Function{
 	Dependencies: []*Function{Selectable, Text},
 }
 
+// ElementsR ...
+var ElementsR = &Function{
+	Name:         "elementsR",
+	Definition:   `function(e,t){var n=t.match(/(\/?)(.+)\1([a-z]*)/i),i=n[3]&&!/^(?!.*?(.).*?\1)[gmixXsuUAJ]+$/.test(n[3])?new RegExp(t):new RegExp(n[2],n[3]);const s=functions.selectable(this);return Array.from(s.querySelectorAll(e)).filter(e=>i.test(functions.text.call(e)))}`,
+	Dependencies: []*Function{Selectable, Text},
+}
+
 // Parents ...
 var Parents = &Function{
 	Name:         "parents",
@@ -108,6 +115,13 @@ var WaitIdle = &Function{
 	Dependencies: []*Function{},
 }
 
+// WaitMainThreadIdle ...
+var WaitMainThreadIdle = &Function{
+	Name:         "waitMainThreadIdle",
+	Definition:   `function(o){const r=performance.now(),e=PerformanceObserver.supportedEntryTypes||[];let n,t=!1;return e.includes("longtask")&&(n=new PerformanceObserver(()=>{t=!0})).observe({entryTypes:["longtask"]}),new Promise(i=>{let s=0;const u=e=>{n&&n.disconnect(),i(e)},c=e=>{if(s=t||e.didTimeout?0:s+1,t=!1,2<=s)return u(!0);e=o-(performance.now()-r);if(e<=0)return u(!1);window.requestIdleCallback(c,{timeout:e})};window.requestIdleCallback(c,{timeout:o})})}`,
+	Dependencies: []*Function{},
+}
+
 // WaitLoad ...
 var WaitLoad = &Function{
 	Name:         "waitLoad",
@@ -181,7 +195,7 @@ var Resource = &Function{
 // AddScriptTag ...
 var AddScriptTag = &Function{
 	Name:         "addScriptTag",
-	Definition:   `function(i,s,r){if(!document.getElementById(i))return new Promise((e,t)=>{var n=document.createElement("script");s?(n.src=s,n.onload=e):(n.type="text/javascript",n.text=r,e()),n.id=i,n.onerror=t,document.head.appendChild(n)})}`,
+	Definition:   `function(i,s,r,o){if(!document.getElementById(i))return new Promise((e,t)=>{var n=document.createElement("script");for(const c in o)o[c]&&n.setAttribute(c,o[c]);s?(n.src=s,n.onload=e):(n.type=n.type||"text/javascript",n.text=r,e()),n.id=i,n.onerror=t,document.head.appendChild(n)})}`,
 	Dependencies: []*Function{},
 }
 
@@ -192,6 +206,27 @@ var AddStyleTag = &Function{
 	Dependencies: []*Function{},
 }
 
+// SnapshotState ...
+var SnapshotState = &Function{
+	Name:         "snapshotState",
+	Definition:   `function(){const e=t=>{const n={};try{for(let e=0;e<t().length;e++){var o=t().key(e);n[o]=t().getItem(o)}}catch(e){}return n},n=[{index:-1,x:window.scrollX,y:window.scrollY}];document.querySelectorAll("*").forEach((e,t)=>{(e.scrollLeft||e.scrollTop)&&n.push({index:t,x:e.scrollLeft,y:e.scrollTop})});const t=[];return document.querySelectorAll("input, textarea, select").forEach((e,n)=>{"file"!==e.type&&t.push({index:n,value:e.value,checked:!!e.checked,selected:e.options?Array.from(e.options).map(e=>e.selected):null})}),{url:location.href,origin:location.origin,localStorage:e(()=>localStorage),sessionStorage:e(()=>sessionStorage),scrolls:n,fields:t}}`,
+	Dependencies: []*Function{},
+}
+
+// RestoreStorage ...
+var RestoreStorage = &Function{
+	Name:         "restoreStorage",
+	Definition:   `function(e,t,o){var n;location.origin===e&&((n=(t,e)=>{try{t().clear(),Object.entries(e||{}).forEach(([e,o])=>t().setItem(e,o))}catch(e){}})(()=>localStorage,t),n(()=>sessionStorage,o))}`,
+	Dependencies: []*Function{},
+}
+
+// RestoreState ...
+var RestoreState = &Function{
+	Name:         "restoreState",
+	Definition:   `function(e,t){const o=document.querySelectorAll("input, textarea, select"),n=((t||[]).forEach(t=>{const e=o[t.index];e&&(t.selected&&e.options?Array.from(e.options).forEach((e,o)=>e.selected=!!t.selected[o]):"checkbox"===e.type||"radio"===e.type?e.checked=t.checked:e.value=t.value,functions.inputEvent.call(e))}),document.querySelectorAll("*"));(e||[]).forEach(e=>{if(e.index<0)return window.scrollTo(e.x,e.y);const t=n[e.index];t&&(t.scrollLeft=e.x,t.scrollTop=e.y)})}`,
+	Dependencies: []*Function{InputEvent},
+}
+
 // Selectable ...
 var Selectable = &Function{
 	Name:         "selectable",
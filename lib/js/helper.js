@@ -52,6 +52,19 @@ const functions = {
     return el ? el : null
   },
 
+  elementsR(selector, regex) {
+    var reg
+    var m = regex.match(/(\/?)(.+)\1([a-z]*)/i)
+    if (m[3] && !/^(?!.*?(.).*?\1)[gmixXsuUAJ]+$/.test(m[3]))
+      reg = new RegExp(regex)
+    else reg = new RegExp(m[2], m[3])
+
+    const s = functions.selectable(this)
+    return Array.from(s.querySelectorAll(selector)).filter((e) =>
+      reg.test(functions.text.call(e))
+    )
+  },
+
   parents(selector) {
     let p = this.parentElement
     const list = []
@@ -194,6 +207,39 @@ const functions = {
     })
   },
 
+  waitMainThreadIdle(budget) {
+    const start = performance.now()
+    const types = PerformanceObserver.supportedEntryTypes || []
+    let busy = false
+    let observer
+    if (types.includes('longtask')) {
+      observer = new PerformanceObserver(() => {
+        busy = true
+      })
+      observer.observe({ entryTypes: ['longtask'] })
+    }
+
+    return new Promise((resolve) => {
+      let calm = 0
+      const done = (idle) => {
+        observer && observer.disconnect()
+        resolve(idle)
+      }
+      const check = (deadline) => {
+        calm = busy || deadline.didTimeout ? 0 : calm + 1
+        busy = false
+
+        // two idle periods in a row without any long task between them
+        if (calm >= 2) return done(true)
+
+        const left = budget - (performance.now() - start)
+        if (left <= 0) return done(false)
+        window.requestIdleCallback(check, { timeout: left })
+      }
+      window.requestIdleCallback(check, { timeout: budget })
+    })
+  },
+
   waitLoad() {
     const isWin = this === window
     return new Promise((resolve, reject) => {
@@ -331,17 +377,20 @@ const functions = {
     })
   },
 
-  addScriptTag(id, url, content) {
+  addScriptTag(id, url, content, attrs) {
     if (document.getElementById(id)) return
 
     return new Promise((resolve, reject) => {
       var s = document.createElement('script')
 
+      // such as the type, nonce, integrity and crossorigin
+      for (const k in attrs) if (attrs[k]) s.setAttribute(k, attrs[k])
+
       if (url) {
         s.src = url
         s.onload = resolve
       } else {
-        s.type = 'text/javascript'
+        s.type = s.type || 'text/javascript'
         s.text = content
         resolve()
       }
@@ -376,6 +425,85 @@ const functions = {
     })
   },
 
+  snapshotState() {
+    const dump = (storage) => {
+      const dict = {}
+      try {
+        for (let i = 0; i < storage().length; i++) {
+          const key = storage().key(i)
+          dict[key] = storage().getItem(key)
+        }
+      } catch (e) {} // storage is not accessible, such as "about:blank"
+      return dict
+    }
+
+    const scrolls = [{ index: -1, x: window.scrollX, y: window.scrollY }]
+    document.querySelectorAll('*').forEach((el, index) => {
+      if (el.scrollLeft || el.scrollTop) {
+        scrolls.push({ index, x: el.scrollLeft, y: el.scrollTop })
+      }
+    })
+
+    const fields = []
+    document.querySelectorAll('input, textarea, select').forEach((el, index) => {
+      if (el.type === 'file') return
+      fields.push({
+        index,
+        value: el.value,
+        checked: !!el.checked,
+        selected: el.options ? Array.from(el.options).map((o) => o.selected) : null
+      })
+    })
+
+    return {
+      url: location.href,
+      origin: location.origin,
+      localStorage: dump(() => localStorage),
+      sessionStorage: dump(() => sessionStorage),
+      scrolls,
+      fields
+    }
+  },
+
+  restoreStorage(origin, local, session) {
+    if (location.origin !== origin) return
+
+    const load = (storage, dict) => {
+      try {
+        storage().clear()
+        Object.entries(dict || {}).forEach(([k, v]) => storage().setItem(k, v))
+      } catch (e) {} // storage is not accessible
+    }
+
+    load(() => localStorage, local)
+    load(() => sessionStorage, session)
+  },
+
+  restoreState(scrolls, fields) {
+    const list = document.querySelectorAll('input, textarea, select')
+    ;(fields || []).forEach((f) => {
+      const el = list[f.index]
+      if (!el) return
+      if (f.selected && el.options) {
+        Array.from(el.options).forEach((o, i) => (o.selected = !!f.selected[i]))
+      } else if (el.type === 'checkbox' || el.type === 'radio') {
+        el.checked = f.checked
+      } else {
+        el.value = f.value
+      }
+      functions.inputEvent.call(el)
+    })
+
+    const all = document.querySelectorAll('*')
+    ;(scrolls || []).forEach((s) => {
+      if (s.index < 0) return window.scrollTo(s.x, s.y)
+      const el = all[s.index]
+      if (!el) return
+      el.scrollLeft = s.x
+      el.scrollTop = s.y
+    })
+  },
+
   selectable(s) {
     return s.querySelector ? s : document
   },
@@ -5,8 +5,8 @@ type Function struct {
 	// Name must be unique and not conflict with the function names in "helper.js"
 	Name string
 
-	// Definition holds the code of a js function from "helper.js",
-	// the js code is compressed by uglify-js.
+	// Definition holds the code of a js function, such as the ones from "helper.js"
+	// compressed by uglify-js, or the user-defined ones used via rod.EvalHelper .
 	Definition string
 
 	// Dependencies will be preloaded and assigned to the global js object "functions"
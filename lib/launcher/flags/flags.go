@@ -18,6 +18,9 @@ const (
 	// RemoteDebuggingPort flag
 	RemoteDebuggingPort Flag = "remote-debugging-port"
 
+	// RemoteDebuggingPipe flag, the browser will use the fd 3 and 4 to communicate instead of a port
+	RemoteDebuggingPipe Flag = "remote-debugging-pipe"
+
 	// NoSandbox flag
 	NoSandbox Flag = "no-sandbox"
 
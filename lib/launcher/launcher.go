@@ -13,6 +13,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/defaults"
 	"github.com/go-rod/rod/lib/launcher/flags"
 	"github.com/go-rod/rod/lib/utils"
@@ -407,6 +408,66 @@ func (l *Launcher) Launch() (string, error) {
 	return ResolveURL(u)
 }
 
+// ErrPipeNotSupported is returned by Launcher.LaunchPipe when the platform doesn't support it
+var ErrPipeNotSupported = errors.New("remote debugging pipe isn't supported on this platform")
+
+// LaunchPipe launches a standalone temp browser instance with the --remote-debugging-pipe flag and returns the pipe
+// to control it, use it like:
+//     client := cdp.New().Start(pipe)
+//     browser := rod.New().Client(client).MustConnect()
+// No port will be opened, so it avoids the port conflicts and the websocket proxy issues.
+// The RemoteDebuggingPort flag will be removed, and leakless isn't supported in this mode.
+// It's not supported on Windows.
+func (l *Launcher) LaunchPipe() (*cdp.Pipe, error) {
+	defer l.ctxCancel()
+
+	if !pipeSupported {
+		return nil, ErrPipeNotSupported
+	}
+
+	bin, err := l.getBin()
+	if err != nil {
+		return nil, err
+	}
+
+	l.Delete(flags.RemoteDebuggingPort)
+	l.Set(flags.RemoteDebuggingPipe)
+
+	// the browser reads from the fd 3 and writes to the fd 4
+	browserIn, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	r, browserOut, err := os.Pipe()
+	if err != nil {
+		_ = browserIn.Close()
+		_ = w.Close()
+		return nil, err
+	}
+
+	cmd := exec.Command(bin, l.FormatArgs()...)
+	l.setupCmd(cmd)
+	cmd.ExtraFiles = []*os.File{browserIn, browserOut}
+
+	err = cmd.Start()
+	_ = browserIn.Close()
+	_ = browserOut.Close()
+	if err != nil {
+		_ = w.Close()
+		_ = r.Close()
+		return nil, err
+	}
+
+	l.pid = cmd.Process.Pid
+
+	go func() {
+		_ = cmd.Wait()
+		close(l.exit)
+	}()
+
+	return cdp.NewPipe(w, r), nil
+}
+
 func (l *Launcher) setupCmd(cmd *exec.Cmd) {
 	l.osSetupCmd(cmd)
 
@@ -15,6 +15,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/defaults"
 	"github.com/go-rod/rod/lib/launcher"
 	"github.com/go-rod/rod/lib/launcher/flags"
@@ -127,6 +128,24 @@ func TestLaunch(t *testing.T) {
 	}
 }
 
+func TestLaunchPipe(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New()
+	defer l.Kill()
+
+	pipe, err := l.LaunchPipe()
+	g.E(err)
+	g.False(l.Has(flags.RemoteDebuggingPort))
+
+	client := cdp.New().Start(pipe)
+	res, err := client.Call(g.Context(), "", "Browser.getVersion", nil)
+	g.E(err)
+	g.Has(string(res), "protocolVersion")
+
+	g.E(pipe.Close())
+}
+
 func TestLaunchUserMode(t *testing.T) {
 	g := setup(t)
 
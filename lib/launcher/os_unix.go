@@ -9,6 +9,8 @@ import (
 	"github.com/go-rod/rod/lib/launcher/flags"
 )
 
+const pipeSupported = true
+
 func killGroup(pid int) {
 	_ = syscall.Kill(-pid, syscall.SIGKILL)
 }
@@ -7,6 +7,9 @@ import (
 	"syscall"
 )
 
+// os/exec doesn't support passing the extra files on Windows
+const pipeSupported = false
+
 func killGroup(pid int) {
 	terminateProcess(pid)
 }
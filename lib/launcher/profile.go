@@ -0,0 +1,190 @@
+package launcher
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-rod/rod/lib/launcher/flags"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// ProfileSkip is the list of file or folder names that will be skipped by SnapshotProfile,
+// they are either the locks of the running browser or the caches that can be regenerated.
+var ProfileSkip = []string{
+	"SingletonLock", "SingletonSocket", "SingletonCookie", "lockfile", "DevToolsActivePort",
+	"Cache", "Code Cache", "GPUCache", "ShaderCache", "GrShaderCache", "GraphiteDawnCache",
+	"DawnCache", "Crashpad", "BrowserMetrics", "Crash Reports",
+}
+
+// SnapshotProfile archives the user data dir into w as zip, such as the cookies, local storage and extensions.
+// The browser should be closed before the snapshot, or the latest state may not be flushed to the disk yet.
+// Use RestoreProfile to extract it later.
+func SnapshotProfile(dir string, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	skip := map[string]bool{}
+	for _, name := range ProfileSkip {
+		skip[name] = true
+	}
+
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if p == dir {
+			return nil
+		}
+
+		if skip[info.Name()] {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// such as the sockets and the symlinks
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		header.Method = zip.Deflate
+		if info.IsDir() {
+			header.Name += "/"
+			_, err = zw.CreateHeader(header)
+			return err
+		}
+
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+
+		_, err = io.Copy(fw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// RestoreProfile extracts the archive created by SnapshotProfile to the user data dir,
+// the existing files with the same names will be overwritten.
+func RestoreProfile(dir string, r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	err = utils.Mkdir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range zr.File {
+		p := filepath.Join(dir, filepath.FromSlash(f.Name))
+
+		// prevent the entries from escaping the dir
+		rel, err := filepath.Rel(dir, p)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("invalid profile entry: %s", f.Name)
+		}
+
+		if f.FileInfo().IsDir() {
+			err = utils.Mkdir(p)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		err = utils.Mkdir(filepath.Dir(p))
+		if err != nil {
+			return err
+		}
+
+		err = extractProfileFile(f, p)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func extractProfileFile(f *zip.File, p string) error {
+	r, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	dst, err := os.OpenFile(p, os.O_RDWR|os.O_CREATE|os.O_TRUNC, f.Mode())
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(dst, r)
+	if err != nil {
+		_ = dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// SnapshotProfile is similar to the SnapshotProfile, it uses the Launcher.UserDataDir as the dir.
+// Call it after the browser exits and before the Launcher.Cleanup, such as:
+//     browser.MustClose()
+//     l.MustSnapshotProfile(file)
+//     l.Cleanup()
+func (l *Launcher) SnapshotProfile(w io.Writer) error {
+	return SnapshotProfile(l.Get(flags.UserDataDir), w)
+}
+
+// MustSnapshotProfile is similar to Launcher.SnapshotProfile
+func (l *Launcher) MustSnapshotProfile(w io.Writer) *Launcher {
+	utils.E(l.SnapshotProfile(w))
+	return l
+}
+
+// RestoreProfile is similar to the RestoreProfile, it uses the Launcher.UserDataDir as the dir.
+// Call it before the Launcher.Launch to relaunch the browser from the archive, such as:
+//     u := launcher.New().MustRestoreProfile(file).MustLaunch()
+func (l *Launcher) RestoreProfile(r io.Reader) error {
+	return RestoreProfile(l.Get(flags.UserDataDir), r)
+}
+
+// MustRestoreProfile is similar to Launcher.RestoreProfile
+func (l *Launcher) MustRestoreProfile(r io.Reader) *Launcher {
+	utils.E(l.RestoreProfile(r))
+	return l
+}
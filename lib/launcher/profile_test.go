@@ -0,0 +1,77 @@
+package launcher_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/launcher/flags"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+func TestProfileSnapshot(t *testing.T) {
+	g := setup(t)
+
+	from := filepath.Join(t.TempDir(), "from")
+	g.E(utils.OutputFile(filepath.Join(from, "Default", "Cookies"), "cookies"))
+	g.E(utils.OutputFile(filepath.Join(from, "Default", "Local Storage", "leveldb", "000003.log"), "storage"))
+	g.E(utils.OutputFile(filepath.Join(from, "Default", "Cache", "data_0"), "cache"))
+	g.E(utils.OutputFile(filepath.Join(from, "DevToolsActivePort"), "9222"))
+	g.E(utils.Mkdir(filepath.Join(from, "Default", "Extensions")))
+
+	buf := bytes.NewBuffer(nil)
+	l := launcher.New().UserDataDir(from).MustSnapshotProfile(buf)
+	g.Eq(l.Get(flags.UserDataDir), from)
+
+	to := filepath.Join(t.TempDir(), "to")
+	l = launcher.New().UserDataDir(to).MustRestoreProfile(bytes.NewReader(buf.Bytes()))
+
+	cookies, err := utils.ReadString(filepath.Join(to, "Default", "Cookies"))
+	g.E(err)
+	g.Eq(cookies, "cookies")
+	storage, err := utils.ReadString(filepath.Join(to, "Default", "Local Storage", "leveldb", "000003.log"))
+	g.E(err)
+	g.Eq(storage, "storage")
+	info, err := os.Stat(filepath.Join(to, "Default", "Extensions"))
+	g.E(err)
+	g.True(info.IsDir())
+	_, err = os.Stat(filepath.Join(to, "Default", "Cache"))
+	g.True(os.IsNotExist(err))
+	g.False(utils.FileExists(filepath.Join(to, "DevToolsActivePort")))
+
+	g.Err(launcher.SnapshotProfile(filepath.Join(from, "not-exists"), buf))
+	g.Err(launcher.RestoreProfile(to, bytes.NewBufferString("not zip")))
+
+	g.Panic(func() {
+		l.MustSnapshotProfile(&MockWriter{})
+	})
+	g.Panic(func() {
+		l.MustRestoreProfile(bytes.NewBufferString("not zip"))
+	})
+}
+
+func TestRestoreProfileEscape(t *testing.T) {
+	g := setup(t)
+
+	buf := bytes.NewBuffer(nil)
+	zw := zip.NewWriter(buf)
+	_, err := zw.Create("../escape")
+	g.E(err)
+	g.E(zw.Close())
+
+	dir := t.TempDir()
+	err = launcher.RestoreProfile(filepath.Join(dir, "profile"), buf)
+	g.Eq(err.Error(), "invalid profile entry: ../escape")
+
+	_, err = os.Stat(filepath.Join(dir, "escape"))
+	g.True(os.IsNotExist(err))
+}
+
+type MockWriter struct{}
+
+func (w *MockWriter) Write([]byte) (int, error) {
+	return 0, os.ErrClosed
+}
@@ -0,0 +1,176 @@
+// A fluent builder for the NetworkCookieParam
+// NetworkCookieParam 的流式构建器
+
+package proto
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// cookieMaxAge is the max lifetime of a cookie, Chrome clamps the expires to it
+// cookieMaxAge 是 Cookie 的最长有效期，Chrome 会将过期时间限制在它以内
+const cookieMaxAge = 400 * 24 * time.Hour
+
+// CookieBuilder builds a NetworkCookieParam, such as:
+//     proto.NewCookie("token", "xxx").WithURL("https://example.com").HTTPOnly().SameSiteLax().Expires(time.Hour)
+// CookieBuilder 用于构建 NetworkCookieParam
+type CookieBuilder struct {
+	param *NetworkCookieParam
+}
+
+// NewCookie creates a CookieBuilder with the name and value
+// NewCookie 使用 name 和 value 创建一个 CookieBuilder
+func NewCookie(name, value string) *CookieBuilder {
+	return &CookieBuilder{param: &NetworkCookieParam{Name: name, Value: value}}
+}
+
+// WithURL sets the url to associate with the cookie, the default domain, path and source scheme
+// will be derived from it
+// WithURL 设置与 Cookie 关联的 url，默认的域名、路径和源 scheme 将从它推导
+func (c *CookieBuilder) WithURL(u string) *CookieBuilder {
+	c.param.URL = u
+	return c
+}
+
+// WithDomain sets the domain of the cookie
+// WithDomain 设置 Cookie 的域名
+func (c *CookieBuilder) WithDomain(domain string) *CookieBuilder {
+	c.param.Domain = domain
+	return c
+}
+
+// WithPath sets the path of the cookie
+// WithPath 设置 Cookie 的路径
+func (c *CookieBuilder) WithPath(path string) *CookieBuilder {
+	c.param.Path = path
+	return c
+}
+
+// Secure marks the cookie as secure
+// Secure 将 Cookie 标记为 secure
+func (c *CookieBuilder) Secure() *CookieBuilder {
+	c.param.Secure = true
+	return c
+}
+
+// HTTPOnly marks the cookie as http only
+// HTTPOnly 将 Cookie 标记为 http only
+func (c *CookieBuilder) HTTPOnly() *CookieBuilder {
+	c.param.HTTPOnly = true
+	return c
+}
+
+// SameSiteStrict sets the SameSite of the cookie to Strict
+// SameSiteStrict 将 Cookie 的 SameSite 设置为 Strict
+func (c *CookieBuilder) SameSiteStrict() *CookieBuilder {
+	c.param.SameSite = NetworkCookieSameSiteStrict
+	return c
+}
+
+// SameSiteLax sets the SameSite of the cookie to Lax
+// SameSiteLax 将 Cookie 的 SameSite 设置为 Lax
+func (c *CookieBuilder) SameSiteLax() *CookieBuilder {
+	c.param.SameSite = NetworkCookieSameSiteLax
+	return c
+}
+
+// SameSiteNone sets the SameSite of the cookie to None, the cookie must be secure
+// SameSiteNone 将 Cookie 的 SameSite 设置为 None，Cookie 必须是 secure 的
+func (c *CookieBuilder) SameSiteNone() *CookieBuilder {
+	c.param.SameSite = NetworkCookieSameSiteNone
+	return c
+}
+
+// Expires sets the cookie to expire after the duration from now
+// Expires 设置 Cookie 在从现在起经过 d 之后过期
+func (c *CookieBuilder) Expires(d time.Duration) *CookieBuilder {
+	return c.ExpiresAt(time.Now().Add(d))
+}
+
+// ExpiresAt sets the cookie to expire at the time
+// ExpiresAt 设置 Cookie 在指定时间过期
+func (c *CookieBuilder) ExpiresAt(t time.Time) *CookieBuilder {
+	c.param.Expires = TimeSinceEpoch(float64(t.UnixNano()) / float64(time.Second))
+	return c
+}
+
+// Partitioned makes the cookie partitioned (CHIPS) by the top-level site, such as "https://example.com".
+// Partitioned 使 Cookie 按顶级站点分区 (CHIPS)，例如 "https://example.com"。
+func (c *CookieBuilder) Partitioned(topLevelSite string) *CookieBuilder {
+	c.param.PartitionKey = topLevelSite
+	return c
+}
+
+// Param returns the built NetworkCookieParam, use CookieBuilder.Warnings to check it before setting it
+// Param 返回构建好的 NetworkCookieParam，在设置它之前使用 CookieBuilder.Warnings 检查它
+func (c *CookieBuilder) Param() *NetworkCookieParam {
+	return c.param
+}
+
+// Warnings returns the problems of the cookie that Chrome will silently reject or alter, it's empty if the cookie is fine
+// Warnings 返回 Cookie 中会被 Chrome 静默拒绝或修改的问题，如果 Cookie 没有问题则为空
+func (c *CookieBuilder) Warnings() []string {
+	p := c.param
+	list := []string{}
+
+	if p.URL == "" && p.Domain == "" {
+		list = append(list, "either the url or the domain must be set")
+	}
+
+	if p.SameSite == NetworkCookieSameSiteNone && !p.Secure {
+		list = append(list, "SameSite=None requires the cookie to be secure")
+	}
+
+	if p.PartitionKey != "" && !p.Secure {
+		list = append(list, "partitioned cookie requires the cookie to be secure")
+	}
+
+	if p.Secure && p.URL != "" {
+		if u, err := url.Parse(p.URL); err == nil && u.Scheme == "http" &&
+			u.Hostname() != "localhost" && u.Hostname() != "127.0.0.1" {
+			list = append(list, "secure cookie can't be set from the insecure url: "+p.URL)
+		}
+	}
+
+	if strings.HasPrefix(p.Name, "__Secure-") && !p.Secure {
+		list = append(list, "the __Secure- prefix requires the cookie to be secure")
+	}
+
+	if strings.HasPrefix(p.Name, "__Host-") {
+		if !p.Secure {
+			list = append(list, "the __Host- prefix requires the cookie to be secure")
+		}
+		if p.Domain != "" {
+			list = append(list, "the __Host- prefix requires the domain to be empty")
+		}
+		if p.Path != "/" && !(p.Path == "" && c.urlPath() == "/") {
+			list = append(list, `the __Host- prefix requires the path to be "/"`)
+		}
+	}
+
+	if p.Expires > 0 {
+		t := p.Expires.Time()
+		if t.Before(time.Now()) {
+			list = append(list, "the expires is in the past, the cookie will be deleted")
+		} else if time.Until(t) > cookieMaxAge {
+			list = append(list, "the expires is more than 400 days later, it will be clamped")
+		}
+	}
+
+	return list
+}
+
+// the default path derived from the url
+// 从 url 推导出的默认路径
+func (c *CookieBuilder) urlPath() string {
+	u, err := url.Parse(c.param.URL)
+	if err != nil || u.Path == "" {
+		return "/"
+	}
+	if i := strings.LastIndex(u.Path, "/"); i > 0 {
+		return u.Path[:i]
+	}
+	return "/"
+}
@@ -0,0 +1,72 @@
+package proto_test
+
+import (
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func (t T) CookieBuilder() {
+	c := proto.NewCookie("a", "1").
+		WithURL("https://example.com/p").
+		WithDomain("example.com").
+		WithPath("/").
+		Secure().
+		HTTPOnly().
+		SameSiteLax().
+		Expires(time.Hour).
+		Partitioned("https://example.com")
+
+	p := c.Param()
+	t.Eq(p.Name, "a")
+	t.Eq(p.Value, "1")
+	t.Eq(p.URL, "https://example.com/p")
+	t.Eq(p.Domain, "example.com")
+	t.Eq(p.Path, "/")
+	t.True(p.Secure)
+	t.True(p.HTTPOnly)
+	t.Eq(p.SameSite, proto.NetworkCookieSameSiteLax)
+	t.Eq(p.PartitionKey, "https://example.com")
+	t.Lt(time.Until(p.Expires.Time())-time.Hour, time.Second)
+	t.Len(c.Warnings(), 0)
+
+	t.Eq(proto.NewCookie("a", "1").SameSiteStrict().Param().SameSite, proto.NetworkCookieSameSiteStrict)
+
+	at := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+	t.Eq(proto.NewCookie("a", "1").ExpiresAt(at).Param().Expires, proto.TimeSinceEpoch(at.Unix()))
+}
+
+func (t T) CookieBuilderWarnings() {
+	warn := func(c *proto.CookieBuilder) []string {
+		return c.Warnings()
+	}
+
+	t.Eq(warn(proto.NewCookie("a", "1")), []string{"either the url or the domain must be set"})
+
+	t.Eq(warn(proto.NewCookie("a", "1").WithDomain("x.com").SameSiteNone()),
+		[]string{"SameSite=None requires the cookie to be secure"})
+
+	t.Eq(warn(proto.NewCookie("a", "1").WithDomain("x.com").Partitioned("https://x.com")),
+		[]string{"partitioned cookie requires the cookie to be secure"})
+
+	t.Eq(warn(proto.NewCookie("a", "1").WithURL("http://x.com").Secure()),
+		[]string{"secure cookie can't be set from the insecure url: http://x.com"})
+	t.Len(warn(proto.NewCookie("a", "1").WithURL("http://localhost:8080").Secure()), 0)
+
+	t.Eq(warn(proto.NewCookie("__Secure-a", "1").WithDomain("x.com")),
+		[]string{"the __Secure- prefix requires the cookie to be secure"})
+
+	t.Eq(warn(proto.NewCookie("__Host-a", "1").WithDomain("x.com").WithPath("/p")), []string{
+		"the __Host- prefix requires the cookie to be secure",
+		"the __Host- prefix requires the domain to be empty",
+		`the __Host- prefix requires the path to be "/"`,
+	})
+	t.Len(warn(proto.NewCookie("__Host-a", "1").WithURL("https://x.com/p").Secure()), 0)
+	t.Len(warn(proto.NewCookie("__Host-a", "1").WithURL("https://x.com/a/b").Secure()), 1)
+
+	t.Eq(warn(proto.NewCookie("a", "1").WithDomain("x.com").Expires(-time.Hour)),
+		[]string{"the expires is in the past, the cookie will be deleted"})
+
+	t.Eq(warn(proto.NewCookie("a", "1").WithDomain("x.com").Expires(500*24*time.Hour)),
+		[]string{"the expires is more than 400 days later, it will be clamped"})
+}
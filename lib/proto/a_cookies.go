@@ -0,0 +1,274 @@
+// Converters between the cookies of the browser and the common cookie formats
+// 浏览器的 Cookie 与常见 Cookie 格式之间的转换器
+
+package proto
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const httpOnlyPrefix = "#HttpOnly_"
+
+// CookiesToNetscape encodes the cookies to the Netscape cookies.txt format.
+// If curl is true, the domains of the HttpOnly cookies will be prefixed with "#HttpOnly_",
+// which is the extension used by curl's cookie jar, or the HttpOnly flag will be lost.
+// CookiesToNetscape 将 Cookie 编码为 Netscape cookies.txt 格式。
+// 如果 curl 为 true，HttpOnly Cookie 的域名将带上 "#HttpOnly_" 前缀，这是 curl 的 cookie jar 使用的扩展，
+// 否则 HttpOnly 标记会丢失。
+func CookiesToNetscape(cookies []*NetworkCookie, curl bool) string {
+	var b strings.Builder
+	b.WriteString("# Netscape HTTP Cookie File\n")
+
+	for _, c := range cookies {
+		domain := c.Domain
+		if c.HTTPOnly && curl {
+			domain = httpOnlyPrefix + domain
+		}
+
+		expires := int64(0)
+		if !c.Session && c.Expires > 0 {
+			expires = int64(c.Expires)
+		}
+
+		fmt.Fprintf(&b, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			domain, netscapeBool(strings.HasPrefix(c.Domain, ".")), c.Path,
+			netscapeBool(c.Secure), expires, c.Name, c.Value)
+	}
+
+	return b.String()
+}
+
+// CookiesFromNetscape decodes the Netscape cookies.txt format, the curl's "#HttpOnly_" extension is supported
+// CookiesFromNetscape 解码 Netscape cookies.txt 格式，支持 curl 的 "#HttpOnly_" 扩展
+func CookiesFromNetscape(text string) ([]*NetworkCookieParam, error) {
+	list := []*NetworkCookieParam{}
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		httpOnly := strings.HasPrefix(line, httpOnlyPrefix)
+		if httpOnly {
+			line = strings.TrimPrefix(line, httpOnlyPrefix)
+		} else if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) < 6 {
+			return nil, fmt.Errorf("invalid netscape cookie at line %d", n)
+		}
+		if len(fields) == 6 {
+			fields = append(fields, "")
+		}
+
+		expires, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expires of netscape cookie at line %d: %w", n, err)
+		}
+
+		domain := fields[0]
+		if fields[1] == "TRUE" && !strings.HasPrefix(domain, ".") {
+			domain = "." + domain
+		}
+
+		list = append(list, &NetworkCookieParam{
+			Name:     fields[5],
+			Value:    fields[6],
+			Domain:   domain,
+			Path:     fields[2],
+			Secure:   fields[3] == "TRUE",
+			HTTPOnly: httpOnly,
+			Expires:  TimeSinceEpoch(expires),
+		})
+	}
+
+	return list, scanner.Err()
+}
+
+func netscapeBool(b bool) string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+// PlaywrightCookie is the cookie format used by Playwright's storage state
+// PlaywrightCookie 是 Playwright 的 storage state 使用的 Cookie 格式
+type PlaywrightCookie struct {
+	Name     string  `json:"name"`
+	Value    string  `json:"value"`
+	Domain   string  `json:"domain"`
+	Path     string  `json:"path"`
+	Expires  float64 `json:"expires"`
+	HTTPOnly bool    `json:"httpOnly"`
+	Secure   bool    `json:"secure"`
+	SameSite string  `json:"sameSite"`
+}
+
+// CookiesToPlaywright encodes the cookies to the storage state JSON of Playwright, such as:
+//     {"cookies": [{"name": "a", ...}], "origins": []}
+// CookiesToPlaywright 将 Cookie 编码为 Playwright 的 storage state JSON。
+func CookiesToPlaywright(cookies []*NetworkCookie) ([]byte, error) {
+	list := []*PlaywrightCookie{}
+	for _, c := range cookies {
+		expires := float64(-1)
+		if !c.Session {
+			expires = float64(c.Expires)
+		}
+
+		sameSite := string(c.SameSite)
+		if sameSite == "" {
+			sameSite = string(NetworkCookieSameSiteLax)
+		}
+
+		list = append(list, &PlaywrightCookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Domain:   c.Domain,
+			Path:     c.Path,
+			Expires:  expires,
+			HTTPOnly: c.HTTPOnly,
+			Secure:   c.Secure,
+			SameSite: sameSite,
+		})
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"cookies": list,
+		"origins": []interface{}{},
+	}, "", "  ")
+}
+
+// CookiesFromPlaywright decodes the storage state JSON of Playwright, a plain JSON array of cookies is also accepted
+// CookiesFromPlaywright 解码 Playwright 的 storage state JSON，也接受普通的 Cookie JSON 数组
+func CookiesFromPlaywright(data []byte) ([]*NetworkCookieParam, error) {
+	var state struct {
+		Cookies []*PlaywrightCookie `json:"cookies"`
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		if err := json.Unmarshal(data, &state.Cookies); err != nil {
+			return nil, err
+		}
+	} else if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+
+	list := []*NetworkCookieParam{}
+	for _, c := range state.Cookies {
+		param := &NetworkCookieParam{
+			Name:     c.Name,
+			Value:    c.Value,
+			Domain:   c.Domain,
+			Path:     c.Path,
+			HTTPOnly: c.HTTPOnly,
+			Secure:   c.Secure,
+			SameSite: NetworkCookieSameSite(c.SameSite),
+		}
+		if c.Expires > 0 {
+			param.Expires = TimeSinceEpoch(c.Expires)
+		}
+		list = append(list, param)
+	}
+	return list, nil
+}
+
+// CookiesToHTTP converts the cookies to net/http cookies
+// CookiesToHTTP 将 Cookie 转换为 net/http 的 Cookie
+func CookiesToHTTP(cookies []*NetworkCookie) []*http.Cookie {
+	list := []*http.Cookie{}
+	for _, c := range cookies {
+		hc := &http.Cookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Domain:   c.Domain,
+			Path:     c.Path,
+			Secure:   c.Secure,
+			HttpOnly: c.HTTPOnly,
+		}
+		if !c.Session && c.Expires > 0 {
+			hc.Expires = c.Expires.Time()
+		}
+		switch c.SameSite {
+		case NetworkCookieSameSiteStrict:
+			hc.SameSite = http.SameSiteStrictMode
+		case NetworkCookieSameSiteLax:
+			hc.SameSite = http.SameSiteLaxMode
+		case NetworkCookieSameSiteNone:
+			hc.SameSite = http.SameSiteNoneMode
+		}
+		list = append(list, hc)
+	}
+	return list
+}
+
+// CookiesFromHTTP converts the net/http cookies to the cookie params, the u is used for the cookies
+// that don't have the domain, such as the cookies from http.Response.Cookies .
+// CookiesFromHTTP 将 net/http 的 Cookie 转换为 Cookie 参数，对于没有域名的 Cookie，例如 http.Response.Cookies 的返回值，
+// 会使用 u 。
+func CookiesFromHTTP(u string, cookies []*http.Cookie) []*NetworkCookieParam {
+	list := []*NetworkCookieParam{}
+	for _, c := range cookies {
+		param := &NetworkCookieParam{
+			Name:     c.Name,
+			Value:    c.Value,
+			Domain:   c.Domain,
+			Path:     c.Path,
+			Secure:   c.Secure,
+			HTTPOnly: c.HttpOnly,
+		}
+		if c.Domain == "" {
+			param.URL = u
+		}
+		if c.MaxAge > 0 {
+			param.Expires = TimeSinceEpoch(time.Now().Add(time.Duration(c.MaxAge) * time.Second).Unix())
+		} else if !c.Expires.IsZero() {
+			param.Expires = TimeSinceEpoch(c.Expires.Unix())
+		}
+		switch c.SameSite {
+		case http.SameSiteStrictMode:
+			param.SameSite = NetworkCookieSameSiteStrict
+		case http.SameSiteLaxMode:
+			param.SameSite = NetworkCookieSameSiteLax
+		case http.SameSiteNoneMode:
+			param.SameSite = NetworkCookieSameSiteNone
+		}
+		list = append(list, param)
+	}
+	return list
+}
+
+// NewCookieJar creates a net/http cookie jar that contains the cookies, it can be used by http.Client
+// to send requests with the same cookies as the browser.
+// NewCookieJar 创建一个包含这些 Cookie 的 net/http cookie jar，http.Client 可以用它发送与浏览器相同的 Cookie。
+func NewCookieJar(cookies []*NetworkCookie) http.CookieJar {
+	jar, _ := cookiejar.New(nil)
+
+	for _, c := range CookiesToHTTP(cookies) {
+		host := strings.TrimPrefix(c.Domain, ".")
+
+		// host-only cookies must not have the domain attribute
+		// 仅限主机的 Cookie 不能有 domain 属性
+		if !strings.HasPrefix(c.Domain, ".") {
+			c.Domain = ""
+		}
+
+		scheme := "http"
+		if c.Secure {
+			scheme = "https"
+		}
+
+		jar.SetCookies(&url.URL{Scheme: scheme, Host: host, Path: c.Path}, []*http.Cookie{c})
+	}
+
+	return jar
+}
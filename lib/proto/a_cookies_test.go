@@ -0,0 +1,116 @@
+package proto_test
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+var testCookies = []*proto.NetworkCookie{{
+	Name:     "a",
+	Value:    "1",
+	Domain:   ".example.com",
+	Path:     "/",
+	Expires:  proto.TimeSinceEpoch(4102444800),
+	Secure:   true,
+	SameSite: proto.NetworkCookieSameSiteStrict,
+}, {
+	Name:     "b",
+	Value:    "2",
+	Domain:   "example.com",
+	Path:     "/p",
+	Expires:  -1,
+	Session:  true,
+	HTTPOnly: true,
+}}
+
+func (t T) CookiesNetscape() {
+	text := proto.CookiesToNetscape(testCookies, false)
+	t.Eq(text, "# Netscape HTTP Cookie File\n"+
+		".example.com\tTRUE\t/\tTRUE\t4102444800\ta\t1\n"+
+		"example.com\tFALSE\t/p\tFALSE\t0\tb\t2\n")
+
+	list, err := proto.CookiesFromNetscape(text)
+	t.E(err)
+	t.Len(list, 2)
+	t.Eq(list[0].Domain, ".example.com")
+	t.Eq(list[0].Expires, proto.TimeSinceEpoch(4102444800))
+	t.True(list[0].Secure)
+	t.Eq(list[1].Path, "/p")
+	t.Eq(list[1].Expires, proto.TimeSinceEpoch(0))
+	t.False(list[1].HTTPOnly)
+
+	text = proto.CookiesToNetscape(testCookies, true)
+	t.Has(text, "#HttpOnly_example.com\tFALSE")
+
+	list, err = proto.CookiesFromNetscape(text + "\n# comment\r\nx.com\tTRUE\t/\tFALSE\t0\tc\n")
+	t.E(err)
+	t.Len(list, 3)
+	t.True(list[1].HTTPOnly)
+	t.Eq(list[2].Domain, ".x.com")
+	t.Eq(list[2].Value, "")
+
+	_, err = proto.CookiesFromNetscape("a\tb")
+	t.Eq(err.Error(), "invalid netscape cookie at line 1")
+
+	_, err = proto.CookiesFromNetscape("a\tTRUE\t/\tFALSE\tx\tc\td")
+	t.Has(err.Error(), "invalid expires of netscape cookie at line 1")
+}
+
+func (t T) CookiesPlaywright() {
+	data, err := proto.CookiesToPlaywright(testCookies)
+	t.E(err)
+	t.Has(string(data), `"origins": []`)
+
+	list, err := proto.CookiesFromPlaywright(data)
+	t.E(err)
+	t.Len(list, 2)
+	t.Eq(list[0].SameSite, proto.NetworkCookieSameSiteStrict)
+	t.Eq(list[0].Expires, proto.TimeSinceEpoch(4102444800))
+	t.Eq(list[1].SameSite, proto.NetworkCookieSameSiteLax)
+	t.Eq(list[1].Expires, proto.TimeSinceEpoch(0))
+	t.True(list[1].HTTPOnly)
+
+	list, err = proto.CookiesFromPlaywright([]byte(` [{"name": "c", "value": "3", "domain": "x.com"}]`))
+	t.E(err)
+	t.Eq(list[0].Name, "c")
+
+	_, err = proto.CookiesFromPlaywright([]byte(`[`))
+	t.Err(err)
+	_, err = proto.CookiesFromPlaywright([]byte(`{`))
+	t.Err(err)
+}
+
+func (t T) CookiesHTTP() {
+	list := proto.CookiesToHTTP(testCookies)
+	t.Len(list, 2)
+	t.Eq(list[0].Expires.Unix(), int64(4102444800))
+	t.Eq(list[0].SameSite, http.SameSiteStrictMode)
+	t.True(list[1].Expires.IsZero())
+	t.True(list[1].HttpOnly)
+
+	params := proto.CookiesFromHTTP("http://example.com", append(list, &http.Cookie{
+		Name: "c", Value: "3", MaxAge: 10, SameSite: http.SameSiteNoneMode,
+	}, &http.Cookie{
+		Name: "d", SameSite: http.SameSiteLaxMode,
+	}))
+	t.Len(params, 4)
+	t.Eq(params[0].Expires, proto.TimeSinceEpoch(4102444800))
+	t.Eq(params[0].URL, "")
+	t.Eq(params[2].URL, "http://example.com")
+	t.Gt(params[2].Expires, proto.TimeSinceEpoch(time.Now().Unix()))
+	t.Eq(params[2].SameSite, proto.NetworkCookieSameSiteNone)
+	t.Eq(params[3].SameSite, proto.NetworkCookieSameSiteLax)
+}
+
+func (t T) CookieJar() {
+	jar := proto.NewCookieJar(testCookies)
+
+	u, _ := url.Parse("https://sub.example.com/")
+	t.Len(jar.Cookies(u), 1)
+
+	u, _ = url.Parse("http://example.com/p")
+	t.Eq(jar.Cookies(u)[0].Name, "b")
+}
@@ -0,0 +1,208 @@
+package proto
+
+import "math"
+
+// Right edge of the rectangle
+// 矩形的右边缘
+func (r *DOMRect) Right() float64 {
+	return r.X + r.Width
+}
+
+// Bottom edge of the rectangle
+// 矩形的下边缘
+func (r *DOMRect) Bottom() float64 {
+	return r.Y + r.Height
+}
+
+// Center of the rectangle
+// 矩形的中心
+func (r *DOMRect) Center() Point {
+	return Point{r.X + r.Width/2, r.Y + r.Height/2}
+}
+
+// Area of the rectangle
+// 矩形的面积
+func (r *DOMRect) Area() float64 {
+	return r.Width * r.Height
+}
+
+// Quad of the rectangle, clockwise from the top-left corner
+// 矩形的四边形，从左上角开始顺时针
+func (r *DOMRect) Quad() DOMQuad {
+	return DOMQuad{r.X, r.Y, r.Right(), r.Y, r.Right(), r.Bottom(), r.X, r.Bottom()}
+}
+
+// Contains returns true if the pt is inside the rectangle, the edges are included
+// Contains 如果 pt 在矩形内部则返回 true，包含边缘
+func (r *DOMRect) Contains(pt Point) bool {
+	return pt.X >= r.X && pt.X <= r.Right() && pt.Y >= r.Y && pt.Y <= r.Bottom()
+}
+
+// Intersect returns the overlapped area of the two rectangles, nil if they don't overlap
+// Intersect 返回两个矩形重叠的区域，如果它们不重叠则返回 nil
+func (r *DOMRect) Intersect(other *DOMRect) *DOMRect {
+	left := math.Max(r.X, other.X)
+	top := math.Max(r.Y, other.Y)
+	right := math.Min(r.Right(), other.Right())
+	bottom := math.Min(r.Bottom(), other.Bottom())
+
+	if right <= left || bottom <= top {
+		return nil
+	}
+	return &DOMRect{left, top, right - left, bottom - top}
+}
+
+// Union returns the smallest rectangle that can cover both rectangles
+// Union 返回可以覆盖两个矩形的最小矩形
+func (r *DOMRect) Union(other *DOMRect) *DOMRect {
+	left := math.Min(r.X, other.X)
+	top := math.Min(r.Y, other.Y)
+	right := math.Max(r.Right(), other.Right())
+	bottom := math.Max(r.Bottom(), other.Bottom())
+
+	return &DOMRect{left, top, right - left, bottom - top}
+}
+
+// Scale the rectangle from the origin (0, 0), such as converting the css pixels to the device pixels
+// Scale 以原点（0，0）为中心缩放矩形，例如将 css 像素转换为设备像素
+func (r *DOMRect) Scale(factor float64) *DOMRect {
+	return &DOMRect{r.X * factor, r.Y * factor, r.Width * factor, r.Height * factor}
+}
+
+// Clamp the rectangle into the viewport whose top-left corner is the origin (0, 0),
+// nil if the rectangle is out of the viewport. It's useful to compute the clip of the screenshot.
+// Clamp 将矩形限制在左上角为原点（0，0）的视口内，如果矩形在视口之外则返回 nil。可以用于计算截图的裁剪区域。
+func (r *DOMRect) Clamp(width, height float64) *DOMRect {
+	return r.Intersect(&DOMRect{0, 0, width, height})
+}
+
+// Scale the point from the origin (0, 0)
+// Scale 以原点（0，0）为中心缩放点
+func (p Point) Scale(factor float64) Point {
+	return Point{p.X * factor, p.Y * factor}
+}
+
+// Clamp the point into the rectangle
+// Clamp 将点限制在矩形内
+func (p Point) Clamp(r *DOMRect) Point {
+	return Point{math.Min(math.Max(p.X, r.X), r.Right()), math.Min(math.Max(p.Y, r.Y), r.Bottom())}
+}
+
+// Contains returns true if the pt is inside the polygon
+// Contains 如果 pt 在多边形内部则返回 true
+// https://en.wikipedia.org/wiki/Point_in_polygon#Ray_casting_algorithm
+func (q DOMQuad) Contains(pt Point) bool {
+	inside := false
+	l := q.Len()
+
+	for i, j := 0, l-1; i < l; j, i = i, i+1 {
+		xi, yi := q[i*2], q[i*2+1]
+		xj, yj := q[j*2], q[j*2+1]
+
+		if (yi > pt.Y) != (yj > pt.Y) && pt.X < (xj-xi)*(pt.Y-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
+
+// Scale the polygon from the origin (0, 0)
+// Scale 以原点（0，0）为中心缩放多边形
+func (q DOMQuad) Scale(factor float64) DOMQuad {
+	res := make(DOMQuad, len(q))
+	for i, v := range q {
+		res[i] = v * factor
+	}
+	return res
+}
+
+// Clip the polygon with the rectangle, the result may have more vertices than the polygon,
+// it's empty if they don't overlap.
+// Clip 使用矩形裁剪多边形，结果的顶点可能比原多边形多，如果它们不重叠则结果为空。
+// https://en.wikipedia.org/wiki/Sutherland%E2%80%93Hodgman_algorithm
+func (q DOMQuad) Clip(r *DOMRect) DOMQuad {
+	edges := []struct {
+		inside func(Point) bool
+		cross  func(a, b Point) Point
+	}{
+		{
+			func(p Point) bool { return p.X >= r.X },
+			func(a, b Point) Point { return Point{r.X, a.Y + (b.Y-a.Y)*(r.X-a.X)/(b.X-a.X)} },
+		}, {
+			func(p Point) bool { return p.X <= r.Right() },
+			func(a, b Point) Point { return Point{r.Right(), a.Y + (b.Y-a.Y)*(r.Right()-a.X)/(b.X-a.X)} },
+		}, {
+			func(p Point) bool { return p.Y >= r.Y },
+			func(a, b Point) Point { return Point{a.X + (b.X-a.X)*(r.Y-a.Y)/(b.Y-a.Y), r.Y} },
+		}, {
+			func(p Point) bool { return p.Y <= r.Bottom() },
+			func(a, b Point) Point { return Point{a.X + (b.X-a.X)*(r.Bottom()-a.Y)/(b.Y-a.Y), r.Bottom()} },
+		},
+	}
+
+	list := []Point{}
+	q.Each(func(pt Point, _ int) { list = append(list, pt) })
+
+	for _, edge := range edges {
+		if len(list) == 0 {
+			break
+		}
+
+		input := list
+		list = []Point{}
+		prev := input[len(input)-1]
+
+		for _, pt := range input {
+			switch {
+			case edge.inside(pt) && !edge.inside(prev):
+				list = append(list, edge.cross(prev, pt), pt)
+			case edge.inside(pt):
+				list = append(list, pt)
+			case edge.inside(prev):
+				list = append(list, edge.cross(prev, pt))
+			}
+			prev = pt
+		}
+	}
+
+	res := DOMQuad{}
+	for _, pt := range list {
+		res = append(res, pt.X, pt.Y)
+	}
+	return res
+}
+
+// Contains returns true if the pt is inside any polygon of the shape
+// Contains 如果 pt 在形状的任意一个多边形内部则返回 true
+func (qs Shape) Contains(pt Point) bool {
+	for _, q := range qs {
+		if q.Contains(pt) {
+			return true
+		}
+	}
+	return false
+}
+
+// Scale the shape from the origin (0, 0)
+// Scale 以原点（0，0）为中心缩放形状
+func (qs Shape) Scale(factor float64) Shape {
+	res := Shape{}
+	for _, q := range qs {
+		res = append(res, q.Scale(factor))
+	}
+	return res
+}
+
+// Clip the shape with the rectangle, the polygons outside the rectangle are removed.
+// Use it with the viewport to get the visible part of the shape.
+// Clip 使用矩形裁剪形状，矩形之外的多边形会被移除。将它与视口一起使用可以得到形状的可见部分。
+func (qs Shape) Clip(r *DOMRect) Shape {
+	res := Shape{}
+	for _, q := range qs {
+		if c := q.Clip(r); c.Len() >= 3 && c.Area() != 0 {
+			res = append(res, c)
+		}
+	}
+	return res
+}
@@ -0,0 +1,62 @@
+package proto_test
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func (t T) RectGeometry() {
+	r := &proto.DOMRect{X: 10, Y: 20, Width: 100, Height: 50}
+
+	t.Eq(r.Right(), 110.0)
+	t.Eq(r.Bottom(), 70.0)
+	t.Eq(r.Center(), proto.Point{X: 60, Y: 45})
+	t.Eq(r.Area(), 5000.0)
+	t.Eq(r.Quad(), proto.DOMQuad{10, 20, 110, 20, 110, 70, 10, 70})
+
+	t.True(r.Contains(proto.Point{X: 10, Y: 20}))
+	t.True(r.Contains(proto.Point{X: 50, Y: 50}))
+	t.False(r.Contains(proto.Point{X: 111, Y: 50}))
+
+	t.Eq(r.Intersect(&proto.DOMRect{X: 100, Y: 0, Width: 50, Height: 30}),
+		&proto.DOMRect{X: 100, Y: 20, Width: 10, Height: 10})
+	t.Nil(r.Intersect(&proto.DOMRect{X: 110, Y: 0, Width: 50, Height: 30}))
+
+	t.Eq(r.Union(&proto.DOMRect{X: 0, Y: 30, Width: 20, Height: 100}),
+		&proto.DOMRect{X: 0, Y: 20, Width: 110, Height: 110})
+
+	t.Eq(r.Scale(2), &proto.DOMRect{X: 20, Y: 40, Width: 200, Height: 100})
+
+	t.Eq(r.Clamp(50, 40), &proto.DOMRect{X: 10, Y: 20, Width: 40, Height: 20})
+	t.Nil(r.Clamp(5, 5))
+}
+
+func (t T) PointGeometry() {
+	r := &proto.DOMRect{X: 10, Y: 20, Width: 100, Height: 50}
+
+	t.Eq(proto.Point{X: 1, Y: 2}.Scale(3), proto.Point{X: 3, Y: 6})
+	t.Eq(proto.Point{X: 0, Y: 100}.Clamp(r), proto.Point{X: 10, Y: 70})
+	t.Eq(proto.Point{X: 50, Y: 30}.Clamp(r), proto.Point{X: 50, Y: 30})
+}
+
+func (t T) ShapeGeometry() {
+	triangle := proto.DOMQuad{0, 0, 10, 0, 0, 10}
+
+	t.True(triangle.Contains(proto.Point{X: 2, Y: 2}))
+	t.False(triangle.Contains(proto.Point{X: 8, Y: 8}))
+	t.Eq(triangle.Scale(2), proto.DOMQuad{0, 0, 20, 0, 0, 20})
+
+	square := (&proto.DOMRect{X: 0, Y: 0, Width: 10, Height: 10}).Quad()
+	clipped := square.Clip(&proto.DOMRect{X: 5, Y: -5, Width: 10, Height: 10})
+	t.Eq(proto.Shape{clipped}.Box(), &proto.DOMRect{X: 5, Y: 0, Width: 5, Height: 5})
+	t.Eq(clipped.Area(), 25.0)
+	t.Len(square.Clip(&proto.DOMRect{X: 20, Y: 20, Width: 10, Height: 10}), 0)
+
+	shape := proto.Shape{triangle, square.Scale(2)}
+	t.True(shape.Contains(proto.Point{X: 15, Y: 15}))
+	t.False(shape.Contains(proto.Point{X: 25, Y: 15}))
+	t.Eq(shape.Scale(0.5)[0], proto.DOMQuad{0, 0, 5, 0, 0, 5})
+
+	visible := shape.Clip(&proto.DOMRect{X: 12, Y: 12, Width: 100, Height: 100})
+	t.Len(visible, 1)
+	t.Eq(visible.Box(), &proto.DOMRect{X: 12, Y: 12, Width: 8, Height: 8})
+}
@@ -0,0 +1,80 @@
+package proto
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ysmood/gson"
+)
+
+// the browser joins the values of the same header with "\n"
+// 浏览器使用 "\n" 连接同一个请求头的多个值
+const headerValueSep = "\n"
+
+// NewNetworkHeaders converts the http.Header to NetworkHeaders, the multiple values of a key are joined by "\n"
+// as the browser does
+// NewNetworkHeaders 将 http.Header 转换为 NetworkHeaders，与浏览器一样，同一个键的多个值会用 "\n" 连接
+func NewNetworkHeaders(header http.Header) NetworkHeaders {
+	h := NetworkHeaders{}
+	for k, vs := range header {
+		h[http.CanonicalHeaderKey(k)] = gson.New(strings.Join(vs, headerValueSep))
+	}
+	return h
+}
+
+// HTTPHeader converts the headers to http.Header with the canonical keys, the multiple values of a key are split
+// HTTPHeader 将请求头转换为键为规范格式的 http.Header，同一个键的多个值会被拆分
+func (h NetworkHeaders) HTTPHeader() http.Header {
+	header := http.Header{}
+	for k, v := range h {
+		for _, item := range strings.Split(v.String(), headerValueSep) {
+			header.Add(k, item)
+		}
+	}
+	return header
+}
+
+// Get the first value of the key, the key is case-insensitive
+// Get 获取键的第一个值，键不区分大小写
+func (h NetworkHeaders) Get(key string) string {
+	vs := h.Values(key)
+	if len(vs) == 0 {
+		return ""
+	}
+	return vs[0]
+}
+
+// Values of the key, the key is case-insensitive
+// Values 获取键的所有值，键不区分大小写
+func (h NetworkHeaders) Values(key string) []string {
+	vs := []string{}
+	for k, v := range h {
+		if strings.EqualFold(k, key) {
+			vs = append(vs, strings.Split(v.String(), headerValueSep)...)
+		}
+	}
+	return vs
+}
+
+// Set the value of the key, the existing values of the key are replaced, the key is case-insensitive
+// Set 设置键的值，键已有的值会被替换，键不区分大小写
+func (h NetworkHeaders) Set(key, value string) {
+	h.Del(key)
+	h[http.CanonicalHeaderKey(key)] = gson.New(value)
+}
+
+// Add the value to the key, the key is case-insensitive
+// Add 为键添加一个值，键不区分大小写
+func (h NetworkHeaders) Add(key, value string) {
+	h.Set(key, strings.Join(append(h.Values(key), value), headerValueSep))
+}
+
+// Del the key, the key is case-insensitive
+// Del 删除键，键不区分大小写
+func (h NetworkHeaders) Del(key string) {
+	for k := range h {
+		if strings.EqualFold(k, key) {
+			delete(h, k)
+		}
+	}
+}
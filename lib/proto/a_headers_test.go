@@ -0,0 +1,41 @@
+package proto_test
+
+import (
+	"net/http"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/ysmood/gson"
+)
+
+func (t T) NetworkHeaders() {
+	h := proto.NetworkHeaders{
+		"content-type": gson.New("text/html"),
+		"Set-Cookie":   gson.New("a=1\nb=2"),
+	}
+
+	t.Eq(h.Get("Content-Type"), "text/html")
+	t.Eq(h.Get("set-cookie"), "a=1")
+	t.Eq(h.Values("SET-COOKIE"), []string{"a=1", "b=2"})
+	t.Eq(h.Get("Not-Exists"), "")
+	t.Len(h.Values("Not-Exists"), 0)
+
+	t.Eq(h.HTTPHeader(), http.Header{
+		"Content-Type": {"text/html"},
+		"Set-Cookie":   {"a=1", "b=2"},
+	})
+
+	h.Set("CONTENT-TYPE", "text/plain")
+	t.Eq(h["Content-Type"].Str(), "text/plain")
+	_, has := h["content-type"]
+	t.False(has)
+
+	h.Add("set-cookie", "c=3")
+	t.Eq(h.Values("Set-Cookie"), []string{"a=1", "b=2", "c=3"})
+
+	h.Del("set-cookie")
+	t.Len(h, 1)
+
+	t.Eq(proto.NewNetworkHeaders(http.Header{
+		"accept": {"a", "b"},
+	})["Accept"].Str(), "a\nb")
+}
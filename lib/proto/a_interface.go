@@ -5,8 +5,10 @@ package proto
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 )
 
 // Client interface to send the request.
@@ -48,9 +50,73 @@ type Event interface {
 // such as proto.GetType("Page.enable") will return the type of proto.PageEnable
 // 从这个包的方法名中获取类型，例如proto.GetType("Page.enable")将返回proto.PageEnable的类型。
 func GetType(methodName string) reflect.Type {
+	typesLock.RLock()
+	defer typesLock.RUnlock()
 	return types[methodName]
 }
 
+var typesLock sync.RWMutex
+
+// NewEvent creates an empty event of the method via the generated constructors, nil if the method is unknown
+// NewEvent 通过生成的构造函数创建方法对应的空事件，如果方法未知则返回 nil
+func NewEvent(method string) Event {
+	typesLock.RLock()
+	defer typesLock.RUnlock()
+
+	if fn, has := events[method]; has {
+		return fn()
+	}
+	return nil
+}
+
+// DecodeEvent decodes the params of the event of the method, such as the cdp.Event.Params
+// DecodeEvent 解码方法对应事件的参数，例如 cdp.Event.Params
+func DecodeEvent(method string, params []byte) (Event, error) {
+	e := NewEvent(method)
+	if e == nil {
+		return nil, fmt.Errorf("unknown event: %s", method)
+	}
+	return e, json.Unmarshal(params, e)
+}
+
+// Register the custom types of Request or Event, such as the types for the vendor-specific domains or the
+// methods that haven't been generated yet. Then they work like the generated ones, such as GetType returns them,
+// the events can be decoded by rod, and the "Xxx.enable" request will be used to enable the domain of the events.
+// A registered type replaces the type of the same method name. It panics if a type is neither Request nor Event.
+// Register 注册自定义的 Request 或 Event 类型，例如厂商特有 domain 的类型或者还没有被生成的方法。
+// 之后它们就像生成的类型一样工作，例如 GetType 会返回它们，rod 可以解码这些事件，并且 "Xxx.enable" 请求会被用于启用事件的 domain。
+// 注册的类型会替换同名方法的类型。如果类型既不是 Request 也不是 Event 则会 panic。
+func Register(list ...interface{}) {
+	typesLock.Lock()
+	defer typesLock.Unlock()
+
+	for _, v := range list {
+		var name string
+		switch t := v.(type) {
+		case Request:
+			name = t.ProtoReq()
+		case Event:
+			name = t.ProtoEvent()
+		default:
+			panic(fmt.Sprintf("%T is neither proto.Request nor proto.Event", v))
+		}
+
+		if !strings.Contains(name, ".") {
+			panic(fmt.Sprintf("the method name of %T should be like Domain.method: %s", v, name))
+		}
+
+		t := reflect.TypeOf(v)
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		types[name] = t
+
+		if _, ok := reflect.New(t).Interface().(Event); ok {
+			events[name] = func() Event { return reflect.New(t).Interface().(Event) }
+		}
+	}
+}
+
 // ParseMethodName to domain and name
 // 解析方法的 domain 和 name
 func ParseMethodName(method string) (domain, name string) {
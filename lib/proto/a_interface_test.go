@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
+	"github.com/ysmood/gson"
 )
 
 type Client struct {
@@ -134,6 +135,37 @@ func (t T) CookiesToParams() {
 	t.Eq(list[0].Value, "val")
 }
 
+func (t T) RemoteObjectInto() {
+	var v struct {
+		Name string `json:"name"`
+		Tags []string
+	}
+
+	obj := &proto.RuntimeRemoteObject{
+		Type:  proto.RuntimeRemoteObjectTypeObject,
+		Value: gson.New(map[string]interface{}{"name": "jack", "Tags": []string{"a"}}),
+	}
+	t.E(obj.Into(&v))
+	t.Eq(v.Name, "jack")
+	t.Eq(v.Tags, []string{"a"})
+
+	var n int
+	err := obj.Into(&n)
+	t.Eq(err.Error(), "failed to decode the object into *int: json: cannot unmarshal object into Go value of type int")
+	var typeErr *json.UnmarshalTypeError
+	t.True(errors.As(err, &typeErr))
+
+	err = (&proto.RuntimeRemoteObject{Type: proto.RuntimeRemoteObjectTypeNumber, UnserializableValue: "NaN"}).Into(&n)
+	t.Eq(err.Error(), "can't decode the unserializable value NaN into *int")
+
+	err = (&proto.RuntimeRemoteObject{
+		Type:        proto.RuntimeRemoteObjectTypeObject,
+		Description: "Window",
+		ObjectID:    "1",
+	}).Into(&n)
+	t.Eq(err.Error(), "can't decode the object (Window) by reference into *int, evaluate it by value instead")
+}
+
 func (t T) GeneratorOptimize() {
 	var _ proto.TargetTargetInfoType = proto.TargetTargetInfoTypeBackgroundPage
 	var _ proto.TargetTargetInfoType = proto.TargetTargetInfoTypePage
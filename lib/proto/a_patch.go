@@ -4,6 +4,8 @@
 package proto
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -172,3 +174,36 @@ func CookiesToParams(cookies []*NetworkCookie) []*NetworkCookieParam {
 	}
 	return list
 }
+
+// Into json-decodes the Value of the remote object into v, such as:
+//     var user struct{ Name string }
+//     res, _ := page.Eval(`() => ({ name: 'jack' })`)
+//     err := res.Into(&user)
+// Into 将远程对象的 Value 通过 json 解码到 v 中
+func (o *RuntimeRemoteObject) Into(v interface{}) error {
+	if o.UnserializableValue != "" {
+		return fmt.Errorf("can't decode the unserializable value %s into %T", o.UnserializableValue, v)
+	}
+
+	if o.Value.Nil() && o.ObjectID != "" {
+		return fmt.Errorf("can't decode the %s by reference into %T, evaluate it by value instead", o.desc(), v)
+	}
+
+	b, err := json.Marshal(o.Value.Val())
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(b, v)
+	if err != nil {
+		return fmt.Errorf("failed to decode the %s into %T: %w", o.desc(), v, err)
+	}
+	return nil
+}
+
+func (o *RuntimeRemoteObject) desc() string {
+	if o.Description != "" {
+		return fmt.Sprintf("%s (%s)", o.Type, o.Description)
+	}
+	return string(o.Type)
+}
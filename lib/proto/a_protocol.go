@@ -0,0 +1,111 @@
+package proto
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Protocol is the schema of the devtools protocol, such as the one served by the browser at "/json/protocol"
+// Protocol 是 devtools 协议的 schema，例如浏览器在 "/json/protocol" 提供的 schema
+type Protocol struct {
+	Version struct {
+		Major string `json:"major"`
+		Minor string `json:"minor"`
+	} `json:"version"`
+
+	Domains []*ProtocolDomain `json:"domains"`
+}
+
+// ProtocolDomain of the Protocol
+// Protocol 中的 domain
+type ProtocolDomain struct {
+	Domain   string          `json:"domain"`
+	Types    []*ProtocolType `json:"types"`
+	Commands []*ProtocolItem `json:"commands"`
+	Events   []*ProtocolItem `json:"events"`
+}
+
+// ProtocolItem is a command or an event of the ProtocolDomain
+// ProtocolItem 是 ProtocolDomain 中的一个命令或者事件
+type ProtocolItem struct {
+	Name         string           `json:"name"`
+	Experimental bool             `json:"experimental"`
+	Deprecated   bool             `json:"deprecated"`
+	Parameters   []*ProtocolParam `json:"parameters"`
+	Returns      []*ProtocolParam `json:"returns"`
+}
+
+// ProtocolType is a type of the ProtocolDomain, such as "DOM.Rect"
+// ProtocolType 是 ProtocolDomain 中的一个类型，例如 "DOM.Rect"
+type ProtocolType struct {
+	ID         string           `json:"id"`
+	Type       string           `json:"type"`
+	Properties []*ProtocolParam `json:"properties"`
+	Enum       []string         `json:"enum"`
+	Items      *ProtocolParam   `json:"items"`
+}
+
+// ProtocolParam is a parameter, a return value or a property, its type is either the Type or the Ref
+// ProtocolParam 是一个参数、返回值或者属性，它的类型是 Type 或者 Ref
+type ProtocolParam struct {
+	Name     string         `json:"name"`
+	Type     string         `json:"type"`
+	Ref      string         `json:"$ref"`
+	Optional bool           `json:"optional"`
+	Enum     []string       `json:"enum"`
+	Items    *ProtocolParam `json:"items"`
+}
+
+// Has tells if the protocol has the command or event, such as "Page.navigate", "Page.loadEventFired"
+// Has 判断协议是否有该命令或者事件，例如 "Page.navigate"、"Page.loadEventFired"
+func (p *Protocol) Has(method string) bool {
+	_, has := p.index()[method]
+	return has
+}
+
+// Missing returns the commands and events of this package that the protocol doesn't have, in order
+// Missing 按顺序返回这个包中有而该协议中没有的命令和事件
+func (p *Protocol) Missing() []string {
+	index := p.index()
+	list := []string{}
+	for _, m := range Methods() {
+		if _, has := index[m]; !has {
+			list = append(list, m)
+		}
+	}
+	return list
+}
+
+func (p *Protocol) index() map[string]struct{} {
+	index := map[string]struct{}{}
+	for _, d := range p.Domains {
+		for _, c := range d.Commands {
+			index[d.Domain+"."+c.Name] = struct{}{}
+		}
+		for _, e := range d.Events {
+			index[d.Domain+"."+e.Name] = struct{}{}
+		}
+	}
+	return index
+}
+
+var (
+	requestType = reflect.TypeOf((*Request)(nil)).Elem()
+	eventType   = reflect.TypeOf((*Event)(nil)).Elem()
+)
+
+// Methods returns the names of all the commands and events of this package, in order
+// Methods 按顺序返回这个包中所有命令和事件的名称
+func Methods() []string {
+	typesLock.RLock()
+	defer typesLock.RUnlock()
+
+	list := []string{}
+	for name, t := range types {
+		if t.Implements(requestType) || t.Implements(eventType) {
+			list = append(list, name)
+		}
+	}
+	sort.Strings(list)
+	return list
+}
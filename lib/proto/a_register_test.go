@@ -0,0 +1,51 @@
+package proto_test
+
+import (
+	"reflect"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+type VendorEnable struct{}
+
+func (m VendorEnable) ProtoReq() string { return "Vendor.enable" }
+
+type VendorPinged struct {
+	Count int `json:"count"`
+}
+
+func (evt VendorPinged) ProtoEvent() string { return "Vendor.pinged" }
+
+type VendorInvalid struct{}
+
+func (m VendorInvalid) ProtoReq() string { return "invalid" }
+
+func (t T) Register() {
+	t.Nil(proto.GetType("Vendor.enable"))
+
+	proto.Register(VendorEnable{}, &VendorPinged{})
+
+	t.Eq(proto.GetType("Vendor.enable"), reflect.TypeOf(VendorEnable{}))
+	t.Eq(proto.GetType("Vendor.pinged"), reflect.TypeOf(VendorPinged{}))
+	t.Has(proto.Methods(), "Vendor.pinged")
+
+	t.Panic(func() { proto.Register(1) })
+	t.Panic(func() { proto.Register(VendorInvalid{}) })
+}
+
+func (t T) DecodeEvent() {
+	e, err := proto.DecodeEvent("Page.loadEventFired", []byte(`{"timestamp":1}`))
+	t.E(err)
+	t.Eq(e, &proto.PageLoadEventFired{Timestamp: 1})
+
+	_, err = proto.DecodeEvent("Page.loadEventFired", []byte(`{`))
+	t.Err(err)
+
+	_, err = proto.DecodeEvent("Not.exists", nil)
+	t.Eq(err.Error(), "unknown event: Not.exists")
+
+	proto.Register(VendorPinged{})
+	e, err = proto.DecodeEvent("Vendor.pinged", []byte(`{"count":2}`))
+	t.E(err)
+	t.Eq(e, &VendorPinged{Count: 2})
+}
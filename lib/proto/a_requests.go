@@ -0,0 +1,91 @@
+// Constructors with defaults and setters for the optional fields of the frequently used requests,
+// so that the pointer fields can be set without the helpers like gson.Int
+// 常用请求的带默认值的构造函数以及可选字段的 setter，这样无需 gson.Int 之类的辅助函数就可以设置指针字段
+
+package proto
+
+// NewPagePrintToPDF creates a PagePrintToPDF that prints the backgrounds, the other fields are the defaults of
+// the browser: the size of the paper is 8.5 x 11 inches, the margins are 0.4 inches.
+// NewPagePrintToPDF 创建一个会打印背景的 PagePrintToPDF，其他字段为浏览器的默认值：纸张大小为 8.5 x 11 英寸，边距为 0.4 英寸。
+func NewPagePrintToPDF() *PagePrintToPDF {
+	return (&PagePrintToPDF{PrintBackground: true}).
+		WithScale(1).
+		WithPaperSize(8.5, 11).
+		WithMargins(0.4, 0.4, 0.4, 0.4)
+}
+
+// WithScale sets the scale of the webpage rendering
+// WithScale 设置网页渲染的缩放比例
+func (m *PagePrintToPDF) WithScale(scale float64) *PagePrintToPDF {
+	m.Scale = &scale
+	return m
+}
+
+// WithPaperSize sets the size of the paper in inches, such as 8.27 x 11.69 for A4
+// WithPaperSize 设置纸张大小，单位为英寸，例如 A4 为 8.27 x 11.69
+func (m *PagePrintToPDF) WithPaperSize(width, height float64) *PagePrintToPDF {
+	m.PaperWidth = &width
+	m.PaperHeight = &height
+	return m
+}
+
+// WithMargins sets the margins in inches
+// WithMargins 设置边距，单位为英寸
+func (m *PagePrintToPDF) WithMargins(top, right, bottom, left float64) *PagePrintToPDF {
+	m.MarginTop = &top
+	m.MarginRight = &right
+	m.MarginBottom = &bottom
+	m.MarginLeft = &left
+	return m
+}
+
+// NewPageCaptureScreenshot creates a PageCaptureScreenshot with the png format
+// NewPageCaptureScreenshot 创建一个格式为 png 的 PageCaptureScreenshot
+func NewPageCaptureScreenshot() *PageCaptureScreenshot {
+	return &PageCaptureScreenshot{Format: PageCaptureScreenshotFormatPng}
+}
+
+// WithQuality sets the format to jpeg and the compression quality from range [0..100]
+// WithQuality 将格式设置为 jpeg 并设置范围为 [0..100] 的压缩质量
+func (m *PageCaptureScreenshot) WithQuality(quality int) *PageCaptureScreenshot {
+	m.Format = PageCaptureScreenshotFormatJpeg
+	m.Quality = &quality
+	return m
+}
+
+// WithClip captures the region of the rect only, scale is the scale of the image
+// WithClip 只截取 rect 区域，scale 是图像的缩放比例
+func (m *PageCaptureScreenshot) WithClip(rect *DOMRect, scale float64) *PageCaptureScreenshot {
+	m.Clip = &PageViewport{X: rect.X, Y: rect.Y, Width: rect.Width, Height: rect.Height, Scale: scale}
+	return m
+}
+
+// NewEmulationSetDeviceMetricsOverride creates a EmulationSetDeviceMetricsOverride for a desktop viewport
+// of the size, the device scale factor is 1
+// NewEmulationSetDeviceMetricsOverride 为该大小的桌面视口创建一个 EmulationSetDeviceMetricsOverride，设备缩放因子为 1
+func NewEmulationSetDeviceMetricsOverride(width, height int) *EmulationSetDeviceMetricsOverride {
+	return &EmulationSetDeviceMetricsOverride{Width: width, Height: height, DeviceScaleFactor: 1}
+}
+
+// WithScale sets the scale to apply to the resulting view image
+// WithScale 设置应用于最终视图图像的缩放比例
+func (m *EmulationSetDeviceMetricsOverride) WithScale(scale float64) *EmulationSetDeviceMetricsOverride {
+	m.Scale = &scale
+	return m
+}
+
+// WithScreenSize overrides the size of the screen
+// WithScreenSize 覆盖屏幕的大小
+func (m *EmulationSetDeviceMetricsOverride) WithScreenSize(width, height int) *EmulationSetDeviceMetricsOverride {
+	m.ScreenWidth = &width
+	m.ScreenHeight = &height
+	return m
+}
+
+// WithPosition overrides the position of the view on the screen
+// WithPosition 覆盖视图在屏幕上的位置
+func (m *EmulationSetDeviceMetricsOverride) WithPosition(x, y int) *EmulationSetDeviceMetricsOverride {
+	m.PositionX = &x
+	m.PositionY = &y
+	return m
+}
@@ -0,0 +1,46 @@
+package proto_test
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/ysmood/gson"
+)
+
+func (t T) NewPagePrintToPDF() {
+	req := proto.NewPagePrintToPDF()
+	t.True(req.PrintBackground)
+	t.Eq(*req.Scale, 1.0)
+	t.Eq(*req.PaperWidth, 8.5)
+	t.Eq(*req.MarginLeft, 0.4)
+
+	req.WithScale(2).WithPaperSize(8.27, 11.69).WithMargins(1, 2, 3, 4)
+	t.Eq(*req.Scale, 2.0)
+	t.Eq(*req.PaperHeight, 11.69)
+	t.Eq([]float64{*req.MarginTop, *req.MarginRight, *req.MarginBottom, *req.MarginLeft}, []float64{1, 2, 3, 4})
+
+	c := &Client{}
+	_, err := req.Call(c)
+	t.E(err)
+	t.Eq(c.methodName, "Page.printToPDF")
+}
+
+func (t T) NewPageCaptureScreenshot() {
+	req := proto.NewPageCaptureScreenshot()
+	t.Eq(req.Format, proto.PageCaptureScreenshotFormatPng)
+	t.Nil(req.Quality)
+
+	req.WithQuality(90).WithClip(&proto.DOMRect{X: 1, Y: 2, Width: 3, Height: 4}, 1)
+	t.Eq(req.Format, proto.PageCaptureScreenshotFormatJpeg)
+	t.Eq(req.Quality, gson.Int(90))
+	t.Eq(req.Clip, &proto.PageViewport{X: 1, Y: 2, Width: 3, Height: 4, Scale: 1})
+}
+
+func (t T) NewEmulationSetDeviceMetricsOverride() {
+	req := proto.NewEmulationSetDeviceMetricsOverride(800, 600)
+	t.Eq(req.Width, 800)
+	t.Eq(req.DeviceScaleFactor, 1.0)
+	t.False(req.Mobile)
+
+	req.WithScale(0.5).WithScreenSize(1920, 1080).WithPosition(10, 20)
+	t.Eq(*req.Scale, 0.5)
+	t.Eq([]int{*req.ScreenWidth, *req.ScreenHeight, *req.PositionX, *req.PositionY}, []int{1920, 1080, 10, 20})
+}
@@ -0,0 +1,48 @@
+package proto
+
+// Stability flags of a cdp method
+// cdp 方法的稳定性标记
+type Stability int
+
+const (
+	// StabilityExperimental means the method may be changed or removed without notice
+	// StabilityExperimental 表示该方法可能会在没有通知的情况下被修改或移除
+	StabilityExperimental Stability = 1 << iota
+
+	// StabilityDeprecated means the method will be removed
+	// StabilityDeprecated 表示该方法将会被移除
+	StabilityDeprecated
+)
+
+// Experimental returns true if the method is experimental
+// Experimental 如果方法是实验性的则返回 true
+func (s Stability) Experimental() bool {
+	return s&StabilityExperimental != 0
+}
+
+// Deprecated returns true if the method is deprecated
+// Deprecated 如果方法已被弃用则返回 true
+func (s Stability) Deprecated() bool {
+	return s&StabilityDeprecated != 0
+}
+
+// String interface
+func (s Stability) String() string {
+	switch {
+	case s.Experimental() && s.Deprecated():
+		return "experimental, deprecated"
+	case s.Experimental():
+		return "experimental"
+	case s.Deprecated():
+		return "deprecated"
+	}
+	return "stable"
+}
+
+// GetStability of the command or event, such as proto.GetStability("Page.addScriptToEvaluateOnLoad").
+// The methods of an experimental domain, such as "Animation", are experimental too.
+// GetStability 获取命令或事件的稳定性，例如 proto.GetStability("Page.addScriptToEvaluateOnLoad")。
+// 实验性 domain（例如 "Animation"）中的方法也是实验性的。
+func GetStability(method string) Stability {
+	return stabilities[method]
+}
@@ -0,0 +1,27 @@
+package proto_test
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func (t T) Stability() {
+	s := proto.GetStability("Page.addScriptToEvaluateOnLoad")
+	t.True(s.Experimental())
+	t.True(s.Deprecated())
+	t.Eq(s.String(), "experimental, deprecated")
+
+	s = proto.GetStability("Page.getManifestIcons")
+	t.True(s.Experimental())
+	t.False(s.Deprecated())
+	t.Eq(s.String(), "experimental")
+
+	t.Eq(proto.GetStability("Security.handleCertificateError").String(), "deprecated")
+
+	// the methods of an experimental domain
+	t.True(proto.GetStability("Animation.enable").Experimental())
+	t.True(proto.GetStability("Animation.animationStarted").Experimental())
+
+	s = proto.GetStability("Page.navigate")
+	t.Eq(s, proto.Stability(0))
+	t.Eq(s.String(), "stable")
+}
@@ -24,3 +24,32 @@ func (t T) PatternToReg() {
 	t.Eq(`\A\?\*\z`, proto.PatternToReg(`\?\*`))
 	t.Eq(`\Aa.com\?a=10&b=\*\z`, proto.PatternToReg(`a.com\?a=10&b=\*`))
 }
+
+func (t T) Protocol() {
+	has := func(list []string, s string) bool {
+		for _, item := range list {
+			if item == s {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.True(has(proto.Methods(), "Page.navigate"))
+	t.True(has(proto.Methods(), "Page.loadEventFired"))
+	t.False(has(proto.Methods(), "Page.Frame"))
+
+	p := &proto.Protocol{Domains: []*proto.ProtocolDomain{{
+		Domain:   "Page",
+		Commands: []*proto.ProtocolItem{{Name: "navigate"}},
+		Events:   []*proto.ProtocolItem{{Name: "loadEventFired"}},
+	}}}
+
+	t.True(p.Has("Page.navigate"))
+	t.True(p.Has("Page.loadEventFired"))
+	t.False(p.Has("Page.reload"))
+
+	missing := p.Missing()
+	t.True(has(missing, "Page.reload"))
+	t.Len(missing, len(proto.Methods())-2)
+}
@@ -0,0 +1,219 @@
+package proto
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Command returns the command of the method, such as "Page.navigate", nil if the protocol doesn't have it
+// Command 返回方法对应的命令，例如 "Page.navigate"，如果协议中没有它则返回 nil
+func (p *Protocol) Command(method string) *ProtocolItem {
+	domain, name := splitMethod(method)
+	if d := p.domain(domain); d != nil {
+		for _, c := range d.Commands {
+			if c.Name == name {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+// Type returns the type of the id, such as "DOM.Rect", nil if the protocol doesn't have it
+// Type 返回 id 对应的类型，例如 "DOM.Rect"，如果协议中没有它则返回 nil
+func (p *Protocol) Type(id string) *ProtocolType {
+	domain, name := splitMethod(id)
+	if d := p.domain(domain); d != nil {
+		for _, t := range d.Types {
+			if t.ID == name {
+				return t
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Protocol) domain(name string) *ProtocolDomain {
+	for _, d := range p.Domains {
+		if d.Domain == name {
+			return d
+		}
+	}
+	return nil
+}
+
+// ValidateParams checks the json of the params of the command against the protocol, such as the unknown fields,
+// the missing required fields, the wrong types and the invalid enum values. It returns the problems found.
+// ValidateParams 根据协议检查命令参数的 json，例如未知的字段、缺少的必填字段、错误的类型以及无效的枚举值。它返回发现的问题。
+func (p *Protocol) ValidateParams(method string, params []byte) []string {
+	cmd := p.Command(method)
+	if cmd == nil {
+		return []string{"unknown method: " + method}
+	}
+
+	var obj interface{} = map[string]interface{}{}
+	if len(params) > 0 && string(params) != "null" {
+		if err := json.Unmarshal(params, &obj); err != nil {
+			return []string{err.Error()}
+		}
+	}
+
+	domain, _ := splitMethod(method)
+	v := &validator{protocol: p, problems: []string{}}
+	v.object(domain, "params", cmd.Parameters, obj)
+	return v.problems
+}
+
+type validator struct {
+	protocol *Protocol
+	problems []string
+}
+
+func (v *validator) report(path, format string, args ...interface{}) {
+	v.problems = append(v.problems, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (v *validator) object(domain, path string, props []*ProtocolParam, val interface{}) {
+	obj, ok := val.(map[string]interface{})
+	if !ok {
+		v.report(path, "should be an object")
+		return
+	}
+
+	known := map[string]*ProtocolParam{}
+	for _, prop := range props {
+		known[prop.Name] = prop
+
+		if _, has := obj[prop.Name]; !has && !prop.Optional {
+			v.report(path, "missing required field %s", prop.Name)
+		}
+	}
+
+	keys := []string{}
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		prop, has := known[k]
+		if !has {
+			v.report(path, "unknown field %s", k)
+			continue
+		}
+		v.value(domain, path+"."+k, prop, obj[k])
+	}
+}
+
+func (v *validator) value(domain, path string, param *ProtocolParam, val interface{}) {
+	if param.Ref != "" {
+		id := param.Ref
+		if !strings.Contains(id, ".") {
+			id = domain + "." + id
+		}
+
+		t := v.protocol.Type(id)
+		if t == nil {
+			return
+		}
+
+		refDomain, _ := splitMethod(id)
+		if t.Type == "object" && t.Properties != nil {
+			v.object(refDomain, path, t.Properties, val)
+			return
+		}
+		param = &ProtocolParam{Type: t.Type, Enum: t.Enum, Items: t.Items}
+		domain = refDomain
+	}
+
+	switch param.Type {
+	case "string":
+		s, ok := val.(string)
+		if !ok {
+			v.report(path, "should be a string")
+			return
+		}
+		if len(param.Enum) > 0 && !hasString(param.Enum, s) {
+			v.report(path, "%q is not one of %s", s, strings.Join(param.Enum, ", "))
+		}
+	case "integer":
+		if n, ok := val.(float64); !ok || n != float64(int64(n)) {
+			v.report(path, "should be an integer")
+		}
+	case "number":
+		if _, ok := val.(float64); !ok {
+			v.report(path, "should be a number")
+		}
+	case "boolean":
+		if _, ok := val.(bool); !ok {
+			v.report(path, "should be a boolean")
+		}
+	case "array":
+		list, ok := val.([]interface{})
+		if !ok {
+			v.report(path, "should be an array")
+			return
+		}
+		if param.Items != nil {
+			for i, item := range list {
+				v.value(domain, fmt.Sprintf("%s[%d]", path, i), param.Items, item)
+			}
+		}
+	case "object":
+		if _, ok := val.(map[string]interface{}); !ok {
+			v.report(path, "should be an object")
+		}
+	}
+}
+
+// UnknownFields returns the top-level fields of the json object that the Go type of the method doesn't have,
+// such as the fields of a response that the "Xxx.yyyResult" of this package doesn't have yet
+// UnknownFields 返回 json 对象中方法对应的 Go 类型所没有的顶层字段，例如响应中 "Xxx.yyyResult" 还没有的字段
+func UnknownFields(method string, data []byte) []string {
+	t := GetType(method)
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(data, &obj) != nil {
+		return nil
+	}
+
+	known := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = t.Field(i).Name
+		}
+		known[name] = true
+	}
+
+	list := []string{}
+	for k := range obj {
+		if !known[k] {
+			list = append(list, k)
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+func splitMethod(method string) (domain, name string) {
+	if i := strings.Index(method, "."); i >= 0 {
+		return method[:i], method[i+1:]
+	}
+	return "", method
+}
+
+func hasString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
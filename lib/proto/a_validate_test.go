@@ -0,0 +1,69 @@
+package proto_test
+
+import (
+	"encoding/json"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func (t T) ValidateParams() {
+	var p proto.Protocol
+	t.E(json.Unmarshal([]byte(`{"domains": [{
+		"domain": "Page",
+		"types": [
+			{"id": "Mode", "type": "string", "enum": ["a", "b"]},
+			{"id": "Size", "type": "object", "properties": [
+				{"name": "width", "type": "integer"},
+				{"name": "height", "type": "number", "optional": true}
+			]}
+		],
+		"commands": [{"name": "set", "parameters": [
+			{"name": "url", "type": "string"},
+			{"name": "mode", "$ref": "Mode", "optional": true},
+			{"name": "size", "$ref": "Page.Size", "optional": true},
+			{"name": "list", "type": "array", "items": {"type": "boolean"}, "optional": true},
+			{"name": "node", "$ref": "DOM.NodeId", "optional": true}
+		]}]
+	}, {
+		"domain": "DOM",
+		"types": [{"id": "NodeId", "type": "integer"}]
+	}]}`), &p))
+
+	t.NotNil(p.Command("Page.set"))
+	t.Nil(p.Command("Page.get"))
+	t.Nil(p.Command("Not.exists"))
+	t.Eq(p.Type("Page.Mode").Enum, []string{"a", "b"})
+	t.Nil(p.Type("Page.Not"))
+
+	t.Len(p.ValidateParams("Page.set", []byte(`{"url": "x", "mode": "a", "size": {"width": 1}, "list": [true]}`)), 0)
+
+	t.Eq(p.ValidateParams("Page.get", nil), []string{"unknown method: Page.get"})
+	t.Eq(p.ValidateParams("Page.set", nil), []string{"params: missing required field url"})
+	t.Eq(p.ValidateParams("Page.set", []byte(`{`)), []string{"unexpected end of JSON input"})
+
+	t.Eq(p.ValidateParams("Page.set", []byte(`{"url": 1, "urll": "x", "mode": "c", "node": 1.5}`)), []string{
+		`params.mode: "c" is not one of a, b`,
+		"params.node: should be an integer",
+		"params.url: should be a string",
+		"params: unknown field urll",
+	})
+
+	t.Eq(p.ValidateParams("Page.set", []byte(`{"url": "x", "size": {"height": true}, "list": [1]}`)), []string{
+		"params.list[0]: should be a boolean",
+		"params.size: missing required field width",
+		"params.size.height: should be a number",
+	})
+
+	t.Eq(p.ValidateParams("Page.set", []byte(`{"url": "x", "size": 1, "list": {}}`)), []string{
+		"params.list: should be an array",
+		"params.size: should be an object",
+	})
+}
+
+func (t T) UnknownFields() {
+	t.Eq(proto.UnknownFields("Page.navigateResult", []byte(`{"frameId": "1", "newField": 1, "a": 2}`)),
+		[]string{"a", "newField"})
+	t.Len(proto.UnknownFields("Page.navigateResult", []byte(`{"frameId": "1"}`)), 0)
+	t.Nil(proto.UnknownFields("Not.exists", nil))
+	t.Nil(proto.UnknownFields("Page.navigateResult", []byte(`[]`)))
+}
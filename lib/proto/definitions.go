@@ -1188,3 +1188,661 @@ var types = map[string]reflect.Type{
 	"Schema.getDomains":                                     reflect.TypeOf(SchemaGetDomains{}),
 	"Schema.getDomainsResult":                               reflect.TypeOf(SchemaGetDomainsResult{}),
 }
+
+var stabilities = map[string]Stability{
+
+	"Accessibility.disable":                            StabilityExperimental,
+	"Accessibility.enable":                             StabilityExperimental,
+	"Accessibility.getPartialAXTree":                   StabilityExperimental,
+	"Accessibility.getFullAXTree":                      StabilityExperimental,
+	"Accessibility.getRootAXNode":                      StabilityExperimental,
+	"Accessibility.getAXNodeAndAncestors":              StabilityExperimental,
+	"Accessibility.getChildAXNodes":                    StabilityExperimental,
+	"Accessibility.queryAXTree":                        StabilityExperimental,
+	"Accessibility.loadComplete":                       StabilityExperimental,
+	"Accessibility.nodesUpdated":                       StabilityExperimental,
+	"Animation.disable":                                StabilityExperimental,
+	"Animation.enable":                                 StabilityExperimental,
+	"Animation.getCurrentTime":                         StabilityExperimental,
+	"Animation.getPlaybackRate":                        StabilityExperimental,
+	"Animation.releaseAnimations":                      StabilityExperimental,
+	"Animation.resolveAnimation":                       StabilityExperimental,
+	"Animation.seekAnimations":                         StabilityExperimental,
+	"Animation.setPaused":                              StabilityExperimental,
+	"Animation.setPlaybackRate":                        StabilityExperimental,
+	"Animation.setTiming":                              StabilityExperimental,
+	"Animation.animationCanceled":                      StabilityExperimental,
+	"Animation.animationCreated":                       StabilityExperimental,
+	"Animation.animationStarted":                       StabilityExperimental,
+	"Audits.getEncodedResponse":                        StabilityExperimental,
+	"Audits.disable":                                   StabilityExperimental,
+	"Audits.enable":                                    StabilityExperimental,
+	"Audits.checkContrast":                             StabilityExperimental,
+	"Audits.issueAdded":                                StabilityExperimental,
+	"BackgroundService.startObserving":                 StabilityExperimental,
+	"BackgroundService.stopObserving":                  StabilityExperimental,
+	"BackgroundService.setRecording":                   StabilityExperimental,
+	"BackgroundService.clearEvents":                    StabilityExperimental,
+	"BackgroundService.recordingStateChanged":          StabilityExperimental,
+	"BackgroundService.backgroundServiceEventReceived": StabilityExperimental,
+	"Browser.setPermission":                            StabilityExperimental,
+	"Browser.grantPermissions":                         StabilityExperimental,
+	"Browser.resetPermissions":                         StabilityExperimental,
+	"Browser.setDownloadBehavior":                      StabilityExperimental,
+	"Browser.cancelDownload":                           StabilityExperimental,
+	"Browser.crash":                                    StabilityExperimental,
+	"Browser.crashGpuProcess":                          StabilityExperimental,
+	"Browser.getBrowserCommandLine":                    StabilityExperimental,
+	"Browser.getHistograms":                            StabilityExperimental,
+	"Browser.getHistogram":                             StabilityExperimental,
+	"Browser.getWindowBounds":                          StabilityExperimental,
+	"Browser.getWindowForTarget":                       StabilityExperimental,
+	"Browser.setWindowBounds":                          StabilityExperimental,
+	"Browser.setDockTile":                              StabilityExperimental,
+	"Browser.executeBrowserCommand":                    StabilityExperimental,
+	"Browser.downloadWillBegin":                        StabilityExperimental,
+	"Browser.downloadProgress":                         StabilityExperimental,
+	"CSS.addRule":                                      StabilityExperimental,
+	"CSS.collectClassNames":                            StabilityExperimental,
+	"CSS.createStyleSheet":                             StabilityExperimental,
+	"CSS.disable":                                      StabilityExperimental,
+	"CSS.enable":                                       StabilityExperimental,
+	"CSS.forcePseudoState":                             StabilityExperimental,
+	"CSS.getBackgroundColors":                          StabilityExperimental,
+	"CSS.getComputedStyleForNode":                      StabilityExperimental,
+	"CSS.getInlineStylesForNode":                       StabilityExperimental,
+	"CSS.getMatchedStylesForNode":                      StabilityExperimental,
+	"CSS.getMediaQueries":                              StabilityExperimental,
+	"CSS.getPlatformFontsForNode":                      StabilityExperimental,
+	"CSS.getStyleSheetText":                            StabilityExperimental,
+	"CSS.getLayersForNode":                             StabilityExperimental,
+	"CSS.trackComputedStyleUpdates":                    StabilityExperimental,
+	"CSS.takeComputedStyleUpdates":                     StabilityExperimental,
+	"CSS.setEffectivePropertyValueForNode":             StabilityExperimental,
+	"CSS.setKeyframeKey":                               StabilityExperimental,
+	"CSS.setMediaText":                                 StabilityExperimental,
+	"CSS.setContainerQueryText":                        StabilityExperimental,
+	"CSS.setSupportsText":                              StabilityExperimental,
+	"CSS.setScopeText":                                 StabilityExperimental,
+	"CSS.setRuleSelector":                              StabilityExperimental,
+	"CSS.setStyleSheetText":                            StabilityExperimental,
+	"CSS.setStyleTexts":                                StabilityExperimental,
+	"CSS.startRuleUsageTracking":                       StabilityExperimental,
+	"CSS.stopRuleUsageTracking":                        StabilityExperimental,
+	"CSS.takeCoverageDelta":                            StabilityExperimental,
+	"CSS.setLocalFontsEnabled":                         StabilityExperimental,
+	"CSS.fontsUpdated":                                 StabilityExperimental,
+	"CSS.mediaQueryResultChanged":                      StabilityExperimental,
+	"CSS.styleSheetAdded":                              StabilityExperimental,
+	"CSS.styleSheetChanged":                            StabilityExperimental,
+	"CSS.styleSheetRemoved":                            StabilityExperimental,
+	"CacheStorage.deleteCache":                         StabilityExperimental,
+	"CacheStorage.deleteEntry":                         StabilityExperimental,
+	"CacheStorage.requestCacheNames":                   StabilityExperimental,
+	"CacheStorage.requestCachedResponse":               StabilityExperimental,
+	"CacheStorage.requestEntries":                      StabilityExperimental,
+	"Cast.enable":                                      StabilityExperimental,
+	"Cast.disable":                                     StabilityExperimental,
+	"Cast.setSinkToUse":                                StabilityExperimental,
+	"Cast.startDesktopMirroring":                       StabilityExperimental,
+	"Cast.startTabMirroring":                           StabilityExperimental,
+	"Cast.stopCasting":                                 StabilityExperimental,
+	"Cast.sinksUpdated":                                StabilityExperimental,
+	"Cast.issueUpdated":                                StabilityExperimental,
+	"DOM.collectClassNamesFromSubtree":                 StabilityExperimental,
+	"DOM.copyTo":                                       StabilityExperimental,
+	"DOM.scrollIntoViewIfNeeded":                       StabilityExperimental,
+	"DOM.discardSearchResults":                         StabilityExperimental,
+	"DOM.getContentQuads":                              StabilityExperimental,
+	"DOM.getFlattenedDocument":                         StabilityDeprecated,
+	"DOM.getNodesForSubtreeByStyle":                    StabilityExperimental,
+	"DOM.getRelayoutBoundary":                          StabilityExperimental,
+	"DOM.getSearchResults":                             StabilityExperimental,
+	"DOM.markUndoableState":                            StabilityExperimental,
+	"DOM.performSearch":                                StabilityExperimental,
+	"DOM.pushNodeByPathToFrontend":                     StabilityExperimental,
+	"DOM.pushNodesByBackendIdsToFrontend":              StabilityExperimental,
+	"DOM.getTopLayerElements":                          StabilityExperimental,
+	"DOM.redo":                                         StabilityExperimental,
+	"DOM.setNodeStackTracesEnabled":                    StabilityExperimental,
+	"DOM.getNodeStackTraces":                           StabilityExperimental,
+	"DOM.getFileInfo":                                  StabilityExperimental,
+	"DOM.setInspectedNode":                             StabilityExperimental,
+	"DOM.undo":                                         StabilityExperimental,
+	"DOM.getFrameOwner":                                StabilityExperimental,
+	"DOM.getContainerForNode":                          StabilityExperimental,
+	"DOM.getQueryingDescendantsForContainer":           StabilityExperimental,
+	"DOM.distributedNodesUpdated":                      StabilityExperimental,
+	"DOM.inlineStyleInvalidated":                       StabilityExperimental,
+	"DOM.pseudoElementAdded":                           StabilityExperimental,
+	"DOM.topLayerElementsUpdated":                      StabilityExperimental,
+	"DOM.pseudoElementRemoved":                         StabilityExperimental,
+	"DOM.shadowRootPopped":                             StabilityExperimental,
+	"DOM.shadowRootPushed":                             StabilityExperimental,
+	"DOMDebugger.removeInstrumentationBreakpoint":      StabilityExperimental,
+	"DOMDebugger.setBreakOnCSPViolation":               StabilityExperimental,
+	"DOMDebugger.setInstrumentationBreakpoint":         StabilityExperimental,
+	"EventBreakpoints.setInstrumentationBreakpoint":    StabilityExperimental,
+	"EventBreakpoints.removeInstrumentationBreakpoint": StabilityExperimental,
+	"DOMSnapshot.disable":                              StabilityExperimental,
+	"DOMSnapshot.enable":                               StabilityExperimental,
+	"DOMSnapshot.getSnapshot":                          StabilityExperimental | StabilityDeprecated,
+	"DOMSnapshot.captureSnapshot":                      StabilityExperimental,
+	"DOMStorage.clear":                                 StabilityExperimental,
+	"DOMStorage.disable":                               StabilityExperimental,
+	"DOMStorage.enable":                                StabilityExperimental,
+	"DOMStorage.getDOMStorageItems":                    StabilityExperimental,
+	"DOMStorage.removeDOMStorageItem":                  StabilityExperimental,
+	"DOMStorage.setDOMStorageItem":                     StabilityExperimental,
+	"DOMStorage.domStorageItemAdded":                   StabilityExperimental,
+	"DOMStorage.domStorageItemRemoved":                 StabilityExperimental,
+	"DOMStorage.domStorageItemUpdated":                 StabilityExperimental,
+	"DOMStorage.domStorageItemsCleared":                StabilityExperimental,
+	"Database.disable":                                 StabilityExperimental,
+	"Database.enable":                                  StabilityExperimental,
+	"Database.executeSQL":                              StabilityExperimental,
+	"Database.getDatabaseTableNames":                   StabilityExperimental,
+	"Database.addDatabase":                             StabilityExperimental,
+	"DeviceOrientation.clearDeviceOrientationOverride": StabilityExperimental,
+	"DeviceOrientation.setDeviceOrientationOverride":   StabilityExperimental,
+	"Emulation.resetPageScaleFactor":                   StabilityExperimental,
+	"Emulation.setFocusEmulationEnabled":               StabilityExperimental,
+	"Emulation.setAutoDarkModeOverride":                StabilityExperimental,
+	"Emulation.setCPUThrottlingRate":                   StabilityExperimental,
+	"Emulation.setScrollbarsHidden":                    StabilityExperimental,
+	"Emulation.setDocumentCookieDisabled":              StabilityExperimental,
+	"Emulation.setEmitTouchEventsForMouse":             StabilityExperimental,
+	"Emulation.setEmulatedVisionDeficiency":            StabilityExperimental,
+	"Emulation.setIdleOverride":                        StabilityExperimental,
+	"Emulation.clearIdleOverride":                      StabilityExperimental,
+	"Emulation.setNavigatorOverrides":                  StabilityExperimental | StabilityDeprecated,
+	"Emulation.setPageScaleFactor":                     StabilityExperimental,
+	"Emulation.setVirtualTimePolicy":                   StabilityExperimental,
+	"Emulation.setLocaleOverride":                      StabilityExperimental,
+	"Emulation.setTimezoneOverride":                    StabilityExperimental,
+	"Emulation.setVisibleSize":                         StabilityExperimental | StabilityDeprecated,
+	"Emulation.setDisabledImageTypes":                  StabilityExperimental,
+	"Emulation.setHardwareConcurrencyOverride":         StabilityExperimental,
+	"Emulation.setAutomationOverride":                  StabilityExperimental,
+	"Emulation.virtualTimeBudgetExpired":               StabilityExperimental,
+	"HeadlessExperimental.beginFrame":                  StabilityExperimental,
+	"HeadlessExperimental.disable":                     StabilityExperimental,
+	"HeadlessExperimental.enable":                      StabilityExperimental,
+	"HeadlessExperimental.needsBeginFramesChanged":     StabilityExperimental | StabilityDeprecated,
+	"IndexedDB.clearObjectStore":                       StabilityExperimental,
+	"IndexedDB.deleteDatabase":                         StabilityExperimental,
+	"IndexedDB.deleteObjectStoreEntries":               StabilityExperimental,
+	"IndexedDB.disable":                                StabilityExperimental,
+	"IndexedDB.enable":                                 StabilityExperimental,
+	"IndexedDB.requestData":                            StabilityExperimental,
+	"IndexedDB.getMetadata":                            StabilityExperimental,
+	"IndexedDB.requestDatabase":                        StabilityExperimental,
+	"IndexedDB.requestDatabaseNames":                   StabilityExperimental,
+	"Input.dispatchDragEvent":                          StabilityExperimental,
+	"Input.insertText":                                 StabilityExperimental,
+	"Input.imeSetComposition":                          StabilityExperimental,
+	"Input.emulateTouchFromMouseEvent":                 StabilityExperimental,
+	"Input.setInterceptDrags":                          StabilityExperimental,
+	"Input.synthesizePinchGesture":                     StabilityExperimental,
+	"Input.synthesizeScrollGesture":                    StabilityExperimental,
+	"Input.synthesizeTapGesture":                       StabilityExperimental,
+	"Input.dragIntercepted":                            StabilityExperimental,
+	"Inspector.disable":                                StabilityExperimental,
+	"Inspector.enable":                                 StabilityExperimental,
+	"Inspector.detached":                               StabilityExperimental,
+	"Inspector.targetCrashed":                          StabilityExperimental,
+	"Inspector.targetReloadedAfterCrash":               StabilityExperimental,
+	"LayerTree.compositingReasons":                     StabilityExperimental,
+	"LayerTree.disable":                                StabilityExperimental,
+	"LayerTree.enable":                                 StabilityExperimental,
+	"LayerTree.loadSnapshot":                           StabilityExperimental,
+	"LayerTree.makeSnapshot":                           StabilityExperimental,
+	"LayerTree.profileSnapshot":                        StabilityExperimental,
+	"LayerTree.releaseSnapshot":                        StabilityExperimental,
+	"LayerTree.replaySnapshot":                         StabilityExperimental,
+	"LayerTree.snapshotCommandLog":                     StabilityExperimental,
+	"LayerTree.layerPainted":                           StabilityExperimental,
+	"LayerTree.layerTreeDidChange":                     StabilityExperimental,
+	"Memory.getDOMCounters":                            StabilityExperimental,
+	"Memory.prepareForLeakDetection":                   StabilityExperimental,
+	"Memory.forciblyPurgeJavaScriptMemory":             StabilityExperimental,
+	"Memory.setPressureNotificationsSuppressed":        StabilityExperimental,
+	"Memory.simulatePressureNotification":              StabilityExperimental,
+	"Memory.startSampling":                             StabilityExperimental,
+	"Memory.stopSampling":                              StabilityExperimental,
+	"Memory.getAllTimeSamplingProfile":                 StabilityExperimental,
+	"Memory.getBrowserSamplingProfile":                 StabilityExperimental,
+	"Memory.getSamplingProfile":                        StabilityExperimental,
+	"Network.setAcceptedEncodings":                     StabilityExperimental,
+	"Network.clearAcceptedEncodingsOverride":           StabilityExperimental,
+	"Network.canClearBrowserCache":                     StabilityDeprecated,
+	"Network.canClearBrowserCookies":                   StabilityDeprecated,
+	"Network.canEmulateNetworkConditions":              StabilityDeprecated,
+	"Network.continueInterceptedRequest":               StabilityExperimental | StabilityDeprecated,
+	"Network.getCertificate":                           StabilityExperimental,
+	"Network.getResponseBodyForInterception":           StabilityExperimental,
+	"Network.takeResponseBodyForInterceptionAsStream":  StabilityExperimental,
+	"Network.replayXHR":                                StabilityExperimental,
+	"Network.searchInResponseBody":                     StabilityExperimental,
+	"Network.setBlockedURLs":                           StabilityExperimental,
+	"Network.setBypassServiceWorker":                   StabilityExperimental,
+	"Network.setAttachDebugStack":                      StabilityExperimental,
+	"Network.setRequestInterception":                   StabilityExperimental | StabilityDeprecated,
+	"Network.getSecurityIsolationStatus":               StabilityExperimental,
+	"Network.enableReportingApi":                       StabilityExperimental,
+	"Network.loadNetworkResource":                      StabilityExperimental,
+	"Network.requestIntercepted":                       StabilityExperimental | StabilityDeprecated,
+	"Network.resourceChangedPriority":                  StabilityExperimental,
+	"Network.signedExchangeReceived":                   StabilityExperimental,
+	"Network.requestWillBeSentExtraInfo":               StabilityExperimental,
+	"Network.responseReceivedExtraInfo":                StabilityExperimental,
+	"Network.trustTokenOperationDone":                  StabilityExperimental,
+	"Network.subresourceWebBundleMetadataReceived":     StabilityExperimental,
+	"Network.subresourceWebBundleMetadataError":        StabilityExperimental,
+	"Network.subresourceWebBundleInnerResponseParsed":  StabilityExperimental,
+	"Network.subresourceWebBundleInnerResponseError":   StabilityExperimental,
+	"Network.reportingApiReportAdded":                  StabilityExperimental,
+	"Network.reportingApiReportUpdated":                StabilityExperimental,
+	"Network.reportingApiEndpointsChangedForOrigin":    StabilityExperimental,
+	"Overlay.disable":                                  StabilityExperimental,
+	"Overlay.enable":                                   StabilityExperimental,
+	"Overlay.getHighlightObjectForTest":                StabilityExperimental,
+	"Overlay.getGridHighlightObjectsForTest":           StabilityExperimental,
+	"Overlay.getSourceOrderHighlightObjectForTest":     StabilityExperimental,
+	"Overlay.hideHighlight":                            StabilityExperimental,
+	"Overlay.highlightFrame":                           StabilityExperimental | StabilityDeprecated,
+	"Overlay.highlightNode":                            StabilityExperimental,
+	"Overlay.highlightQuad":                            StabilityExperimental,
+	"Overlay.highlightRect":                            StabilityExperimental,
+	"Overlay.highlightSourceOrder":                     StabilityExperimental,
+	"Overlay.setInspectMode":                           StabilityExperimental,
+	"Overlay.setShowAdHighlights":                      StabilityExperimental,
+	"Overlay.setPausedInDebuggerMessage":               StabilityExperimental,
+	"Overlay.setShowDebugBorders":                      StabilityExperimental,
+	"Overlay.setShowFPSCounter":                        StabilityExperimental,
+	"Overlay.setShowGridOverlays":                      StabilityExperimental,
+	"Overlay.setShowFlexOverlays":                      StabilityExperimental,
+	"Overlay.setShowScrollSnapOverlays":                StabilityExperimental,
+	"Overlay.setShowContainerQueryOverlays":            StabilityExperimental,
+	"Overlay.setShowPaintRects":                        StabilityExperimental,
+	"Overlay.setShowLayoutShiftRegions":                StabilityExperimental,
+	"Overlay.setShowScrollBottleneckRects":             StabilityExperimental,
+	"Overlay.setShowHitTestBorders":                    StabilityExperimental | StabilityDeprecated,
+	"Overlay.setShowWebVitals":                         StabilityExperimental,
+	"Overlay.setShowViewportSizeOnResize":              StabilityExperimental,
+	"Overlay.setShowHinge":                             StabilityExperimental,
+	"Overlay.setShowIsolatedElements":                  StabilityExperimental,
+	"Overlay.inspectNodeRequested":                     StabilityExperimental,
+	"Overlay.nodeHighlightRequested":                   StabilityExperimental,
+	"Overlay.screenshotRequested":                      StabilityExperimental,
+	"Overlay.inspectModeCanceled":                      StabilityExperimental,
+	"Page.addScriptToEvaluateOnLoad":                   StabilityExperimental | StabilityDeprecated,
+	"Page.captureSnapshot":                             StabilityExperimental,
+	"Page.clearDeviceMetricsOverride":                  StabilityExperimental | StabilityDeprecated,
+	"Page.clearDeviceOrientationOverride":              StabilityExperimental | StabilityDeprecated,
+	"Page.clearGeolocationOverride":                    StabilityDeprecated,
+	"Page.deleteCookie":                                StabilityExperimental | StabilityDeprecated,
+	"Page.getInstallabilityErrors":                     StabilityExperimental,
+	"Page.getManifestIcons":                            StabilityExperimental,
+	"Page.getAppId":                                    StabilityExperimental,
+	"Page.getCookies":                                  StabilityExperimental | StabilityDeprecated,
+	"Page.getResourceContent":                          StabilityExperimental,
+	"Page.getResourceTree":                             StabilityExperimental,
+	"Page.removeScriptToEvaluateOnLoad":                StabilityExperimental | StabilityDeprecated,
+	"Page.screencastFrameAck":                          StabilityExperimental,
+	"Page.searchInResource":                            StabilityExperimental,
+	"Page.setAdBlockingEnabled":                        StabilityExperimental,
+	"Page.setBypassCSP":                                StabilityExperimental,
+	"Page.getPermissionsPolicyState":                   StabilityExperimental,
+	"Page.getOriginTrials":                             StabilityExperimental,
+	"Page.setDeviceMetricsOverride":                    StabilityExperimental | StabilityDeprecated,
+	"Page.setDeviceOrientationOverride":                StabilityExperimental | StabilityDeprecated,
+	"Page.setFontFamilies":                             StabilityExperimental,
+	"Page.setFontSizes":                                StabilityExperimental,
+	"Page.setDownloadBehavior":                         StabilityExperimental | StabilityDeprecated,
+	"Page.setGeolocationOverride":                      StabilityDeprecated,
+	"Page.setLifecycleEventsEnabled":                   StabilityExperimental,
+	"Page.setTouchEmulationEnabled":                    StabilityExperimental | StabilityDeprecated,
+	"Page.startScreencast":                             StabilityExperimental,
+	"Page.crash":                                       StabilityExperimental,
+	"Page.close":                                       StabilityExperimental,
+	"Page.setWebLifecycleState":                        StabilityExperimental,
+	"Page.stopScreencast":                              StabilityExperimental,
+	"Page.produceCompilationCache":                     StabilityExperimental,
+	"Page.addCompilationCache":                         StabilityExperimental,
+	"Page.clearCompilationCache":                       StabilityExperimental,
+	"Page.setSPCTransactionMode":                       StabilityExperimental,
+	"Page.generateTestReport":                          StabilityExperimental,
+	"Page.waitForDebugger":                             StabilityExperimental,
+	"Page.setInterceptFileChooserDialog":               StabilityExperimental,
+	"Page.frameClearedScheduledNavigation":             StabilityDeprecated,
+	"Page.documentOpened":                              StabilityExperimental,
+	"Page.frameResized":                                StabilityExperimental,
+	"Page.frameRequestedNavigation":                    StabilityExperimental,
+	"Page.frameScheduledNavigation":                    StabilityDeprecated,
+	"Page.frameStartedLoading":                         StabilityExperimental,
+	"Page.frameStoppedLoading":                         StabilityExperimental,
+	"Page.downloadWillBegin":                           StabilityExperimental | StabilityDeprecated,
+	"Page.downloadProgress":                            StabilityExperimental | StabilityDeprecated,
+	"Page.backForwardCacheNotUsed":                     StabilityExperimental,
+	"Page.navigatedWithinDocument":                     StabilityExperimental,
+	"Page.screencastFrame":                             StabilityExperimental,
+	"Page.screencastVisibilityChanged":                 StabilityExperimental,
+	"Page.compilationCacheProduced":                    StabilityExperimental,
+	"Performance.setTimeDomain":                        StabilityExperimental | StabilityDeprecated,
+	"PerformanceTimeline.enable":                       StabilityExperimental,
+	"PerformanceTimeline.timelineEventAdded":           StabilityExperimental,
+	"Security.setIgnoreCertificateErrors":              StabilityExperimental,
+	"Security.handleCertificateError":                  StabilityDeprecated,
+	"Security.setOverrideCertificateErrors":            StabilityDeprecated,
+	"Security.certificateError":                        StabilityDeprecated,
+	"Security.visibleSecurityStateChanged":             StabilityExperimental,
+	"Security.securityStateChanged":                    StabilityDeprecated,
+	"ServiceWorker.deliverPushMessage":                 StabilityExperimental,
+	"ServiceWorker.disable":                            StabilityExperimental,
+	"ServiceWorker.dispatchSyncEvent":                  StabilityExperimental,
+	"ServiceWorker.dispatchPeriodicSyncEvent":          StabilityExperimental,
+	"ServiceWorker.enable":                             StabilityExperimental,
+	"ServiceWorker.inspectWorker":                      StabilityExperimental,
+	"ServiceWorker.setForceUpdateOnPageLoad":           StabilityExperimental,
+	"ServiceWorker.skipWaiting":                        StabilityExperimental,
+	"ServiceWorker.startWorker":                        StabilityExperimental,
+	"ServiceWorker.stopAllWorkers":                     StabilityExperimental,
+	"ServiceWorker.stopWorker":                         StabilityExperimental,
+	"ServiceWorker.unregister":                         StabilityExperimental,
+	"ServiceWorker.updateRegistration":                 StabilityExperimental,
+	"ServiceWorker.workerErrorReported":                StabilityExperimental,
+	"ServiceWorker.workerRegistrationUpdated":          StabilityExperimental,
+	"ServiceWorker.workerVersionUpdated":               StabilityExperimental,
+	"Storage.getStorageKeyForFrame":                    StabilityExperimental,
+	"Storage.clearDataForOrigin":                       StabilityExperimental,
+	"Storage.clearDataForStorageKey":                   StabilityExperimental,
+	"Storage.getCookies":                               StabilityExperimental,
+	"Storage.setCookies":                               StabilityExperimental,
+	"Storage.clearCookies":                             StabilityExperimental,
+	"Storage.getUsageAndQuota":                         StabilityExperimental,
+	"Storage.overrideQuotaForOrigin":                   StabilityExperimental,
+	"Storage.trackCacheStorageForOrigin":               StabilityExperimental,
+	"Storage.trackIndexedDBForOrigin":                  StabilityExperimental,
+	"Storage.trackIndexedDBForStorageKey":              StabilityExperimental,
+	"Storage.untrackCacheStorageForOrigin":             StabilityExperimental,
+	"Storage.untrackIndexedDBForOrigin":                StabilityExperimental,
+	"Storage.untrackIndexedDBForStorageKey":            StabilityExperimental,
+	"Storage.getTrustTokens":                           StabilityExperimental,
+	"Storage.clearTrustTokens":                         StabilityExperimental,
+	"Storage.getInterestGroupDetails":                  StabilityExperimental,
+	"Storage.setInterestGroupTracking":                 StabilityExperimental,
+	"Storage.cacheStorageContentUpdated":               StabilityExperimental,
+	"Storage.cacheStorageListUpdated":                  StabilityExperimental,
+	"Storage.indexedDBContentUpdated":                  StabilityExperimental,
+	"Storage.indexedDBListUpdated":                     StabilityExperimental,
+	"Storage.interestGroupAccessed":                    StabilityExperimental,
+	"SystemInfo.getInfo":                               StabilityExperimental,
+	"SystemInfo.getProcessInfo":                        StabilityExperimental,
+	"Target.attachToBrowserTarget":                     StabilityExperimental,
+	"Target.exposeDevToolsProtocol":                    StabilityExperimental,
+	"Target.createBrowserContext":                      StabilityExperimental,
+	"Target.getBrowserContexts":                        StabilityExperimental,
+	"Target.disposeBrowserContext":                     StabilityExperimental,
+	"Target.getTargetInfo":                             StabilityExperimental,
+	"Target.sendMessageToTarget":                       StabilityDeprecated,
+	"Target.setAutoAttach":                             StabilityExperimental,
+	"Target.autoAttachRelated":                         StabilityExperimental,
+	"Target.setRemoteLocations":                        StabilityExperimental,
+	"Target.attachedToTarget":                          StabilityExperimental,
+	"Target.detachedFromTarget":                        StabilityExperimental,
+	"Tethering.bind":                                   StabilityExperimental,
+	"Tethering.unbind":                                 StabilityExperimental,
+	"Tethering.accepted":                               StabilityExperimental,
+	"Fetch.continueResponse":                           StabilityExperimental,
+	"WebAudio.enable":                                  StabilityExperimental,
+	"WebAudio.disable":                                 StabilityExperimental,
+	"WebAudio.getRealtimeData":                         StabilityExperimental,
+	"WebAudio.contextCreated":                          StabilityExperimental,
+	"WebAudio.contextWillBeDestroyed":                  StabilityExperimental,
+	"WebAudio.contextChanged":                          StabilityExperimental,
+	"WebAudio.audioListenerCreated":                    StabilityExperimental,
+	"WebAudio.audioListenerWillBeDestroyed":            StabilityExperimental,
+	"WebAudio.audioNodeCreated":                        StabilityExperimental,
+	"WebAudio.audioNodeWillBeDestroyed":                StabilityExperimental,
+	"WebAudio.audioParamCreated":                       StabilityExperimental,
+	"WebAudio.audioParamWillBeDestroyed":               StabilityExperimental,
+	"WebAudio.nodesConnected":                          StabilityExperimental,
+	"WebAudio.nodesDisconnected":                       StabilityExperimental,
+	"WebAudio.nodeParamConnected":                      StabilityExperimental,
+	"WebAudio.nodeParamDisconnected":                   StabilityExperimental,
+	"WebAuthn.enable":                                  StabilityExperimental,
+	"WebAuthn.disable":                                 StabilityExperimental,
+	"WebAuthn.addVirtualAuthenticator":                 StabilityExperimental,
+	"WebAuthn.removeVirtualAuthenticator":              StabilityExperimental,
+	"WebAuthn.addCredential":                           StabilityExperimental,
+	"WebAuthn.getCredential":                           StabilityExperimental,
+	"WebAuthn.getCredentials":                          StabilityExperimental,
+	"WebAuthn.removeCredential":                        StabilityExperimental,
+	"WebAuthn.clearCredentials":                        StabilityExperimental,
+	"WebAuthn.setUserVerified":                         StabilityExperimental,
+	"WebAuthn.setAutomaticPresenceSimulation":          StabilityExperimental,
+	"Media.enable":                                     StabilityExperimental,
+	"Media.disable":                                    StabilityExperimental,
+	"Media.playerPropertiesChanged":                    StabilityExperimental,
+	"Media.playerEventsAdded":                          StabilityExperimental,
+	"Media.playerMessagesLogged":                       StabilityExperimental,
+	"Media.playerErrorsRaised":                         StabilityExperimental,
+	"Media.playersCreated":                             StabilityExperimental,
+	"Debugger.disassembleWasmModule":                   StabilityExperimental,
+	"Debugger.nextWasmDisassemblyChunk":                StabilityExperimental,
+	"Debugger.getWasmBytecode":                         StabilityDeprecated,
+	"Debugger.getStackTrace":                           StabilityExperimental,
+	"Debugger.pauseOnAsyncCall":                        StabilityExperimental | StabilityDeprecated,
+	"Debugger.setBlackboxPatterns":                     StabilityExperimental,
+	"Debugger.setBlackboxedRanges":                     StabilityExperimental,
+	"Debugger.setBreakpointOnFunctionCall":             StabilityExperimental,
+	"Debugger.setReturnValue":                          StabilityExperimental,
+	"HeapProfiler.addInspectedHeapObject":              StabilityExperimental,
+	"HeapProfiler.collectGarbage":                      StabilityExperimental,
+	"HeapProfiler.disable":                             StabilityExperimental,
+	"HeapProfiler.enable":                              StabilityExperimental,
+	"HeapProfiler.getHeapObjectId":                     StabilityExperimental,
+	"HeapProfiler.getObjectByHeapObjectId":             StabilityExperimental,
+	"HeapProfiler.getSamplingProfile":                  StabilityExperimental,
+	"HeapProfiler.startSampling":                       StabilityExperimental,
+	"HeapProfiler.startTrackingHeapObjects":            StabilityExperimental,
+	"HeapProfiler.stopSampling":                        StabilityExperimental,
+	"HeapProfiler.stopTrackingHeapObjects":             StabilityExperimental,
+	"HeapProfiler.takeHeapSnapshot":                    StabilityExperimental,
+	"HeapProfiler.addHeapSnapshotChunk":                StabilityExperimental,
+	"HeapProfiler.heapStatsUpdate":                     StabilityExperimental,
+	"HeapProfiler.lastSeenObjectId":                    StabilityExperimental,
+	"HeapProfiler.reportHeapSnapshotProgress":          StabilityExperimental,
+	"HeapProfiler.resetProfiles":                       StabilityExperimental,
+	"Profiler.startTypeProfile":                        StabilityExperimental,
+	"Profiler.stopTypeProfile":                         StabilityExperimental,
+	"Profiler.takeTypeProfile":                         StabilityExperimental,
+	"Profiler.preciseCoverageDeltaUpdate":              StabilityExperimental,
+	"Runtime.getIsolateId":                             StabilityExperimental,
+	"Runtime.getHeapUsage":                             StabilityExperimental,
+	"Runtime.setCustomObjectFormatterEnabled":          StabilityExperimental,
+	"Runtime.setMaxCallStackSizeToCapture":             StabilityExperimental,
+	"Runtime.terminateExecution":                       StabilityExperimental,
+	"Runtime.addBinding":                               StabilityExperimental,
+	"Runtime.removeBinding":                            StabilityExperimental,
+	"Runtime.getExceptionDetails":                      StabilityExperimental,
+	"Runtime.bindingCalled":                            StabilityExperimental,
+}
+
+var events = map[string]func() Event{
+
+	"Accessibility.loadComplete":                       func() Event { return &AccessibilityLoadComplete{} },
+	"Accessibility.nodesUpdated":                       func() Event { return &AccessibilityNodesUpdated{} },
+	"Animation.animationCanceled":                      func() Event { return &AnimationAnimationCanceled{} },
+	"Animation.animationCreated":                       func() Event { return &AnimationAnimationCreated{} },
+	"Animation.animationStarted":                       func() Event { return &AnimationAnimationStarted{} },
+	"Audits.issueAdded":                                func() Event { return &AuditsIssueAdded{} },
+	"BackgroundService.recordingStateChanged":          func() Event { return &BackgroundServiceRecordingStateChanged{} },
+	"BackgroundService.backgroundServiceEventReceived": func() Event { return &BackgroundServiceBackgroundServiceEventReceived{} },
+	"Browser.downloadWillBegin":                        func() Event { return &BrowserDownloadWillBegin{} },
+	"Browser.downloadProgress":                         func() Event { return &BrowserDownloadProgress{} },
+	"CSS.fontsUpdated":                                 func() Event { return &CSSFontsUpdated{} },
+	"CSS.mediaQueryResultChanged":                      func() Event { return &CSSMediaQueryResultChanged{} },
+	"CSS.styleSheetAdded":                              func() Event { return &CSSStyleSheetAdded{} },
+	"CSS.styleSheetChanged":                            func() Event { return &CSSStyleSheetChanged{} },
+	"CSS.styleSheetRemoved":                            func() Event { return &CSSStyleSheetRemoved{} },
+	"Cast.sinksUpdated":                                func() Event { return &CastSinksUpdated{} },
+	"Cast.issueUpdated":                                func() Event { return &CastIssueUpdated{} },
+	"DOM.attributeModified":                            func() Event { return &DOMAttributeModified{} },
+	"DOM.attributeRemoved":                             func() Event { return &DOMAttributeRemoved{} },
+	"DOM.characterDataModified":                        func() Event { return &DOMCharacterDataModified{} },
+	"DOM.childNodeCountUpdated":                        func() Event { return &DOMChildNodeCountUpdated{} },
+	"DOM.childNodeInserted":                            func() Event { return &DOMChildNodeInserted{} },
+	"DOM.childNodeRemoved":                             func() Event { return &DOMChildNodeRemoved{} },
+	"DOM.distributedNodesUpdated":                      func() Event { return &DOMDistributedNodesUpdated{} },
+	"DOM.documentUpdated":                              func() Event { return &DOMDocumentUpdated{} },
+	"DOM.inlineStyleInvalidated":                       func() Event { return &DOMInlineStyleInvalidated{} },
+	"DOM.pseudoElementAdded":                           func() Event { return &DOMPseudoElementAdded{} },
+	"DOM.topLayerElementsUpdated":                      func() Event { return &DOMTopLayerElementsUpdated{} },
+	"DOM.pseudoElementRemoved":                         func() Event { return &DOMPseudoElementRemoved{} },
+	"DOM.setChildNodes":                                func() Event { return &DOMSetChildNodes{} },
+	"DOM.shadowRootPopped":                             func() Event { return &DOMShadowRootPopped{} },
+	"DOM.shadowRootPushed":                             func() Event { return &DOMShadowRootPushed{} },
+	"DOMStorage.domStorageItemAdded":                   func() Event { return &DOMStorageDomStorageItemAdded{} },
+	"DOMStorage.domStorageItemRemoved":                 func() Event { return &DOMStorageDomStorageItemRemoved{} },
+	"DOMStorage.domStorageItemUpdated":                 func() Event { return &DOMStorageDomStorageItemUpdated{} },
+	"DOMStorage.domStorageItemsCleared":                func() Event { return &DOMStorageDomStorageItemsCleared{} },
+	"Database.addDatabase":                             func() Event { return &DatabaseAddDatabase{} },
+	"Emulation.virtualTimeBudgetExpired":               func() Event { return &EmulationVirtualTimeBudgetExpired{} },
+	"HeadlessExperimental.needsBeginFramesChanged":     func() Event { return &HeadlessExperimentalNeedsBeginFramesChanged{} },
+	"Input.dragIntercepted":                            func() Event { return &InputDragIntercepted{} },
+	"Inspector.detached":                               func() Event { return &InspectorDetached{} },
+	"Inspector.targetCrashed":                          func() Event { return &InspectorTargetCrashed{} },
+	"Inspector.targetReloadedAfterCrash":               func() Event { return &InspectorTargetReloadedAfterCrash{} },
+	"LayerTree.layerPainted":                           func() Event { return &LayerTreeLayerPainted{} },
+	"LayerTree.layerTreeDidChange":                     func() Event { return &LayerTreeLayerTreeDidChange{} },
+	"Log.entryAdded":                                   func() Event { return &LogEntryAdded{} },
+	"Network.dataReceived":                             func() Event { return &NetworkDataReceived{} },
+	"Network.eventSourceMessageReceived":               func() Event { return &NetworkEventSourceMessageReceived{} },
+	"Network.loadingFailed":                            func() Event { return &NetworkLoadingFailed{} },
+	"Network.loadingFinished":                          func() Event { return &NetworkLoadingFinished{} },
+	"Network.requestIntercepted":                       func() Event { return &NetworkRequestIntercepted{} },
+	"Network.requestServedFromCache":                   func() Event { return &NetworkRequestServedFromCache{} },
+	"Network.requestWillBeSent":                        func() Event { return &NetworkRequestWillBeSent{} },
+	"Network.resourceChangedPriority":                  func() Event { return &NetworkResourceChangedPriority{} },
+	"Network.signedExchangeReceived":                   func() Event { return &NetworkSignedExchangeReceived{} },
+	"Network.responseReceived":                         func() Event { return &NetworkResponseReceived{} },
+	"Network.webSocketClosed":                          func() Event { return &NetworkWebSocketClosed{} },
+	"Network.webSocketCreated":                         func() Event { return &NetworkWebSocketCreated{} },
+	"Network.webSocketFrameError":                      func() Event { return &NetworkWebSocketFrameError{} },
+	"Network.webSocketFrameReceived":                   func() Event { return &NetworkWebSocketFrameReceived{} },
+	"Network.webSocketFrameSent":                       func() Event { return &NetworkWebSocketFrameSent{} },
+	"Network.webSocketHandshakeResponseReceived":       func() Event { return &NetworkWebSocketHandshakeResponseReceived{} },
+	"Network.webSocketWillSendHandshakeRequest":        func() Event { return &NetworkWebSocketWillSendHandshakeRequest{} },
+	"Network.webTransportCreated":                      func() Event { return &NetworkWebTransportCreated{} },
+	"Network.webTransportConnectionEstablished":        func() Event { return &NetworkWebTransportConnectionEstablished{} },
+	"Network.webTransportClosed":                       func() Event { return &NetworkWebTransportClosed{} },
+	"Network.requestWillBeSentExtraInfo":               func() Event { return &NetworkRequestWillBeSentExtraInfo{} },
+	"Network.responseReceivedExtraInfo":                func() Event { return &NetworkResponseReceivedExtraInfo{} },
+	"Network.trustTokenOperationDone":                  func() Event { return &NetworkTrustTokenOperationDone{} },
+	"Network.subresourceWebBundleMetadataReceived":     func() Event { return &NetworkSubresourceWebBundleMetadataReceived{} },
+	"Network.subresourceWebBundleMetadataError":        func() Event { return &NetworkSubresourceWebBundleMetadataError{} },
+	"Network.subresourceWebBundleInnerResponseParsed":  func() Event { return &NetworkSubresourceWebBundleInnerResponseParsed{} },
+	"Network.subresourceWebBundleInnerResponseError":   func() Event { return &NetworkSubresourceWebBundleInnerResponseError{} },
+	"Network.reportingApiReportAdded":                  func() Event { return &NetworkReportingAPIReportAdded{} },
+	"Network.reportingApiReportUpdated":                func() Event { return &NetworkReportingAPIReportUpdated{} },
+	"Network.reportingApiEndpointsChangedForOrigin":    func() Event { return &NetworkReportingAPIEndpointsChangedForOrigin{} },
+	"Overlay.inspectNodeRequested":                     func() Event { return &OverlayInspectNodeRequested{} },
+	"Overlay.nodeHighlightRequested":                   func() Event { return &OverlayNodeHighlightRequested{} },
+	"Overlay.screenshotRequested":                      func() Event { return &OverlayScreenshotRequested{} },
+	"Overlay.inspectModeCanceled":                      func() Event { return &OverlayInspectModeCanceled{} },
+	"Page.domContentEventFired":                        func() Event { return &PageDomContentEventFired{} },
+	"Page.fileChooserOpened":                           func() Event { return &PageFileChooserOpened{} },
+	"Page.frameAttached":                               func() Event { return &PageFrameAttached{} },
+	"Page.frameClearedScheduledNavigation":             func() Event { return &PageFrameClearedScheduledNavigation{} },
+	"Page.frameDetached":                               func() Event { return &PageFrameDetached{} },
+	"Page.frameNavigated":                              func() Event { return &PageFrameNavigated{} },
+	"Page.documentOpened":                              func() Event { return &PageDocumentOpened{} },
+	"Page.frameResized":                                func() Event { return &PageFrameResized{} },
+	"Page.frameRequestedNavigation":                    func() Event { return &PageFrameRequestedNavigation{} },
+	"Page.frameScheduledNavigation":                    func() Event { return &PageFrameScheduledNavigation{} },
+	"Page.frameStartedLoading":                         func() Event { return &PageFrameStartedLoading{} },
+	"Page.frameStoppedLoading":                         func() Event { return &PageFrameStoppedLoading{} },
+	"Page.downloadWillBegin":                           func() Event { return &PageDownloadWillBegin{} },
+	"Page.downloadProgress":                            func() Event { return &PageDownloadProgress{} },
+	"Page.interstitialHidden":                          func() Event { return &PageInterstitialHidden{} },
+	"Page.interstitialShown":                           func() Event { return &PageInterstitialShown{} },
+	"Page.javascriptDialogClosed":                      func() Event { return &PageJavascriptDialogClosed{} },
+	"Page.javascriptDialogOpening":                     func() Event { return &PageJavascriptDialogOpening{} },
+	"Page.lifecycleEvent":                              func() Event { return &PageLifecycleEvent{} },
+	"Page.backForwardCacheNotUsed":                     func() Event { return &PageBackForwardCacheNotUsed{} },
+	"Page.prerenderAttemptCompleted":                   func() Event { return &PagePrerenderAttemptCompleted{} },
+	"Page.loadEventFired":                              func() Event { return &PageLoadEventFired{} },
+	"Page.navigatedWithinDocument":                     func() Event { return &PageNavigatedWithinDocument{} },
+	"Page.screencastFrame":                             func() Event { return &PageScreencastFrame{} },
+	"Page.screencastVisibilityChanged":                 func() Event { return &PageScreencastVisibilityChanged{} },
+	"Page.windowOpen":                                  func() Event { return &PageWindowOpen{} },
+	"Page.compilationCacheProduced":                    func() Event { return &PageCompilationCacheProduced{} },
+	"Performance.metrics":                              func() Event { return &PerformanceMetrics{} },
+	"PerformanceTimeline.timelineEventAdded":           func() Event { return &PerformanceTimelineTimelineEventAdded{} },
+	"Security.certificateError":                        func() Event { return &SecurityCertificateError{} },
+	"Security.visibleSecurityStateChanged":             func() Event { return &SecurityVisibleSecurityStateChanged{} },
+	"Security.securityStateChanged":                    func() Event { return &SecuritySecurityStateChanged{} },
+	"ServiceWorker.workerErrorReported":                func() Event { return &ServiceWorkerWorkerErrorReported{} },
+	"ServiceWorker.workerRegistrationUpdated":          func() Event { return &ServiceWorkerWorkerRegistrationUpdated{} },
+	"ServiceWorker.workerVersionUpdated":               func() Event { return &ServiceWorkerWorkerVersionUpdated{} },
+	"Storage.cacheStorageContentUpdated":               func() Event { return &StorageCacheStorageContentUpdated{} },
+	"Storage.cacheStorageListUpdated":                  func() Event { return &StorageCacheStorageListUpdated{} },
+	"Storage.indexedDBContentUpdated":                  func() Event { return &StorageIndexedDBContentUpdated{} },
+	"Storage.indexedDBListUpdated":                     func() Event { return &StorageIndexedDBListUpdated{} },
+	"Storage.interestGroupAccessed":                    func() Event { return &StorageInterestGroupAccessed{} },
+	"Target.attachedToTarget":                          func() Event { return &TargetAttachedToTarget{} },
+	"Target.detachedFromTarget":                        func() Event { return &TargetDetachedFromTarget{} },
+	"Target.receivedMessageFromTarget":                 func() Event { return &TargetReceivedMessageFromTarget{} },
+	"Target.targetCreated":                             func() Event { return &TargetTargetCreated{} },
+	"Target.targetDestroyed":                           func() Event { return &TargetTargetDestroyed{} },
+	"Target.targetCrashed":                             func() Event { return &TargetTargetCrashed{} },
+	"Target.targetInfoChanged":                         func() Event { return &TargetTargetInfoChanged{} },
+	"Tethering.accepted":                               func() Event { return &TetheringAccepted{} },
+	"Tracing.bufferUsage":                              func() Event { return &TracingBufferUsage{} },
+	"Tracing.dataCollected":                            func() Event { return &TracingDataCollected{} },
+	"Tracing.tracingComplete":                          func() Event { return &TracingTracingComplete{} },
+	"Fetch.requestPaused":                              func() Event { return &FetchRequestPaused{} },
+	"Fetch.authRequired":                               func() Event { return &FetchAuthRequired{} },
+	"WebAudio.contextCreated":                          func() Event { return &WebAudioContextCreated{} },
+	"WebAudio.contextWillBeDestroyed":                  func() Event { return &WebAudioContextWillBeDestroyed{} },
+	"WebAudio.contextChanged":                          func() Event { return &WebAudioContextChanged{} },
+	"WebAudio.audioListenerCreated":                    func() Event { return &WebAudioAudioListenerCreated{} },
+	"WebAudio.audioListenerWillBeDestroyed":            func() Event { return &WebAudioAudioListenerWillBeDestroyed{} },
+	"WebAudio.audioNodeCreated":                        func() Event { return &WebAudioAudioNodeCreated{} },
+	"WebAudio.audioNodeWillBeDestroyed":                func() Event { return &WebAudioAudioNodeWillBeDestroyed{} },
+	"WebAudio.audioParamCreated":                       func() Event { return &WebAudioAudioParamCreated{} },
+	"WebAudio.audioParamWillBeDestroyed":               func() Event { return &WebAudioAudioParamWillBeDestroyed{} },
+	"WebAudio.nodesConnected":                          func() Event { return &WebAudioNodesConnected{} },
+	"WebAudio.nodesDisconnected":                       func() Event { return &WebAudioNodesDisconnected{} },
+	"WebAudio.nodeParamConnected":                      func() Event { return &WebAudioNodeParamConnected{} },
+	"WebAudio.nodeParamDisconnected":                   func() Event { return &WebAudioNodeParamDisconnected{} },
+	"Media.playerPropertiesChanged":                    func() Event { return &MediaPlayerPropertiesChanged{} },
+	"Media.playerEventsAdded":                          func() Event { return &MediaPlayerEventsAdded{} },
+	"Media.playerMessagesLogged":                       func() Event { return &MediaPlayerMessagesLogged{} },
+	"Media.playerErrorsRaised":                         func() Event { return &MediaPlayerErrorsRaised{} },
+	"Media.playersCreated":                             func() Event { return &MediaPlayersCreated{} },
+	"Console.messageAdded":                             func() Event { return &ConsoleMessageAdded{} },
+	"Debugger.breakpointResolved":                      func() Event { return &DebuggerBreakpointResolved{} },
+	"Debugger.paused":                                  func() Event { return &DebuggerPaused{} },
+	"Debugger.resumed":                                 func() Event { return &DebuggerResumed{} },
+	"Debugger.scriptFailedToParse":                     func() Event { return &DebuggerScriptFailedToParse{} },
+	"Debugger.scriptParsed":                            func() Event { return &DebuggerScriptParsed{} },
+	"HeapProfiler.addHeapSnapshotChunk":                func() Event { return &HeapProfilerAddHeapSnapshotChunk{} },
+	"HeapProfiler.heapStatsUpdate":                     func() Event { return &HeapProfilerHeapStatsUpdate{} },
+	"HeapProfiler.lastSeenObjectId":                    func() Event { return &HeapProfilerLastSeenObjectID{} },
+	"HeapProfiler.reportHeapSnapshotProgress":          func() Event { return &HeapProfilerReportHeapSnapshotProgress{} },
+	"HeapProfiler.resetProfiles":                       func() Event { return &HeapProfilerResetProfiles{} },
+	"Profiler.consoleProfileFinished":                  func() Event { return &ProfilerConsoleProfileFinished{} },
+	"Profiler.consoleProfileStarted":                   func() Event { return &ProfilerConsoleProfileStarted{} },
+	"Profiler.preciseCoverageDeltaUpdate":              func() Event { return &ProfilerPreciseCoverageDeltaUpdate{} },
+	"Runtime.bindingCalled":                            func() Event { return &RuntimeBindingCalled{} },
+	"Runtime.consoleAPICalled":                         func() Event { return &RuntimeConsoleAPICalled{} },
+	"Runtime.exceptionRevoked":                         func() Event { return &RuntimeExceptionRevoked{} },
+	"Runtime.exceptionThrown":                          func() Event { return &RuntimeExceptionThrown{} },
+	"Runtime.executionContextCreated":                  func() Event { return &RuntimeExecutionContextCreated{} },
+	"Runtime.executionContextDestroyed":                func() Event { return &RuntimeExecutionContextDestroyed{} },
+	"Runtime.executionContextsCleared":                 func() Event { return &RuntimeExecutionContextsCleared{} },
+	"Runtime.inspectRequested":                         func() Event { return &RuntimeInspectRequested{} },
+}
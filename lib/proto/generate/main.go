@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -11,13 +12,20 @@ import (
 	"github.com/go-rod/rod/lib/utils"
 )
 
+var milestone = flag.Int("milestone", 0, "pin the definitions to the browser milestone, such as 112, "+
+	"the schema is read from lib/proto/generate/schemas/m{milestone}.json, "+
+	"if it doesn't exist the schema of the launched browser will be saved to it")
+var bin = flag.String("bin", "", "the browser to launch, default is the one of lib/launcher")
+
 func main() {
+	flag.Parse()
+
+	schema := getSchema(*milestone, *bin)
+
 	cleanup()
 
 	comment := `// This file is generated by "./lib/proto/generate"`
 
-	schema := getSchema()
-
 	init := comment + utils.S(`
 
 		package proto
@@ -33,6 +41,14 @@ func main() {
 		var types = map[string]reflect.Type{
 	`, "major", schema.Get("version.major").Str(), "minor", schema.Get("version.minor").Str())
 
+	stabilities := `
+		var stabilities = map[string]Stability{
+	`
+
+	events := `
+		var events = map[string]func() Event{
+	`
+
 	testsCode := comment + `
 
 		package proto_test
@@ -75,6 +91,22 @@ func main() {
 					"type", definition.name,
 				)
 			}
+
+			if definition.cdpType == cdpTypeEvents {
+				events += utils.S(`
+					"{{.name}}": func() Event { return &{{.type}}{} },`,
+					"name", definition.domain.name+"."+definition.originName,
+					"type", definition.name,
+				)
+			}
+
+			if stability := definition.stability(); stability != "" {
+				stabilities += utils.S(`
+					"{{.name}}": {{.stability}},`,
+					"name", definition.domain.name+"."+definition.originName,
+					"stability", stability,
+				)
+			}
 		}
 
 		utils.E(utils.OutputFile(
@@ -85,6 +117,10 @@ func main() {
 
 	init += `
 		}
+	` + stabilities + `
+		}
+	` + events + `
+		}
 	`
 
 	utils.E(utils.OutputFile(filepath.FromSlash("lib/proto/definitions.go"), init))
@@ -121,6 +157,23 @@ func (d *definition) comment() string {
 	return regexp.MustCompile(`(?m)^`).ReplaceAllString(comment, "// ")
 }
 
+// the Stability flags of the commands and events, empty if the method is stable.
+// The methods of an experimental domain are experimental too.
+func (d *definition) stability() string {
+	if !d.command && d.cdpType != cdpTypeEvents {
+		return ""
+	}
+
+	flags := []string{}
+	if d.experimental || d.domain.experimental {
+		flags = append(flags, "StabilityExperimental")
+	}
+	if d.deprecated {
+		flags = append(flags, "StabilityDeprecated")
+	}
+	return strings.Join(flags, " | ")
+}
+
 func (d *definition) format() (code string) {
 	switch d.objType {
 	case objTypePrimitive:
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -13,28 +14,55 @@ import (
 	"github.com/ysmood/gson"
 )
 
-func getSchema() gson.JSON {
-	l := launcher.New().Bin(launcher.NewBrowser().MustGet())
+// getSchema returns the pinned schema of the milestone if it exists, or the schema of the launched browser
+func getSchema(milestone int, bin string) gson.JSON {
+	pinned := filepath.FromSlash(fmt.Sprintf("lib/proto/generate/schemas/m%d.json", milestone))
+	if milestone > 0 && utils.FileExists(pinned) {
+		data, err := ioutil.ReadFile(pinned)
+		utils.E(err)
+		return gson.New(data)
+	}
+
+	if bin == "" {
+		bin = launcher.NewBrowser().MustGet()
+	}
+	l := launcher.New().Bin(bin)
 	defer l.Kill()
 
 	u := l.MustLaunch()
 	parsed, err := url.Parse(u)
 	utils.E(err)
 	parsed.Scheme = "http"
+
+	if milestone > 0 {
+		parsed.Path = "/json/version"
+		product := getJSON(parsed.String()).Get("Browser").Str()
+		if !strings.Contains(product, fmt.Sprintf("/%d.", milestone)) {
+			panic(fmt.Sprintf("the milestone of the browser %s doesn't match %d, use -bin to set the browser", product, milestone))
+		}
+	}
+
 	parsed.Path = "/json/protocol"
+	obj := getJSON(parsed.String())
+
+	utils.E(utils.OutputFile("tmp/proto.json", obj.JSON("", "  ")))
 
-	res, err := http.Get(parsed.String())
+	if milestone > 0 {
+		utils.E(utils.OutputFile(pinned, obj.JSON("", "  ")))
+	}
+
+	return obj
+}
+
+func getJSON(u string) gson.JSON {
+	res, err := http.Get(u)
 	utils.E(err)
 	defer func() { _ = res.Body.Close() }()
 
 	data, err := ioutil.ReadAll(res.Body)
 	utils.E(err)
 
-	obj := gson.New(data)
-
-	utils.E(utils.OutputFile("tmp/proto.json", obj.JSON("", "  ")))
-
-	return obj
+	return gson.New(data)
 }
 
 func mapType(n string) string {
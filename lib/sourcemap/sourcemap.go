@@ -0,0 +1,188 @@
+// Package sourcemap decodes the source map v3 to map the positions of the generated js to the original source.
+// Spec: https://sourcemaps.info/spec.html
+package sourcemap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Map is a decoded source map
+type Map struct {
+	File    string
+	Sources []string
+	Names   []string
+
+	// the segments of each generated line, sorted by the generated column
+	lines [][]segment
+}
+
+type segment struct {
+	genCol  int
+	source  int
+	line    int
+	col     int
+	name    int
+	mapped  bool
+	hasName bool
+}
+
+// Position in the original source, the Line and Column are zero-based
+type Position struct {
+	Source string
+	Line   int
+	Column int
+	Name   string
+}
+
+// Parse the json of a source map v3
+func Parse(data []byte) (*Map, error) {
+	var raw struct {
+		Version    int           `json:"version"`
+		File       string        `json:"file"`
+		SourceRoot string        `json:"sourceRoot"`
+		Sources    []string      `json:"sources"`
+		Names      []string      `json:"names"`
+		Mappings   string        `json:"mappings"`
+		Sections   []interface{} `json:"sections"`
+	}
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if raw.Version != 3 {
+		return nil, fmt.Errorf("unsupported source map version: %d", raw.Version)
+	}
+
+	if raw.Sections != nil {
+		return nil, errors.New("index source map is not supported")
+	}
+
+	m := &Map{File: raw.File, Names: raw.Names}
+	for _, s := range raw.Sources {
+		// the root is a url prefix such as "webpack:///", path.Join would collapse its slashes
+		if raw.SourceRoot != "" {
+			if strings.HasSuffix(raw.SourceRoot, "/") {
+				s = raw.SourceRoot + s
+			} else {
+				s = raw.SourceRoot + "/" + s
+			}
+		}
+		m.Sources = append(m.Sources, s)
+	}
+
+	m.lines, err = decodeMappings(raw.Mappings)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Lookup the original position of the zero-based line and column of the generated js
+func (m *Map) Lookup(line, col int) (*Position, bool) {
+	if line < 0 || line >= len(m.lines) {
+		return nil, false
+	}
+
+	segs := m.lines[line]
+	i := sort.Search(len(segs), func(i int) bool { return segs[i].genCol > col }) - 1
+	if i < 0 || !segs[i].mapped || segs[i].source >= len(m.Sources) {
+		return nil, false
+	}
+
+	s := segs[i]
+	pos := &Position{Source: m.Sources[s.source], Line: s.line, Column: s.col}
+	if s.hasName && s.name < len(m.Names) {
+		pos.Name = m.Names[s.name]
+	}
+	return pos, true
+}
+
+func decodeMappings(mappings string) ([][]segment, error) {
+	lines := [][]segment{}
+
+	// the fields except the generated column are relative to the previous segment of the whole map
+	var source, line, col, name int
+
+	for _, l := range strings.Split(mappings, ";") {
+		segs := []segment{}
+		genCol := 0
+
+		for _, raw := range strings.Split(l, ",") {
+			if raw == "" {
+				continue
+			}
+
+			fields, err := decodeVLQ(raw)
+			if err != nil {
+				return nil, err
+			}
+
+			genCol += fields[0]
+			s := segment{genCol: genCol}
+
+			switch len(fields) {
+			case 1:
+			case 4, 5:
+				source += fields[1]
+				line += fields[2]
+				col += fields[3]
+				s.source, s.line, s.col, s.mapped = source, line, col, true
+
+				if len(fields) == 5 {
+					name += fields[4]
+					s.name, s.hasName = name, true
+				}
+			default:
+				return nil, fmt.Errorf("invalid source map segment: %s", raw)
+			}
+
+			segs = append(segs, s)
+		}
+
+		sort.SliceStable(segs, func(i, j int) bool { return segs[i].genCol < segs[j].genCol })
+		lines = append(lines, segs)
+	}
+
+	return lines, nil
+}
+
+const base64Chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+func decodeVLQ(s string) ([]int, error) {
+	list := []int{}
+	value, shift := 0, 0
+
+	for _, c := range s {
+		digit := strings.IndexRune(base64Chars, c)
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid source map segment: %s", s)
+		}
+
+		value += (digit & 31) << shift
+
+		if digit&32 != 0 {
+			shift += 5
+			continue
+		}
+
+		n := value >> 1
+		if value&1 == 1 {
+			n = -n
+		}
+		list = append(list, n)
+		value, shift = 0, 0
+	}
+
+	if shift != 0 {
+		return nil, fmt.Errorf("invalid source map segment: %s", s)
+	}
+
+	return list, nil
+}
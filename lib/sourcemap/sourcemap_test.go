@@ -0,0 +1,80 @@
+package sourcemap_test
+
+import (
+	"testing"
+
+	"github.com/go-rod/rod/lib/sourcemap"
+	"github.com/ysmood/got"
+)
+
+func TestLookup(t *testing.T) {
+	g := got.New(t)
+
+	m, err := sourcemap.Parse([]byte(`{
+		"version": 3,
+		"file": "out.js",
+		"sourceRoot": "src",
+		"sources": ["a.ts", "b.ts"],
+		"names": ["foo"],
+		"mappings": "AAAA,UACEA;oBCDK;U"
+	}`))
+	g.E(err)
+	g.Eq(m.File, "out.js")
+	g.Eq(m.Sources, []string{"src/a.ts", "src/b.ts"})
+
+	pos, ok := m.Lookup(0, 0)
+	g.True(ok)
+	g.Eq(pos, &sourcemap.Position{Source: "src/a.ts", Line: 0, Column: 0})
+
+	pos, _ = m.Lookup(0, 5)
+	g.Eq(pos, &sourcemap.Position{Source: "src/a.ts", Line: 0, Column: 0})
+
+	pos, _ = m.Lookup(0, 15)
+	g.Eq(pos, &sourcemap.Position{Source: "src/a.ts", Line: 1, Column: 2, Name: "foo"})
+
+	pos, _ = m.Lookup(1, 25)
+	g.Eq(pos, &sourcemap.Position{Source: "src/b.ts", Line: 0, Column: 7})
+
+	_, ok = m.Lookup(1, 5)
+	g.False(ok)
+
+	_, ok = m.Lookup(2, 10)
+	g.False(ok)
+
+	_, ok = m.Lookup(5, 0)
+	g.False(ok)
+}
+
+func TestSourceRoot(t *testing.T) {
+	g := got.New(t)
+
+	m, err := sourcemap.Parse([]byte(`{"version": 3, "sourceRoot": "webpack:///", "sources": ["src/a.ts"]}`))
+	g.E(err)
+	g.Eq(m.Sources, []string{"webpack:///src/a.ts"})
+
+	m, err = sourcemap.Parse([]byte(`{"version": 3, "sourceRoot": "http://a.com/src", "sources": ["a.ts"]}`))
+	g.E(err)
+	g.Eq(m.Sources, []string{"http://a.com/src/a.ts"})
+}
+
+func TestParseErr(t *testing.T) {
+	g := got.New(t)
+
+	_, err := sourcemap.Parse([]byte(`{`))
+	g.Err(err)
+
+	_, err = sourcemap.Parse([]byte(`{"version": 2}`))
+	g.Eq(err.Error(), "unsupported source map version: 2")
+
+	_, err = sourcemap.Parse([]byte(`{"version": 3, "sections": []}`))
+	g.Eq(err.Error(), "index source map is not supported")
+
+	_, err = sourcemap.Parse([]byte(`{"version": 3, "mappings": "A!"}`))
+	g.Eq(err.Error(), "invalid source map segment: A!")
+
+	_, err = sourcemap.Parse([]byte(`{"version": 3, "mappings": "AA"}`))
+	g.Eq(err.Error(), "invalid source map segment: AA")
+
+	_, err = sourcemap.Parse([]byte(`{"version": 3, "mappings": "g"}`))
+	g.Eq(err.Error(), "invalid source map segment: g")
+}
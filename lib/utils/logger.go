@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LogLevel of LeveledLogger
+type LogLevel int
+
+const (
+	// LogDebug level
+	LogDebug LogLevel = iota
+	// LogInfo level
+	LogInfo
+	// LogWarn level
+	LogWarn
+)
+
+// String interface
+func (l LogLevel) String() string {
+	switch l {
+	case LogDebug:
+		return "debug"
+	case LogInfo:
+		return "info"
+	default:
+		return "warn"
+	}
+}
+
+// LeveledLogger is a structured logger with levels, the kv are the key-value pairs of the fields,
+// such as: Info("navigate", "url", "https://a.com").
+// The *slog.Logger satisfies it, use LeveledSugar to adapt the *zap.SugaredLogger.
+type LeveledLogger interface {
+	Debug(msg string, kv ...interface{})
+	Info(msg string, kv ...interface{})
+	Warn(msg string, kv ...interface{})
+}
+
+// LeveledQuiet does nothing
+var LeveledQuiet LeveledLogger = Leveled(LoggerQuiet, LogWarn+1)
+
+// Leveled adapts the Logger to LeveledLogger, the entries below the min level are dropped.
+// Each entry is printed as: [level] msg key=value key=value
+func Leveled(l Logger, min LogLevel) LeveledLogger {
+	return &printLogger{l, min}
+}
+
+type printLogger struct {
+	logger Logger
+	min    LogLevel
+}
+
+func (l *printLogger) Debug(msg string, kv ...interface{}) { l.log(LogDebug, msg, kv) }
+func (l *printLogger) Info(msg string, kv ...interface{})  { l.log(LogInfo, msg, kv) }
+func (l *printLogger) Warn(msg string, kv ...interface{})  { l.log(LogWarn, msg, kv) }
+
+func (l *printLogger) log(level LogLevel, msg string, kv []interface{}) {
+	if level < l.min {
+		return
+	}
+
+	list := []interface{}{"[" + level.String() + "]", msg}
+	for i := 0; i < len(kv); i += 2 {
+		if i+1 == len(kv) {
+			list = append(list, fmt.Sprint(kv[i]))
+			break
+		}
+		list = append(list, fmt.Sprintf("%v=%s", kv[i], formatLogValue(kv[i+1])))
+	}
+	l.logger.Println(list...)
+}
+
+// quote the value if it contains spaces, so that the fields are easy to split
+func formatLogValue(v interface{}) string {
+	s := fmt.Sprint(v)
+	if strings.ContainsAny(s, " \t\n\"=") {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
+
+// SugaredLogger is the subset of the *zap.SugaredLogger
+type SugaredLogger interface {
+	Debugw(msg string, kv ...interface{})
+	Infow(msg string, kv ...interface{})
+	Warnw(msg string, kv ...interface{})
+}
+
+// LeveledSugar adapts the SugaredLogger, such as the *zap.SugaredLogger, to LeveledLogger
+func LeveledSugar(l SugaredLogger) LeveledLogger {
+	return sugarLogger{l}
+}
+
+type sugarLogger struct {
+	l SugaredLogger
+}
+
+func (l sugarLogger) Debug(msg string, kv ...interface{}) { l.l.Debugw(msg, kv...) }
+func (l sugarLogger) Info(msg string, kv ...interface{})  { l.l.Infow(msg, kv...) }
+func (l sugarLogger) Warn(msg string, kv ...interface{})  { l.l.Warnw(msg, kv...) }
+
+// WithFields returns a LeveledLogger that appends the kv to the fields of each entry
+func WithFields(l LeveledLogger, kv ...interface{}) LeveledLogger {
+	return fieldsLogger{l, kv}
+}
+
+type fieldsLogger struct {
+	l  LeveledLogger
+	kv []interface{}
+}
+
+func (l fieldsLogger) Debug(msg string, kv ...interface{}) { l.l.Debug(msg, l.fields(kv)...) }
+func (l fieldsLogger) Info(msg string, kv ...interface{})  { l.l.Info(msg, l.fields(kv)...) }
+func (l fieldsLogger) Warn(msg string, kv ...interface{})  { l.l.Warn(msg, l.fields(kv)...) }
+
+func (l fieldsLogger) fields(kv []interface{}) []interface{} {
+	return append(append([]interface{}{}, kv...), l.kv...)
+}
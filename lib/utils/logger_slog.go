@@ -0,0 +1,9 @@
+//go:build go1.21
+// +build go1.21
+
+package utils
+
+import "log/slog"
+
+// the *slog.Logger can be used as LeveledLogger directly
+var _ LeveledLogger = (*slog.Logger)(nil)
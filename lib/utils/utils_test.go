@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"image"
 	"image/jpeg"
 	"image/png"
@@ -31,6 +32,45 @@ func TestTestLog(t *testing.T) {
 	g.Eq(res, []interface{}{"ok", "ok", "ok"})
 }
 
+type sugar struct {
+	list []string
+}
+
+func (s *sugar) Debugw(msg string, kv ...interface{}) { s.list = append(s.list, "debug "+msg) }
+func (s *sugar) Infow(msg string, kv ...interface{})  { s.list = append(s.list, "info "+msg) }
+func (s *sugar) Warnw(msg string, kv ...interface{})  { s.list = append(s.list, "warn "+msg) }
+
+func TestLeveled(t *testing.T) {
+	g := setup(t)
+
+	var res []string
+	lg := utils.Log(func(msg ...interface{}) { res = append(res, fmt.Sprintln(msg...)) })
+
+	l := utils.Leveled(lg, utils.LogInfo)
+	l.Debug("no")
+	l.Info("ok", "a", 1, "b", "x y")
+	l.Warn("ok", "c")
+	g.Eq(res, []string{"[info] ok a=1 b=\"x y\"\n", "[warn] ok c\n"})
+
+	res = nil
+	utils.WithFields(utils.Leveled(lg, utils.LogDebug), "k", "v").Debug("ok", "a", 1)
+	g.Eq(res, []string{"[debug] ok a=1 k=v\n"})
+
+	utils.LeveledQuiet.Warn("no")
+	g.Len(res, 1)
+
+	s := &sugar{}
+	sl := utils.LeveledSugar(s)
+	sl.Debug("a")
+	sl.Info("b")
+	sl.Warn("c")
+	g.Eq(s.list, []string{"debug a", "info b", "warn c"})
+
+	g.Eq(utils.LogDebug.String(), "debug")
+	g.Eq(utils.LogInfo.String(), "info")
+	g.Eq(utils.LogWarn.String(), "warn")
+}
+
 func TestTestE(t *testing.T) {
 	g := setup(t)
 
@@ -0,0 +1,65 @@
+package rod
+
+import (
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// LogSubsystem is a source of the logs of rod, use Browser.LogSubsystems to enable them
+// LogSubsystem 是 rod 日志的来源，使用 Browser.LogSubsystems 启用它们
+type LogSubsystem string
+
+const (
+	// LogTrace is the input actions and the waits when Browser.Trace is enabled, it's enabled by default
+	// LogTrace 是启用 Browser.Trace 时的输入操作和等待，它默认是启用的
+	LogTrace LogSubsystem = "trace"
+
+	// LogCDP is the cdp calls and events, they are logged at the debug level
+	// LogCDP 是 cdp 的调用和事件，它们以 debug 级别记录
+	LogCDP LogSubsystem = "cdp"
+
+	// LogHijack is how the hijacked requests are handled, the failures are logged at the warn level
+	// LogHijack 是被劫持的请求的处理情况，失败会以 warn 级别记录
+	LogHijack LogSubsystem = "hijack"
+)
+
+// LeveledLogger sets the structured logger of the browser. Each entry has the "subsystem" field.
+// The *slog.Logger can be used directly, use utils.LeveledSugar for the *zap.SugaredLogger.
+// LeveledLogger 设置浏览器的结构化日志记录器，每条日志都有 "subsystem" 字段。
+// *slog.Logger 可以被直接使用，*zap.SugaredLogger 请使用 utils.LeveledSugar。
+func (b *Browser) LeveledLogger(l utils.LeveledLogger) *Browser {
+	b.logger = l
+	b.plainLogger = nil
+	return b
+}
+
+// LogSubsystems sets the subsystems to log, the others will be disabled
+// LogSubsystems 设置需要记录日志的子系统，其他子系统会被禁用
+func (b *Browser) LogSubsystems(list ...LogSubsystem) *Browser {
+	enabled := map[LogSubsystem]bool{}
+	for _, s := range list {
+		enabled[s] = true
+	}
+	b.logSubsystems = enabled
+	return b
+}
+
+// the logger of the subsystem, it does nothing if the subsystem is disabled
+// 子系统的日志记录器，如果子系统被禁用，它什么也不做
+func (b *Browser) log(s LogSubsystem) utils.LeveledLogger {
+	if !b.logSubsystems[s] {
+		return utils.LeveledQuiet
+	}
+	return utils.WithFields(b.logger, "subsystem", s)
+}
+
+// log the trace entry, the plain logger set by Browser.Logger receives the legacy list via Println
+// 记录追踪日志，通过 Browser.Logger 设置的普通日志记录器会通过 Println 收到旧格式的列表
+func (b *Browser) logTrace(legacy []interface{}, msg string, kv ...interface{}) {
+	if b.plainLogger == nil {
+		b.log(LogTrace).Info(msg, kv...)
+		return
+	}
+	if b.logSubsystems[LogTrace] {
+		b.plainLogger.Println(legacy...)
+	}
+}
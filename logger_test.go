@@ -0,0 +1,69 @@
+package rod_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-rod/rod"
+)
+
+type memLogger struct {
+	lock sync.Mutex
+	list []string
+}
+
+func (l *memLogger) add(level, msg string, kv []interface{}) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.list = append(l.list, fmt.Sprint(level, " ", msg, " ", kv))
+}
+
+func (l *memLogger) Debug(msg string, kv ...interface{}) { l.add("debug", msg, kv) }
+func (l *memLogger) Info(msg string, kv ...interface{})  { l.add("info", msg, kv) }
+func (l *memLogger) Warn(msg string, kv ...interface{})  { l.add("warn", msg, kv) }
+
+func (l *memLogger) has(s string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for _, line := range l.list {
+		if strings.Contains(line, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLeveledLogger(t *testing.T) {
+	g := setup(t)
+
+	l := &memLogger{}
+	b := g.browser.Context(g.Context()).LeveledLogger(l).LogSubsystems(rod.LogCDP, rod.LogHijack)
+
+	p := b.MustPage()
+	defer p.MustClose()
+
+	g.True(l.has("debug call [method Target.createTarget"))
+	g.True(l.has("subsystem cdp"))
+
+	router := p.HijackRequests()
+	defer router.MustStop()
+	router.MustAdd("*", func(ctx *rod.Hijack) {
+		ctx.Response.SetBody("ok")
+	})
+	go router.Run()
+
+	u := g.srcFile("fixtures/click.html")
+	p.MustNavigate(u)
+	g.Eq(p.MustElement("body").MustText(), "ok")
+	g.True(l.has("debug fulfill [url " + u))
+	g.True(l.has("subsystem hijack"))
+
+	b.LogSubsystems()
+	l.lock.Lock()
+	l.list = nil
+	l.lock.Unlock()
+	p.MustEval(`() => 1`)
+	g.False(l.has("Runtime.callFunctionOn"))
+}
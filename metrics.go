@@ -0,0 +1,101 @@
+package rod
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// Metrics returns the run-time metrics of the page, such as "JSHeapUsedSize", "Nodes", "LayoutCount".
+// The Performance domain will be enabled during the call if it isn't, then restored.
+// Metrics 返回页面的运行时指标，例如 "JSHeapUsedSize"、"Nodes"、"LayoutCount"。
+// 如果页面的 Performance domain 没有被启用，在调用期间它会被启用，之后再恢复。
+func (p *Page) Metrics() (map[string]float64, error) {
+	defer p.EnableDomain(&proto.PerformanceEnable{})()
+
+	res, err := proto.PerformanceGetMetrics{}.Call(p)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := map[string]float64{}
+	for _, m := range res.Metrics {
+		metrics[m.Name] = m.Value
+	}
+	return metrics, nil
+}
+
+// WebVitals of the page, the times are in milliseconds relative to the start of the navigation.
+// A field is 0 if the browser hasn't reported it yet.
+// WebVitals 是页面的性能指标，时间的单位为毫秒，相对于导航的开始。如果浏览器还没有报告某个字段，它的值为 0。
+type WebVitals struct {
+	// TTFB is the time to the first byte of the response
+	// TTFB 是收到响应第一个字节的时间
+	TTFB float64 `json:"ttfb"`
+
+	// DOMContentLoaded is the time when the DOMContentLoaded event ends
+	// DOMContentLoaded 是 DOMContentLoaded 事件结束的时间
+	DOMContentLoaded float64 `json:"domContentLoaded"`
+
+	// Load is the time when the load event ends
+	// Load 是 load 事件结束的时间
+	Load float64 `json:"load"`
+
+	// FCP is the first contentful paint
+	// FCP 是首次内容绘制的时间
+	FCP float64 `json:"fcp"`
+
+	// LCP is the latest largest contentful paint
+	// LCP 是最新的最大内容绘制的时间
+	LCP float64 `json:"lcp"`
+
+	// CLS is the sum of the layout shifts that are not caused by user input
+	// CLS 是所有非用户输入导致的布局偏移的总和
+	CLS float64 `json:"cls"`
+}
+
+// the buffered entries are taken synchronously, so there's no need to wait for the callback of the observer
+// 缓存的条目会被同步取出，所以无需等待观察者的回调
+const webVitalsJS = `() => {
+	const take = (type) => {
+		try {
+			const o = new PerformanceObserver(() => {})
+			o.observe({ type, buffered: true })
+			const list = o.takeRecords()
+			o.disconnect()
+			return list
+		} catch (e) {
+			return []
+		}
+	}
+
+	const nav = performance.getEntriesByType('navigation')[0]
+	const fcp = take('paint').find((e) => e.name === 'first-contentful-paint')
+	const lcp = take('largest-contentful-paint').pop()
+	const cls = take('layout-shift')
+		.filter((e) => !e.hadRecentInput)
+		.reduce((sum, e) => sum + e.value, 0)
+
+	return {
+		ttfb: nav ? nav.responseStart : 0,
+		domContentLoaded: nav ? nav.domContentLoadedEventEnd : 0,
+		load: nav ? nav.loadEventEnd : 0,
+		fcp: fcp ? fcp.startTime : 0,
+		lcp: lcp ? lcp.startTime : 0,
+		cls,
+	}
+}`
+
+// WebVitals returns the navigation timing, FCP, LCP and CLS of the page via the PerformanceObserver
+// WebVitals 通过 PerformanceObserver 返回页面的导航时间、FCP、LCP 和 CLS
+func (p *Page) WebVitals() (*WebVitals, error) {
+	res, err := p.Evaluate(Eval(webVitalsJS))
+	if err != nil {
+		return nil, err
+	}
+
+	v := &WebVitals{}
+	err = res.Into(v)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
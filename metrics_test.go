@@ -0,0 +1,38 @@
+package rod_test
+
+import (
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestPageMetrics(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.srcFile("fixtures/click.html")).MustWaitLoad()
+
+	m := p.MustMetrics()
+	g.Gt(m["Nodes"], 0.0)
+	_, has := m["JSHeapUsedSize"]
+	g.True(has)
+
+	g.mc.stubErr(1, proto.PerformanceEnable{})
+	g.Err(p.Metrics())
+
+	g.mc.stubErr(1, proto.PerformanceGetMetrics{})
+	g.Err(p.Metrics())
+}
+
+func TestPageWebVitals(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.srcFile("fixtures/click.html")).MustWaitLoad()
+
+	v := p.MustWebVitals()
+	g.Gt(v.Load, 0.0)
+	g.Gte(v.Load, v.DOMContentLoaded)
+	g.Gte(v.CLS, 0.0)
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.WebVitals())
+}
@@ -0,0 +1,161 @@
+package rod
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// the max number of the trace entries that the monitor keeps
+// 监控服务保留的 trace 条目的最大数量
+const monitorTraceLimit = 1000
+
+// MonitorTrace is an entry of the "/api/traces" of the monitor
+// MonitorTrace 是监控服务 "/api/traces" 中的一个条目
+type MonitorTrace struct {
+	Time   time.Time            `json:"time"`
+	Type   TraceType            `json:"type"`
+	PageID proto.TargetTargetID `json:"pageId"`
+
+	// Detail of the action, such as the selector of a query
+	// Detail 是操作的详情，例如查询的选择器
+	Detail string `json:"detail"`
+}
+
+// MonitorHealth is the response of the "/api/health" of the monitor
+// MonitorHealth 是监控服务 "/api/health" 的响应
+type MonitorHealth struct {
+	OK      bool   `json:"ok"`
+	Error   string `json:"error,omitempty"`
+	Product string `json:"product,omitempty"`
+	Pages   int    `json:"pages"`
+}
+
+type monitorTracesKey struct{}
+
+type monitorTraces struct {
+	lock sync.Mutex
+	list []*MonitorTrace
+}
+
+func (m *monitorTraces) add(t *MonitorTrace) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.list = append(m.list, t)
+	if len(m.list) > monitorTraceLimit {
+		m.list = m.list[len(m.list)-monitorTraceLimit:]
+	}
+}
+
+// the last limit entries of the page, empty id means all the pages
+// 页面最近的 limit 个条目，id 为空表示所有页面
+func (m *monitorTraces) last(id proto.TargetTargetID, limit int) []*MonitorTrace {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	list := []*MonitorTrace{}
+	for i := len(m.list) - 1; i >= 0 && len(list) < limit; i-- {
+		if id == "" || m.list[i].PageID == id {
+			list = append(list, m.list[i])
+		}
+	}
+
+	// oldest first
+	// 最早的在前
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+	return list
+}
+
+// record the action for the monitor if it's served
+// 如果监控服务已启动，为它记录该操作
+func (p *Page) recordMonitorTrace(typ TraceType, msg []interface{}) {
+	m, has := p.browser.states.Load(monitorTracesKey{})
+	if !has {
+		return
+	}
+
+	t := &MonitorTrace{
+		Time:   time.Now(),
+		Type:   typ,
+		PageID: p.TargetID,
+		Detail: strings.TrimSuffix(fmt.Sprintln(msg...), "\n"),
+	}
+	m.(*monitorTraces).add(t)
+	p.recordMonitorLog(t)
+}
+
+// the json api of the monitor, check Browser.ServeMonitor for the list
+// 监控服务的 json api，列表见 Browser.ServeMonitor
+func (b *Browser) serveMonitorAPI(mux *http.ServeMux) {
+	traces := &monitorTraces{}
+	b.states.Store(monitorTracesKey{}, traces)
+
+	mux.HandleFunc("/api/health", func(w http.ResponseWriter, r *http.Request) {
+		health := MonitorHealth{OK: true}
+		if err := b.monitorHealth(&health); err != nil {
+			health.OK = false
+			health.Error = err.Error()
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			utils.E(w.Write(utils.MustToJSONBytes(health)))
+			return
+		}
+		httJSON(w, health)
+	})
+	mux.HandleFunc("/api/screenshot/", func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		p := b.MustPageFromTargetID(proto.TargetTargetID(id))
+
+		req := &proto.PageCaptureScreenshot{Format: proto.PageCaptureScreenshotFormatPng}
+		if r.URL.Query().Get("format") == "jpeg" {
+			req.Format = proto.PageCaptureScreenshotFormatJpeg
+		}
+		if q := r.URL.Query().Get("quality"); q != "" {
+			quality, err := strconv.Atoi(q)
+			utils.E(err)
+			req.Quality = &quality
+		}
+
+		bin, err := p.Screenshot(r.URL.Query().Get("full") == "true", req)
+		utils.E(err)
+		w.Header().Add("Content-Type", "image/"+string(req.Format))
+		utils.E(w.Write(bin))
+	})
+	mux.HandleFunc("/api/traces", func(w http.ResponseWriter, r *http.Request) {
+		limit := 100
+		if l := r.URL.Query().Get("limit"); l != "" {
+			var err error
+			limit, err = strconv.Atoi(l)
+			utils.E(err)
+		}
+		httJSON(w, traces.last(proto.TargetTargetID(r.URL.Query().Get("page")), limit))
+	})
+}
+
+func (b *Browser) monitorHealth(health *MonitorHealth) error {
+	ver, err := proto.BrowserGetVersion{}.Call(b)
+	if err != nil {
+		return err
+	}
+	health.Product = ver.Product
+
+	res, err := proto.TargetGetTargets{}.Call(b)
+	if err != nil {
+		return err
+	}
+	for _, info := range res.TargetInfos {
+		if info.Type == proto.TargetTargetInfoTypePage {
+			health.Pages++
+		}
+	}
+	return nil
+}
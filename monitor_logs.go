@@ -0,0 +1,180 @@
+package rod
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// the max number of the log entries that the log panel keeps for each page
+// 日志面板为每个页面保留的日志条目的最大数量
+const monitorLogLimit = 1000
+
+// MonitorLog is an entry of the log panel of the monitor, it's a console message or a trace entry
+// MonitorLog 是监控服务日志面板中的一个条目，它是一条 console 消息或者一个 trace 条目
+type MonitorLog struct {
+	// Seq of the entry, used by "/api/logs/<id>?since=<seq>"
+	// Seq 是条目的序号，用于 "/api/logs/<id>?since=<seq>"
+	Seq int64 `json:"seq"`
+
+	Time time.Time `json:"time"`
+
+	// Level is "debug", "log", "info", "warning", "error" for the console messages, "trace" for the trace entries
+	// Level 对于 console 消息是 "debug"、"log"、"info"、"warning"、"error"，对于 trace 条目是 "trace"
+	Level string `json:"level"`
+
+	Text string `json:"text"`
+
+	// Location of the console message, such as "https://a.com/a.js:10:2"
+	// Location 是 console 消息的位置，例如 "https://a.com/a.js:10:2"
+	Location string `json:"location,omitempty"`
+}
+
+// MonitorLogs is the response of the "/api/logs/<id>" of the monitor
+// MonitorLogs 是监控服务 "/api/logs/<id>" 的响应
+type MonitorLogs struct {
+	// Seq of the latest entry, pass it as the "since" query to only get the newer entries
+	// Seq 是最新条目的序号，将它作为 "since" query 传入，只获取更新的条目
+	Seq int64 `json:"seq"`
+
+	Entries []*MonitorLog `json:"entries"`
+}
+
+type monitorLogsKey struct {
+	targetID proto.TargetTargetID
+}
+
+type monitorLogs struct {
+	lock sync.Mutex
+	seq  int64
+	list []*MonitorLog
+}
+
+// the log recorder of the page, it starts on the first call with the recent trace entries of the page,
+// and stops when the page is closed
+// 页面的日志记录器，它在第一次调用时启动并包含页面最近的 trace 条目，在页面关闭时停止
+func (b *Browser) monitorLogs(id proto.TargetTargetID) (*monitorLogs, error) {
+	if v, has := b.states.Load(monitorLogsKey{id}); has {
+		return v.(*monitorLogs), nil
+	}
+
+	p, err := b.PageFromTarget(id)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &monitorLogs{list: []*MonitorLog{}}
+	if v, has := b.states.Load(monitorTracesKey{}); has {
+		for _, t := range v.(*monitorTraces).last(id, monitorLogLimit) {
+			l.add(t.log())
+		}
+	}
+
+	if v, loaded := b.states.LoadOrStore(monitorLogsKey{id}, l); loaded {
+		return v.(*monitorLogs), nil
+	}
+
+	wait := p.EachEvent(func(e *proto.RuntimeConsoleAPICalled) {
+		m := p.consoleMessage(e)
+
+		location := ""
+		if m.Location != nil {
+			location = fmt.Sprintf("%s:%d:%d", m.Location.URL, m.Location.LineNumber+1, m.Location.ColumnNumber+1)
+		}
+
+		l.add(&MonitorLog{
+			Time:     time.Now(),
+			Level:    monitorLogLevel(m.Level),
+			Text:     m.Text,
+			Location: location,
+		})
+	})
+
+	go func() {
+		wait()
+		b.RemoveState(monitorLogsKey{id})
+	}()
+
+	return l, nil
+}
+
+// group the console api types into the levels of the log panel
+// 将 console api 的类型归类为日志面板的级别
+func monitorLogLevel(t proto.RuntimeConsoleAPICalledType) string {
+	switch t {
+	case proto.RuntimeConsoleAPICalledTypeDebug,
+		proto.RuntimeConsoleAPICalledTypeInfo,
+		proto.RuntimeConsoleAPICalledTypeWarning,
+		proto.RuntimeConsoleAPICalledTypeError:
+		return string(t)
+	case proto.RuntimeConsoleAPICalledTypeAssert:
+		return string(proto.RuntimeConsoleAPICalledTypeError)
+	default:
+		return string(proto.RuntimeConsoleAPICalledTypeLog)
+	}
+}
+
+func (t *MonitorTrace) log() *MonitorLog {
+	return &MonitorLog{Time: t.Time, Level: "trace", Text: t.Type.String() + " " + t.Detail}
+}
+
+func (l *monitorLogs) add(e *MonitorLog) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.seq++
+	e.Seq = l.seq
+
+	l.list = append(l.list, e)
+	if len(l.list) > monitorLogLimit {
+		l.list = l.list[len(l.list)-monitorLogLimit:]
+	}
+}
+
+// the entries after the seq
+// 序号在 seq 之后的条目
+func (l *monitorLogs) since(seq int64) *MonitorLogs {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	res := &MonitorLogs{Seq: l.seq, Entries: []*MonitorLog{}}
+	for _, e := range l.list {
+		if e.Seq > seq {
+			res.Entries = append(res.Entries, e)
+		}
+	}
+	return res
+}
+
+// record the trace entry for the log panel of the page if it's open
+// 如果页面的日志面板已打开，为它记录该 trace 条目
+func (p *Page) recordMonitorLog(t *MonitorTrace) {
+	if l, has := p.browser.states.Load(monitorLogsKey{p.TargetID}); has {
+		l.(*monitorLogs).add(t.log())
+	}
+}
+
+// the json api of the log panel, check Browser.ServeMonitor for the list
+// 日志面板的 json api，列表见 Browser.ServeMonitor
+func (b *Browser) serveMonitorLogs(mux *http.ServeMux) {
+	mux.HandleFunc("/api/logs/", func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+		var since int64
+		if s := r.URL.Query().Get("since"); s != "" {
+			var err error
+			since, err = strconv.ParseInt(s, 10, 64)
+			utils.E(err)
+		}
+
+		l, err := b.monitorLogs(proto.TargetTargetID(id))
+		utils.E(err)
+		httJSON(w, l.since(since))
+	})
+}
@@ -0,0 +1,230 @@
+package rod
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// the max number of the requests that the network panel keeps for each page
+// 网络面板为每个页面保留的请求的最大数量
+const monitorNetworkLimit = 500
+
+// the max bytes of the body preview of the network panel
+// 网络面板中 body 预览的最大字节数
+const monitorBodyPreviewLimit = 100 * 1024
+
+// MonitorRequest is a request of the network panel of the monitor
+// MonitorRequest 是监控服务网络面板中的一个请求
+type MonitorRequest struct {
+	ID     proto.NetworkRequestID `json:"id"`
+	Method string                 `json:"method"`
+	URL    string                 `json:"url"`
+	Type   string                 `json:"type"`
+
+	// Initiator is the type and the url of the initiator, such as "script https://a.com/a.js"
+	// Initiator 是发起者的类型和 url，例如 "script https://a.com/a.js"
+	Initiator string `json:"initiator"`
+
+	Status   int    `json:"status"`
+	MIMEType string `json:"mimeType"`
+
+	// Size is the encoded bytes received
+	// Size 是收到的编码后的字节数
+	Size float64 `json:"size"`
+
+	Error string    `json:"error,omitempty"`
+	Start time.Time `json:"start"`
+
+	// Duration in milliseconds, it's 0 until the request is finished or failed
+	// Duration 的单位为毫秒，在请求完成或失败之前为 0
+	Duration float64 `json:"duration"`
+
+	Done bool `json:"done"`
+
+	// Version of the last update, used by "/api/network/<id>?since=<version>"
+	// Version 是最后一次更新的版本，用于 "/api/network/<id>?since=<version>"
+	Version int64 `json:"version"`
+}
+
+// MonitorNetwork is the response of the "/api/network/<id>" of the monitor
+// MonitorNetwork 是监控服务 "/api/network/<id>" 的响应
+type MonitorNetwork struct {
+	// Version of the latest update, pass it as the "since" query to only get the newer updates
+	// Version 是最新一次更新的版本，将它作为 "since" query 传入，只获取更新的请求
+	Version int64 `json:"version"`
+
+	Requests []*MonitorRequest `json:"requests"`
+}
+
+type monitorNetworkKey struct {
+	targetID proto.TargetTargetID
+}
+
+type monitorNetwork struct {
+	lock     sync.Mutex
+	version  int64
+	list     []*MonitorRequest
+	requests map[proto.NetworkRequestID]*MonitorRequest
+}
+
+// the network recorder of the page, it starts on the first call and stops when the page is closed
+// 页面的网络记录器，它在第一次调用时启动，并在页面关闭时停止
+func (b *Browser) monitorNetwork(id proto.TargetTargetID) (*monitorNetwork, error) {
+	if v, has := b.states.Load(monitorNetworkKey{id}); has {
+		return v.(*monitorNetwork), nil
+	}
+
+	p, err := b.PageFromTarget(id)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &monitorNetwork{requests: map[proto.NetworkRequestID]*MonitorRequest{}}
+	if v, loaded := b.states.LoadOrStore(monitorNetworkKey{id}, n); loaded {
+		return v.(*monitorNetwork), nil
+	}
+
+	wait := p.EachEvent(func(e *proto.NetworkRequestWillBeSent) {
+		initiator := ""
+		if e.Initiator != nil {
+			initiator = strings.TrimSpace(string(e.Initiator.Type) + " " + e.Initiator.URL)
+		}
+		n.add(&MonitorRequest{
+			ID:        e.RequestID,
+			Method:    e.Request.Method,
+			URL:       e.Request.URL,
+			Type:      string(e.Type),
+			Initiator: initiator,
+			Start:     time.Now(),
+		})
+	}, func(e *proto.NetworkResponseReceived) {
+		n.update(e.RequestID, func(r *MonitorRequest) {
+			r.Status = e.Response.Status
+			r.MIMEType = e.Response.MIMEType
+		})
+	}, func(e *proto.NetworkLoadingFinished) {
+		n.update(e.RequestID, func(r *MonitorRequest) {
+			r.Size = e.EncodedDataLength
+			r.done()
+		})
+	}, func(e *proto.NetworkLoadingFailed) {
+		n.update(e.RequestID, func(r *MonitorRequest) {
+			r.Error = e.ErrorText
+			r.done()
+		})
+	})
+
+	go func() {
+		wait()
+		b.RemoveState(monitorNetworkKey{id})
+	}()
+
+	return n, nil
+}
+
+func (r *MonitorRequest) done() {
+	r.Done = true
+	r.Duration = float64(time.Since(r.Start)) / float64(time.Millisecond)
+}
+
+func (n *monitorNetwork) add(r *MonitorRequest) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.version++
+	r.Version = n.version
+
+	if old, has := n.requests[r.ID]; has {
+		// it's a redirect, replace the old one
+		// 这是一个重定向，替换旧的请求
+		*old = *r
+		return
+	}
+
+	n.requests[r.ID] = r
+	n.list = append(n.list, r)
+	if len(n.list) > monitorNetworkLimit {
+		delete(n.requests, n.list[0].ID)
+		n.list = n.list[1:]
+	}
+}
+
+func (n *monitorNetwork) update(id proto.NetworkRequestID, fn func(*MonitorRequest)) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	r, has := n.requests[id]
+	if !has {
+		return
+	}
+
+	n.version++
+	r.Version = n.version
+	fn(r)
+}
+
+// the requests updated after the version
+// 在该版本之后更新过的请求
+func (n *monitorNetwork) since(version int64) *MonitorNetwork {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	res := &MonitorNetwork{Version: n.version, Requests: []*MonitorRequest{}}
+	for _, r := range n.list {
+		if r.Version > version {
+			clone := *r
+			res.Requests = append(res.Requests, &clone)
+		}
+	}
+	return res
+}
+
+// the json api of the network panel, check Browser.ServeMonitor for the list
+// 网络面板的 json api，列表见 Browser.ServeMonitor
+func (b *Browser) serveMonitorNetwork(mux *http.ServeMux) {
+	mux.HandleFunc("/api/network/", func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+		var since int64
+		if s := r.URL.Query().Get("since"); s != "" {
+			var err error
+			since, err = strconv.ParseInt(s, 10, 64)
+			utils.E(err)
+		}
+
+		n, err := b.monitorNetwork(proto.TargetTargetID(id))
+		utils.E(err)
+		httJSON(w, n.since(since))
+	})
+	mux.HandleFunc("/api/body/", func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		p := b.MustPageFromTargetID(proto.TargetTargetID(id))
+
+		res, err := proto.NetworkGetResponseBody{
+			RequestID: proto.NetworkRequestID(r.URL.Query().Get("request")),
+		}.Call(p)
+		utils.E(err)
+
+		body := []byte(res.Body)
+		if res.Base64Encoded {
+			body, err = base64.StdEncoding.DecodeString(res.Body)
+			utils.E(err)
+		}
+		if len(body) > monitorBodyPreviewLimit {
+			body = body[:monitorBodyPreviewLimit]
+		}
+
+		// the body is from any site, never let the browser render it under the origin of the monitor
+		// body 可能来自任何网站，永远不要让浏览器在监控服务的源下渲染它
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		utils.E(w.Write(body))
+	})
+}
@@ -0,0 +1,183 @@
+package rod
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"net/http"
+	"strings"
+
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// the cookie to remember the token passed by the query, so that the web ui can load the api and the images
+// 用于记住通过 query 传入的 token 的 cookie，这样 web 界面就可以加载 api 和图片
+const monitorTokenCookie = "rod-monitor-token"
+
+// MonitorOptions for Browser.ServeMonitorWith and Browser.MonitorHandler
+// Browser.ServeMonitorWith 和 Browser.MonitorHandler 的选项
+type MonitorOptions struct {
+	// Host to listen on, if it's empty a random port of 127.0.0.1 will be used.
+	// The input api only accepts the requests whose Host header is an ip, "localhost", or the hostname of it,
+	// set it to the domain of the monitor if it's served by a domain name, such as "rod.example.com".
+	// Host 是要监听的地址，如果为空，将使用 127.0.0.1 的随机端口。
+	// 输入 api 只接受 Host 请求头是 ip、"localhost" 或者它的主机名的请求，如果监控服务通过域名提供服务，
+	// 请将它设置为监控服务的域名，例如 "rod.example.com"。
+	Host string
+
+	// Prefix of the path to mount the monitor under, such as "/rod"
+	// Prefix 是挂载监控服务的路径前缀，例如 "/rod"
+	Prefix string
+
+	// Username and Password of the basic auth, the basic auth is disabled if both are empty
+	// Username 和 Password 用于 basic auth，如果两者都为空，basic auth 将被禁用
+	Username string
+	Password string
+
+	// Token of the bearer auth, it's disabled if it's empty. The token can be sent via the "Authorization: Bearer"
+	// header, or the "token" query which will be remembered by a cookie, such as open "/?token=xxx" in the browser.
+	// Token 用于 bearer auth，如果为空将被禁用。token 可以通过 "Authorization: Bearer" 请求头发送，
+	// 或者通过 "token" query 发送，它会被 cookie 记住，例如在浏览器中打开 "/?token=xxx"。
+	Token string
+
+	// CertFile and KeyFile to serve https
+	// CertFile 和 KeyFile 用于提供 https 服务
+	CertFile string
+	KeyFile  string
+
+	// TLSConfig to serve https, the certificate of CertFile and KeyFile will be appended to it
+	// TLSConfig 用于提供 https 服务，CertFile 和 KeyFile 的证书会被追加到其中
+	TLSConfig *tls.Config
+}
+
+// ServeMonitorWith is similar to Browser.ServeMonitor, but with the options of auth and TLS.
+// If both the basic auth and the token are set, either of them can pass.
+// ServeMonitorWith 类似于 Browser.ServeMonitor，但是带有鉴权和 TLS 的选项。如果同时设置了 basic auth 和 token，满足其中之一即可通过。
+func (b *Browser) ServeMonitorWith(opts MonitorOptions) (string, error) {
+	config, err := opts.tlsConfig()
+	if err != nil {
+		return "", err
+	}
+
+	url, close, err := serve(opts.Host, b.MonitorHandler(opts), config)
+	if err != nil {
+		return "", err
+	}
+	url += strings.TrimSuffix(opts.Prefix, "/")
+
+	go func() {
+		<-b.ctx.Done()
+		b.RemoveState(monitorTracesKey{})
+		utils.E(close())
+	}()
+
+	return url, nil
+}
+
+// MonitorHandler returns the handler of the monitor, so that it can be mounted into an existing server, mux or
+// middlewares, no listener will be opened and no browser will be launched to view it.
+// Only the Prefix, the Host and the auth options are used, the Prefix must match the path it's mounted at, such as:
+//     mux.Handle("/rod/", b.MonitorHandler(rod.MonitorOptions{Prefix: "/rod"}))
+// MonitorHandler 返回监控服务的 handler，以便将它挂载到已有的服务、mux 或者中间件之中，不会打开监听端口，也不会启动浏览器来查看它。
+// 只有 Prefix、Host 和鉴权相关的选项会被使用，Prefix 必须与挂载的路径匹配，例如上面的例子。
+func (b *Browser) MonitorHandler(opts MonitorOptions) http.Handler {
+	prefix := strings.TrimSuffix(opts.Prefix, "/")
+	h := opts.withAuth(recoverHandler(b.monitorMux(prefix, opts.Host)))
+
+	if prefix == "" {
+		return h
+	}
+
+	h = http.StripPrefix(prefix, h)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the relative urls of the web ui need the trailing slash
+		// web 界面的相对 url 需要结尾的斜杠
+		if r.URL.Path == prefix {
+			http.Redirect(w, r, prefix+"/", http.StatusMovedPermanently)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+func (opts MonitorOptions) withAuth(h http.Handler) http.Handler {
+	if opts.Username == "" && opts.Password == "" && opts.Token == "" {
+		return h
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !opts.authorized(w, r) {
+			if opts.Username != "" || opts.Password != "" {
+				w.Header().Set("WWW-Authenticate", `Basic realm="rod monitor"`)
+			}
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+func (opts MonitorOptions) authorized(w http.ResponseWriter, r *http.Request) bool {
+	if opts.Username != "" || opts.Password != "" {
+		if u, p, ok := r.BasicAuth(); ok && secureEqual(u, opts.Username) && secureEqual(p, opts.Password) {
+			return true
+		}
+	}
+
+	if opts.Token == "" {
+		return false
+	}
+
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") &&
+		secureEqual(strings.TrimPrefix(auth, "Bearer "), opts.Token) {
+		return true
+	}
+
+	if c, err := r.Cookie(monitorTokenCookie); err == nil && secureEqual(c.Value, opts.Token) {
+		return true
+	}
+
+	if secureEqual(r.URL.Query().Get("token"), opts.Token) {
+		http.SetCookie(w, &http.Cookie{
+			Name:     monitorTokenCookie,
+			Value:    opts.Token,
+			Path:     opts.cookiePath(),
+			Secure:   r.TLS != nil,
+			HttpOnly: true,
+			SameSite: http.SameSiteStrictMode,
+		})
+		return true
+	}
+
+	return false
+}
+
+// scope the cookie to the prefix, so that it won't be sent to the other apps of the same server
+// 将 cookie 限定在 prefix 下，这样它就不会被发送给同一个服务中的其他应用
+func (opts MonitorOptions) cookiePath() string {
+	if p := strings.TrimSuffix(opts.Prefix, "/"); p != "" {
+		return p
+	}
+	return "/"
+}
+
+func (opts MonitorOptions) tlsConfig() (*tls.Config, error) {
+	if opts.CertFile == "" && opts.KeyFile == "" {
+		return opts.TLSConfig, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{}
+	if opts.TLSConfig != nil {
+		config = opts.TLSConfig.Clone()
+	}
+	config.Certificates = append(config.Certificates, cert)
+	return config, nil
+}
+
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
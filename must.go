@@ -15,7 +15,9 @@
 package rod
 
 import (
+	"context"
 	"errors"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
@@ -25,6 +27,7 @@ import (
 
 	"github.com/go-rod/rod/lib/devices"
 	"github.com/go-rod/rod/lib/input"
+	"github.com/go-rod/rod/lib/js"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
 	"github.com/ysmood/gson"
@@ -70,6 +73,12 @@ func (b *Browser) MustClose() {
 	_ = b.Close()
 }
 
+// MustCloseGracefully is similar to Browser.CloseGracefully
+// MustCloseGracefully 类似于 Browser.CloseGracefully
+func (b *Browser) MustCloseGracefully(ctx context.Context) {
+	b.e(b.CloseGracefully(ctx))
+}
+
 // MustIncognito is similar to Browser.Incognito
 // MustIncognito 类似于 Browser.Incognito
 func (b *Browser) MustIncognito() *Browser {
@@ -96,6 +105,51 @@ func (b *Browser) MustPages() Pages {
 	return list
 }
 
+// MustTargets is similar to Browser.Targets
+// MustTargets 类似于 Browser.Targets
+func (b *Browser) MustTargets(types ...proto.TargetTargetInfoType) []*proto.TargetTargetInfo {
+	list, err := b.Targets(types...)
+	b.e(err)
+	return list
+}
+
+// MustSetSourceMap is similar to Browser.SetSourceMap
+// MustSetSourceMap 类似于 Browser.SetSourceMap
+func (b *Browser) MustSetSourceMap(scriptURL string, sourceMap []byte) *Browser {
+	b.e(b.SetSourceMap(scriptURL, sourceMap))
+	return b
+}
+
+// MustCDPLogger is similar to Browser.CDPLogger
+// MustCDPLogger 类似于 Browser.CDPLogger
+func (b *Browser) MustCDPLogger(opts CDPLoggerOptions) *CDPLogger {
+	l, err := b.CDPLogger(opts)
+	b.e(err)
+	return l
+}
+
+// MustStartTrace is similar to Browser.StartTrace
+// MustStartTrace 类似于 Browser.StartTrace
+func (b *Browser) MustStartTrace(categories ...string) *Browser {
+	b.e(b.StartTrace(categories...))
+	return b
+}
+
+// MustStopTrace is similar to Browser.StopTrace
+// MustStopTrace 类似于 Browser.StopTrace
+func (b *Browser) MustStopTrace(file string) *Browser {
+	b.e(b.StopTrace(file))
+	return b
+}
+
+// MustActivatePage is similar to Browser.ActivatePage
+// MustActivatePage 类似于 Browser.ActivatePage
+func (b *Browser) MustActivatePage(matcher func(*proto.TargetTargetInfo) bool) *Page {
+	p, err := b.ActivatePage(matcher)
+	b.e(err)
+	return p
+}
+
 // MustPageFromTargetID is similar to Browser.PageFromTargetID
 // MustPageFromTargetID 类似于 Browser.PageFromTargetID
 func (b *Browser) MustPageFromTargetID(targetID proto.TargetTargetID) *Page {
@@ -118,6 +172,14 @@ func (b *Browser) MustIgnoreCertErrors(enable bool) *Browser {
 	return b
 }
 
+// MustRawCall is similar to Browser.RawCall, the result is returned as gson.JSON
+// MustRawCall 类似于 Browser.RawCall，结果以 gson.JSON 的形式返回
+func (b *Browser) MustRawCall(method string, params interface{}) gson.JSON {
+	var res gson.JSON
+	b.e(b.RawCall(method, params, &res))
+	return res
+}
+
 // MustGetCookies is similar to Browser.GetCookies
 // MustGetCookies 类似于 Browser.GetCookies
 func (b *Browser) MustGetCookies() []*proto.NetworkCookie {
@@ -139,13 +201,73 @@ func (b *Browser) MustSetCookies(cookies ...*proto.NetworkCookie) *Browser {
 	return b
 }
 
+// MustExportCookies is similar to Browser.ExportCookies
+// MustExportCookies 类似于 Browser.ExportCookies
+func (b *Browser) MustExportCookies(format CookieFormat) []byte {
+	data, err := b.ExportCookies(format)
+	b.e(err)
+	return data
+}
+
+// MustImportCookies is similar to Browser.ImportCookies
+// MustImportCookies 类似于 Browser.ImportCookies
+func (b *Browser) MustImportCookies(format CookieFormat, data []byte) *Browser {
+	b.e(b.ImportCookies(format, data))
+	return b
+}
+
+// MustCookieJar is similar to Browser.CookieJar
+// MustCookieJar 类似于 Browser.CookieJar
+func (b *Browser) MustCookieJar() http.CookieJar {
+	jar, err := b.CookieJar()
+	b.e(err)
+	return jar
+}
+
+// MustSession is similar to Browser.Session
+// MustSession 类似于 Browser.Session
+func (b *Browser) MustSession(opts *SessionOptions) *SessionSnapshot {
+	s, err := b.Session(opts)
+	b.e(err)
+	return s
+}
+
+// MustSaveSession is similar to Browser.SaveSession
+// MustSaveSession 类似于 Browser.SaveSession
+func (b *Browser) MustSaveSession(w io.Writer, opts *SessionOptions) *Browser {
+	b.e(b.SaveSession(w, opts))
+	return b
+}
+
+// MustLoadSession is similar to Browser.LoadSession
+// MustLoadSession 类似于 Browser.LoadSession
+func (b *Browser) MustLoadSession(r io.Reader) *Browser {
+	b.e(b.LoadSession(r))
+	return b
+}
+
+// MustClearData is similar to Browser.ClearData
+// MustClearData 类似于 Browser.ClearData
+func (b *Browser) MustClearData(opts *ClearDataOptions) *Browser {
+	b.e(b.ClearData(opts))
+	return b
+}
+
 // MustWaitDownload is similar to Browser.WaitDownload.
 // MustWaitDownload 类似于 Browser.WaitDownload.
 // It will read the file into bytes then remove the file.
 // 它将把文件读入字节，然后删除文件。
 func (b *Browser) MustWaitDownload() func() []byte {
+	return b.MustWaitDownloadMatch(func(*proto.PageDownloadWillBegin) bool { return true })
+}
+
+// MustWaitDownloadMatch is similar to Browser.WaitDownloadMatch.
+// MustWaitDownloadMatch 类似于 Browser.WaitDownloadMatch.
+// It will read the file into bytes then remove the file.
+// 它将把文件读入字节，然后删除文件。
+func (b *Browser) MustWaitDownloadMatch(match func(*proto.PageDownloadWillBegin) bool) func() []byte {
 	tmpDir := filepath.Join(os.TempDir(), "rod", "downloads")
-	wait := b.WaitDownload(tmpDir)
+	wait := b.WaitDownloadMatch(tmpDir, match)
 
 	return func() []byte {
 		info := wait()
@@ -157,6 +279,25 @@ func (b *Browser) MustWaitDownload() func() []byte {
 	}
 }
 
+// MustWaitDownloadBegin is similar to Browser.WaitDownloadBegin
+// MustWaitDownloadBegin 类似于 Browser.WaitDownloadBegin
+func (b *Browser) MustWaitDownloadBegin(dir string) func() *Download {
+	wait := b.WaitDownloadBegin(dir)
+	return func() *Download {
+		d, err := wait()
+		b.e(err)
+		return d
+	}
+}
+
+// MustDownloadManager is similar to Browser.DownloadManager
+// MustDownloadManager 类似于 Browser.DownloadManager
+func (b *Browser) MustDownloadManager(dir string) *DownloadManager {
+	m, err := b.DownloadManager(dir)
+	b.e(err)
+	return m
+}
+
 // MustVersion is similar to Browser.Version.
 // MustVersion 类似于 Browser.Version。
 func (b *Browser) MustVersion() *proto.BrowserGetVersionResult {
@@ -165,6 +306,45 @@ func (b *Browser) MustVersion() *proto.BrowserGetVersionResult {
 	return v
 }
 
+// MustMap is similar to Elements.Map
+// MustMap 类似于 Elements.Map
+func (els Elements) MustMap(fn func(*Element) *Element) Elements {
+	list, _ := els.Map(func(el *Element) (*Element, error) {
+		return fn(el), nil
+	})
+	return list
+}
+
+// MustTexts is similar to Elements.Texts
+// MustTexts 类似于 Elements.Texts
+func (els Elements) MustTexts() []string {
+	list, err := els.Texts()
+	els.e(err)
+	return list
+}
+
+// MustAttributes is similar to Elements.Attributes
+// MustAttributes 类似于 Elements.Attributes
+func (els Elements) MustAttributes(name string) []*string {
+	list, err := els.Attributes(name)
+	els.e(err)
+	return list
+}
+
+// MustRelease is similar to Elements.Release
+// MustRelease 类似于 Elements.Release
+func (els Elements) MustRelease() {
+	els.e(els.Release())
+}
+
+func (els Elements) e(err error) {
+	if len(els) > 0 {
+		els[0].e(err)
+	} else {
+		utils.E(err)
+	}
+}
+
 // MustFind is similar to Browser.Find
 // MustFind 类似于 Browser.Find
 func (ps Pages) MustFind(selector string) *Page {
@@ -199,6 +379,33 @@ func (ps Pages) MustFindByURL(regex string) *Page {
 	return p
 }
 
+// MustFindByTitle is similar to Pages.FindByTitle
+// MustFindByTitle 类似于 Pages.FindByTitle
+func (ps Pages) MustFindByTitle(regex string) *Page {
+	p, err := ps.FindByTitle(regex)
+	if err != nil {
+		if len(ps) > 0 {
+			ps[0].e(err)
+		} else {
+			// fallback to utils.E, because we don't have enough
+			// context to call the scope `.e`.
+			// 失败会调用 utils.E ，因为没有足够的 ctx 去调用 `.e`
+			utils.E(err)
+		}
+	}
+	return p
+}
+
+// MustGroupByWindow is similar to Pages.GroupByWindow
+// MustGroupByWindow 类似于 Pages.GroupByWindow
+func (ps Pages) MustGroupByWindow() map[proto.BrowserWindowID]Pages {
+	groups, err := ps.GroupByWindow()
+	if err != nil {
+		ps[0].e(err)
+	}
+	return groups
+}
+
 // WithPanic returns a page clone with the specified panic function.
 // Withpanic 会返回一个带有指定 panic 函数 Page 的克隆
 // The fail must stop the current goroutine's execution immediately, such as use runtime.Goexit() or panic inside it.
@@ -245,6 +452,66 @@ func (p *Page) MustSetCookies(cookies ...*proto.NetworkCookieParam) *Page {
 	return p
 }
 
+// MustStorageUsage is similar to Page.StorageUsage
+// MustStorageUsage 类似于 Page.StorageUsage
+func (p *Page) MustStorageUsage() *proto.StorageGetUsageAndQuotaResult {
+	res, err := p.StorageUsage()
+	p.e(err)
+	return res
+}
+
+// MustSetStorageQuota is similar to Page.SetStorageQuota
+// MustSetStorageQuota 类似于 Page.SetStorageQuota
+func (p *Page) MustSetStorageQuota(size float64) *Page {
+	p.e(p.SetStorageQuota(size))
+	return p
+}
+
+// MustResetStorageQuota is similar to Page.ResetStorageQuota
+// MustResetStorageQuota 类似于 Page.ResetStorageQuota
+func (p *Page) MustResetStorageQuota() *Page {
+	p.e(p.ResetStorageQuota())
+	return p
+}
+
+// MustCaches is similar to Page.Caches
+// MustCaches 类似于 Page.Caches
+func (p *Page) MustCaches() []*Cache {
+	list, err := p.Caches()
+	p.e(err)
+	return list
+}
+
+// MustCache is similar to Page.Cache
+// MustCache 类似于 Page.Cache
+func (p *Page) MustCache(name string) *Cache {
+	c, err := p.Cache(name)
+	p.e(err)
+	return c
+}
+
+// MustClearData is similar to Page.ClearData
+// MustClearData 类似于 Page.ClearData
+func (p *Page) MustClearData(opts *ClearDataOptions) *Page {
+	p.e(p.ClearData(opts))
+	return p
+}
+
+// MustExportCookies is similar to Page.ExportCookies
+// MustExportCookies 类似于 Page.ExportCookies
+func (p *Page) MustExportCookies(format CookieFormat, urls ...string) []byte {
+	data, err := p.ExportCookies(format, urls...)
+	p.e(err)
+	return data
+}
+
+// MustImportCookies is similar to Page.ImportCookies
+// MustImportCookies 类似于 Page.ImportCookies
+func (p *Page) MustImportCookies(format CookieFormat, data []byte) *Page {
+	p.e(p.ImportCookies(format, data))
+	return p
+}
+
 // MustSetExtraHeaders is similar to Page.SetExtraHeaders
 // MustSetExtraHeaders 类似于 Page.SetExtraHeaders
 func (p *Page) MustSetExtraHeaders(dict ...string) (cleanup func()) {
@@ -253,6 +520,14 @@ func (p *Page) MustSetExtraHeaders(dict ...string) (cleanup func()) {
 	return
 }
 
+// MustSetScopedExtraHeaders is similar to Page.SetScopedExtraHeaders
+// MustSetScopedExtraHeaders 类似于 Page.SetScopedExtraHeaders
+func (p *Page) MustSetScopedExtraHeaders(patterns []string, dict ...string) (cleanup func()) {
+	cleanup, err := p.SetScopedExtraHeaders(patterns, dict)
+	p.e(err)
+	return
+}
+
 // MustSetUserAgent is similar to Page.SetUserAgent
 // MustSetUserAgent 类似于 Page.SetUserAgent
 func (p *Page) MustSetUserAgent(req *proto.NetworkSetUserAgentOverride) *Page {
@@ -260,6 +535,21 @@ func (p *Page) MustSetUserAgent(req *proto.NetworkSetUserAgentOverride) *Page {
 	return p
 }
 
+// MustSnapshot is similar to Page.Snapshot
+// MustSnapshot 类似于 Page.Snapshot
+func (p *Page) MustSnapshot() *PageSnapshot {
+	snap, err := p.Snapshot()
+	p.e(err)
+	return snap
+}
+
+// MustRestore is similar to Page.Restore
+// MustRestore 类似于 Page.Restore
+func (p *Page) MustRestore(snap *PageSnapshot) *Page {
+	p.e(p.Restore(snap))
+	return p
+}
+
 // MustNavigate is similar to Page.Navigate
 // MustNavigate 类似于 Page.Navigate
 func (p *Page) MustNavigate(url string) *Page {
@@ -378,6 +668,14 @@ func (p *Page) MustStopLoading() *Page {
 	return p
 }
 
+// MustRawCall is similar to Page.RawCall, the result is returned as gson.JSON
+// MustRawCall 类似于 Page.RawCall，结果以 gson.JSON 的形式返回
+func (p *Page) MustRawCall(method string, params interface{}) gson.JSON {
+	var res gson.JSON
+	p.e(p.RawCall(method, params, &res))
+	return res
+}
+
 // MustClose is similar to Page.Close
 // MustClose 类似于 Page.Close
 func (p *Page) MustClose() {
@@ -396,6 +694,35 @@ func (p *Page) MustHandleDialog() (wait func() *proto.PageJavascriptDialogOpenin
 	}
 }
 
+// MustDownloadResources is similar to Page.DownloadResources
+// MustDownloadResources 类似于 Page.DownloadResources
+func (p *Page) MustDownloadResources(dir string, filter func(*proto.PageFrameResource) bool) []string {
+	list, err := p.DownloadResources(dir, filter)
+	p.e(err)
+	return list
+}
+
+// MustSetDownloadDir is similar to Page.SetDownloadDir
+// MustSetDownloadDir 类似于 Page.SetDownloadDir
+func (p *Page) MustSetDownloadDir(dir string) string {
+	d, err := p.SetDownloadDir(dir)
+	p.e(err)
+	return d
+}
+
+// MustHandleFileChooser is similar to Page.HandleFileChooser
+// MustHandleFileChooser 类似于 Page.HandleFileChooser
+func (p *Page) MustHandleFileChooser() (wait func() *Element, handle func(paths ...string)) {
+	w, h := p.HandleFileChooser()
+	return func() *Element {
+			el, err := w()
+			p.e(err)
+			return el
+		}, func(paths ...string) {
+			p.e(h(paths))
+		}
+}
+
 // MustScreenshot is similar to Screenshot.
 // MustScreenshot 类似于 Screenshot.
 // If the toFile is "", it Page.will save output to "tmp/screenshots" folder, time as the file name.
@@ -462,6 +789,13 @@ func (p *Page) MustWaitIdle() *Page {
 	return p
 }
 
+// MustWaitMainThreadIdle is similar to Page.WaitMainThreadIdle
+// MustWaitMainThreadIdle 类似于 Page.WaitMainThreadIdle
+func (p *Page) MustWaitMainThreadIdle() *Page {
+	p.e(p.WaitMainThreadIdle(time.Minute))
+	return p
+}
+
 // MustWaitLoad is similar to Page.WaitLoad
 // MustWaitLoad 类似于 Page.WaitLoad
 func (p *Page) MustWaitLoad() *Page {
@@ -475,6 +809,21 @@ func (p *Page) MustAddScriptTag(url string) *Page {
 	return p
 }
 
+// MustAddScript is similar to Page.AddScript
+// MustAddScript 类似于 Page.AddScript
+func (p *Page) MustAddScript(opts *ScriptTagOptions) *Page {
+	p.e(p.AddScript(opts))
+	return p
+}
+
+// MustInjectBundle is similar to Page.InjectBundle
+// MustInjectBundle 类似于 Page.InjectBundle
+func (p *Page) MustInjectBundle(bundle http.FileSystem, entry string, module bool) (stop func()) {
+	s, err := p.InjectBundle(bundle, entry, module)
+	p.e(err)
+	return func() { p.e(s()) }
+}
+
 // MustAddStyleTag is similar to Page.AddStyleTag
 // MustAddStyleTag 类似于 Page.AddStyleTag
 func (p *Page) MustAddStyleTag(url string) *Page {
@@ -489,6 +838,21 @@ func (p *Page) MustEvalOnNewDocument(js string) {
 	p.e(err)
 }
 
+// MustAddInitScript is similar to Page.AddInitScript
+// MustAddInitScript 类似于 Page.AddInitScript
+func (p *Page) MustAddInitScript(js string, opts *InitScriptOptions) *InitScript {
+	s, err := p.AddInitScript(js, opts)
+	p.e(err)
+	return s
+}
+
+// MustClearInitScripts is similar to Page.ClearInitScripts
+// MustClearInitScripts 类似于 Page.ClearInitScripts
+func (p *Page) MustClearInitScripts() *Page {
+	p.e(p.ClearInitScripts())
+	return p
+}
+
 // MustExpose is similar to Page.Expose
 // MustExpose 类似于 Page.Expose
 func (p *Page) MustExpose(name string, fn func(gson.JSON) (interface{}, error)) (stop func()) {
@@ -497,6 +861,29 @@ func (p *Page) MustExpose(name string, fn func(gson.JSON) (interface{}, error))
 	return func() { p.e(s()) }
 }
 
+// MustExposeFunc is similar to Page.ExposeFunc
+// MustExposeFunc 类似于 Page.ExposeFunc
+func (p *Page) MustExposeFunc(name string, fn interface{}) (stop func()) {
+	s, err := p.ExposeFunc(name, fn)
+	p.e(err)
+	return func() { p.e(s()) }
+}
+
+// MustExposeMethods is similar to Page.ExposeMethods
+// MustExposeMethods 类似于 Page.ExposeMethods
+func (p *Page) MustExposeMethods(namespace string, obj interface{}) (stop func()) {
+	s, err := p.ExposeMethods(namespace, obj)
+	p.e(err)
+	return func() { p.e(s()) }
+}
+
+// MustLoadHelpers is similar to Page.LoadHelpers
+// MustLoadHelpers 类似于 Page.LoadHelpers
+func (p *Page) MustLoadHelpers(list ...*js.Function) *Page {
+	p.e(p.LoadHelpers(list...))
+	return p
+}
+
 // MustEval is similar to Page.Eval
 // MustEval 类似于 Page.Eval
 func (p *Page) MustEval(js string, params ...interface{}) gson.JSON {
@@ -505,6 +892,21 @@ func (p *Page) MustEval(js string, params ...interface{}) gson.JSON {
 	return res.Value
 }
 
+// MustEvalInto is similar to Page.EvalInto
+// MustEvalInto 类似于 Page.EvalInto
+func (p *Page) MustEvalInto(v interface{}, js string, params ...interface{}) *Page {
+	p.e(p.EvalInto(v, js, params...))
+	return p
+}
+
+// MustEvalBatch is similar to Page.EvalBatch
+// MustEvalBatch 类似于 Page.EvalBatch
+func (p *Page) MustEvalBatch(list ...*EvalOptions) []*proto.RuntimeRemoteObject {
+	res, err := p.EvalBatch(list...)
+	p.e(err)
+	return res
+}
+
 // MustEvaluate is similar to Page.Evaluate
 // MustEvaluate 类似于 Page.Evaluate
 func (p *Page) MustEvaluate(opts *EvalOptions) *proto.RuntimeRemoteObject {
@@ -593,6 +995,20 @@ func (p *Page) MustHasR(selector, regex string) bool {
 	return has
 }
 
+// MustWaitHas is similar to Page.WaitHas
+// MustWaitHas 类似于 Page.WaitHas
+func (p *Page) MustWaitHas(selector string) *Page {
+	p.e(p.WaitHas(selector))
+	return p
+}
+
+// MustWaitNotHas is similar to Page.WaitNotHas
+// MustWaitNotHas 类似于 Page.WaitNotHas
+func (p *Page) MustWaitNotHas(selector string) *Page {
+	p.e(p.WaitNotHas(selector))
+	return p
+}
+
 // MustSearch is similar to Page.Search .
 // MustSearch 类似于 Page.Search .
 // It only returns the first element in the search result.
@@ -604,6 +1020,14 @@ func (p *Page) MustSearch(query string) *Element {
 	return res.First
 }
 
+// MustEach is similar to SearchResult.Each
+// MustEach 类似于 SearchResult.Each
+func (s *SearchResult) MustEach(fn func(*Element) (stop bool)) {
+	s.page.e(s.Each(func(el *Element) (bool, error) {
+		return fn(el), nil
+	}))
+}
+
 // MustElement is similar to Page.Element
 // MustElement 类似于 Page.Element
 func (p *Page) MustElement(selector string) *Element {
@@ -652,6 +1076,22 @@ func (p *Page) MustElementsX(xpath string) Elements {
 	return list
 }
 
+// MustElementsR is similar to Page.ElementsR
+// MustElementsR 类似于 Page.ElementsR
+func (p *Page) MustElementsR(selector, jsRegex string) Elements {
+	list, err := p.ElementsR(selector, jsRegex)
+	p.e(err)
+	return list
+}
+
+// MustElementsIter is similar to Page.ElementsIter
+// MustElementsIter 类似于 Page.ElementsIter
+func (p *Page) MustElementsIter(selector string) *ElementsIterator {
+	it, err := p.ElementsIter(selector)
+	p.e(err)
+	return it
+}
+
 // MustElementsByJS is similar to Page.ElementsByJS
 // MustElementsByJS 类似于 Page.ElementsByJS
 func (p *Page) MustElementsByJS(js string, params ...interface{}) Elements {
@@ -697,6 +1137,20 @@ func (m *Mouse) MustScroll(x, y float64) *Mouse {
 	return m
 }
 
+// MustWheelZoom is similar to Mouse.WheelZoom
+// MustWheelZoom 类似于 Mouse.WheelZoom
+func (m *Mouse) MustWheelZoom(delta float64) *Mouse {
+	m.page.e(m.WheelZoom(delta, 0))
+	return m
+}
+
+// MustScrollGesture is similar to Mouse.ScrollGesture
+// MustScrollGesture 类似于 Mouse.ScrollGesture
+func (m *Mouse) MustScrollGesture(offsetX, offsetY float64) *Mouse {
+	m.page.e(m.ScrollGesture(offsetX, offsetY, nil))
+	return m
+}
+
 // MustDown is similar to Mouse.Down
 // MustDown 类似于 Mouse.Down
 func (m *Mouse) MustDown(button proto.InputMouseButton) *Mouse {
@@ -718,6 +1172,13 @@ func (m *Mouse) MustClick(button proto.InputMouseButton) *Mouse {
 	return m
 }
 
+// MustDrag is similar to Mouse.Drag
+// MustDrag 类似于 Mouse.Drag
+func (m *Mouse) MustDrag(fromX, fromY, toX, toY float64) *Mouse {
+	m.page.e(m.Drag(fromX, fromY, toX, toY, nil))
+	return m
+}
+
 // MustType is similar to Keyboard.Type
 // MustType 类似于 Keyboard.Type
 func (k *Keyboard) MustType(key ...input.Key) *Keyboard {
@@ -731,6 +1192,13 @@ func (ka *KeyActions) MustDo() {
 	ka.keyboard.page.e(ka.Do())
 }
 
+// MustScrollGesture is similar to Page.ScrollGesture
+// MustScrollGesture 类似于 Page.ScrollGesture
+func (p *Page) MustScrollGesture(x, y, offsetX, offsetY float64) *Page {
+	p.e(p.ScrollGesture(x, y, offsetX, offsetY, nil))
+	return p
+}
+
 // MustInsertText is similar to Page.InsertText
 // MustInsertText 类似于 Page.InsertText
 func (p *Page) MustInsertText(text string) *Page {
@@ -738,6 +1206,62 @@ func (p *Page) MustInsertText(text string) *Page {
 	return p
 }
 
+// MustIMEComposition is similar to Page.IMEComposition
+// MustIMEComposition 类似于 Page.IMEComposition
+func (p *Page) MustIMEComposition(compositions []string, commit string) *Page {
+	p.e(p.IMEComposition(compositions, commit))
+	return p
+}
+
+// MustIMECancel is similar to Page.IMECancel
+// MustIMECancel 类似于 Page.IMECancel
+func (p *Page) MustIMECancel() *Page {
+	p.e(p.IMECancel())
+	return p
+}
+
+// MustInsertTextIME is similar to Page.InsertTextIME
+// MustInsertTextIME 类似于 Page.InsertTextIME
+func (p *Page) MustInsertTextIME(text string) *Page {
+	p.e(p.InsertTextIME(text))
+	return p
+}
+
+// MustMove is similar to Pen.Move
+// MustMove 类似于 Pen.Move
+func (pen *Pen) MustMove(x, y float64) *Pen {
+	pen.page.e(pen.Move(x, y, nil))
+	return pen
+}
+
+// MustDown is similar to Pen.Down
+// MustDown 类似于 Pen.Down
+func (pen *Pen) MustDown() *Pen {
+	pen.page.e(pen.Down(nil))
+	return pen
+}
+
+// MustUp is similar to Pen.Up
+// MustUp 类似于 Pen.Up
+func (pen *Pen) MustUp() *Pen {
+	pen.page.e(pen.Up())
+	return pen
+}
+
+// MustStroke is similar to Pen.Stroke
+// MustStroke 类似于 Pen.Stroke
+func (pen *Pen) MustStroke(points []proto.Point, states ...*PenState) *Pen {
+	pen.page.e(pen.Stroke(points, states...))
+	return pen
+}
+
+// MustReplayInput is similar to Page.ReplayInput
+// MustReplayInput 类似于 Page.ReplayInput
+func (p *Page) MustReplayInput(script *InputScript) *Page {
+	p.e(p.ReplayInput(script, 1))
+	return p
+}
+
 // MustStart is similar to Touch.Start
 // MustStart 类似于 Touch.Start
 func (t *Touch) MustStart(points ...*proto.InputTouchPoint) *Touch {
@@ -766,6 +1290,27 @@ func (t *Touch) MustCancel() *Touch {
 	return t
 }
 
+// MustSwipe is similar to Touch.Swipe
+// MustSwipe 类似于 Touch.Swipe
+func (t *Touch) MustSwipe(from, to proto.Point) *Touch {
+	t.page.e(t.Swipe(from, to, 300*time.Millisecond))
+	return t
+}
+
+// MustPinch is similar to Touch.Pinch
+// MustPinch 类似于 Touch.Pinch
+func (t *Touch) MustPinch(center proto.Point, scale float64) *Touch {
+	t.page.e(t.Pinch(center, scale, 300*time.Millisecond))
+	return t
+}
+
+// MustRotate is similar to Touch.Rotate
+// MustRotate 类似于 Touch.Rotate
+func (t *Touch) MustRotate(center proto.Point, angle float64) *Touch {
+	t.page.e(t.Rotate(center, angle, 300*time.Millisecond))
+	return t
+}
+
 // MustTap is similar to Touch.Tap
 // MustTap 类似于 Touch.Tap
 func (t *Touch) MustTap(x, y float64) *Touch {
@@ -895,6 +1440,13 @@ func (el *Element) MustInput(text string) *Element {
 	return el
 }
 
+// MustInputIME is similar to Element.InputIME
+// MustInputIME 类似于 Element.InputIME
+func (el *Element) MustInputIME(text string) *Element {
+	el.e(el.InputIME(text))
+	return el
+}
+
 // MustInputTime is similar to Element.Input
 // MustInputTime 类似于 Element.Input
 func (el *Element) MustInputTime(t time.Time) *Element {
@@ -955,6 +1507,13 @@ func (el *Element) MustSetFiles(paths ...string) *Element {
 	return el
 }
 
+// MustDropFiles is similar to Element.DropFiles
+// MustDropFiles 类似于 Element.DropFiles
+func (el *Element) MustDropFiles(paths ...string) *Element {
+	el.e(el.DropFiles(paths))
+	return el
+}
+
 // MustSetDocumentContent is similar to Page.SetDocumentContent
 // MustSetDocumentContent 类似于 Page.SetDocumentContent
 func (p *Page) MustSetDocumentContent(html string) *Page {
@@ -1096,6 +1655,13 @@ func (el *Element) MustEval(js string, params ...interface{}) gson.JSON {
 	return res.Value
 }
 
+// MustEvalInto is similar to Element.EvalInto
+// MustEvalInto 类似于 Element.EvalInto
+func (el *Element) MustEvalInto(v interface{}, js string, params ...interface{}) *Element {
+	el.e(el.EvalInto(v, js, params...))
+	return el
+}
+
 // MustHas is similar to Element.Has
 // MustHas 类似于 Element.Has
 func (el *Element) MustHas(selector string) bool {
@@ -1120,6 +1686,20 @@ func (el *Element) MustHasR(selector, regex string) bool {
 	return has
 }
 
+// MustWaitHas is similar to Element.WaitHas
+// MustWaitHas 类似于 Element.WaitHas
+func (el *Element) MustWaitHas(selector string) *Element {
+	el.e(el.WaitHas(selector))
+	return el
+}
+
+// MustWaitNotHas is similar to Element.WaitNotHas
+// MustWaitNotHas 类似于 Element.WaitNotHas
+func (el *Element) MustWaitNotHas(selector string) *Element {
+	el.e(el.WaitNotHas(selector))
+	return el
+}
+
 // MustElement is similar to Element.Element
 // MustElement 类似于 Element.Element
 func (el *Element) MustElement(selector string) *Element {
@@ -1200,6 +1780,22 @@ func (el *Element) MustElementsX(xpath string) Elements {
 	return list
 }
 
+// MustElementsR is similar to Element.ElementsR
+// MustElementsR 类似于 Element.ElementsR
+func (el *Element) MustElementsR(selector, jsRegex string) Elements {
+	list, err := el.ElementsR(selector, jsRegex)
+	el.e(err)
+	return list
+}
+
+// MustElementsIter is similar to Element.ElementsIter
+// MustElementsIter 类似于 Element.ElementsIter
+func (el *Element) MustElementsIter(selector string) *ElementsIterator {
+	it, err := el.ElementsIter(selector)
+	el.e(err)
+	return it
+}
+
 // MustElementsByJS is similar to Element.ElementsByJS
 // MustElementsByJS 类似于 Element.ElementsByJS
 func (el *Element) MustElementsByJS(js string, params ...interface{}) Elements {
@@ -1248,3 +1844,344 @@ func (el *Element) MustMoveMouseOut() *Element {
 	el.e(el.MoveMouseOut())
 	return el
 }
+
+// MustAddURL is similar to Cluster.AddURL
+// MustAddURL 类似于 Cluster.AddURL
+func (c *Cluster) MustAddURL(u string) *Browser {
+	b, err := c.AddURL(u)
+	utils.E(err)
+	return b
+}
+
+// MustPage is similar to Cluster.Page.
+// The url list will be joined by "/".
+// MustPage 类似于 Cluster.Page。
+// 网址列表将以"/"连接。
+func (c *Cluster) MustPage(url ...string) *Page {
+	p, err := c.Page(proto.TargetCreateTarget{URL: strings.Join(url, "/")})
+	utils.E(err)
+	return p
+}
+
+// MustExtensionTargets is similar to Browser.ExtensionTargets
+// MustExtensionTargets 类似于 Browser.ExtensionTargets
+func (b *Browser) MustExtensionTargets(extensionID string) []*proto.TargetTargetInfo {
+	list, err := b.ExtensionTargets(extensionID)
+	b.e(err)
+	return list
+}
+
+// MustExtension is similar to Browser.Extension
+// MustExtension 类似于 Browser.Extension
+func (b *Browser) MustExtension(extensionID string) *Extension {
+	ext, err := b.Extension(extensionID)
+	b.e(err)
+	return ext
+}
+
+// MustEval is similar to Extension.Eval
+// MustEval 类似于 Extension.Eval
+func (e *Extension) MustEval(js string, params ...interface{}) gson.JSON {
+	res, err := e.Eval(js, params...)
+	e.page.e(err)
+	return res.Value
+}
+
+// MustSendMessage is similar to Extension.SendMessage
+// MustSendMessage 类似于 Extension.SendMessage
+func (e *Extension) MustSendMessage(msg interface{}) gson.JSON {
+	res, err := e.SendMessage(msg)
+	e.page.e(err)
+	return res
+}
+
+// MustOnMessage is similar to Extension.OnMessage
+// MustOnMessage 类似于 Extension.OnMessage
+func (e *Extension) MustOnMessage(fn func(msg gson.JSON)) (stop func()) {
+	s, err := e.OnMessage(fn)
+	e.page.e(err)
+	return func() { e.page.e(s()) }
+}
+
+// MustWait is similar to Download.Wait.
+// It will read the downloaded file into bytes.
+// MustWait 类似于 Download.Wait。
+// 它将把下载的文件读入字节。
+func (d *Download) MustWait() []byte {
+	b := d.manager.browser
+	b.e(d.Wait())
+	data, err := ioutil.ReadFile(d.Path())
+	b.e(err)
+	return data
+}
+
+// MustCancel is similar to Download.Cancel
+// MustCancel 类似于 Download.Cancel
+func (d *Download) MustCancel() {
+	d.manager.browser.e(d.Cancel())
+}
+
+// MustItems is similar to WebStorage.Items
+// MustItems 类似于 WebStorage.Items
+func (s *WebStorage) MustItems() map[string]string {
+	items, err := s.Items()
+	s.page.e(err)
+	return items
+}
+
+// MustGet is similar to WebStorage.Get
+// MustGet 类似于 WebStorage.Get
+func (s *WebStorage) MustGet(key string) string {
+	value, _, err := s.Get(key)
+	s.page.e(err)
+	return value
+}
+
+// MustKeys is similar to WebStorage.Keys
+// MustKeys 类似于 WebStorage.Keys
+func (s *WebStorage) MustKeys() []string {
+	keys, err := s.Keys()
+	s.page.e(err)
+	return keys
+}
+
+// MustSet is similar to WebStorage.Set
+// MustSet 类似于 WebStorage.Set
+func (s *WebStorage) MustSet(key, value string) *WebStorage {
+	s.page.e(s.Set(key, value))
+	return s
+}
+
+// MustRemove is similar to WebStorage.Remove
+// MustRemove 类似于 WebStorage.Remove
+func (s *WebStorage) MustRemove(key string) *WebStorage {
+	s.page.e(s.Remove(key))
+	return s
+}
+
+// MustClear is similar to WebStorage.Clear
+// MustClear 类似于 WebStorage.Clear
+func (s *WebStorage) MustClear() *WebStorage {
+	s.page.e(s.Clear())
+	return s
+}
+
+// MustOnChange is similar to WebStorage.OnChange
+// MustOnChange 类似于 WebStorage.OnChange
+func (s *WebStorage) MustOnChange(fn func(*WebStorageChange)) (cancel func()) {
+	cancel, err := s.OnChange(fn)
+	s.page.e(err)
+	return cancel
+}
+
+// MustEntries is similar to Cache.Entries
+// MustEntries 类似于 Cache.Entries
+func (c *Cache) MustEntries(pathFilter string) []*proto.CacheStorageDataEntry {
+	list, err := c.Entries(pathFilter)
+	c.page.e(err)
+	return list
+}
+
+// MustResponse is similar to Cache.Response
+// MustResponse 类似于 Cache.Response
+func (c *Cache) MustResponse(entry *proto.CacheStorageDataEntry) []byte {
+	body, err := c.Response(entry)
+	c.page.e(err)
+	return body
+}
+
+// MustMatch is similar to Cache.Match
+// MustMatch 类似于 Cache.Match
+func (c *Cache) MustMatch(url string) (*proto.CacheStorageDataEntry, []byte) {
+	e, body, err := c.Match(url)
+	c.page.e(err)
+	return e, body
+}
+
+// MustDeleteEntry is similar to Cache.DeleteEntry
+// MustDeleteEntry 类似于 Cache.DeleteEntry
+func (c *Cache) MustDeleteEntry(url string) *Cache {
+	c.page.e(c.DeleteEntry(url))
+	return c
+}
+
+// MustDelete is similar to Cache.Delete
+// MustDelete 类似于 Cache.Delete
+func (c *Cache) MustDelete() {
+	c.page.e(c.Delete())
+}
+
+// MustRemove is similar to InitScript.Remove
+// MustRemove 类似于 InitScript.Remove
+func (s *InitScript) MustRemove() {
+	s.page.e(s.Remove())
+}
+
+// MustChannel is similar to Page.Channel
+// MustChannel 类似于 Page.Channel
+func (p *Page) MustChannel(name string) *Channel {
+	c, err := p.Channel(name)
+	p.e(err)
+	return c
+}
+
+// MustSend is similar to Channel.Send
+// MustSend 类似于 Channel.Send
+func (c *Channel) MustSend(v interface{}) *Channel {
+	c.page.e(c.Send(v))
+	return c
+}
+
+// MustClose is similar to Channel.Close
+// MustClose 类似于 Channel.Close
+func (c *Channel) MustClose() {
+	c.page.e(c.Close())
+}
+
+// MustWaitDownloadReader is similar to Browser.WaitDownloadReader
+// MustWaitDownloadReader 类似于 Browser.WaitDownloadReader
+func (b *Browser) MustWaitDownloadReader() func() *DownloadReader {
+	wait := b.WaitDownloadReader()
+	return func() *DownloadReader {
+		r, err := wait()
+		b.e(err)
+		return r
+	}
+}
+
+// MustWaitDownloadStream is similar to Browser.WaitDownloadStream
+// MustWaitDownloadStream 类似于 Browser.WaitDownloadStream
+func (b *Browser) MustWaitDownloadStream(w io.Writer) func() *Download {
+	wait := b.WaitDownloadStream(w)
+	return func() *Download {
+		d, err := wait()
+		b.e(err)
+		return d
+	}
+}
+
+// MustFetch is similar to ResourceFetcher.Fetch
+// MustFetch 类似于 ResourceFetcher.Fetch
+func (f *ResourceFetcher) MustFetch(url string) *Resource {
+	res, err := f.Fetch(url)
+	f.page.e(err)
+	return res
+}
+
+// MustFetchAll is similar to ResourceFetcher.FetchAll
+// MustFetchAll 类似于 ResourceFetcher.FetchAll
+func (f *ResourceFetcher) MustFetchAll(urls ...string) []*Resource {
+	list, err := f.FetchAll(urls)
+	f.page.e(err)
+	return list
+}
+
+// MustStartTrace is similar to Page.StartTrace
+// MustStartTrace 类似于 Page.StartTrace
+func (p *Page) MustStartTrace(categories ...string) *Page {
+	p.e(p.StartTrace(categories...))
+	return p
+}
+
+// MustStopTrace is similar to Page.StopTrace
+// MustStopTrace 类似于 Page.StopTrace
+func (p *Page) MustStopTrace(file string) *Page {
+	p.e(p.StopTrace(file))
+	return p
+}
+
+// MustMetrics is similar to Page.Metrics
+// MustMetrics 类似于 Page.Metrics
+func (p *Page) MustMetrics() map[string]float64 {
+	m, err := p.Metrics()
+	p.e(err)
+	return m
+}
+
+// MustWebVitals is similar to Page.WebVitals
+// MustWebVitals 类似于 Page.WebVitals
+func (p *Page) MustWebVitals() *WebVitals {
+	v, err := p.WebVitals()
+	p.e(err)
+	return v
+}
+
+// MustHeapSnapshot is similar to Page.HeapSnapshot
+// MustHeapSnapshot 类似于 Page.HeapSnapshot
+func (p *Page) MustHeapSnapshot(file string) *Page {
+	p.e(p.HeapSnapshot(file))
+	return p
+}
+
+// MustCollectGarbage is similar to Page.CollectGarbage
+// MustCollectGarbage 类似于 Page.CollectGarbage
+func (p *Page) MustCollectGarbage() *Page {
+	p.e(p.CollectGarbage())
+	return p
+}
+
+// MustHeapUsage is similar to Page.HeapUsage
+// MustHeapUsage 类似于 Page.HeapUsage
+func (p *Page) MustHeapUsage() *HeapUsage {
+	u, err := p.HeapUsage()
+	p.e(err)
+	return u
+}
+
+// MustStartHeapSampling is similar to Page.StartHeapSampling
+// MustStartHeapSampling 类似于 Page.StartHeapSampling
+func (p *Page) MustStartHeapSampling(interval float64) *Page {
+	p.e(p.StartHeapSampling(interval))
+	return p
+}
+
+// MustStopHeapSampling is similar to Page.StopHeapSampling
+// MustStopHeapSampling 类似于 Page.StopHeapSampling
+func (p *Page) MustStopHeapSampling() *proto.HeapProfilerSamplingHeapProfile {
+	profile, err := p.StopHeapSampling()
+	p.e(err)
+	return profile
+}
+
+// MustDumpDebug is similar to Page.DumpDebug
+// MustDumpDebug 类似于 Page.DumpDebug
+func (p *Page) MustDumpDebug(dir string) *Page {
+	p.e(p.DumpDebug(dir))
+	return p
+}
+
+// MustDo is similar to Batch.Do
+// MustDo 类似于 Batch.Do
+func (b *Batch) MustDo() {
+	b.page.e(b.Do())
+}
+
+// MustDOMSnapshot is similar to Page.DOMSnapshot
+// MustDOMSnapshot 类似于 Page.DOMSnapshot
+func (p *Page) MustDOMSnapshot(opts proto.DOMSnapshotCaptureSnapshot) *DOMSnapshot {
+	s, err := p.DOMSnapshot(opts)
+	p.e(err)
+	return s
+}
+
+// MustScreenshot is similar to Reporter.Screenshot
+// MustScreenshot 类似于 Reporter.Screenshot
+func (r *Reporter) MustScreenshot(name string) *Reporter {
+	r.page.e(r.Screenshot(name))
+	return r
+}
+
+// MustWriteFile is similar to Reporter.WriteFile
+// MustWriteFile 类似于 Reporter.WriteFile
+func (r *Reporter) MustWriteFile(file string) *Reporter {
+	r.page.e(r.WriteFile(file))
+	return r
+}
+
+// MustPickElement is similar to Page.PickElement
+// MustPickElement 类似于 Page.PickElement
+func (p *Page) MustPickElement() *PickedElement {
+	el, err := p.PickElement()
+	p.e(err)
+	return el
+}
@@ -71,6 +71,7 @@ type Page struct {
 	Mouse    *Mouse
 	Keyboard *Keyboard
 	Touch    *Touch
+	Pen      *Pen
 
 	element *Element // iframe only
 
@@ -78,6 +79,9 @@ type Page struct {
 	jsCtxID     *proto.RuntimeRemoteObjectID // use pointer so that page clones can share the change  // 使用指针，以便于页面克隆时可以共享更改
 	helpersLock *sync.Mutex
 	helpers     map[proto.RuntimeRemoteObjectID]map[string]proto.RuntimeRemoteObjectID
+	world       *isolatedWorld // isolated only // 仅用于隔离环境
+
+	inputRecording *inputRecording
 }
 
 // String interface
@@ -179,11 +183,16 @@ func (p *Page) SetUserAgent(req *proto.NetworkSetUserAgentOverride) error {
 // 导航至 url 地址，如果 url 是空的，则默认使用 "about:blank"
 // It will return immediately after the server responds the http header.
 // 在接收到服务器HTTP响应头后，立即返回。
-func (p *Page) Navigate(url string) error {
+func (p *Page) Navigate(url string) (err error) {
 	if url == "" {
 		url = "about:blank"
 	}
 
+	p, end := p.startSpan("Navigate", func() map[string]interface{} {
+		return map[string]interface{}{"url": url}
+	})
+	defer func() { end(err) }()
+
 	// try to stop loading
 	// 尝试停止加载页面
 	_ = p.StopLoading()
@@ -251,7 +260,7 @@ func (p *Page) Activate() (*Page, error) {
 }
 
 func (p *Page) getWindowID() (proto.BrowserWindowID, error) {
-	res, err := proto.BrowserGetWindowForTarget{TargetID: p.TargetID}.Call(p)
+	res, err := proto.BrowserGetWindowForTarget{TargetID: p.TargetID}.Call(rodCall(p))
 	if err != nil {
 		return 0, err
 	}
@@ -266,7 +275,7 @@ func (p *Page) GetWindow() (*proto.BrowserBounds, error) {
 		return nil, err
 	}
 
-	res, err := proto.BrowserGetWindowBounds{WindowID: id}.Call(p)
+	res, err := proto.BrowserGetWindowBounds{WindowID: id}.Call(rodCall(p))
 	if err != nil {
 		return nil, err
 	}
@@ -282,7 +291,7 @@ func (p *Page) SetWindow(bounds *proto.BrowserBounds) error {
 		return err
 	}
 
-	err = proto.BrowserSetWindowBounds{WindowID: id, Bounds: bounds}.Call(p)
+	err = proto.BrowserSetWindowBounds{WindowID: id, Bounds: bounds}.Call(rodCall(p))
 	return err
 }
 
@@ -335,9 +344,13 @@ func (p *Page) Close() error {
 	success := true
 	ctx, cancel := context.WithCancel(p.ctx)
 	defer cancel()
-	messages := p.browser.Context(ctx).Event()
+	b := p.browser.Context(ctx)
+	messages := b.messages(b.router.subscribeMethods(ctx, "", []string{
+		(proto.TargetTargetDestroyed{}).ProtoEvent(),
+		(proto.PageJavascriptDialogClosed{}).ProtoEvent(),
+	}, false))
 
-	err := proto.PageClose{}.Call(p)
+	err := proto.PageClose{}.Call(rodCall(p))
 	if err != nil {
 		return err
 	}
@@ -397,6 +410,54 @@ func (p *Page) HandleDialog() (
 		}
 }
 
+// HandleFileChooser intercepts the next file chooser opened by the page, so that no native dialog will be shown.
+// It's useful when the file input is hidden behind a custom button. The wait returns the file input element
+// that opens the chooser, and the handle sets the files to it, empty paths will cancel the chooser.
+// HandleFileChooser 拦截页面打开的下一个文件选择器，这样不会显示原生的对话框。当文件输入元素隐藏在自定义按钮后面时它很有用。
+// wait 返回打开选择器的文件输入元素，handle 将文件设置给它，paths 为空将取消选择器。
+// For example:
+//
+//     wait, handle := page.MustHandleFileChooser()
+//     page.MustElement("#upload-button").MustClick()
+//     wait()
+//     handle("a.txt", "b.txt")
+//
+func (p *Page) HandleFileChooser() (
+	wait func() (*Element, error),
+	handle func(paths []string) error,
+) {
+	restore := p.EnableDomain(&proto.PageEnable{})
+
+	var e proto.PageFileChooserOpened
+	w := p.WaitEvent(&e)
+
+	err := proto.PageSetInterceptFileChooserDialog{Enabled: true}.Call(rodCall(p))
+
+	var input *Element
+
+	return func() (*Element, error) {
+			if err != nil {
+				return nil, err
+			}
+
+			w()
+
+			input, err = p.ElementFromNode(&proto.DOMNode{BackendNodeID: e.BackendNodeID})
+			return input, err
+		}, func(paths []string) error {
+			defer restore()
+			defer func() { _ = proto.PageSetInterceptFileChooserDialog{Enabled: false}.Call(rodCall(p)) }()
+
+			if err != nil {
+				return err
+			}
+			if input == nil {
+				return &ErrFileChooserNotOpened{}
+			}
+			return input.SetFiles(paths)
+		}
+}
+
 // Screenshot captures the screenshot of current page.
 // 捕获当前页面的截图
 func (p *Page) Screenshot(fullpage bool, req *proto.PageCaptureScreenshot) ([]byte, error) {
@@ -453,11 +514,17 @@ func (p *Page) PDF(req *proto.PagePrintToPDF) (*StreamReader, error) {
 // 通过URL获取页面中的资源，例如 image,css,html等
 // Use the proto.PageGetResourceTree to list all the resources.
 // 使用 proto.PageGetResourceTree 会返回所有的资源
+// It fails if the page hasn't loaded the resource, use Page.ResourceFetcher to request it as the page does.
+// 如果页面没有加载过该资源，它会失败，使用 Page.ResourceFetcher 可以像页面一样请求它。
 func (p *Page) GetResource(url string) ([]byte, error) {
+	return p.getResource(p.FrameID, url)
+}
+
+func (p *Page) getResource(frameID proto.PageFrameID, url string) ([]byte, error) {
 	res, err := proto.PageGetResourceContent{
-		FrameID: p.FrameID,
+		FrameID: frameID,
 		URL:     url,
-	}.Call(p)
+	}.Call(rodCall(p))
 	if err != nil {
 		return nil, err
 	}
@@ -478,17 +545,30 @@ func (p *Page) GetResource(url string) ([]byte, error) {
 // WaitOpen waits for the next new page opened by the current one
 // 等待打开从当前页面打开的新页面
 func (p *Page) WaitOpen() func() (*Page, error) {
+	wait := p.WaitOpenContext()
+	return func() (*Page, error) {
+		return wait(context.Background())
+	}
+}
+
+// WaitOpenContext is similar to Page.WaitOpen, but the wait function accepts a ctx,
+// the errors of it are the same as Page.EachEventContext
+// WaitOpenContext 类似于 Page.WaitOpen，但是等待函数接受一个 ctx，它的错误与 Page.EachEventContext 相同
+func (p *Page) WaitOpenContext() func(ctx context.Context) (*Page, error) {
 	var targetID proto.TargetTargetID
 
 	b := p.browser.Context(p.ctx)
-	wait := b.EachEvent(func(e *proto.TargetTargetCreated) bool {
+	wait := b.EachEventContext(func(e *proto.TargetTargetCreated) bool {
 		targetID = e.TargetInfo.TargetID
 		return e.TargetInfo.OpenerID == p.TargetID
 	})
 
-	return func() (*Page, error) {
+	return func(ctx context.Context) (*Page, error) {
 		defer p.tryTrace(TraceTypeWait, "wait open")()
-		wait()
+		err := wait(ctx)
+		if err != nil {
+			return nil, err
+		}
 		return b.PageFromTarget(targetID)
 	}
 }
@@ -518,6 +598,15 @@ func (p *Page) EachEvent(callbacks ...interface{}) (wait func()) {
 	return p.browser.Context(p.ctx).eachEvent(p.SessionID, callbacks...)
 }
 
+// EachEventContext is similar to Page.EachEvent, but the wait function accepts a ctx. It returns the error of
+// the ctx when the ctx is done, returns ErrWaitAborted when the events stop before any callback returns true,
+// such as the page is closed. So the waiting goroutines won't leak when the page dies early.
+// EachEventContext 类似于 Page.EachEvent，但是等待函数接受一个 ctx。当 ctx 结束时它返回 ctx 的错误，
+// 当事件在任何回调返回 true 之前停止时返回 ErrWaitAborted，例如页面被关闭。这样当页面过早死亡时，等待的 goroutine 不会泄漏。
+func (p *Page) EachEventContext(callbacks ...interface{}) (wait func(ctx context.Context) error) {
+	return p.browser.Context(p.ctx).eachEventContext(p.SessionID, callbacks...)
+}
+
 // WaitEvent waits for the next event for one time. It will also load the data into the event object.
 // 等待下一个发生的事件一次。它还会将数据加载到事件对象中。
 func (p *Page) WaitEvent(e proto.Event) (wait func()) {
@@ -525,21 +614,37 @@ func (p *Page) WaitEvent(e proto.Event) (wait func()) {
 	return p.browser.Context(p.ctx).waitEvent(p.SessionID, e)
 }
 
+// WaitEventContext is similar to Page.WaitEvent, the errors of the wait function are the same as
+// Page.EachEventContext
+// WaitEventContext 类似于 Page.WaitEvent，等待函数的错误与 Page.EachEventContext 相同
+func (p *Page) WaitEventContext(e proto.Event) (wait func(ctx context.Context) error) {
+	defer p.tryTrace(TraceTypeWait, "event", e.ProtoEvent())()
+	return p.browser.Context(p.ctx).waitEventContext(p.SessionID, e)
+}
+
 // WaitNavigation wait for a page lifecycle event when navigating.
 // WaitNavigation 在导航时等待一个页面生命周期事件。
 // Usually you will wait for proto.PageLifecycleEventNameNetworkAlmostIdle
 // 通常等待的是：proto.PageLifecycleEventNameNetworkAlmostIdle
 func (p *Page) WaitNavigation(name proto.PageLifecycleEventName) func() {
-	_ = proto.PageSetLifecycleEventsEnabled{Enabled: true}.Call(p)
+	wait := p.WaitNavigationContext(name)
+	return func() { _ = wait(context.Background()) }
+}
 
-	wait := p.EachEvent(func(e *proto.PageLifecycleEvent) bool {
+// WaitNavigationContext is similar to Page.WaitNavigation, the errors of the wait function are the same as
+// Page.EachEventContext
+// WaitNavigationContext 类似于 Page.WaitNavigation，等待函数的错误与 Page.EachEventContext 相同
+func (p *Page) WaitNavigationContext(name proto.PageLifecycleEventName) func(ctx context.Context) error {
+	_ = proto.PageSetLifecycleEventsEnabled{Enabled: true}.Call(rodCall(p))
+
+	wait := p.EachEventContext(func(e *proto.PageLifecycleEvent) bool {
 		return e.Name == name
 	})
 
-	return func() {
+	return func(ctx context.Context) error {
 		defer p.tryTrace(TraceTypeWait, "navigation", name)()
-		wait()
-		_ = proto.PageSetLifecycleEventsEnabled{Enabled: false}.Call(p)
+		defer func() { _ = proto.PageSetLifecycleEventsEnabled{Enabled: false}.Call(rodCall(p)) }()
+		return wait(ctx)
 	}
 }
 
@@ -552,6 +657,14 @@ func (p *Page) WaitNavigation(name proto.PageLifecycleEventName) func() {
 // Use the includes and excludes regexp list to filter the requests by their url.
 // 使用includes和excludes regexp列表按请求的url筛选请求
 func (p *Page) WaitRequestIdle(d time.Duration, includes, excludes []string) func() {
+	wait := p.WaitRequestIdleContext(d, includes, excludes)
+	return func() { _ = wait(context.Background()) }
+}
+
+// WaitRequestIdleContext is similar to Page.WaitRequestIdle, the errors of the wait function are the same as
+// Page.EachEventContext
+// WaitRequestIdleContext 类似于 Page.WaitRequestIdle，等待函数的错误与 Page.EachEventContext 相同
+func (p *Page) WaitRequestIdleContext(d time.Duration, includes, excludes []string) func(ctx context.Context) error {
 	if len(includes) == 0 {
 		includes = []string{""}
 	}
@@ -571,7 +684,7 @@ func (p *Page) WaitRequestIdle(d time.Duration, includes, excludes []string) fun
 		}
 	}
 
-	wait := p.EachEvent(func(sent *proto.NetworkRequestWillBeSent) {
+	wait := p.EachEventContext(func(sent *proto.NetworkRequestWillBeSent) {
 		if match(sent.Request.URL) {
 			// Redirect will send multiple NetworkRequestWillBeSent events with the same RequestID,
 			// we should filter them out.
@@ -588,12 +701,25 @@ func (p *Page) WaitRequestIdle(d time.Duration, includes, excludes []string) fun
 		checkDone(e.RequestID)
 	})
 
-	return func() {
+	return func(ctx context.Context) error {
+		defer cancel()
+
+		idle := make(chan struct{})
 		go func() {
 			idleCounter.Wait(p.ctx)
+			if p.ctx.Err() == nil {
+				close(idle)
+			}
 			cancel()
 		}()
-		wait()
+
+		err := wait(ctx)
+		select {
+		case <-idle:
+			return nil
+		default:
+			return err
+		}
 	}
 }
 
@@ -604,6 +730,27 @@ func (p *Page) WaitIdle(timeout time.Duration) (err error) {
 	return err
 }
 
+// WaitMainThreadIdle waits until the page's main thread has calmed down, it's useful for pages with heavy client-side rendering
+// that WaitRequestIdle can't catch.
+// WaitMainThreadIdle 等待页面的主线程平静下来，对于 WaitRequestIdle 无法捕获的大量客户端渲染的页面很有用。
+// The main thread is treated as idle when two window.requestIdleCallback fire in a row without any long task
+// (PerformanceObserver longtask) between them.
+// 当连续两次 window.requestIdleCallback 被调用，并且它们之间没有任何长任务（PerformanceObserver longtask）时，主线程被视为空闲。
+// If it's still busy when the budget runs out ErrMainThreadBusy will be returned.
+// 如果 budget 耗尽时主线程仍然繁忙，将返回 ErrMainThreadBusy。
+func (p *Page) WaitMainThreadIdle(budget time.Duration) error {
+	defer p.tryTrace(TraceTypeWait, "main thread idle")()
+
+	res, err := p.Evaluate(evalHelper(js.WaitMainThreadIdle, budget.Milliseconds()).ByPromise())
+	if err != nil {
+		return err
+	}
+	if !res.Value.Bool() {
+		return &ErrMainThreadBusy{budget}
+	}
+	return nil
+}
+
 // WaitRepaint waits until the next repaint.
 // WaitRepaint会等待下一次重绘。
 // Doc: https://developer.mozilla.org/en-US/docs/Web/API/window/requestAnimationFrame
@@ -624,9 +771,54 @@ func (p *Page) WaitLoad() error {
 // AddScriptTag to page. If url is empty, content will be used.
 // 向页面添加 Script 标签。如果url是空的,content参数将会被使用
 func (p *Page) AddScriptTag(url, content string) error {
-	hash := md5.Sum([]byte(url + content))
+	return p.AddScript(&ScriptTagOptions{URL: url, Content: content})
+}
+
+// ScriptTagOptions for Page.AddScript
+// Page.AddScript 的选项
+type ScriptTagOptions struct {
+	// URL of the script, if it's empty the Content will be used
+	// 脚本的 url，如果为空将使用 Content
+	URL string
+
+	// Content of the inline script
+	// 内联脚本的内容
+	Content string
+
+	// Module sets the type of the script to "module"
+	// Module 将脚本的类型设置为 "module"
+	Module bool
+
+	// Nonce to pass the Content-Security-Policy of the page
+	// 用于通过页面 Content-Security-Policy 的 nonce
+	Nonce string
+
+	// Integrity is the subresource integrity of the URL, such as "sha384-xxx"
+	// Integrity 是 URL 的子资源完整性，例如 "sha384-xxx"
+	Integrity string
+
+	// CrossOrigin attribute of the script, such as "anonymous"
+	// 脚本的 crossorigin 属性，例如 "anonymous"
+	CrossOrigin string
+}
+
+// AddScript is similar to Page.AddScriptTag, but with more options. If the URL is set, it waits until the script
+// is loaded. An inline module won't be waited, because the browser doesn't tell when it's executed.
+// AddScript 类似于 Page.AddScriptTag，但是有更多的选项。如果设置了 URL，它会等待脚本加载完成。
+// 内联的 module 不会被等待，因为浏览器不会告知它何时被执行。
+func (p *Page) AddScript(opts *ScriptTagOptions) error {
+	attrs := map[string]string{
+		"nonce":       opts.Nonce,
+		"integrity":   opts.Integrity,
+		"crossorigin": opts.CrossOrigin,
+	}
+	if opts.Module {
+		attrs["type"] = "module"
+	}
+
+	hash := md5.Sum([]byte(opts.URL + opts.Content + utils.MustToJSON(attrs)))
 	id := hex.EncodeToString(hash[:])
-	_, err := p.Evaluate(evalHelper(js.AddScriptTag, id, url, content).ByPromise())
+	_, err := p.Evaluate(evalHelper(js.AddScriptTag, id, opts.URL, opts.Content, attrs).ByPromise())
 	return err
 }
 
@@ -641,19 +833,14 @@ func (p *Page) AddStyleTag(url, content string) error {
 
 // EvalOnNewDocument Evaluates given script in every frame upon creation (before loading frame's scripts).
 // 会在每一个新的 frame 创建时，执行给定的JS脚本
+// The script will be listed in Page.InitScripts .
+// 该脚本会被列在 Page.InitScripts 中。
 func (p *Page) EvalOnNewDocument(js string) (remove func() error, err error) {
-	res, err := proto.PageAddScriptToEvaluateOnNewDocument{Source: js}.Call(p)
+	s, err := p.addInitScript(proto.PageAddScriptToEvaluateOnNewDocument{Source: js})
 	if err != nil {
 		return
 	}
-
-	remove = func() error {
-		return proto.PageRemoveScriptToEvaluateOnNewDocument{
-			Identifier: res.Identifier,
-		}.Call(p)
-	}
-
-	return
+	return s.Remove, nil
 }
 
 // Wait until the js returns true
@@ -726,10 +913,10 @@ func (p *Page) ElementFromObject(obj *proto.RuntimeRemoteObject) (*Element, erro
 // ElementFromNode creates an Element from the node, NodeID or BackendNodeID must be specified.
 // ElementFromNode从节点创建一个元素，必须指定NodeID或BackendNodeID。
 func (p *Page) ElementFromNode(node *proto.DOMNode) (*Element, error) {
-	res, err := proto.DOMResolveNode{
+	res, err := p.resolveNode(proto.DOMResolveNode{
 		NodeID:        node.NodeID,
 		BackendNodeID: node.BackendNodeID,
-	}.Call(p)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -784,7 +971,19 @@ func (p *Page) Release(obj *proto.RuntimeRemoteObject) error {
 // Call implements the proto.Client
 // 实现了 `proto.Client`
 func (p *Page) Call(ctx context.Context, sessionID, methodName string, params interface{}) (res []byte, err error) {
-	return p.browser.Call(ctx, sessionID, methodName, params)
+	err = p.crashed(methodName)
+	if err != nil {
+		return
+	}
+
+	defer p.trackConcurrentUse(ctx, methodName)()
+
+	res, err = p.browser.Call(ctx, sessionID, methodName, params)
+	if err == nil {
+		p.recordInput(methodName, params)
+		p.recovered(methodName)
+	}
+	return
 }
 
 // Event of the page
@@ -817,24 +1016,15 @@ func (p *Page) Event() <-chan *Message {
 
 func (p *Page) initEvents() {
 	p.event = goob.New(p.ctx)
-	event := p.browser.Context(p.ctx).Event()
+	event := p.browser.Context(p.ctx).sessionEvent(p.SessionID)
 
 	go func() {
 		for msg := range event {
-			detached := proto.TargetDetachedFromTarget{}
-			destroyed := proto.TargetTargetDestroyed{}
-
-			if (msg.Load(&detached) && detached.SessionID == p.SessionID) ||
-				(msg.Load(destroyed) && destroyed.TargetID == p.TargetID) {
-				p.sessionCancel()
-				return
-			}
-
-			if msg.SessionID != p.SessionID {
-				continue
-			}
-
 			p.event.Publish(msg)
 		}
+
+		// the session is detached or the target is destroyed
+		// session 被分离或者目标被销毁
+		p.sessionCancel()
 	}()
 }
@@ -0,0 +1,105 @@
+package rod
+
+import (
+	"strings"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// PageError is an uncaught js error of the page, the positions are rewritten by the source maps registered via
+// Browser.SetSourceMap
+// PageError 是页面中未被捕获的 js 错误，其中的位置会被通过 Browser.SetSourceMap 注册的 source map 重写
+type PageError struct {
+	*proto.RuntimeExceptionDetails
+
+	// Message of the error, such as "Error: boom"
+	// Message 是错误的信息，例如 "Error: boom"
+	Message string
+
+	// Stack is the call frames from the innermost one, the frames of the async parents are appended
+	// Stack 是从最内层开始的调用帧，异步父级的调用帧会被追加到后面
+	Stack []*proto.RuntimeCallFrame
+}
+
+func (e *PageError) Error() string {
+	if e.Exception != nil && e.Exception.Description != "" {
+		return e.Exception.Description
+	}
+	return e.Message
+}
+
+// OnPageError calls fn for each uncaught js error of the page, call stop to unsubscribe
+// OnPageError 对页面中每个未被捕获的 js 错误调用 fn，调用 stop 取消订阅
+func (p *Page) OnPageError(fn func(*PageError)) (stop func()) {
+	p, cancel := p.WithCancel()
+	go p.EachEvent(func(e *proto.RuntimeExceptionThrown) {
+		fn(p.pageError(e.ExceptionDetails))
+	})()
+	return cancel
+}
+
+// ErrorCollector collects the uncaught js errors of a page, use Page.CollectErrors to create it
+// ErrorCollector 收集页面中未被捕获的 js 错误，使用 Page.CollectErrors 创建它
+type ErrorCollector struct {
+	collector
+}
+
+// CollectErrors starts to collect the uncaught js errors of the page
+// CollectErrors 开始收集页面中未被捕获的 js 错误
+func (p *Page) CollectErrors() *ErrorCollector {
+	c := &ErrorCollector{}
+	c.stop = p.OnPageError(func(e *PageError) { c.add(e) })
+	return c
+}
+
+// Errors returns the collected errors in order
+// Errors 按顺序返回已收集的错误
+func (c *ErrorCollector) Errors() []*PageError {
+	list := []*PageError{}
+	for _, e := range c.items() {
+		list = append(list, e.(*PageError))
+	}
+	return list
+}
+
+// TestingT is the subset of testing.TB that Page.FailOnPageError needs
+// TestingT 是 Page.FailOnPageError 需要的 testing.TB 的子集
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// FailOnPageError collects the uncaught js errors of the page until done is called, then each of them will be
+// reported via t.Errorf. Such as:
+//     defer page.FailOnPageError(t)()
+// FailOnPageError 收集页面中未被捕获的 js 错误直到 done 被调用，然后通过 t.Errorf 报告其中的每个错误。
+func (p *Page) FailOnPageError(t TestingT) (done func()) {
+	c := p.CollectErrors()
+	return func() {
+		t.Helper()
+		c.Stop()
+		for _, e := range c.Errors() {
+			t.Errorf("uncaught js error of %s: %s", p, e.Error())
+		}
+	}
+}
+
+func (p *Page) pageError(d *proto.RuntimeExceptionDetails) *PageError {
+	p.browser.mapException(d)
+
+	e := &PageError{RuntimeExceptionDetails: d, Message: d.Text, Stack: []*proto.RuntimeCallFrame{}}
+
+	if d.Exception != nil {
+		if d.Exception.Description != "" {
+			e.Message = strings.SplitN(d.Exception.Description, "\n", 2)[0]
+		} else if s, ok := d.Exception.Value.Val().(string); ok {
+			e.Message = s
+		}
+	}
+
+	for st := d.StackTrace; st != nil; st = st.Parent {
+		e.Stack = append(e.Stack, st.CallFrames...)
+	}
+
+	return e
+}
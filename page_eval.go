@@ -4,9 +4,11 @@
 package rod
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-rod/rod/lib/cdp"
@@ -52,6 +54,12 @@ type EvalOptions struct {
 	// Whether execution should be treated as initiated by user in the UI.
 	// 在用户界面中是否应执行应由用户发起。
 	UserGesture bool
+
+	// TimeLimit of the execution, zero means no limit. When it's exceeded or the context of the page is canceled,
+	// the js execution will be terminated via Runtime.terminateExecution .
+	// TimeLimit 是执行的时间限制，0 表示没有限制。当超出限制或者页面的 context 被取消时，
+	// js 的执行将通过 Runtime.terminateExecution 被终止。
+	TimeLimit time.Duration
 }
 
 // Eval creates a EvalOptions with ByValue set to true.
@@ -67,6 +75,24 @@ func Eval(js string, args ...interface{}) *EvalOptions {
 	}
 }
 
+// EvalHelper creates a EvalOptions that calls the fn with the args, such as:
+//     double := &js.Function{Name: "myDouble", Definition: "n => n * 2"}
+//     add := &js.Function{
+//         Name:         "myAdd",
+//         Definition:   "(a, b) => functions.myDouble(a) + b",
+//         Dependencies: []*js.Function{double},
+//     }
+//     page.Evaluate(rod.EvalHelper(add, 1, 2))
+// The fn and its Dependencies are defined only once for each js context, the following evals will reuse them without
+// sending the definitions to the browser again. Use "functions.name" in the Definition to call the dependencies.
+// The name must be unique and not conflict with the built-in helpers of lib/js, a prefix is recommended.
+// EvalHelper 创建一个使用 args 调用 fn 的 EvalOptions。fn 和它的 Dependencies 在每个 js ctx 中只会被定义一次，
+// 之后的 eval 会复用它们，不会再次将定义发送给浏览器。在 Definition 中使用 "functions.name" 来调用依赖。
+// name 必须是唯一的，并且不能和 lib/js 中内置的辅助函数冲突，建议使用前缀。
+func EvalHelper(fn *js.Function, args ...interface{}) *EvalOptions {
+	return evalHelper(fn, args...)
+}
+
 func evalHelper(fn *js.Function, args ...interface{}) *EvalOptions {
 	return &EvalOptions{
 		ByValue: true,
@@ -126,6 +152,13 @@ func (e *EvalOptions) ByPromise() *EvalOptions {
 	return e
 }
 
+// Timeout sets the TimeLimit
+// 设置 TimeLimit
+func (e *EvalOptions) Timeout(d time.Duration) *EvalOptions {
+	e.TimeLimit = d
+	return e
+}
+
 func (e *EvalOptions) formatToJSFunc() string {
 	js := strings.Trim(e.JS, "\t\n\v\f\r ;")
 	return fmt.Sprintf(`function() { return (%s).apply(this, arguments) }`, js)
@@ -137,9 +170,24 @@ func (p *Page) Eval(js string, args ...interface{}) (*proto.RuntimeRemoteObject,
 	return p.Evaluate(Eval(js, args...).ByPromise())
 }
 
+// EvalInto is similar to Page.Eval, but json-decodes the result into v
+// EvalInto 类似于 Page.Eval，但是会将结果通过 json 解码到 v 中
+func (p *Page) EvalInto(v interface{}, js string, args ...interface{}) error {
+	res, err := p.Eval(js, args...)
+	if err != nil {
+		return err
+	}
+	return res.Into(v)
+}
+
 // Evaluate js on the page.
 // 在页面中执行 JS
 func (p *Page) Evaluate(opts *EvalOptions) (res *proto.RuntimeRemoteObject, err error) {
+	p, end := p.startSpan("Eval", func() map[string]interface{} {
+		return map[string]interface{}{"js": opts.String()}
+	})
+	defer func() { end(err) }()
+
 	var backoff utils.Sleeper
 
 	// js context will be invalid if a frame is reloaded or not ready, then the isNilContextErr
@@ -166,6 +214,52 @@ func (p *Page) Evaluate(opts *EvalOptions) (res *proto.RuntimeRemoteObject, err
 	}
 }
 
+// EvalBatch evaluates the list concurrently, the requests are pipelined over the same connection, so it only costs
+// about one round trip. The results are in the same order as the list. If any of them fails, the error of the first
+// failed one in the list will be returned.
+// EvalBatch 并发地执行 list，请求会在同一个连接上流水线式发送，所以大约只需要一次往返的时间。结果的顺序与 list 相同。
+// 如果其中有执行失败的，将返回 list 中第一个失败项的错误。
+func (p *Page) EvalBatch(list ...*EvalOptions) ([]*proto.RuntimeRemoteObject, error) {
+	// prepare the shared js context and helpers once, so that the concurrent evals won't race to create them
+	// 预先准备好共享的 js ctx 和辅助函数，这样并发的 eval 就不会竞争创建它们
+	if len(list) > 0 {
+		_, err := p.getJSCtxID()
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, opts := range list {
+		for _, arg := range opts.JSArgs {
+			if fn, ok := arg.(*js.Function); ok {
+				_, err := p.ensureJSHelper(fn)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	results := make([]*proto.RuntimeRemoteObject, len(list))
+	errs := make([]error, len(list))
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(list))
+	for i, opts := range list {
+		go func(i int, opts *EvalOptions) {
+			defer wg.Done()
+			results[i], errs[i] = p.Evaluate(opts)
+		}(i, opts)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
 func (p *Page) evaluate(opts *EvalOptions) (*proto.RuntimeRemoteObject, error) {
 	args, err := p.formatArgs(opts)
 	if err != nil {
@@ -189,18 +283,59 @@ func (p *Page) evaluate(opts *EvalOptions) (*proto.RuntimeRemoteObject, error) {
 		req.ObjectID = opts.ThisObj.ObjectID
 	}
 
-	res, err := req.Call(p)
+	c := p
+	if opts.TimeLimit > 0 {
+		ctx, cancel := context.WithTimeout(p.ctx, opts.TimeLimit)
+		defer cancel()
+		c = p.Context(ctx)
+	}
+
+	res, err := req.Call(c)
 	if err != nil {
+		if c.ctx.Err() != nil {
+			p.terminateExecution()
+		}
 		return nil, err
 	}
 
 	if res.ExceptionDetails != nil {
+		p.browser.mapException(res.ExceptionDetails)
 		return nil, &ErrEval{res.ExceptionDetails}
 	}
 
 	return res.Result, nil
 }
 
+// terminate the js execution that is still running in the browser. If there's no running execution, the next
+// one will be terminated and the termination won't respond until then, such as when the eval is waiting for a
+// pending promise. So empty evals are sent until the termination responds, or it may land later and kill an
+// unrelated one.
+// 终止浏览器中仍在运行的 js 执行。如果当前没有正在运行的执行，下一个执行将被终止，并且在此之前终止不会响应，
+// 例如当 eval 正在等待一个 pending 的 promise 时。所以会一直发送空的 eval 直到终止响应，否则它可能会在之后到达并杀死一个无关的执行。
+func (p *Page) terminateExecution() {
+	c := p.Context(p.browser.ctx)
+
+	terminated := make(chan struct{})
+	go func() {
+		defer close(terminated)
+		_ = proto.RuntimeTerminateExecution{}.Call(rodCall(c))
+	}()
+
+	for {
+		_, err := proto.RuntimeEvaluate{Expression: "0"}.Call(c)
+
+		select {
+		case <-terminated:
+			return
+		default:
+		}
+
+		if err != nil && c.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
 // Expose fn to the page's window object with the name. The exposure survives reloads.
 // 将fn暴露给名为的页面窗口对象。exposure 在重新加载后仍然有效。
 // Call stop to unbind the fn.
@@ -208,7 +343,7 @@ func (p *Page) evaluate(opts *EvalOptions) (*proto.RuntimeRemoteObject, error) {
 func (p *Page) Expose(name string, fn func(gson.JSON) (interface{}, error)) (stop func() error, err error) {
 	bind := "_" + utils.RandString(8)
 
-	err = proto.RuntimeAddBinding{Name: bind}.Call(p)
+	err = proto.RuntimeAddBinding{Name: bind}.Call(rodCall(p))
 	if err != nil {
 		return
 	}
@@ -232,7 +367,7 @@ func (p *Page) Expose(name string, fn func(gson.JSON) (interface{}, error)) (sto
 		if err != nil {
 			return err
 		}
-		return proto.RuntimeRemoveBinding{Name: bind}.Call(p)
+		return proto.RuntimeRemoveBinding{Name: bind}.Call(rodCall(p))
 	}
 
 	go p.EachEvent(func(e *proto.RuntimeBindingCalled) {
@@ -247,6 +382,21 @@ func (p *Page) Expose(name string, fn func(gson.JSON) (interface{}, error)) (sto
 	return
 }
 
+// LoadHelpers defines the helpers and their dependencies in the current js context of the page ahead of time,
+// so that the first EvalHelper call won't pay for it. The helpers will be defined again automatically
+// when they're used in a new js context.
+// LoadHelpers 预先在页面当前的 js ctx 中定义这些辅助函数和它们的依赖，这样第一次调用 EvalHelper 时就不需要再定义。
+// 当它们在新的 js ctx 中被使用时，会被自动重新定义。
+func (p *Page) LoadHelpers(list ...*js.Function) error {
+	for _, fn := range list {
+		_, err := p.ensureJSHelper(fn)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (p *Page) formatArgs(opts *EvalOptions) ([]*proto.RuntimeCallArgument, error) {
 	formated := []*proto.RuntimeCallArgument{}
 	for _, arg := range opts.JSArgs {
@@ -354,8 +504,14 @@ func (p *Page) getJSCtxID() (proto.RuntimeRemoteObjectID, error) {
 		return *p.jsCtxID, nil
 	}
 
+	if p.world != nil {
+		return p.createIsolatedWorld()
+	}
+
 	if !p.IsIframe() {
-		obj, err := proto.RuntimeEvaluate{Expression: "window"}.Call(p)
+		// use globalThis so that it also works for the workers that don't have window
+		// 使用 globalThis 以便它也适用于没有 window 的 worker
+		obj, err := proto.RuntimeEvaluate{Expression: "globalThis"}.Call(p)
 		if err != nil {
 			return "", err
 		}
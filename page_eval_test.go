@@ -1,11 +1,13 @@
 package rod_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/cdp"
+	"github.com/go-rod/rod/lib/js"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
 	"github.com/ysmood/gson"
@@ -281,3 +283,123 @@ func TestEvalOptionsString(t *testing.T) {
 
 	g.Eq(rod.Eval(`() => this.parentElement`).This(el.Object).String(), "() => this.parentElement() button")
 }
+
+func TestPageEvalInto(t *testing.T) {
+	g := setup(t)
+
+	page := g.page.MustNavigate(g.srcFile("fixtures/click.html"))
+
+	var user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	page.MustEvalInto(&user, `(name) => ({ name, age: 10 })`, "jack")
+	g.Eq(user.Name, "jack")
+	g.Eq(user.Age, 10)
+
+	var tag string
+	page.MustElement("button").MustEvalInto(&tag, `() => this.tagName`)
+	g.Eq(tag, "BUTTON")
+
+	var n int
+	g.Has(page.EvalInto(&n, `() => "x"`).Error(), "failed to decode the string into *int")
+
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		page.MustEvalInto(&n, `() => 1`)
+	})
+	g.Panic(func() {
+		el := page.MustElement("button")
+		g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		el.MustEvalInto(&n, `() => 1`)
+	})
+}
+
+func TestPageEvalTimeout(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.blank()).MustWaitLoad()
+
+	_, err := page.Evaluate(rod.Eval(`() => { while (true) {} }`).Timeout(100 * time.Millisecond))
+	g.Is(err, context.DeadlineExceeded)
+	g.Eq(page.MustEval(`() => 1`).Int(), 1)
+
+	_, err = page.Evaluate(rod.Eval(`() => new Promise(() => {})`).ByPromise().Timeout(100 * time.Millisecond))
+	g.Is(err, context.DeadlineExceeded)
+	g.Eq(page.MustEval(`() => 2`).Int(), 2)
+
+	ctx, cancel := context.WithCancel(g.Context())
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+	}()
+	_, err = page.Context(ctx).Eval(`() => { while (true) {} }`)
+	g.Is(err, context.Canceled)
+	g.Eq(page.MustEval(`() => 3`).Int(), 3)
+}
+
+func TestPageEvalHelper(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.blank()).MustWaitLoad()
+
+	double := &js.Function{
+		Name: "testDouble",
+		// count how many times the definition is sent
+		Definition: "(() => { window.defined = (window.defined || 0) + 1; return n => n * 2 })()",
+	}
+	add := &js.Function{
+		Name:         "testAdd",
+		Definition:   "(a, b) => functions.testDouble(a) + b",
+		Dependencies: []*js.Function{double},
+	}
+
+	page.MustLoadHelpers(add)
+	g.Eq(page.MustEval(`() => window.defined`).Int(), 1)
+
+	g.Eq(page.MustEvaluate(rod.EvalHelper(add, 1, 2)).Value.Int(), 4)
+	g.Eq(page.MustEvaluate(rod.EvalHelper(add, 2, 3)).Value.Int(), 7)
+	g.Eq(page.MustEvaluate(rod.EvalHelper(double, 5)).Value.Int(), 10)
+	g.Eq(page.MustEval(`() => window.defined`).Int(), 1)
+
+	// a new js context
+	page.MustReload().MustWaitLoad()
+	g.Eq(page.MustEvaluate(rod.EvalHelper(add, 1, 2)).Value.Int(), 4)
+	g.Eq(page.MustEval(`() => window.defined`).Int(), 1)
+
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		page.MustLoadHelpers(&js.Function{Name: "testNew", Definition: "() => 1"})
+	})
+}
+
+func TestPageEvalBatch(t *testing.T) {
+	g := setup(t)
+
+	page := g.page.MustNavigate(g.srcFile("fixtures/click.html"))
+	btn := page.MustElement("button")
+
+	list := page.MustEvalBatch(
+		rod.Eval(`() => document.title`),
+		rod.Eval(`(a, b) => a + b`, 1, 2),
+		rod.Eval(`function() { return this.tagName }`).This(btn.Object),
+		rod.Eval(`() => new Promise(r => setTimeout(() => r('later'), 30))`).ByPromise(),
+		rod.EvalHelper(js.ElementR, "button", "/click/"),
+	)
+	g.Len(list, 5)
+	g.Eq(list[1].Value.Int(), 3)
+	g.Eq(list[2].Value.Str(), "BUTTON")
+	g.Eq(list[3].Value.Str(), "later")
+
+	g.Len(page.MustEvalBatch(), 0)
+
+	res, err := page.EvalBatch(rod.Eval(`() => 1`), rod.Eval(`() => { throw new Error('x') }`))
+	g.Is(err, &rod.ErrEval{})
+	g.Eq(res[0].Value.Int(), 1)
+	g.Nil(res[1])
+
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		page.MustEvalBatch(rod.Eval(`() => 1`))
+	})
+}
@@ -0,0 +1,272 @@
+package rod
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// PagePoolOptions for NewManagedPagePool
+// NewManagedPagePool 的选项
+type PagePoolOptions struct {
+	// Limit of the pages that can be used at the same time, 0 means no limit
+	// Limit 是同一时间可以使用的页面数量，0 表示没有限制
+	Limit int
+
+	// Create a new page for the pool
+	// Create 为池创建一个新页面
+	Create func() (*Page, error)
+
+	// IdleTTL closes the pages that have been idle in the pool longer than it, 0 means no limit
+	// IdleTTL 关闭在池中空闲时间超过它的页面，0 表示没有限制
+	IdleTTL time.Duration
+
+	// MaxUses closes a page after it has been put back to the pool MaxUses times, 0 means no limit
+	// MaxUses 在页面被放回池中 MaxUses 次之后关闭它，0 表示没有限制
+	MaxUses int
+
+	// HealthCheck runs when a page is put back to the pool, the page will be closed if it returns an error.
+	// Such as ResetPage.
+	// HealthCheck 在页面被放回池中时运行，如果它返回错误，页面会被关闭。例如 ResetPage。
+	HealthCheck func(*Page) error
+}
+
+// ManagedPagePool is similar to PagePool, but it recycles the pages by the PagePoolOptions, and replaces the
+// crashed or closed pages automatically, so the long-running programs won't accumulate the zombie tabs.
+// ManagedPagePool 类似于 PagePool，但是它会根据 PagePoolOptions 回收页面，并自动替换崩溃或者已关闭的页面，
+// 这样长时间运行的程序就不会积累僵尸标签页。
+type ManagedPagePool struct {
+	opts  PagePoolOptions
+	slots chan struct{} // nil if there's no limit
+	stop  chan struct{}
+
+	lock   sync.Mutex
+	idle   []*pooledPage
+	inUse  map[proto.TargetTargetID]*pooledPage
+	closed bool
+}
+
+type pooledPage struct {
+	page  *Page
+	uses  int
+	since time.Time
+}
+
+// NewManagedPagePool instance
+// NewManagedPagePool 实例
+func NewManagedPagePool(opts PagePoolOptions) *ManagedPagePool {
+	pp := &ManagedPagePool{
+		opts:  opts,
+		stop:  make(chan struct{}),
+		idle:  []*pooledPage{},
+		inUse: map[proto.TargetTargetID]*pooledPage{},
+	}
+	if opts.Limit > 0 {
+		pp.slots = make(chan struct{}, opts.Limit)
+	}
+
+	if opts.IdleTTL > 0 {
+		go pp.sweep()
+	}
+
+	return pp
+}
+
+// Get a page from the pool, it blocks until a page is available.
+// Use ManagedPagePool.Put to make it reusable later. It returns ErrPoolClosed after ManagedPagePool.Cleanup.
+// Get 从池中获取一个页面，它会阻塞直到有页面可用。使用 ManagedPagePool.Put 使它以后可以被重复使用。
+// 在 ManagedPagePool.Cleanup 之后它返回 ErrPoolClosed。
+func (pp *ManagedPagePool) Get() (*Page, error) {
+	err := pp.acquire()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		pp.lock.Lock()
+		if len(pp.idle) == 0 {
+			pp.lock.Unlock()
+			break
+		}
+		item := pp.idle[len(pp.idle)-1]
+		pp.idle = pp.idle[:len(pp.idle)-1]
+		pp.lock.Unlock()
+
+		if pp.expired(item) || !alive(item.page) {
+			_ = item.page.Close()
+			continue
+		}
+
+		pp.lock.Lock()
+		pp.inUse[item.page.TargetID] = item
+		pp.lock.Unlock()
+		return item.page, nil
+	}
+
+	p, err := pp.opts.Create()
+	if err != nil {
+		pp.release()
+		return nil, err
+	}
+
+	pp.lock.Lock()
+	pp.inUse[p.TargetID] = &pooledPage{page: p}
+	pp.lock.Unlock()
+	return p, nil
+}
+
+// Put a page back to the pool, the page will be closed if it has been used for the MaxUses times,
+// or it fails the HealthCheck. The page can be a clone of the one from Get, such as via Page.Timeout,
+// the one from Get will be reused.
+// Put 将页面放回池中，如果页面已经被使用了 MaxUses 次，或者没有通过 HealthCheck，页面会被关闭。
+// 页面可以是 Get 返回页面的一个克隆，例如通过 Page.Timeout 得到的，被重复使用的是 Get 返回的页面。
+func (pp *ManagedPagePool) Put(p *Page) {
+	pp.lock.Lock()
+	item, has := pp.inUse[p.TargetID]
+	delete(pp.inUse, p.TargetID)
+	closed := pp.closed
+	pp.lock.Unlock()
+
+	if !has {
+		return
+	}
+	defer pp.release()
+
+	// the clone may have a canceled context
+	// 克隆的页面可能有一个已经取消的 context
+	p = item.page
+
+	item.uses++
+	if closed || (pp.opts.MaxUses > 0 && item.uses >= pp.opts.MaxUses) {
+		_ = p.Close()
+		return
+	}
+
+	if pp.opts.HealthCheck != nil {
+		if err := pp.opts.HealthCheck(p); err != nil {
+			_ = p.Close()
+			return
+		}
+	}
+
+	item.since = time.Now()
+
+	pp.lock.Lock()
+	defer pp.lock.Unlock()
+	if pp.closed {
+		_ = p.Close()
+		return
+	}
+	pp.idle = append(pp.idle, item)
+}
+
+// Cleanup closes the idle pages and stops the pool, the pages in use will be closed when they are put back
+// Cleanup 关闭空闲的页面并停止池，正在使用的页面会在被放回时关闭
+func (pp *ManagedPagePool) Cleanup() {
+	pp.lock.Lock()
+	list := pp.idle
+	pp.idle = []*pooledPage{}
+	if !pp.closed {
+		pp.closed = true
+		close(pp.stop)
+	}
+	pp.lock.Unlock()
+
+	for _, item := range list {
+		_ = item.page.Close()
+	}
+}
+
+// take a slot, it fails if the pool is closed
+// 占用一个位置，如果池已经关闭则失败
+func (pp *ManagedPagePool) acquire() error {
+	if pp.slots != nil {
+		select {
+		case <-pp.stop:
+			return ErrPoolClosed
+		case pp.slots <- struct{}{}:
+		}
+	}
+
+	pp.lock.Lock()
+	closed := pp.closed
+	pp.lock.Unlock()
+
+	if closed {
+		pp.release()
+		return ErrPoolClosed
+	}
+	return nil
+}
+
+func (pp *ManagedPagePool) release() {
+	if pp.slots != nil {
+		<-pp.slots
+	}
+}
+
+func (pp *ManagedPagePool) expired(item *pooledPage) bool {
+	return pp.opts.IdleTTL > 0 && time.Since(item.since) > pp.opts.IdleTTL
+}
+
+// close the expired idle pages periodically
+// 定期关闭过期的空闲页面
+func (pp *ManagedPagePool) sweep() {
+	t := time.NewTicker(pp.opts.IdleTTL / 2)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-pp.stop:
+			return
+		case <-t.C:
+		}
+
+		pp.lock.Lock()
+		list := []*pooledPage{}
+		expired := []*pooledPage{}
+		for _, item := range pp.idle {
+			if pp.expired(item) {
+				expired = append(expired, item)
+			} else {
+				list = append(list, item)
+			}
+		}
+		pp.idle = list
+		pp.lock.Unlock()
+
+		for _, item := range expired {
+			_ = item.page.Close()
+		}
+	}
+}
+
+// check if the page is neither crashed nor closed
+// 检查页面是否既没有崩溃也没有被关闭
+func alive(p *Page) bool {
+	if _, crashed := p.browser.states.Load(crashKey{p.TargetID}); crashed {
+		return false
+	}
+	_, err := p.Info()
+	return err == nil
+}
+
+// ResetPage clears the storages of the current origin of the page, then navigates it to "about:blank".
+// It can be used as the PagePoolOptions.HealthCheck.
+// ResetPage 清除页面当前源的存储，然后将页面导航到 "about:blank"。它可以用作 PagePoolOptions.HealthCheck。
+func ResetPage(p *Page) error {
+	origin, err := p.origin()
+	if err != nil {
+		return err
+	}
+
+	if origin != "null" {
+		err = clearDataForOrigins(p, []string{origin}, NewClearData().All())
+		if err != nil {
+			return err
+		}
+	}
+
+	return p.Navigate("about:blank")
+}
@@ -0,0 +1,129 @@
+package rod_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestManagedPagePool(t *testing.T) {
+	g := setup(t)
+
+	created := 0
+	pool := rod.NewManagedPagePool(rod.PagePoolOptions{
+		Limit: 2,
+		Create: func() (*rod.Page, error) {
+			created++
+			return g.browser.Page(proto.TargetCreateTarget{})
+		},
+		MaxUses:     2,
+		HealthCheck: rod.ResetPage,
+	})
+	defer pool.Cleanup()
+
+	p, err := pool.Get()
+	g.E(err)
+	p.MustNavigate(g.blank())
+	p.MustEval(`() => localStorage.setItem('a', '1')`)
+	pool.Put(p)
+
+	p2, err := pool.Get()
+	g.E(err)
+	g.Eq(p2, p)
+	g.Eq(p2.MustInfo().URL, "about:blank")
+	pool.Put(p2)
+
+	// the page has been used twice, it should be recycled
+	p3, err := pool.Get()
+	g.E(err)
+	g.Neq(p3, p)
+	g.Eq(created, 2)
+
+	// the closed page should be replaced
+	p3.MustClose()
+	pool.Put(p3)
+	p4, err := pool.Get()
+	g.E(err)
+	g.Eq(created, 3)
+	pool.Put(p4)
+
+	// the clone of the page can be put back
+	p5, err := pool.Get()
+	g.E(err)
+	p6, err := pool.Get()
+	g.E(err)
+	pool.Put(p5.Timeout(time.Second))
+	pool.Put(p6.Context(g.Context()))
+	p7, err := pool.Get()
+	g.E(err)
+	g.Eq(p7.TargetID, p6.TargetID)
+	pool.Put(p7)
+}
+
+func TestManagedPagePoolIdleTTL(t *testing.T) {
+	g := setup(t)
+
+	pool := rod.NewManagedPagePool(rod.PagePoolOptions{
+		Limit:   1,
+		Create:  func() (*rod.Page, error) { return g.browser.Page(proto.TargetCreateTarget{}) },
+		IdleTTL: 100 * time.Millisecond,
+	})
+
+	p, err := pool.Get()
+	g.E(err)
+	pool.Put(p)
+
+	time.Sleep(300 * time.Millisecond)
+
+	p2, err := pool.Get()
+	g.E(err)
+	g.Neq(p2, p)
+	pool.Put(p2)
+
+	pool.Cleanup()
+	pool.Cleanup()
+}
+
+func TestManagedPagePoolErr(t *testing.T) {
+	g := setup(t)
+
+	pool := rod.NewManagedPagePool(rod.PagePoolOptions{
+		Limit:  1,
+		Create: func() (*rod.Page, error) { return nil, errors.New("err") },
+	})
+	defer pool.Cleanup()
+
+	_, err := pool.Get()
+	g.Err(err)
+
+	// the slot should be released
+	_, err = pool.Get()
+	g.Err(err)
+
+	pool.Put(g.page)
+
+	pool.Cleanup()
+	_, err = pool.Get()
+	g.Eq(err, rod.ErrPoolClosed)
+}
+
+func TestManagedPagePoolNoLimit(t *testing.T) {
+	g := setup(t)
+
+	pool := rod.NewManagedPagePool(rod.PagePoolOptions{
+		Create: func() (*rod.Page, error) { return g.browser.Page(proto.TargetCreateTarget{}) },
+	})
+	defer pool.Cleanup()
+
+	a, err := pool.Get()
+	g.E(err)
+	b, err := pool.Get()
+	g.E(err)
+	g.Neq(a, b)
+
+	pool.Put(a)
+	pool.Put(b)
+}
@@ -0,0 +1,138 @@
+package rod
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// the concurrency of Page.DownloadResources
+// Page.DownloadResources 的并发数
+const downloadResourcesConcurrency = 4
+
+// DownloadResources saves the resources that the page and its iframes have loaded to the dir concurrently,
+// the path of each file is the host and the path of its url, such as "dir/example.com/img/a.png".
+// If the filter is nil, the images, scripts and stylesheets will be saved. It returns the paths of the saved files.
+// DownloadResources 将页面及其 iframe 已加载的资源并发地保存到 dir 中，每个文件的路径是它的 url 的主机和路径，
+// 例如 "dir/example.com/img/a.png"。如果 filter 为 nil，将保存图片、脚本和样式表。它返回已保存文件的路径。
+func (p *Page) DownloadResources(dir string, filter func(*proto.PageFrameResource) bool) ([]string, error) {
+	if filter == nil {
+		filter = func(r *proto.PageFrameResource) bool {
+			switch r.Type {
+			case proto.NetworkResourceTypeImage, proto.NetworkResourceTypeScript, proto.NetworkResourceTypeStylesheet:
+				return true
+			}
+			return false
+		}
+	}
+
+	tree, err := proto.PageGetResourceTree{}.Call(rodCall(p))
+	if err != nil {
+		return nil, err
+	}
+
+	type task struct {
+		frameID proto.PageFrameID
+		url     string
+		path    string
+	}
+
+	all := []*task{}
+
+	var walk func(t *proto.PageFrameResourceTree)
+	walk = func(t *proto.PageFrameResourceTree) {
+		for _, r := range t.Resources {
+			if r.Failed || r.Canceled || !filter(r) {
+				continue
+			}
+			if p := resourcePath(dir, r.URL); p != "" {
+				all = append(all, &task{t.Frame.ID, r.URL, p})
+			}
+		}
+		for _, child := range t.ChildFrames {
+			walk(child)
+		}
+	}
+	walk(tree.FrameTree)
+
+	// a path like "a" that is also the dir of another path like "a/b" is saved as "a/index"
+	// 像 "a" 这样同时也是另一个路径（例如 "a/b"）的目录的路径，会被保存为 "a/index"
+	dirs := map[string]bool{}
+	for _, t := range all {
+		for d := filepath.Dir(t.path); len(d) > len(dir); d = filepath.Dir(d) {
+			dirs[d] = true
+		}
+	}
+
+	tasks := []*task{}
+	has := map[string]bool{}
+	for _, t := range all {
+		if dirs[t.path] {
+			t.path = filepath.Join(t.path, "index")
+		}
+		if has[t.path] {
+			continue
+		}
+		has[t.path] = true
+		tasks = append(tasks, t)
+	}
+
+	errs := make([]error, len(tasks))
+	sem := make(chan struct{}, downloadResourcesConcurrency)
+	wg := sync.WaitGroup{}
+
+	for i, t := range tasks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, t *task) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			data, err := p.getResource(t.frameID, t.url)
+			if err == nil {
+				err = os.MkdirAll(filepath.Dir(t.path), 0755)
+			}
+			if err == nil {
+				err = ioutil.WriteFile(t.path, data, 0644)
+			}
+			errs[i] = err
+		}(i, t)
+	}
+	wg.Wait()
+
+	list := []string{}
+	for i, t := range tasks {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		list = append(list, t.path)
+	}
+	return list, nil
+}
+
+// the path to save the resource of the url, it's empty if the url has no host, such as the data url
+// 保存 url 对应资源的路径，如果 url 没有主机则为空，例如 data url
+func resourcePath(dir, u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+
+	// path.Clean on a rooted path removes the ".." so the file can't escape the dir
+	// 对以 / 开头的路径使用 path.Clean 会去掉 ".."，这样文件不会逃逸出 dir
+	p := path.Clean("/" + parsed.Path)
+	if strings.HasSuffix(parsed.Path, "/") || p == "/" {
+		p = path.Join(p, "index")
+	}
+
+	host := strings.ReplaceAll(parsed.Host, ":", "_")
+	return filepath.Join(dir, host, filepath.FromSlash(p))
+}
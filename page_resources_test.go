@@ -0,0 +1,65 @@
+package rod_test
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+func TestPageDownloadResources(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/page", ".html", `<html>
+		<link rel="stylesheet" href="/css/a.css">
+		<script src="/js/a.js"></script>
+		<img src="/img/a.png">
+		<img src="/img">
+		<img src="data:image/png;base64,AA==">
+		<iframe src="/frame"></iframe>
+	</html>`)
+	s.Route("/frame", ".html", `<html><img src="/img/b.png?v=1"></html>`)
+	s.Route("/css/a.css", ".css", "body {}")
+	s.Route("/js/a.js", ".js", "1")
+	s.Route("/img/a.png", ".png", "a")
+	s.Route("/img/b.png", ".png", "b")
+	s.Route("/img", ".png", "img")
+
+	page := g.newPage(s.URL("/page")).MustWaitLoad()
+	page.MustElement("iframe").MustFrame().MustElement("img").MustWaitLoad()
+
+	dir := filepath.Join(os.TempDir(), "rod", "resources", g.RandStr(8))
+	host := strings.ReplaceAll(strings.TrimPrefix(s.URL(), "http://"), ":", "_")
+	file := func(p string) string { return filepath.Join(dir, host, filepath.FromSlash(p)) }
+
+	list := page.MustDownloadResources(dir, nil)
+	sort.Strings(list)
+	g.Eq(list, []string{
+		file("css/a.css"), file("img/a.png"), file("img/b.png"), file("img/index"), file("js/a.js"),
+	})
+
+	read := func(p string) string {
+		s, err := utils.ReadString(p)
+		g.E(err)
+		return s
+	}
+	g.Eq(read(file("css/a.css")), "body {}")
+	g.Eq(read(file("img/b.png")), "b")
+	g.Eq(read(file("img/index")), "img")
+
+	list = page.MustDownloadResources(dir, func(r *proto.PageFrameResource) bool {
+		return r.Type == proto.NetworkResourceTypeScript
+	})
+	g.Eq(list, []string{file("js/a.js")})
+
+	g.mc.stubErr(1, proto.PageGetResourceTree{})
+	g.Panic(func() { page.MustDownloadResources(dir, nil) })
+
+	g.mc.stubErr(1, proto.PageGetResourceContent{})
+	g.Panic(func() { page.MustDownloadResources(dir, nil) })
+}
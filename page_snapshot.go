@@ -0,0 +1,118 @@
+// This file serves for the Page.Snapshot and Page.Restore.
+// 这个文件是为 Page.Snapshot 和 Page.Restore 服务
+
+package rod
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-rod/rod/lib/js"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// PageSnapshot holds the state of a page that Page.Restore can return to.
+// PageSnapshot 保存了一个页面的状态，Page.Restore 可以用它回到该状态。
+// It's useful for exploration patterns like "try branch A, restore, try branch B"
+// without re-running the whole login or navigation prefix.
+// 它适用于 "尝试分支A，恢复，再尝试分支B" 这样的探索模式，而不需要重新执行整个登录或导航流程。
+type PageSnapshot struct {
+	URL    string `json:"url"`
+	Origin string `json:"origin"`
+
+	Cookies        []*proto.NetworkCookie `json:"cookies"`
+	LocalStorage   map[string]string      `json:"localStorage"`
+	SessionStorage map[string]string      `json:"sessionStorage"`
+
+	Scrolls []*PageSnapshotScroll `json:"scrolls"`
+	Fields  []*PageSnapshotField  `json:"fields"`
+}
+
+// PageSnapshotScroll is the scroll position of the window or a scrolled element
+// PageSnapshotScroll 是窗口或某个滚动过的元素的滚动位置
+type PageSnapshotScroll struct {
+	// Index of the element in document.querySelectorAll('*'), -1 means the window
+	// 元素在 document.querySelectorAll('*') 中的索引，-1 表示窗口
+	Index int     `json:"index"`
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+}
+
+// PageSnapshotField is the value of a form field
+// PageSnapshotField 是一个表单字段的值
+type PageSnapshotField struct {
+	// Index of the element in document.querySelectorAll('input, textarea, select')
+	// 元素在 document.querySelectorAll('input, textarea, select') 中的索引
+	Index    int    `json:"index"`
+	Value    string `json:"value"`
+	Checked  bool   `json:"checked"`
+	Selected []bool `json:"selected"`
+}
+
+// Snapshot captures the URL, cookies, storage, scroll positions and form values of the page.
+// Snapshot 捕获页面的URL、Cookies、存储、滚动位置以及表单的值。
+func (p *Page) Snapshot() (*PageSnapshot, error) {
+	res, err := p.Evaluate(evalHelper(js.SnapshotState))
+	if err != nil {
+		return nil, err
+	}
+
+	snap := &PageSnapshot{}
+	err = json.Unmarshal([]byte(res.Value.JSON("", "")), snap)
+	if err != nil {
+		return nil, err
+	}
+
+	snap.Cookies, err = p.Cookies([]string{snap.URL})
+	if err != nil {
+		return nil, err
+	}
+
+	return snap, nil
+}
+
+// Restore the page to the state of the snapshot. It will navigate the page to the snapshot's URL,
+// the cookies and storage will be restored before any script of the page runs.
+// Restore 将页面恢复到快照的状态。它会将页面导航至快照的URL，Cookies 和存储会在页面的任何脚本执行之前被恢复。
+func (p *Page) Restore(snap *PageSnapshot) error {
+	current, err := p.Cookies([]string{snap.URL})
+	if err != nil {
+		return err
+	}
+	for _, c := range current {
+		err = proto.NetworkDeleteCookies{Name: c.Name, Domain: c.Domain, Path: c.Path}.Call(p)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = p.SetCookies(proto.CookiesToParams(snap.Cookies))
+	if err != nil {
+		return err
+	}
+
+	remove, err := p.EvalOnNewDocument(fmt.Sprintf(`(%s)(%s, %s, %s)`,
+		js.RestoreStorage.Definition,
+		utils.MustToJSON(snap.Origin),
+		utils.MustToJSON(snap.LocalStorage),
+		utils.MustToJSON(snap.SessionStorage),
+	))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = remove() }()
+
+	err = p.Navigate(snap.URL)
+	if err != nil {
+		return err
+	}
+
+	err = p.WaitLoad()
+	if err != nil {
+		return err
+	}
+
+	_, err = p.Evaluate(evalHelper(js.RestoreState, snap.Scrolls, snap.Fields))
+	return err
+}
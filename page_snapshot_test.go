@@ -0,0 +1,64 @@
+package rod_test
+
+import (
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestPageSnapshot(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve().Route("/", ".html", `<html><body>
+		<input id="text"><input id="check" type="checkbox">
+		<select id="sel"><option>a</option><option>b</option></select>
+		<div id="box" style="height: 50px; overflow: auto"><div style="height: 500px"></div></div>
+	</body></html>`)
+
+	p := g.newPage(s.URL())
+	p.MustSetCookies(&proto.NetworkCookieParam{Name: "c", Value: "1", URL: s.URL()})
+	p.MustElement("#text").MustInput("A")
+	p.MustElement("#check").MustClick()
+	p.MustElement("#sel").MustSelect("b")
+	p.MustEval(`() => {
+		localStorage.setItem('k', '1')
+		sessionStorage.setItem('s', '2')
+		document.querySelector('#box').scrollTop = 20
+	}`)
+
+	snap := p.MustSnapshot()
+	g.Eq(snap.LocalStorage["k"], "1")
+	g.Eq(snap.SessionStorage["s"], "2")
+	g.Len(snap.Cookies, 1)
+
+	// branch A
+	p.MustElement("#text").MustSelectAllText().MustInput("B")
+	p.MustEval(`() => { localStorage.setItem('k', 'x'); localStorage.setItem('other', 'y') }`)
+	p.MustSetCookies(&proto.NetworkCookieParam{Name: "d", Value: "2", URL: s.URL()})
+	p.MustNavigate(g.blank())
+
+	p.MustRestore(snap)
+
+	g.Eq(p.MustElement("#text").MustProperty("value").Str(), "A")
+	g.True(p.MustElement("#check").MustProperty("checked").Bool())
+	g.Eq(p.MustElement("#sel").MustProperty("value").Str(), "b")
+	g.Eq(p.MustEval(`() => localStorage.getItem('k')`).Str(), "1")
+	g.True(p.MustEval(`() => localStorage.getItem('other') === null`).Bool())
+	g.Eq(p.MustEval(`() => document.querySelector('#box').scrollTop`).Int(), 20)
+	g.Len(p.MustCookies(), 1)
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.Snapshot())
+
+	g.mc.stubErr(1, proto.NetworkGetCookies{})
+	g.Err(p.Snapshot())
+
+	g.mc.stubErr(1, proto.NetworkGetCookies{})
+	g.Err(p.Restore(snap))
+
+	g.mc.stubErr(1, proto.PageAddScriptToEvaluateOnNewDocument{})
+	g.Err(p.Restore(snap))
+
+	g.mc.stubErr(1, proto.PageNavigate{})
+	g.Err(p.Restore(snap))
+}
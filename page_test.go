@@ -3,6 +3,7 @@ package rod_test
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"image/png"
 	"math"
 	"net/http"
@@ -356,6 +357,32 @@ func TestPageAddScriptTag(t *testing.T) {
 	g.Eq("yes", res.String())
 }
 
+func TestPageAddScript(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/lib.js", ".js", `export const x = 'module'`)
+	s.Mux.HandleFunc("/", func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Security-Policy", "script-src 'nonce-abc' 'self'")
+		rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = rw.Write([]byte(`<html></html>`))
+	})
+
+	p := g.newPage(s.URL()).MustWaitLoad()
+
+	p.MustAddScript(&rod.ScriptTagOptions{Content: `window.inline = 'ok'`, Nonce: "abc"})
+	g.Eq(p.MustEval(`() => window.inline`).Str(), "ok")
+
+	p.MustAddScript(&rod.ScriptTagOptions{
+		Content: `import { x } from '/lib.js'; window.mod = x`,
+		Module:  true,
+		Nonce:   "abc",
+	})
+	p.MustWait(`() => window.mod === 'module'`)
+
+	g.Err(p.AddScript(&rod.ScriptTagOptions{URL: s.URL("/lib.js"), Integrity: "sha256-invalid"}))
+}
+
 func TestPageAddStyleTag(t *testing.T) {
 	g := setup(t)
 
@@ -487,6 +514,22 @@ func TestPageWaitIdle(t *testing.T) {
 	g.True(p.MustHas("[a=ok]"))
 }
 
+func TestPageWaitMainThreadIdle(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/click.html"))
+	p.MustElement("button").MustClick()
+	p.MustWaitMainThreadIdle()
+
+	g.True(p.MustHas("[a=ok]"))
+
+	// keep the main thread busy with long tasks
+	p.MustEval(`() => { const f = () => { const t = Date.now(); while (Date.now() - t < 80); setTimeout(f) }; f() }`)
+	err := p.WaitMainThreadIdle(300 * time.Millisecond)
+	g.Is(err, &rod.ErrMainThreadBusy{})
+	g.Has(err.Error(), "main thread is still busy after 300ms")
+}
+
 func TestPageEventSession(t *testing.T) {
 	g := setup(t)
 
@@ -509,6 +552,29 @@ func TestPageWaitEvent(t *testing.T) {
 	wait()
 }
 
+func TestPageWaitEventContext(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank())
+
+	wait := p.WaitEventContext(&proto.PageFrameNavigated{})
+	p.MustNavigate(g.blank())
+	g.E(wait(g.Context()))
+
+	ctx, cancel := context.WithTimeout(g.Context(), 100*time.Millisecond)
+	defer cancel()
+	wait = p.WaitEventContext(&proto.PageFrameNavigated{})
+	g.Eq(wait(ctx), context.DeadlineExceeded)
+
+	// the wait should be aborted when the page dies early
+	waitOpen := p.WaitOpenContext()
+	waitIdle := p.WaitNavigationContext(proto.PageLifecycleEventNameNetworkAlmostIdle)
+	p.MustClose()
+	_, err := waitOpen(g.Context())
+	g.Is(err, &rod.ErrWaitAborted{})
+	g.Is(waitIdle(g.Context()), &rod.ErrWaitAborted{})
+}
+
 func TestPageWaitEventParseEventOnlyOnce(t *testing.T) {
 	g := setup(t)
 
@@ -567,6 +633,33 @@ func TestAlert(t *testing.T) {
 	handle(true, "")
 }
 
+func TestPageHandleFileChooser(t *testing.T) {
+	g := setup(t)
+
+	page := g.page.MustNavigate(g.srcFile("fixtures/file-chooser.html"))
+
+	wait, handle := page.MustHandleFileChooser()
+	page.MustElement("button").MustClick()
+
+	el := wait()
+	g.Eq(el.MustProperty("id").Str(), "file")
+
+	handle(slash("fixtures/click.html"), slash("fixtures/alert.html"))
+
+	list := el.MustEval("() => Array.from(this.files).map(f => f.name)").Arr()
+	g.Len(list, 2)
+	g.Eq("alert.html", list[1].String())
+
+	_, h := page.HandleFileChooser()
+	g.Is(h(nil), &rod.ErrFileChooserNotOpened{})
+
+	g.mc.stubErr(1, proto.PageSetInterceptFileChooserDialog{})
+	w, h := page.HandleFileChooser()
+	_, err := w()
+	g.Err(err)
+	g.Err(h(nil))
+}
+
 func TestPageScreenshot(t *testing.T) {
 	g := setup(t)
 
@@ -643,6 +736,98 @@ func TestPageConsoleLog(t *testing.T) {
 	g.Eq(`1 map[b:[test]]`, p.MustObjectsToJSON(e.Args).Join(" "))
 }
 
+func TestPageOnConsole(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank()).MustWaitLoad()
+
+	c := p.CollectConsole()
+
+	got := make(chan *rod.ConsoleMessage, 10)
+	stop := p.OnConsole(func(m *rod.ConsoleMessage) { got <- m })
+
+	p.MustEval(`() => console.log("a", 1, {b: ['test']})`)
+	m := <-got
+	g.Eq(m.Level, proto.RuntimeConsoleAPICalledTypeLog)
+	g.Eq(m.Text, `a 1 {"b":["test"]}`)
+	g.Eq(m.Args[2].Get("b.0").Str(), "test")
+	g.Eq(m.String(), `[log] a 1 {"b":["test"]}`)
+	g.NotNil(m.Location)
+	g.NotNil(m.StackTrace)
+
+	p.MustEval(`() => console.error("err")`)
+	g.Eq((<-got).Level, proto.RuntimeConsoleAPICalledTypeError)
+	stop()
+
+	for len(c.Messages()) < 2 {
+		utils.Sleep(0.01)
+	}
+	list := c.Messages()
+	g.Len(list, 2)
+	g.Eq(list[1].Text, "err")
+
+	c.Clear()
+	g.Len(c.Messages(), 0)
+
+	c.Stop()
+	p.MustEval(`() => console.log("ignored")`)
+	utils.Sleep(0.1)
+	g.Len(c.Messages(), 0)
+}
+
+type fakeT struct {
+	errs []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errs = append(t.errs, fmt.Sprintf(format, args...))
+}
+
+func TestPageOnPageError(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank()).MustWaitLoad()
+
+	c := p.CollectErrors()
+
+	got := make(chan *rod.PageError, 10)
+	stop := p.OnPageError(func(e *rod.PageError) { got <- e })
+
+	p.MustEval(`() => setTimeout(function boom() { throw new Error("x") })`)
+	e := <-got
+	g.Eq(e.Message, "Error: x")
+	g.Has(e.Error(), "boom")
+	g.Gt(len(e.Stack), 0)
+	g.Eq(e.Stack[0].FunctionName, "boom")
+
+	p.MustEval(`() => setTimeout(() => { throw "str" })`)
+	g.Eq((<-got).Message, "str")
+	stop()
+
+	for len(c.Errors()) < 2 {
+		utils.Sleep(0.01)
+	}
+	g.Len(c.Errors(), 2)
+
+	c.Clear()
+	g.Len(c.Errors(), 0)
+	c.Stop()
+
+	ft := &fakeT{}
+	done := p.FailOnPageError(ft)
+	p.MustEval(`() => setTimeout(() => { throw new Error("y") })`)
+	utils.Sleep(0.3)
+	done()
+	g.Len(ft.errs, 1)
+	g.Has(ft.errs[0], "Error: y")
+
+	ft = &fakeT{}
+	p.FailOnPageError(ft)()
+	g.Len(ft.errs, 0)
+}
+
 func TestFonts(t *testing.T) {
 	g := setup(t)
 
@@ -0,0 +1,373 @@
+package rod
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-rod/rod/lib/cdp"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+type connectedURLKey struct{}
+
+type missingMethodKey struct {
+	method string
+}
+
+type unstableMethodKey struct {
+	method string
+}
+
+// ProtocolReport of Browser.CheckProtocol
+// Browser.CheckProtocol 的报告
+type ProtocolReport struct {
+	// Product of the browser, such as "HeadlessChrome/120.0.6099.71"
+	// Product 是浏览器的产品名，例如 "HeadlessChrome/120.0.6099.71"
+	Product string
+
+	// Milestone of the browser, such as 120
+	// Milestone 是浏览器的里程碑版本，例如 120
+	Milestone int
+
+	// ProtocolVersion of the browser
+	// ProtocolVersion 是浏览器的协议版本
+	ProtocolVersion string
+
+	// Missing are the commands and events of the lib/proto that the browser doesn't have
+	// Missing 是 lib/proto 中有而浏览器中没有的命令和事件
+	Missing []string
+}
+
+// CheckProtocol compares the lib/proto with the protocol of the connected browser, the schema of the browser is
+// fetched from the "/json/protocol" of the control url. It's useful to diagnose the errors like
+// "'Page.xxx' wasn't found" when the browser is older or newer than the lib/proto.
+// CheckProtocol 将 lib/proto 与所连接的浏览器的协议进行比较，浏览器的 schema 从控制 url 的 "/json/protocol" 获取。
+// 当浏览器比 lib/proto 更旧或者更新时，它可以用于诊断类似 "'Page.xxx' wasn't found" 的错误。
+func (b *Browser) CheckProtocol() (*ProtocolReport, error) {
+	report, err := b.protocolVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := b.Protocol()
+	if err != nil {
+		return nil, err
+	}
+	report.Missing = schema.Missing()
+
+	return report, nil
+}
+
+// ProtocolPin for Browser.PinProtocol
+// Browser.PinProtocol 的选项
+type ProtocolPin struct {
+	// MinMilestone of the browser, such as 110, 0 means no limit
+	// MinMilestone 是浏览器的最小里程碑版本，例如 110，0 表示没有限制
+	MinMilestone int
+
+	// MaxMilestone of the browser, 0 means no limit
+	// MaxMilestone 是浏览器的最大里程碑版本，0 表示没有限制
+	MaxMilestone int
+
+	// Methods the code depends on, such as "Page.navigate", they will be checked against the schema of the browser
+	// Methods 是代码所依赖的方法，例如 "Page.navigate"，它们会根据浏览器的 schema 进行检查
+	Methods []string
+
+	// Strict makes the Browser.Connect return ErrProtocolMismatch on the mismatches, otherwise they are only warned
+	// Strict 使得 Browser.Connect 在不匹配时返回 ErrProtocolMismatch，否则只会发出警告
+	Strict bool
+}
+
+// PinProtocol pins the code to the browsers that match the pin, Browser.Connect will check the connected browser.
+// After it's set, the calls to the methods that don't exist in the browser will be warned too.
+// PinProtocol 将代码固定到与 pin 匹配的浏览器上，Browser.Connect 会检查所连接的浏览器。
+// 设置它之后，对浏览器中不存在的方法的调用也会发出警告。
+func (b *Browser) PinProtocol(pin ProtocolPin) *Browser {
+	b.protocolPin = &pin
+	return b
+}
+
+func (b *Browser) checkProtocolPin() error {
+	pin := b.protocolPin
+	if pin == nil {
+		return nil
+	}
+
+	report, err := b.protocolVersion()
+	if err != nil {
+		return err
+	}
+
+	problems := []string{}
+	if pin.MinMilestone > 0 && report.Milestone < pin.MinMilestone {
+		problems = append(problems, fmt.Sprintf("milestone %d is lower than %d", report.Milestone, pin.MinMilestone))
+	}
+	if pin.MaxMilestone > 0 && report.Milestone > pin.MaxMilestone {
+		problems = append(problems, fmt.Sprintf("milestone %d is higher than %d", report.Milestone, pin.MaxMilestone))
+	}
+
+	if len(pin.Methods) > 0 {
+		schema, err := b.Protocol()
+		if err != nil {
+			return err
+		}
+		for _, m := range pin.Methods {
+			if !schema.Has(m) {
+				problems = append(problems, "missing method: "+m)
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	if pin.Strict {
+		return &ErrProtocolMismatch{Product: report.Product, Problems: problems}
+	}
+	b.logger.Warn("protocol mismatch", "product", report.Product, "problems", strings.Join(problems, "; "))
+	return nil
+}
+
+// close the connection opened by Browser.Connect, so the browser rejected by the pin won't stay connected
+// 关闭由 Browser.Connect 打开的连接，这样被 pin 拒绝的浏览器不会保持连接
+func (b *Browser) closeClient() {
+	switch c := b.client.(type) {
+	case *cdp.Client:
+		if ws, ok := c.WebSocket().(io.Closer); ok {
+			_ = ws.Close()
+		}
+	case *reconnectClient:
+		c.close()
+	}
+	b.client = nil
+	b.RemoveState(connectedURLKey{})
+}
+
+var regMilestone = regexp.MustCompile(`/(\d+)\.`)
+
+func (b *Browser) protocolVersion() (*ProtocolReport, error) {
+	v, err := proto.BrowserGetVersion{}.Call(b)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ProtocolReport{Product: v.Product, ProtocolVersion: v.ProtocolVersion}
+	if m := regMilestone.FindStringSubmatch(v.Product); m != nil {
+		report.Milestone, _ = strconv.Atoi(m[1])
+	}
+	return report, nil
+}
+
+// Protocol returns the schema of the protocol of the connected browser, it's fetched from the "/json/protocol"
+// of the control url. Use it with Browser.ValidateProtocol, or save it for the tools.
+// Protocol 返回所连接的浏览器的协议 schema，它从控制 url 的 "/json/protocol" 获取。
+// 将它与 Browser.ValidateProtocol 一起使用，或者保存下来供工具使用。
+func (b *Browser) Protocol() (*proto.Protocol, error) {
+	client, controlURL, err := b.devtoolsHTTP()
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(controlURL)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "ws":
+		u.Scheme = "http"
+	case "wss":
+		u.Scheme = "https"
+	}
+	u.Path = "/json/protocol"
+	u.RawQuery = ""
+
+	req, err := http.NewRequestWithContext(b.ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get the protocol schema: %s", res.Status)
+	}
+
+	var schema proto.Protocol
+	err = json.NewDecoder(res.Body).Decode(&schema)
+	return &schema, err
+}
+
+// the http client and the control url to fetch the http endpoints of the devtools, the client dials the browser
+// the same way as the websocket, such as via the cdp.WebSocketOptions.Proxy
+// 获取 devtools 的 http 接口所用的 http 客户端和控制 url，客户端以与 websocket 相同的方式连接浏览器，例如通过 cdp.WebSocketOptions.Proxy
+func (b *Browser) devtoolsHTTP() (*http.Client, string, error) {
+	if c, ok := b.client.(*cdp.Client); ok {
+		if ws, ok := c.WebSocket().(*cdp.WebSocket); ok && ws.URL() != "" {
+			return ws.HTTPClient(), ws.URL(), nil
+		}
+	}
+
+	if v, has := b.states.Load(connectedURLKey{}); has {
+		return http.DefaultClient, v.(string), nil
+	}
+	return nil, "", errors.New("the control url of the browser is unknown, use Browser.ControlURL to connect")
+}
+
+// warn once for each method that doesn't exist in the browser, only when the protocol is pinned
+// 当协议被固定时，对浏览器中不存在的每个方法只警告一次
+func (b *Browser) warnMissingMethod(method string, err error) {
+	if b.protocolPin == nil || !errors.Is(err, cdp.ErrMethodNotFound) {
+		return
+	}
+
+	if _, has := b.states.LoadOrStore(missingMethodKey{method}, true); !has {
+		b.logger.Warn("method doesn't exist in the connected browser", "method", method)
+	}
+}
+
+// MethodPolicy for Browser.GateMethods
+// Browser.GateMethods 的策略
+type MethodPolicy int
+
+const (
+	// MethodAllow calls the method as usual
+	// MethodAllow 像往常一样调用方法
+	MethodAllow MethodPolicy = iota
+
+	// MethodWarn warns once for each method via the logger of the browser, then calls it
+	// MethodWarn 通过浏览器的 logger 对每个方法警告一次，然后调用它
+	MethodWarn
+
+	// MethodError makes the call fail with ErrUnstableMethod
+	// MethodError 使调用以 ErrUnstableMethod 失败
+	MethodError
+)
+
+// GateMethods sets the policies for the calls of the experimental and the deprecated methods, the stability
+// of a method comes from proto.GetStability. Only the calls from the user's code are gated, such as
+// proto.PageGetManifestIcons{}.Call(page) and Page.RawCall, the calls made by rod itself, such as the
+// Target.createBrowserContext of Browser.Incognito, are not. Both are MethodAllow by default.
+// GateMethods 设置调用实验性方法和已弃用方法的策略，方法的稳定性来自 proto.GetStability。
+// 只有来自用户代码的调用会受到限制，例如 proto.PageGetManifestIcons{}.Call(page) 和 Page.RawCall，
+// rod 自身发出的调用不会，例如 Browser.Incognito 中的 Target.createBrowserContext。默认两者都是 MethodAllow。
+func (b *Browser) GateMethods(experimental, deprecated MethodPolicy) *Browser {
+	b.experimentalPolicy = experimental
+	b.deprecatedPolicy = deprecated
+	return b
+}
+
+// when a method is both experimental and deprecated, the stricter policy wins
+// 当方法既是实验性的又是已弃用的，更严格的策略生效
+func (b *Browser) gateMethod(ctx context.Context, method string) error {
+	if b.experimentalPolicy == MethodAllow && b.deprecatedPolicy == MethodAllow {
+		return nil
+	}
+
+	stability := proto.GetStability(method)
+	if stability == 0 || ctx.Value(rodCallKey{}) != nil {
+		return nil
+	}
+
+	policy := MethodAllow
+	if stability.Experimental() {
+		policy = b.experimentalPolicy
+	}
+	if stability.Deprecated() && b.deprecatedPolicy > policy {
+		policy = b.deprecatedPolicy
+	}
+
+	switch policy {
+	case MethodError:
+		return &ErrUnstableMethod{Method: method, Stability: stability}
+	case MethodWarn:
+		if _, has := b.states.LoadOrStore(unstableMethodKey{method}, true); !has {
+			b.logger.Warn("calling unstable method", "method", method, "stability", stability)
+		}
+	}
+	return nil
+}
+
+// the context value that marks the calls made by rod itself
+// 标记 rod 自身发出的调用的 context 值
+type rodCallKey struct{}
+
+// rodCall marks the calls via c as made by rod itself, so that they aren't gated by Browser.GateMethods.
+// The internal calls of the unstable methods must use it, such as proto.TargetCreateBrowserContext{}.Call(rodCall(b)).
+// rodCall 将通过 c 发出的调用标记为 rod 自身发出的，这样它们就不会被 Browser.GateMethods 限制。
+// 调用不稳定方法的内部调用必须使用它，例如 proto.TargetCreateBrowserContext{}.Call(rodCall(b))。
+func rodCall(c proto.Client) proto.Client {
+	return rodClient{c}
+}
+
+type rodClient struct {
+	proto.Client
+}
+
+// GetContext interface
+func (c rodClient) GetContext() context.Context {
+	ctx := context.Background()
+	if cta, ok := c.Client.(proto.Contextable); ok {
+		ctx = cta.GetContext()
+	}
+	return context.WithValue(ctx, rodCallKey{}, true)
+}
+
+// GetSessionID interface
+func (c rodClient) GetSessionID() proto.TargetSessionID {
+	if s, ok := c.Client.(proto.Sessionable); ok {
+		return s.GetSessionID()
+	}
+	return ""
+}
+
+// ValidateProtocol enables the validation mode, the params of the calls will be checked against the schema before
+// they are sent, the calls fail with ErrProtocolValidation if there are problems, such as the unknown fields or the
+// missing required fields. The fields of the results that the lib/proto doesn't have will be warned via the logger.
+// It's useful to catch the typos in the hand-built params during development, nil schema disables it.
+// Use Browser.Protocol to get the schema.
+// ValidateProtocol 启用验证模式，调用的参数在发送之前会根据 schema 进行检查，如果有问题，例如未知的字段或者缺少的必填字段，
+// 调用会以 ErrProtocolValidation 失败。结果中 lib/proto 没有的字段会通过 logger 发出警告。
+// 它可以用于在开发期间发现手动构建的参数中的拼写错误，schema 为 nil 则禁用它。使用 Browser.Protocol 获取 schema。
+func (b *Browser) ValidateProtocol(schema *proto.Protocol) *Browser {
+	b.validation = schema
+	return b
+}
+
+func (b *Browser) validateParams(method string, params interface{}) error {
+	if b.validation == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	if problems := b.validation.ValidateParams(method, data); len(problems) > 0 {
+		return &ErrProtocolValidation{Method: method, Problems: problems}
+	}
+	return nil
+}
+
+func (b *Browser) validateResult(method string, res []byte) {
+	if b.validation == nil {
+		return
+	}
+
+	if fields := proto.UnknownFields(method+"Result", res); len(fields) > 0 {
+		b.logger.Warn("unknown fields in the result", "method", method, "fields", strings.Join(fields, ", "))
+	}
+}
@@ -0,0 +1,124 @@
+package rod_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/cdp"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestCheckProtocol(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New()
+	g.Cleanup(l.Kill)
+	b := rod.New().ControlURL(l.MustLaunch()).MustConnect()
+	defer b.MustClose()
+
+	report, err := b.CheckProtocol()
+	g.E(err)
+	g.Has(report.Product, "Chrome")
+	g.Gt(report.Milestone, 0)
+	g.NotZero(report.ProtocolVersion)
+
+	_, err = g.browser.CheckProtocol()
+	g.Err(err)
+}
+
+func TestPinProtocol(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New()
+	g.Cleanup(l.Kill)
+	u := l.MustLaunch()
+
+	b := rod.New().ControlURL(u).PinProtocol(rod.ProtocolPin{
+		MinMilestone: 1,
+		Methods:      []string{"Page.navigate"},
+		Strict:       true,
+	}).MustConnect()
+	g.E(b.Close())
+
+	// the schema is fetched the same way as the websocket of the client
+	client := cdp.MustStartWithOptions(g.Context(), u, cdp.WebSocketOptions{Dialer: &net.Dialer{}})
+	schema, err := rod.New().Client(client).MustConnect().Protocol()
+	g.E(err)
+	g.True(schema.Has("Page.navigate"))
+
+	l = launcher.New()
+	g.Cleanup(l.Kill)
+	rejected := rod.New().ControlURL(l.MustLaunch()).PinProtocol(rod.ProtocolPin{
+		MaxMilestone: 1,
+		Methods:      []string{"Not.exists"},
+		Strict:       true,
+	})
+	err = rejected.Connect()
+	g.Is(err, &rod.ErrProtocolMismatch{})
+	g.Len(err.(*rod.ErrProtocolMismatch).Problems, 2)
+	g.Has(err.Error(), "missing method: Not.exists")
+
+	// the connection opened by Connect is closed
+	_, err = rejected.Protocol()
+	g.Err(err)
+}
+
+func TestGateMethods(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank())
+	defer g.browser.GateMethods(rod.MethodAllow, rod.MethodAllow)
+
+	g.browser.GateMethods(rod.MethodError, rod.MethodWarn)
+
+	_, err := proto.PageGetManifestIcons{}.Call(p)
+	g.Is(err, &rod.ErrUnstableMethod{})
+	g.Eq(err.Error(), "method Page.getManifestIcons is experimental")
+
+	_, err = proto.PageAddScriptToEvaluateOnLoad{ScriptSource: "1"}.Call(p)
+	g.Is(err, &rod.ErrUnstableMethod{})
+
+	g.Is(p.RawCall("Page.getManifestIcons", nil, nil), &rod.ErrUnstableMethod{})
+
+	// the internal calls of rod aren't gated, such as the Target.createBrowserContext
+	b := g.browser.MustIncognito()
+	b.MustPage(g.blank()).MustClose()
+	g.E(b.Close())
+	g.NotNil(p.MustElement("body").MustShape()) // the DOM.getContentQuads is experimental
+
+	g.browser.GateMethods(rod.MethodAllow, rod.MethodError)
+	_, err = proto.PageGetManifestIcons{}.Call(p)
+	g.E(err)
+	g.Is(proto.SecurityHandleCertificateError{}.Call(p), &rod.ErrUnstableMethod{})
+
+	g.browser.GateMethods(rod.MethodWarn, rod.MethodWarn)
+	_, err = proto.PageGetManifestIcons{}.Call(p)
+	g.E(err)
+}
+
+func TestValidateProtocol(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New()
+	g.Cleanup(l.Kill)
+	b := rod.New().ControlURL(l.MustLaunch()).MustConnect()
+	defer b.MustClose()
+
+	schema, err := b.Protocol()
+	g.E(err)
+	g.NotNil(schema.Command("Page.navigate"))
+
+	b.ValidateProtocol(schema)
+
+	p := b.MustPage(g.blank())
+	g.Eq(p.MustEval(`() => 1`).Int(), 1)
+
+	err = p.RawCall("Page.navigate", map[string]interface{}{"urll": g.blank()}, nil)
+	g.Is(err, &rod.ErrProtocolValidation{})
+	g.Has(err.Error(), "unknown field urll")
+
+	b.ValidateProtocol(nil)
+	g.Err(p.RawCall("Page.navigate", map[string]interface{}{"urll": g.blank()}, nil))
+}
@@ -4,8 +4,11 @@
 package rod
 
 import (
+	"context"
 	"errors"
 	"regexp"
+	"sort"
+	"time"
 
 	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/js"
@@ -54,6 +57,79 @@ func (els Elements) Empty() bool {
 	return len(els) == 0
 }
 
+// Filter returns the elements that make the fn return true, the remote objects of the others will be released,
+// so don't use them after the call
+// Filter 返回使 fn 返回 true 的元素，其他元素的远程对象会被释放，所以在调用之后不要再使用它们
+func (els Elements) Filter(fn func(*Element) bool) Elements {
+	list := Elements{}
+	for _, el := range els {
+		if fn(el) {
+			list = append(list, el)
+		} else {
+			_ = el.Release()
+		}
+	}
+	return list
+}
+
+// Map each element to another element, such as its parent. It stops at the first error.
+// Map 将每个元素映射为另一个元素，例如它的父元素。遇到第一个错误时停止。
+// If fn returns nil the element will be skipped.
+// 如果 fn 返回 nil，该元素将被跳过。
+func (els Elements) Map(fn func(*Element) (*Element, error)) (Elements, error) {
+	list := Elements{}
+	for _, el := range els {
+		e, err := fn(el)
+		if err != nil {
+			return nil, err
+		}
+		if e != nil {
+			list = append(list, e)
+		}
+	}
+	return list, nil
+}
+
+// Texts returns the text of each element
+// Texts 返回每个元素的文本
+func (els Elements) Texts() ([]string, error) {
+	list := []string{}
+	for _, el := range els {
+		s, err := el.Text()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, s)
+	}
+	return list, nil
+}
+
+// Attributes returns the attribute of each element, the item will be nil if the element doesn't have the attribute
+// Attributes 返回每个元素的属性，如果元素没有该属性，对应的项为 nil
+func (els Elements) Attributes(name string) ([]*string, error) {
+	list := []*string{}
+	for _, el := range els {
+		attr, err := el.Attribute(name)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, attr)
+	}
+	return list, nil
+}
+
+// Release all the elements, it returns the first error but it won't stop releasing the rest
+// Release 释放所有元素，它会返回第一个错误，但不会停止释放剩余的元素
+func (els Elements) Release() error {
+	var err error
+	for _, el := range els {
+		if e := el.Release(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
 // Pages provides some helpers to deal with page list
 // Pages 提供了一些帮助工具来处理页面列表
 type Pages []*Page
@@ -113,6 +189,63 @@ func (ps Pages) FindByURL(jsRegex string) (*Page, error) {
 	return nil, &ErrPageNotFound{}
 }
 
+// FindByTitle returns the page that has the title that matches the regex, the regex uses the syntax of Go regexp
+// 返回标题与 regex 匹配的页面，regex 使用 Go regexp 的语法
+func (ps Pages) FindByTitle(regex string) (*Page, error) {
+	r, err := regexp.Compile(regex)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, page := range ps {
+		res, err := page.Eval(`() => document.title`)
+		if err != nil {
+			return nil, err
+		}
+		title := res.Value.String()
+		if r.MatchString(title) {
+			return page, nil
+		}
+	}
+	return nil, &ErrPageNotFound{}
+}
+
+// Filter returns the pages that the fn returns true for
+// Filter 返回 fn 返回 true 的页面
+func (ps Pages) Filter(fn func(*Page) bool) Pages {
+	list := Pages{}
+	for _, page := range ps {
+		if fn(page) {
+			list = append(list, page)
+		}
+	}
+	return list
+}
+
+// Sort returns a sorted copy of the pages, the order of the equal pages is kept
+// Sort 返回页面列表排序后的副本，相等的页面保持原有顺序
+func (ps Pages) Sort(less func(a, b *Page) bool) Pages {
+	list := append(Pages{}, ps...)
+	sort.SliceStable(list, func(i, j int) bool {
+		return less(list[i], list[j])
+	})
+	return list
+}
+
+// GroupByWindow groups the pages by the browser windows they belong to
+// GroupByWindow 按页面所属的浏览器窗口对页面进行分组
+func (ps Pages) GroupByWindow() (map[proto.BrowserWindowID]Pages, error) {
+	groups := map[proto.BrowserWindowID]Pages{}
+	for _, page := range ps {
+		id, err := page.getWindowID()
+		if err != nil {
+			return nil, err
+		}
+		groups[id] = append(groups[id], page)
+	}
+	return groups, nil
+}
+
 // Has an element that matches the css selector
 // 在页面中用css selector查找某个元素是否存在
 func (p *Page) Has(selector string) (bool, *Element, error) {
@@ -152,11 +285,39 @@ func (p *Page) HasR(selector, jsRegex string) (bool, *Element, error) {
 	return true, el.Sleeper(p.sleeper), nil
 }
 
+// WaitHas waits until an element that matches the css selector appears in the page.
+// WaitHas 等待直到页面中出现和 CSS 选择器匹配的元素。
+// It follows the sleeper and timeout of the page, such as page.Timeout(time.Second).WaitHas("a").
+// 它遵循页面的 sleeper 和超时设置，例如 page.Timeout(time.Second).WaitHas("a")。
+func (p *Page) WaitHas(selector string) error {
+	defer p.tryTrace(TraceTypeWait, "has", selector)()
+	return p.Wait(Eval(`s => document.querySelector(s) !== null`, selector))
+}
+
+// WaitNotHas waits until no element in the page matches the css selector.
+// WaitNotHas 等待直到页面中没有和 CSS 选择器匹配的元素。
+func (p *Page) WaitNotHas(selector string) error {
+	defer p.tryTrace(TraceTypeWait, "not has", selector)()
+	return p.Wait(Eval(`s => document.querySelector(s) === null`, selector))
+}
+
 // Element retries until an element in the page that matches the CSS selector, then returns
 // the matched element.
 // Element 会重试，直到页面中的元素与CSS选择器匹配，然后返回匹配的元素。
-func (p *Page) Element(selector string) (*Element, error) {
-	return p.ElementByJS(evalHelper(js.Element, selector))
+func (p *Page) Element(selector string) (el *Element, err error) {
+	orig := p
+	p, end := p.startSpan("Element", func() map[string]interface{} {
+		return map[string]interface{}{"selector": selector}
+	})
+	defer func() { end(err) }()
+
+	return orig.cachedQuery("css:"+selector, func() (*Element, error) {
+		el, err := p.ElementByJS(evalHelper(js.Element, selector))
+		if err != nil {
+			return nil, err
+		}
+		return el.detachSpan(orig.ctx), nil
+	})
 }
 
 // ElementR retries until an element in the page that matches the css selector and it's text matches the jsRegex,
@@ -169,8 +330,20 @@ func (p *Page) ElementR(selector, jsRegex string) (*Element, error) {
 // ElementX retries until an element in the page that matches one of the XPath selectors, then returns
 // the matched element.
 // ElementX 会重试，直到页面中的元素与XPath选择器匹配，然后返回匹配的元素。
-func (p *Page) ElementX(xPath string) (*Element, error) {
-	return p.ElementByJS(evalHelper(js.ElementX, xPath))
+func (p *Page) ElementX(xPath string) (el *Element, err error) {
+	orig := p
+	p, end := p.startSpan("ElementX", func() map[string]interface{} {
+		return map[string]interface{}{"xpath": xPath}
+	})
+	defer func() { end(err) }()
+
+	return orig.cachedQuery("xpath:"+xPath, func() (*Element, error) {
+		el, err := p.ElementByJS(evalHelper(js.ElementX, xPath))
+		if err != nil {
+			return nil, err
+		}
+		return el.detachSpan(orig.ctx), nil
+	})
 }
 
 // ElementByJS returns the element from the return value of the js function.
@@ -211,7 +384,14 @@ func (p *Page) ElementByJS(opts *EvalOptions) (*Element, error) {
 		return nil, &ErrExpectElement{res}
 	}
 
-	return p.ElementFromObject(res)
+	el, err := p.ElementFromObject(res)
+	if err != nil {
+		return nil, err
+	}
+
+	p.tryHighlightQuery(el, opts)
+
+	return el, nil
 }
 
 // Elements returns all elements that match the css selector
@@ -226,6 +406,12 @@ func (p *Page) ElementsX(xpath string) (Elements, error) {
 	return p.ElementsByJS(evalHelper(js.ElementsX, xpath))
 }
 
+// ElementsR returns all elements that match the css selector and their text matches the jsRegex
+// ElementsR 返回所有符合css选择器并且其文本符合jsRegex的元素
+func (p *Page) ElementsR(selector, jsRegex string) (Elements, error) {
+	return p.ElementsByJS(evalHelper(js.ElementsR, selector, jsRegex))
+}
+
 // ElementsByJS returns the elements from the return value of the js
 // ElementsByJS 从 js 的返回值中返回元素。
 func (p *Page) ElementsByJS(opts *EvalOptions) (Elements, error) {
@@ -267,9 +453,132 @@ func (p *Page) ElementsByJS(opts *EvalOptions) (Elements, error) {
 		elemList = append(elemList, el)
 	}
 
+	for _, el := range elemList {
+		p.tryHighlightQuery(el, opts)
+	}
+
 	return elemList, err
 }
 
+// ElementsIter returns an iterator over all elements that match the css selector.
+// ElementsIter 返回一个迭代器，用于遍历和 CSS 选择器匹配的所有元素。
+// Unlike Page.Elements, each element is resolved only when the iterator reaches it and released when it moves on,
+// it's much faster for lists with thousands of elements.
+// 和 Page.Elements 不同，每个元素只有在迭代器到达时才会被解析，并在迭代器继续前进时被释放，对于有成千上万个元素的列表要快得多。
+func (p *Page) ElementsIter(selector string) (*ElementsIterator, error) {
+	return p.elementsIterByJS(evalHelper(js.Elements, selector))
+}
+
+func (p *Page) elementsIterByJS(opts *EvalOptions) (*ElementsIterator, error) {
+	list, err := p.Evaluate(opts.ByObject())
+	if err != nil {
+		return nil, err
+	}
+
+	if list.Subtype != proto.RuntimeRemoteObjectSubtypeArray {
+		return nil, &ErrExpectElements{list}
+	}
+
+	res, err := p.Evaluate(Eval(`() => this.length`).This(list))
+	if err != nil {
+		_ = p.Release(list)
+		return nil, err
+	}
+
+	return &ElementsIterator{
+		page:   p,
+		list:   list,
+		length: res.Value.Int(),
+	}, nil
+}
+
+// ElementsIterator resolves the elements of a remote list one by one.
+// ElementsIterator 会逐个解析远程列表中的元素。
+// The usage is similar to bufio.Scanner:
+// 它的用法类似于 bufio.Scanner：
+//
+//     it := page.MustElementsIter("tr")
+//     defer it.Release()
+//     for it.Next() {
+//         fmt.Println(it.Element().MustText())
+//     }
+//     if it.Err() != nil { ... }
+//
+type ElementsIterator struct {
+	page    *Page
+	list    *proto.RuntimeRemoteObject
+	length  int
+	index   int
+	current *Element
+	err     error
+}
+
+// Len returns the total count of the elements in the list
+// Len 返回列表中元素的总数
+func (it *ElementsIterator) Len() int {
+	return it.length
+}
+
+// Next resolves the next element, it returns false when the list is exhausted or an error occurs.
+// Next 解析下一个元素，当列表遍历完毕或发生错误时返回 false。
+// The element resolved by the previous call will be released, don't use it after calling Next.
+// 上一次调用解析的元素将被释放，在调用 Next 之后不要再使用它。
+func (it *ElementsIterator) Next() bool {
+	it.releaseCurrent()
+
+	if it.err != nil || it.index >= it.length {
+		return false
+	}
+
+	res, err := it.page.Evaluate(Eval(`i => this[i]`, it.index).This(it.list).ByObject())
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	if res.Subtype != proto.RuntimeRemoteObjectSubtypeNode {
+		it.err = &ErrExpectElement{res}
+		return false
+	}
+
+	el, err := it.page.ElementFromObject(res)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.index++
+	it.current = el
+	return true
+}
+
+// Element returns the element resolved by the last call of Next
+// Element 返回最后一次调用 Next 时解析的元素
+func (it *ElementsIterator) Element() *Element {
+	return it.current
+}
+
+// Err returns the first error that stopped the iteration
+// Err 返回导致迭代停止的第一个错误
+func (it *ElementsIterator) Err() error {
+	return it.err
+}
+
+// Release the current element and the remote list
+// 释放当前元素和远程列表
+func (it *ElementsIterator) Release() error {
+	it.releaseCurrent()
+	it.index = it.length
+	return it.page.Release(it.list)
+}
+
+func (it *ElementsIterator) releaseCurrent() {
+	if it.current != nil {
+		_ = it.current.Release()
+		it.current = nil
+	}
+}
+
 // Search for the given query in the DOM tree until the result count is not zero, before that it will keep retrying.
 // 在DOM树中搜索给定的查询，直到结果计数不为零，在此之前，它将不断重试。
 // The query can be plain text or css selector or xpath.
@@ -279,18 +588,19 @@ func (p *Page) ElementsByJS(opts *EvalOptions) (Elements, error) {
 func (p *Page) Search(query string) (*SearchResult, error) {
 	sr := &SearchResult{
 		page:    p,
+		query:   query,
 		restore: p.EnableDomain(proto.DOMEnable{}),
 	}
 
 	err := utils.Retry(p.ctx, p.sleeper(), func() (bool, error) {
 		if sr.DOMPerformSearchResult != nil {
-			_ = proto.DOMDiscardSearchResults{SearchID: sr.SearchID}.Call(p)
+			_ = proto.DOMDiscardSearchResults{SearchID: sr.SearchID}.Call(rodCall(p))
 		}
 
 		res, err := proto.DOMPerformSearch{
 			Query:                     query,
 			IncludeUserAgentShadowDOM: true,
-		}.Call(p)
+		}.Call(rodCall(p))
 		if err != nil {
 			return true, err
 		}
@@ -305,7 +615,7 @@ func (p *Page) Search(query string) (*SearchResult, error) {
 			SearchID:  res.SearchID,
 			FromIndex: 0,
 			ToIndex:   1,
-		}.Call(p)
+		}.Call(rodCall(p))
 		if err != nil {
 			// when the page is still loading the search result is not ready
 			// 当页面仍然在加载时，此时的搜索结果尚未准备好。
@@ -353,6 +663,7 @@ type SearchResult struct {
 	*proto.DOMPerformSearchResult
 
 	page    *Page
+	query   string
 	restore func()
 
 	// First element in the search result
@@ -367,7 +678,7 @@ func (s *SearchResult) Get(i, l int) (Elements, error) {
 		SearchID:  s.SearchID,
 		FromIndex: i,
 		ToIndex:   i + l,
-	}.Call(s.page)
+	}.Call(rodCall(s.page))
 	if err != nil {
 		return nil, err
 	}
@@ -391,22 +702,116 @@ func (s *SearchResult) All() (Elements, error) {
 	return s.Get(0, s.ResultCount)
 }
 
+// Each iterates all the elements in the search result, it fetches the result page by page,
+// so huge result won't be resolved at once. If fn returns stop as true or an error the iteration stops.
+// Each 遍历搜索结果中的所有元素，它会一页一页地获取结果，所以巨大的结果不会被一次性解析。如果 fn 返回的 stop 为 true 或者返回错误，遍历将停止。
+// When the document is updated during the iteration, the search will be performed again to re-resolve the stale NodeIDs.
+// 如果在遍历期间文档被更新，将重新执行搜索以重新解析失效的 NodeID。
+// The iteration continues from the same index of the new search result, so if the matched elements are added or removed
+// before the index, some elements may be skipped or visited twice. Use SearchResult.All to iterate on a snapshot instead.
+// 遍历会从新搜索结果的相同索引处继续，所以如果在该索引之前有匹配的元素被添加或删除，一些元素可能会被跳过或者被访问两次。
+// 如果需要遍历一个快照，请使用 SearchResult.All。
+// The search result will be released automatically after the iteration.
+// 遍历结束后，搜索结果会被自动释放。
+func (s *SearchResult) Each(fn func(*Element) (stop bool, err error)) error {
+	defer s.Release()
+
+	for i := 0; i < s.ResultCount; {
+		ids, err := s.nodeIDs(i, searchEachPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			el, err := s.page.ElementFromNode(&proto.DOMNode{NodeID: id})
+			if err != nil {
+				return err
+			}
+
+			stop, err := fn(el)
+			if stop || err != nil {
+				return err
+			}
+		}
+
+		i += len(ids)
+	}
+
+	return nil
+}
+
+// the count of nodes to fetch for each round trip of SearchResult.Each
+const searchEachPageSize = 50
+
+func (s *SearchResult) nodeIDs(i, l int) (ids []proto.DOMNodeID, err error) {
+	err = utils.Retry(s.page.ctx, s.page.sleeper(), func() (bool, error) {
+		to := i + l
+		if to > s.ResultCount {
+			to = s.ResultCount
+		}
+		if to <= i {
+			ids = nil
+			return true, nil
+		}
+
+		res, err := proto.DOMGetSearchResults{
+			SearchID:  s.SearchID,
+			FromIndex: i,
+			ToIndex:   to,
+		}.Call(rodCall(s.page))
+		if err != nil {
+			return true, err
+		}
+
+		for _, id := range res.NodeIds {
+			// Same as Page.Search, zero id means the proto.DOMDocumentUpdated has fired,
+			// all the existing NodeIDs are invalidated, we have to search again.
+			// 和 Page.Search 一样，id 为 0 表示 proto.DOMDocumentUpdated 已经触发，所有现有的 NodeID 都已失效，我们必须重新搜索。
+			if id == 0 {
+				_, _ = proto.DOMGetDocument{}.Call(s.page)
+				return false, s.research()
+			}
+		}
+
+		ids = res.NodeIds
+		return true, nil
+	})
+	return
+}
+
+func (s *SearchResult) research() error {
+	_ = proto.DOMDiscardSearchResults{SearchID: s.SearchID}.Call(rodCall(s.page))
+
+	res, err := proto.DOMPerformSearch{
+		Query:                     s.query,
+		IncludeUserAgentShadowDOM: true,
+	}.Call(rodCall(s.page))
+	if err != nil {
+		return err
+	}
+
+	s.DOMPerformSearchResult = res
+	return nil
+}
+
 // Release the remote search result
 // 释放搜索结果
 func (s *SearchResult) Release() {
 	s.restore()
-	_ = proto.DOMDiscardSearchResults{SearchID: s.SearchID}.Call(s.page)
+	_ = proto.DOMDiscardSearchResults{SearchID: s.SearchID}.Call(rodCall(s.page))
 }
 
 type raceBranch struct {
 	condition func(*Page) (*Element, error)
 	callback  func(*Element) error
+	timeout   time.Duration
 }
 
 // RaceContext stores the branches to race
 // 存储了 race 的分支
 type RaceContext struct {
 	page     *Page
+	element  *Element // the subtree to race within, nil means the whole page
 	branches []*raceBranch
 }
 
@@ -416,49 +821,116 @@ func (p *Page) Race() *RaceContext {
 	return &RaceContext{page: p}
 }
 
+// Race creates a context to race selectors within the subtree of the element
+// 创建一个 RaceContext，只在元素的子树中 race 选择器
+func (el *Element) Race() *RaceContext {
+	return &RaceContext{page: el.page.Context(el.ctx).Sleeper(el.sleeper), element: el}
+}
+
+func (rc *RaceContext) add(condition func(*Page) (*Element, error)) *RaceContext {
+	rc.branches = append(rc.branches, &raceBranch{condition: condition})
+	return rc
+}
+
 // Element the doc is similar to MustElement
 // 类似于 MustElement
 func (rc *RaceContext) Element(selector string) *RaceContext {
-	rc.branches = append(rc.branches, &raceBranch{
-		condition: func(p *Page) (*Element, error) { return p.Element(selector) },
+	return rc.add(func(p *Page) (*Element, error) {
+		if rc.element != nil {
+			return rc.element.Element(selector)
+		}
+		return p.Element(selector)
 	})
-	return rc
 }
 
 // ElementFunc takes a custom function to determine race success
 // ElementFunc 采用自定义函数确定 race 成功
 func (rc *RaceContext) ElementFunc(fn func(*Page) (*Element, error)) *RaceContext {
-	rc.branches = append(rc.branches, &raceBranch{
-		condition: fn,
-	})
-	return rc
+	return rc.add(fn)
 }
 
 // ElementX the doc is similar to ElementX
 // 类似于 ElementX
 func (rc *RaceContext) ElementX(selector string) *RaceContext {
-	rc.branches = append(rc.branches, &raceBranch{
-		condition: func(p *Page) (*Element, error) { return p.ElementX(selector) },
+	return rc.add(func(p *Page) (*Element, error) {
+		if rc.element != nil {
+			return rc.element.ElementX(selector)
+		}
+		return p.ElementX(selector)
 	})
-	return rc
 }
 
 // ElementR the doc is similar to ElementR
 //类似于 ElementR
 func (rc *RaceContext) ElementR(selector, regex string) *RaceContext {
-	rc.branches = append(rc.branches, &raceBranch{
-		condition: func(p *Page) (*Element, error) { return p.ElementR(selector, regex) },
+	return rc.add(func(p *Page) (*Element, error) {
+		if rc.element != nil {
+			return rc.element.ElementR(selector, regex)
+		}
+		return p.ElementR(selector, regex)
 	})
-	return rc
 }
 
 // ElementByJS the doc is similar to MustElementByJS
 // 类似于 MustELementByJS
 func (rc *RaceContext) ElementByJS(opts *EvalOptions) *RaceContext {
-	rc.branches = append(rc.branches, &raceBranch{
-		condition: func(p *Page) (*Element, error) { return p.ElementByJS(opts) },
+	return rc.add(func(p *Page) (*Element, error) {
+		if rc.element != nil {
+			return rc.element.ElementByJS(opts)
+		}
+		return p.ElementByJS(opts)
+	})
+}
+
+// ElementsMoreThan wins the race when there are more than num elements that match the css selector,
+// the first matched element will be passed to the callback.
+// ElementsMoreThan 当和CSS选择器匹配的元素数量超过 num 时赢得 race，第一个匹配的元素会被传递给回调函数。
+func (rc *RaceContext) ElementsMoreThan(selector string, num int) *RaceContext {
+	return rc.add(func(p *Page) (*Element, error) {
+		var res *proto.RuntimeRemoteObject
+		var err error
+		if rc.element != nil {
+			res, err = rc.element.Eval(`(s, n) => this.querySelectorAll(s).length > n`, selector, num)
+		} else {
+			res, err = p.Eval(`(s, n) => document.querySelectorAll(s).length > n`, selector, num)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !res.Value.Bool() {
+			return nil, &ErrElementNotFound{}
+		}
+
+		if rc.element != nil {
+			return rc.element.Element(selector)
+		}
+		return p.Element(selector)
+	})
+}
+
+// Not wins the race when no element matches the css selector.
+// Not 当没有元素和CSS选择器匹配时赢得 race。
+// Because there's no element, the callback and the Do will get a nil element.
+// 因为没有元素，回调函数和 Do 得到的元素为 nil。
+func (rc *RaceContext) Not(selector string) *RaceContext {
+	return rc.add(func(p *Page) (*Element, error) {
+		var has bool
+		var el *Element
+		var err error
+		if rc.element != nil {
+			has, el, err = rc.element.Has(selector)
+		} else {
+			has, el, err = p.Has(selector)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if has {
+			_ = el.Release()
+			return nil, &ErrElementNotFound{}
+		}
+		return nil, nil
 	})
-	return rc
 }
 
 // Handle adds a callback function to the most recent chained selector.
@@ -471,15 +943,33 @@ func (rc *RaceContext) Handle(callback func(*Element) error) *RaceContext {
 	return rc
 }
 
+// Timeout sets the timeout for the most recent chained selector, after the timeout the branch will drop out of the race.
+// Timeout 为最近的链式选择器设置超时，超时后该分支将退出 race。
+// If all the branches drop out, Do will return context.DeadlineExceeded.
+// 如果所有分支都退出了，Do 将返回 context.DeadlineExceeded。
+func (rc *RaceContext) Timeout(d time.Duration) *RaceContext {
+	rc.branches[len(rc.branches)-1].timeout = d
+	return rc
+}
+
 // Do the race
 // 执行 Trace
 func (rc *RaceContext) Do() (*Element, error) {
 	var el *Element
+	start := time.Now()
 	err := utils.Retry(rc.page.ctx, rc.page.sleeper(), func() (stop bool, err error) {
+		active := 0
 		for _, branch := range rc.branches {
+			if branch.timeout > 0 && time.Since(start) > branch.timeout {
+				continue
+			}
+			active++
+
 			bEl, err := branch.condition(rc.page.Sleeper(NotFoundSleeper))
 			if err == nil {
-				el = bEl.Sleeper(rc.page.sleeper)
+				if bEl != nil {
+					el = bEl.Sleeper(rc.page.sleeper)
+				}
 
 				if branch.callback != nil {
 					err = branch.callback(el)
@@ -489,6 +979,9 @@ func (rc *RaceContext) Do() (*Element, error) {
 				return true, err
 			}
 		}
+		if active == 0 {
+			return true, context.DeadlineExceeded
+		}
 		return
 	})
 	return el, err
@@ -524,6 +1017,20 @@ func (el *Element) HasR(selector, jsRegex string) (bool, *Element, error) {
 	return err == nil, el, err
 }
 
+// WaitHas waits until a child element that matches the css selector appears
+// WaitHas 等待直到出现和 CSS 选择器匹配的子元素
+func (el *Element) WaitHas(selector string) error {
+	defer el.tryTrace(TraceTypeWait, "has", selector)()
+	return el.Wait(Eval(`s => this.querySelector(s) !== null`, selector))
+}
+
+// WaitNotHas waits until no child element matches the css selector
+// WaitNotHas 等待直到没有和 CSS 选择器匹配的子元素
+func (el *Element) WaitNotHas(selector string) error {
+	defer el.tryTrace(TraceTypeWait, "not has", selector)()
+	return el.Wait(Eval(`s => this.querySelector(s) === null`, selector))
+}
+
 // Element returns the first child that matches the css selector
 // 返回第一个和CSS选择器匹配的子元素
 func (el *Element) Element(selector string) (*Element, error) {
@@ -588,6 +1095,18 @@ func (el *Element) ElementsX(xpath string) (Elements, error) {
 	return el.ElementsByJS(evalHelper(js.ElementsX, xpath))
 }
 
+// ElementsR returns all child elements that match the css selector and their text matches the jsRegex
+// ElementsR 返回所有符合css选择器并且其文本符合jsRegex的子元素
+func (el *Element) ElementsR(selector, jsRegex string) (Elements, error) {
+	return el.ElementsByJS(evalHelper(js.ElementsR, selector, jsRegex))
+}
+
+// ElementsIter is similar to Page.ElementsIter but only iterates the children
+// ElementsIter 类似于 Page.ElementsIter，但只遍历子元素
+func (el *Element) ElementsIter(selector string) (*ElementsIterator, error) {
+	return el.page.Context(el.ctx).elementsIterByJS(evalHelper(js.Elements, selector).This(el.Object))
+}
+
 // ElementsByJS returns the elements from the return value of the js
 // ElementsByJS 从 js 的返回值中返回元素。
 func (el *Element) ElementsByJS(opts *EvalOptions) (Elements, error) {
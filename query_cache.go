@@ -0,0 +1,98 @@
+package rod
+
+import (
+	"sync"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+type queryCacheKey struct {
+	targetID proto.TargetTargetID
+	frameID  proto.PageFrameID
+}
+
+type queryCache struct {
+	lock     sync.Mutex
+	elements map[string]*Element
+
+	// increased on each invalidation, so that a query started before it won't be cached
+	// 每次失效时递增，这样在失效之前开始的查询不会被缓存
+	generation int
+}
+
+// CacheQueries enables the cache of the elements resolved by Page.Element and Page.ElementX for the page,
+// so the repeated queries of the same selector won't query the DOM again. The cache will be invalidated when
+// the document is updated or the frame is navigated. An element removed by js won't invalidate the cache,
+// only use it when the queried elements are stable. Call the returned function to disable the cache.
+// CacheQueries 为页面启用 Page.Element 和 Page.ElementX 解析出的元素的缓存，这样对相同选择器的重复查询不会再次查询 DOM。
+// 当文档被更新或者 frame 发生导航时缓存会失效。被 js 移除的元素不会使缓存失效，只有在被查询的元素稳定时才使用它。
+// 调用返回的函数来禁用缓存。
+func (p *Page) CacheQueries() (disable func()) {
+	key := queryCacheKey{p.TargetID, p.FrameID}
+	c := &queryCache{elements: map[string]*Element{}}
+
+	page, cancel := p.WithCancel()
+	wait := page.EachEvent(func(e *proto.DOMDocumentUpdated) {
+		c.invalidate()
+	}, func(e *proto.PageFrameNavigated) {
+		c.invalidate()
+	})
+	go wait()
+
+	p.browser.states.Store(key, c)
+
+	return func() {
+		cancel()
+		p.browser.RemoveState(key)
+	}
+}
+
+// remove the caches of all the frames of the page
+// 移除页面所有 frame 的缓存
+func (p *Page) removeQueryCaches() {
+	p.browser.states.Range(func(k, _ interface{}) bool {
+		if key, ok := k.(queryCacheKey); ok && key.targetID == p.TargetID {
+			p.browser.states.Delete(k)
+		}
+		return true
+	})
+}
+
+func (c *queryCache) invalidate() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.elements = map[string]*Element{}
+	c.generation++
+}
+
+// query via the cache if it's enabled for the page
+// 如果页面启用了缓存，则通过缓存进行查询
+func (p *Page) cachedQuery(key string, query func() (*Element, error)) (*Element, error) {
+	v, has := p.browser.states.Load(queryCacheKey{p.TargetID, p.FrameID})
+	if !has {
+		return query()
+	}
+	c := v.(*queryCache)
+
+	c.lock.Lock()
+	el, hit := c.elements[key]
+	generation := c.generation
+	c.lock.Unlock()
+
+	if hit {
+		return el.Context(p.ctx).Sleeper(p.sleeper), nil
+	}
+
+	el, err := query()
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	if c.generation == generation {
+		c.elements[key] = el
+	}
+	c.lock.Unlock()
+
+	return el, nil
+}
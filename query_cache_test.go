@@ -0,0 +1,24 @@
+package rod_test
+
+import (
+	"testing"
+)
+
+func TestCacheQueries(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.srcFile("fixtures/click.html"))
+	disable := p.CacheQueries()
+
+	el := p.MustElement("button")
+	g.Eq(p.MustElement("button").Object.ObjectID, el.Object.ObjectID)
+	g.Eq(p.MustElementX("//button").Object.ObjectID, p.MustElementX("//button").Object.ObjectID)
+
+	// the cache should be invalidated by the navigation
+	p.MustNavigate(g.srcFile("fixtures/click.html")).MustWaitLoad()
+	g.Neq(p.MustElement("button").Object.ObjectID, el.Object.ObjectID)
+
+	disable()
+	el = p.MustElement("button")
+	g.Neq(p.MustElement("button").Object.ObjectID, el.Object.ObjectID)
+}
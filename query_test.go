@@ -24,6 +24,83 @@ func TestPageElements(t *testing.T) {
 	g.Eq("submit", list.Last().MustText())
 }
 
+func TestPageElementsIter(t *testing.T) {
+	g := setup(t)
+
+	g.page.MustNavigate(g.srcFile("fixtures/input.html"))
+	g.page.MustElement("input")
+
+	it := g.page.MustElementsIter("input")
+	defer func() { g.E(it.Release()) }()
+
+	texts := []string{}
+	for it.Next() {
+		texts = append(texts, it.Element().MustDescribe().LocalName)
+	}
+	g.E(it.Err())
+	g.Len(texts, it.Len())
+	g.Eq(texts[0], "input")
+	g.Nil(it.Element())
+	g.False(it.Next())
+
+	form := g.page.MustElement("form")
+	sub := form.MustElementsIter("input")
+	g.Eq(sub.Len(), it.Len())
+	g.E(sub.Release())
+	g.False(sub.Next())
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(g.page.ElementsIter("input"))
+
+	_, err := g.page.ElementsIter("")
+	g.Err(err)
+}
+
+func TestElementsHelpers(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/selector.html"))
+	list := p.MustElements("button")
+
+	all := p.MustElements("button")
+	odd := all.Filter(func(el *rod.Element) bool {
+		return el.MustText() != "02"
+	})
+	g.Eq(odd.MustTexts(), []string{"01", "03", "04"})
+	g.Err(all[1].Text())
+
+	parents := list.MustMap(func(el *rod.Element) *rod.Element {
+		if el.MustText() == "04" {
+			return nil
+		}
+		return el.MustParent()
+	})
+	g.Len(parents, 3)
+	g.Eq(parents.Last().MustDescribe().LocalName, "div")
+
+	_, err := list.Map(func(el *rod.Element) (*rod.Element, error) { return nil, errors.New("err") })
+	g.Eq(err.Error(), "err")
+
+	g.Nil(list.MustAttributes("id")[0])
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(list.Texts())
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(list.Attributes("id"))
+
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		list.MustTexts()
+	})
+
+	g.Len(rod.Elements{}.MustTexts(), 0)
+
+	g.mc.stubErr(1, proto.RuntimeReleaseObject{})
+	g.Err(list.Release())
+	parents.MustRelease()
+}
+
 func TestPagesQuery(t *testing.T) {
 	g := setup(t)
 
@@ -54,6 +131,57 @@ func TestPagesQuery(t *testing.T) {
 	})
 }
 
+func TestPagesTabHelpers(t *testing.T) {
+	g := setup(t)
+
+	b := g.browser
+
+	p := b.MustPage(g.srcFile("fixtures/click.html")).MustWaitLoad()
+	p.MustEval(`() => document.title = "tab-helpers"`)
+	pages := b.MustPages()
+
+	g.Eq(pages.MustFindByTitle("^tab-helpers$").TargetID, p.TargetID)
+	g.Panic(func() { rod.Pages{}.MustFindByTitle("____") })
+	g.Panic(func() { pages.MustFindByTitle("____") })
+	_, err := pages.FindByTitle("(")
+	g.Err(err)
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		pages.MustFindByTitle("____")
+	})
+
+	filtered := pages.Filter(func(page *rod.Page) bool { return page.TargetID == p.TargetID })
+	g.Len(filtered, 1)
+
+	sorted := pages.Sort(func(a, b *rod.Page) bool { return a.TargetID == p.TargetID })
+	g.Eq(sorted.First().TargetID, p.TargetID)
+	g.Len(sorted, len(pages))
+
+	groups := pages.MustGroupByWindow()
+	total := 0
+	for _, list := range groups {
+		total += len(list)
+	}
+	g.Eq(total, len(pages))
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.BrowserGetWindowForTarget{})
+		pages.MustGroupByWindow()
+	})
+
+	activated := b.MustActivatePage(func(info *proto.TargetTargetInfo) bool {
+		return info.TargetID == p.TargetID
+	})
+	g.Eq(activated.TargetID, p.TargetID)
+
+	_, err = b.ActivatePage(func(*proto.TargetTargetInfo) bool { return false })
+	g.Eq(err.Error(), "cannot find page")
+
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.TargetGetTargets{})
+		b.MustActivatePage(func(*proto.TargetTargetInfo) bool { return true })
+	})
+}
+
 func TestPagesOthers(t *testing.T) {
 	g := setup(t)
 
@@ -99,6 +227,36 @@ func TestElementHas(t *testing.T) {
 	g.False(b.MustHasR("button", "11"))
 }
 
+func TestWaitHas(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/selector.html"))
+	p.MustWaitHas("span")
+
+	go func() {
+		utils.Sleep(0.1)
+		p.MustEval(`() => {
+			document.querySelector('span').remove()
+			document.querySelector('div').appendChild(document.createElement('a'))
+		}`)
+	}()
+	p.MustWaitNotHas("span")
+
+	div := p.MustElement("div")
+	div.MustWaitHas("a").MustWaitNotHas("span")
+
+	err := p.Timeout(100 * time.Millisecond).WaitHas("span")
+	g.Is(err, context.DeadlineExceeded)
+
+	err = div.Timeout(100 * time.Millisecond).WaitNotHas("a")
+	g.Is(err, context.DeadlineExceeded)
+
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		p.MustWaitHas("span")
+	})
+}
+
 func TestSearch(t *testing.T) {
 	g := setup(t)
 
@@ -172,6 +330,64 @@ func TestSearchElements(t *testing.T) {
 	}
 }
 
+func TestSearchEach(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/selector.html"))
+
+	texts := []string{}
+	res, err := p.Search("button")
+	g.E(err)
+	res.MustEach(func(el *rod.Element) bool {
+		texts = append(texts, el.MustText())
+		return false
+	})
+	g.Eq(texts, []string{"01", "02", "03", "04"})
+
+	// stop early
+	count := 0
+	res, err = p.Search("button")
+	g.E(err)
+	g.E(res.Each(func(el *rod.Element) (bool, error) {
+		count++
+		return true, nil
+	}))
+	g.Eq(count, 1)
+
+	// when node id is stale
+	{
+		res, err = p.Search("button")
+		g.E(err)
+		g.mc.stub(1, proto.DOMGetSearchResults{}, func(send StubSend) (gson.JSON, error) {
+			return gson.New(proto.DOMGetSearchResultsResult{
+				NodeIds: []proto.DOMNodeID{0, 0, 0, 0},
+			}), nil
+		})
+		count = 0
+		res.MustEach(func(el *rod.Element) bool {
+			count++
+			return false
+		})
+		g.Eq(count, 4)
+	}
+
+	res, err = p.Search("button")
+	g.E(err)
+	g.Eq(res.Each(func(el *rod.Element) (bool, error) {
+		return false, errors.New("err")
+	}).Error(), "err")
+
+	res, err = p.Search("button")
+	g.E(err)
+	g.mc.stubErr(1, proto.DOMGetSearchResults{})
+	g.Err(res.Each(func(el *rod.Element) (bool, error) { return false, nil }))
+
+	res, err = p.Search("button")
+	g.E(err)
+	g.mc.stubErr(1, proto.DOMResolveNode{})
+	g.Err(res.Each(func(el *rod.Element) (bool, error) { return false, nil }))
+}
+
 func TestSearchIframes(t *testing.T) {
 	g := setup(t)
 
@@ -231,6 +447,45 @@ func TestPageRace(t *testing.T) {
 	g.Nil(el)
 }
 
+func TestPageRaceV2(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/selector.html"))
+
+	g.Eq("01", p.Race().ElementsMoreThan("button", 3).MustDo().MustText())
+	g.Eq("01", p.Race().
+		ElementsMoreThan("button", 4).
+		Element("button").MustDo().MustText())
+
+	el, err := p.Race().Not("a").Handle(func(e *rod.Element) error {
+		g.Nil(e)
+		return nil
+	}).Do()
+	g.E(err)
+	g.Nil(el)
+
+	div := p.MustElement("div")
+	g.Eq("02", div.Race().Element("button").MustDo().MustText())
+	g.Eq("03", div.Race().ElementR("button", "03").MustDo().MustText())
+	g.Eq("02", div.Race().ElementX("./button").MustDo().MustText())
+	g.Eq("02", div.Race().MustElementByJS(`() => this.querySelector('button')`, nil).MustDo().MustText())
+	g.Eq("02", div.Race().ElementsMoreThan("button", 1).MustDo().MustText())
+	g.Nil(div.Race().Element("span").Timeout(300 * time.Millisecond).Not("span").MustDo())
+
+	_, err = p.Race().Element("a").Timeout(300 * time.Millisecond).Do()
+	g.Is(err, context.DeadlineExceeded)
+
+	_, err = p.Race().ElementsMoreThan("button", 10).Timeout(300 * time.Millisecond).
+		Not("button").Timeout(300 * time.Millisecond).Do()
+	g.Is(err, context.DeadlineExceeded)
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.Race().ElementsMoreThan("button", 1).Do())
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.Race().Not("button").Do())
+}
+
 func TestPageRaceRetryInHandle(t *testing.T) {
 	g := setup(t)
 
@@ -283,6 +538,27 @@ func TestElementR(t *testing.T) {
 	g.Eq("CC", el.MustText())
 }
 
+func TestElementsR(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/selector.html"))
+	list := p.MustElementsR("button", `0[14]`)
+	g.Len(list, 2)
+	g.Eq("01", list.First().MustText())
+	g.Eq("04", list.Last().MustText())
+
+	list = p.MustElement("div").MustElementsR("button", `0\d`)
+	g.Len(list, 2)
+	g.Eq("03", list.Last().MustText())
+
+	g.Len(p.MustElementsR("button", `/xx/i`), 0)
+
+	p = g.page.MustNavigate(g.srcFile("fixtures/input.html"))
+	list = p.MustElementsR("option", `/c/i`)
+	g.Len(list, 2)
+	g.Eq("CC", list.Last().MustText())
+}
+
 func TestElementFromElement(t *testing.T) {
 	g := setup(t)
 
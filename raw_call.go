@@ -0,0 +1,35 @@
+package rod
+
+import (
+	"encoding/json"
+)
+
+// RawCall calls the devtools method with the params, then decodes the result into the into if it's not nil.
+// The params and into can be any json compatible value, such as a map or a gson.JSON, so the new or
+// experimental methods can be used before the lib/proto is regenerated.
+// RawCall 使用 params 调用 devtools 方法，如果 into 不为 nil，则将结果解码到 into 中。
+// params 和 into 可以是任何兼容 json 的值，例如 map 或者 gson.JSON，这样在 lib/proto 重新生成之前就可以使用新的或者实验性的方法。
+func (b *Browser) RawCall(method string, params interface{}, into interface{}) error {
+	res, err := b.Call(b.ctx, "", method, params)
+	if err != nil {
+		return err
+	}
+	return decodeRaw(res, into)
+}
+
+// RawCall is similar to Browser.RawCall, but the method is called on the session of the page
+// RawCall 类似于 Browser.RawCall，但方法会在页面的 session 上被调用
+func (p *Page) RawCall(method string, params interface{}, into interface{}) error {
+	res, err := p.Call(p.ctx, string(p.SessionID), method, params)
+	if err != nil {
+		return err
+	}
+	return decodeRaw(res, into)
+}
+
+func decodeRaw(res []byte, into interface{}) error {
+	if into == nil || len(res) == 0 {
+		return nil
+	}
+	return json.Unmarshal(res, into)
+}
@@ -0,0 +1,39 @@
+package rod_test
+
+import (
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestRawCall(t *testing.T) {
+	g := setup(t)
+
+	g.Has(g.browser.MustRawCall("Browser.getVersion", nil).Get("product").String(), "Chrome")
+
+	p := g.newPage(g.blank())
+
+	res := p.MustRawCall("Runtime.evaluate", map[string]interface{}{
+		"expression":    "1 + 2",
+		"returnByValue": true,
+	})
+	g.Eq(res.Get("result.value").Int(), 3)
+
+	var into struct {
+		Result struct {
+			Value string `json:"value"`
+		} `json:"result"`
+	}
+	g.E(p.RawCall("Runtime.evaluate", map[string]interface{}{
+		"expression":    "'ok'",
+		"returnByValue": true,
+	}, &into))
+	g.Eq(into.Result.Value, "ok")
+
+	g.E(p.RawCall("Page.enable", nil, nil))
+
+	g.mc.stubErr(1, proto.PageEnable{})
+	g.Err(p.RawCall("Page.enable", nil, nil))
+
+	g.Err(g.browser.RawCall("Not.exists", nil, nil))
+}
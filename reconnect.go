@@ -0,0 +1,531 @@
+package rod
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod/lib/cdp"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// ReconnectPolicy for Browser.Reconnect
+// Browser.Reconnect 的重连策略
+type ReconnectPolicy struct {
+	// Sleeper to wait before each dial, the reconnection gives up when it returns an error.
+	// Default is a backoff sleeper from 100ms to 5s that never gives up.
+	// 每次重新连接之前的等待策略，当它返回错误时放弃重连。
+	// 默认是一个从 100ms 到 5s 的退避策略，它永远不会放弃。
+	Sleeper func() utils.Sleeper
+
+	// OnReconnect will be called after each successful reconnection
+	// 每次成功重连后都会调用 OnReconnect
+	OnReconnect func()
+}
+
+var _ CDPClient = &reconnectClient{}
+
+// reconnectClient re-dials the control url when the websocket drops, then re-attaches the targets and
+// restores the enabled domains. It maps the new session ids to the original ones, so the existing Page
+// objects keep working.
+// reconnectClient 在 websocket 断开时重新连接控制 url，然后重新附加目标并恢复已启用的 domain。
+// 它将新的 session id 映射为原来的 session id，所以已有的 Page 对象可以继续工作。
+type reconnectClient struct {
+	ctx      context.Context
+	cancel   func()
+	url      string
+	policy   *ReconnectPolicy
+	relaunch func() (string, error)
+	states   *sync.Map
+
+	event chan *cdp.Event
+
+	lock    sync.Mutex
+	client  *cdp.Client
+	ready   chan struct{} // closed when the client is connected or the reconnection gave up
+	err     error         // why the reconnection gave up
+	targets map[string]proto.TargetTargetID
+	current map[string]string // original session id -> current session id
+	origin  map[string]string // current session id -> original session id
+
+	relaunched bool
+	generation int               // increased after each relaunch
+	contexts   map[string]string // original browser context id -> current browser context id
+}
+
+func newReconnectClient(
+	ctx context.Context, u string, policy *ReconnectPolicy, relaunch func() (string, error), states *sync.Map,
+) (*reconnectClient, error) {
+	c, err := cdp.StartWithURL(ctx, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	ready := make(chan struct{})
+	close(ready)
+
+	rc := &reconnectClient{
+		ctx:      ctx,
+		cancel:   cancel,
+		url:      u,
+		policy:   policy,
+		relaunch: relaunch,
+		states:   states,
+		event:    make(chan *cdp.Event),
+		client:   c,
+		ready:    ready,
+		targets:  map[string]proto.TargetTargetID{},
+		current:  map[string]string{},
+		origin:   map[string]string{},
+		contexts: map[string]string{},
+	}
+
+	go rc.pump(c, make(chan struct{}))
+
+	return rc, nil
+}
+
+// Event interface
+func (rc *reconnectClient) Event() <-chan *cdp.Event {
+	return rc.event
+}
+
+// the max times to send a call when the connection keeps dropping
+// 当连接不断断开时，一个调用最多发送的次数
+const reconnectCallLimit = 5
+
+// Call interface. If the connection drops during the call, it will be retried after the reconnection if the
+// method is idempotent, such as "DOM.getDocument", see cdp.IsIdempotent. The others may have reached the browser,
+// so they return the error to avoid doing the same action twice, such as a click.
+// Call 接口。如果调用期间连接断开，且方法是幂等的（例如 "DOM.getDocument"，参见 cdp.IsIdempotent），它将在重连后重试。
+// 其他的调用可能已经到达了浏览器，所以它们会返回错误，以避免重复执行同一个操作，例如点击。
+func (rc *reconnectClient) Call(ctx context.Context, sessionID, method string, params interface{}) ([]byte, error) {
+	var broken *cdp.Client
+	generation := rc.getGeneration()
+
+	for attempt := 1; ; attempt++ {
+		c, err := rc.connected(ctx, broken)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := c.Call(ctx, rc.toCurrent(sessionID), method, rc.translate(params))
+
+		var cdpErr *cdp.Error
+		if err == nil || ctx.Err() != nil || errors.As(err, &cdpErr) {
+			if err == nil {
+				rc.track(method, params, res)
+			}
+			return res, err
+		}
+
+		// the call that isn't idempotent or the call to a relaunched browser shouldn't be retried
+		// 不是幂等的调用或者对重新启动的浏览器的调用不应该重试
+		if !cdp.IsIdempotent(method) || attempt >= reconnectCallLimit || !rc.lost(c) {
+			return nil, err
+		}
+		if _, e := rc.connected(ctx, c); e != nil {
+			return nil, e
+		}
+		if rc.getGeneration() != generation {
+			return nil, err
+		}
+
+		broken = c
+	}
+}
+
+func (rc *reconnectClient) getGeneration() int {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	return rc.generation
+}
+
+// lost waits a moment for the pump to notice the disconnection of c, it returns false if c is still connected,
+// so the errors that aren't caused by the disconnection, such as a closed client, won't be retried.
+// lost 等待一会儿让 pump 发现 c 的断开，如果 c 仍然处于连接状态则返回 false，
+// 这样不是由断开连接引起的错误（例如客户端已被关闭）就不会被重试。
+func (rc *reconnectClient) lost(c *cdp.Client) bool {
+	for i := 0; i < 100; i++ {
+		rc.lock.Lock()
+		current, err := rc.client, rc.err
+		rc.lock.Unlock()
+
+		if current != c || err != nil {
+			return true
+		}
+		utils.Sleep(0.01)
+	}
+	return false
+}
+
+// connected waits until a client other than the broken one is connected
+func (rc *reconnectClient) connected(ctx context.Context, broken *cdp.Client) (*cdp.Client, error) {
+	for {
+		rc.lock.Lock()
+		c, ready, err := rc.client, rc.ready, rc.err
+		rc.lock.Unlock()
+
+		if err != nil {
+			return nil, err
+		}
+		if c != nil && c != broken {
+			return c, nil
+		}
+
+		if c != nil {
+			// the pump hasn't noticed the disconnection yet
+			// pump 还没有发现连接已断开
+			ready = nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ready:
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// pump forwards the events of c, done is closed when c is disconnected
+// pump 转发 c 的事件，当 c 断开时 done 会被关闭
+func (rc *reconnectClient) pump(c *cdp.Client, done chan struct{}) {
+	for e := range c.Event() {
+		if e.SessionID != "" {
+			e.SessionID = rc.toOrigin(e.SessionID)
+		}
+
+		select {
+		case <-rc.ctx.Done():
+		case rc.event <- e:
+		}
+	}
+
+	if rc.ctx.Err() != nil {
+		rc.giveUp(rc.ctx.Err())
+		return
+	}
+
+	rc.lock.Lock()
+	close(done)
+	published := rc.client == c
+	if published {
+		rc.client = nil
+		rc.ready = make(chan struct{})
+	}
+	rc.lock.Unlock()
+
+	// c dropped during its recovery, the reconnect that dialed it will dial again
+	// c 在恢复期间断开了，拨号它的 reconnect 会重新拨号
+	if !published {
+		return
+	}
+
+	rc.reconnect()
+}
+
+func (rc *reconnectClient) reconnect() {
+	sleeper := rc.policy.Sleeper
+	if sleeper == nil {
+		sleeper = func() utils.Sleeper { return utils.BackoffSleeper(100*time.Millisecond, 5*time.Second, nil) }
+	}
+	sleep := sleeper()
+
+	for {
+		err := sleep(rc.ctx)
+		if err != nil {
+			rc.giveUp(err)
+			return
+		}
+
+		relaunched := false
+		c, err := cdp.StartWithURL(rc.ctx, rc.url, nil)
+		if err != nil && rc.relaunch != nil {
+			// the browser is gone, launch a new one
+			// 浏览器已经不存在了，启动一个新的浏览器
+			u, e := rc.relaunch()
+			if e != nil {
+				continue
+			}
+			rc.url = u
+			relaunched = true
+			c, err = cdp.StartWithURL(rc.ctx, rc.url, nil)
+		}
+		if err != nil {
+			continue
+		}
+
+		// the events must be consumed or the client will be blocked
+		// 必须消费事件，否则客户端会被阻塞
+		done := make(chan struct{})
+		go rc.pump(c, done)
+
+		if relaunched {
+			rc.rebuild(c)
+		}
+		rc.recover(c)
+
+		published, err := rc.publish(c, done)
+		if err != nil {
+			return
+		}
+		if !published {
+			continue
+		}
+
+		// the browser is reachable again, the later errors shouldn't be treated as crashes
+		// 浏览器又可以访问了，之后的错误不应该被当作崩溃
+		rc.states.Delete(crashKey{})
+
+		if rc.policy.OnReconnect != nil {
+			rc.policy.OnReconnect()
+		}
+		return
+	}
+}
+
+// publish c as the current client, it returns false if c has dropped, the error is why the reconnection has given up
+// 将 c 发布为当前的客户端，如果 c 已经断开则返回 false，error 是重连已经放弃的原因
+func (rc *reconnectClient) publish(c *cdp.Client, done chan struct{}) (bool, error) {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+
+	if rc.err != nil {
+		return false, rc.err
+	}
+
+	select {
+	case <-done:
+		return false, nil
+	default:
+	}
+
+	rc.client = c
+	close(rc.ready)
+	return true, nil
+}
+
+// recover the browser level states, re-attach the targets, then restore the enabled domains of each session.
+// The remote objects of the old sessions are invalid, so the cached js contexts of pages will be reset.
+// 恢复浏览器级别的状态，重新附加目标，然后恢复每个 session 已启用的 domain。
+// 旧 session 的远程对象已经失效，所以页面缓存的 js 上下文将被重置。
+func (rc *reconnectClient) recover(c *cdp.Client) {
+	rc.restoreStates(c, "")
+
+	rc.lock.Lock()
+	targets := map[string]proto.TargetTargetID{}
+	for s, id := range rc.targets {
+		targets[s] = id
+	}
+	rc.lock.Unlock()
+
+	for session, targetID := range targets {
+		res, err := c.Call(rc.ctx, "", (proto.TargetAttachToTarget{}).ProtoReq(), proto.TargetAttachToTarget{
+			TargetID: targetID,
+			Flatten:  true,
+		})
+
+		rc.lock.Lock()
+		delete(rc.origin, rc.current[session])
+		if err != nil {
+			delete(rc.targets, session)
+			delete(rc.current, session)
+			rc.lock.Unlock()
+			continue
+		}
+
+		var attached proto.TargetAttachToTargetResult
+		_ = json.Unmarshal(res, &attached)
+		rc.current[session] = string(attached.SessionID)
+		rc.origin[string(attached.SessionID)] = session
+		rc.lock.Unlock()
+
+		rc.restoreStates(c, session)
+	}
+
+	rc.states.Range(func(_, v interface{}) bool {
+		if p, ok := v.(*Page); ok {
+			p.unsetJSCtxID()
+		}
+		return true
+	})
+}
+
+func (rc *reconnectClient) restoreStates(c *cdp.Client, session string) {
+	rc.states.Range(func(k, v interface{}) bool {
+		key, ok := k.(stateKey)
+		if !ok || string(key.sessionID) != session {
+			return true
+		}
+
+		_, name := proto.ParseMethodName(key.methodName)
+		if name == "enable" || key.methodName == (proto.TargetSetDiscoverTargets{}).ProtoReq() {
+			_, _ = c.Call(rc.ctx, rc.toCurrent(session), key.methodName, v)
+		}
+		return true
+	})
+}
+
+// rebuild the incognito contexts on the relaunched browser, the targets of the old browser are gone
+// 在重新启动的浏览器上重建无痕上下文，旧浏览器的目标已经不存在了
+func (rc *reconnectClient) rebuild(c *cdp.Client) {
+	rc.lock.Lock()
+	rc.relaunched = true
+	rc.generation++
+	rc.targets = map[string]proto.TargetTargetID{}
+	rc.current = map[string]string{}
+	rc.origin = map[string]string{}
+	contexts := []string{}
+	for id := range rc.contexts {
+		contexts = append(contexts, id)
+	}
+	rc.lock.Unlock()
+
+	for _, id := range contexts {
+		res, err := c.Call(rc.ctx, "", (proto.TargetCreateBrowserContext{}).ProtoReq(), proto.TargetCreateBrowserContext{})
+		if err != nil {
+			continue
+		}
+
+		var created proto.TargetCreateBrowserContextResult
+		_ = json.Unmarshal(res, &created)
+
+		rc.lock.Lock()
+		rc.contexts[id] = string(created.BrowserContextID)
+		rc.lock.Unlock()
+	}
+}
+
+// track the sessions and browser contexts created by the calls
+// 跟踪调用创建的 session 和浏览器上下文
+func (rc *reconnectClient) track(method string, params interface{}, res []byte) {
+	switch req := params.(type) {
+	case proto.TargetAttachToTarget:
+		var attached proto.TargetAttachToTargetResult
+		if !req.Flatten || json.Unmarshal(res, &attached) != nil {
+			return
+		}
+
+		session := string(attached.SessionID)
+
+		rc.lock.Lock()
+		defer rc.lock.Unlock()
+		rc.targets[session] = req.TargetID
+		rc.current[session] = session
+		rc.origin[session] = session
+
+	case proto.TargetCreateBrowserContext:
+		var created proto.TargetCreateBrowserContextResult
+		if json.Unmarshal(res, &created) != nil {
+			return
+		}
+
+		rc.lock.Lock()
+		defer rc.lock.Unlock()
+		rc.contexts[string(created.BrowserContextID)] = string(created.BrowserContextID)
+
+	case proto.TargetDisposeBrowserContext:
+		rc.lock.Lock()
+		defer rc.lock.Unlock()
+		delete(rc.contexts, string(req.BrowserContextID))
+	}
+}
+
+// translate the original browser context ids in the params to the ones of the relaunched browser
+// 将参数中原来的浏览器上下文 id 转换为重新启动的浏览器中的 id
+func (rc *reconnectClient) translate(params interface{}) interface{} {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+
+	if !rc.relaunched || params == nil {
+		return params
+	}
+
+	b, err := json.Marshal(params)
+	if err != nil {
+		return params
+	}
+
+	var v interface{}
+	if json.Unmarshal(b, &v) != nil {
+		return params
+	}
+
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch val := v.(type) {
+		case map[string]interface{}:
+			for k, item := range val {
+				if id, ok := item.(string); ok && k == "browserContextId" {
+					if to, has := rc.contexts[id]; has {
+						val[k] = to
+					}
+					continue
+				}
+				walk(item)
+			}
+		case []interface{}:
+			for _, item := range val {
+				walk(item)
+			}
+		}
+	}
+	walk(v)
+
+	b, _ = json.Marshal(v)
+	return json.RawMessage(b)
+}
+
+func (rc *reconnectClient) toCurrent(session string) string {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	if s, has := rc.current[session]; has {
+		return s
+	}
+	return session
+}
+
+func (rc *reconnectClient) toOrigin(session string) string {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	if s, has := rc.origin[session]; has {
+		return s
+	}
+	return session
+}
+
+// close the current connection and stop reconnecting
+// 关闭当前的连接并停止重连
+func (rc *reconnectClient) close() {
+	rc.cancel()
+
+	rc.lock.Lock()
+	c := rc.client
+	rc.lock.Unlock()
+
+	if c != nil {
+		if ws, ok := c.WebSocket().(io.Closer); ok {
+			_ = ws.Close()
+		}
+	}
+}
+
+func (rc *reconnectClient) giveUp(err error) {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+
+	if rc.err != nil {
+		return
+	}
+	rc.err = err
+	if rc.client == nil {
+		close(rc.ready)
+	}
+	close(rc.event)
+}
@@ -0,0 +1,123 @@
+package rod_test
+
+import (
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// a tcp proxy that can drop all the connections going through it
+type dropProxy struct {
+	lock  sync.Mutex
+	ln    net.Listener
+	conns []net.Conn
+}
+
+func newDropProxy(g G, target string) *dropProxy {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	g.E(err)
+	g.Cleanup(func() { _ = ln.Close() })
+
+	p := &dropProxy{ln: ln}
+
+	go func() {
+		for {
+			src, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			dst, err := net.Dial("tcp", target)
+			if err != nil {
+				_ = src.Close()
+				continue
+			}
+
+			p.lock.Lock()
+			p.conns = append(p.conns, src, dst)
+			p.lock.Unlock()
+
+			go func() { _, _ = io.Copy(dst, src) }()
+			go func() { _, _ = io.Copy(src, dst) }()
+		}
+	}()
+
+	return p
+}
+
+func (p *dropProxy) drop() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, c := range p.conns {
+		_ = c.Close()
+	}
+	p.conns = nil
+}
+
+func TestBrowserReconnect(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New()
+	g.Cleanup(l.Kill)
+	u, err := url.Parse(l.MustLaunch())
+	g.E(err)
+
+	proxy := newDropProxy(g, u.Host)
+
+	reconnected := make(chan struct{}, 1)
+	b := rod.New().
+		ControlURL(strings.Replace(u.String(), u.Host, proxy.ln.Addr().String(), 1)).
+		Reconnect(&rod.ReconnectPolicy{OnReconnect: func() { reconnected <- struct{}{} }}).
+		MustConnect()
+
+	p := b.MustPage(g.blank())
+	p.MustEval(`() => window.flag = 1`)
+
+	wait := p.WaitEvent(&proto.PageLoadEventFired{})
+
+	proxy.drop()
+	<-reconnected
+
+	p.MustNavigate(g.blank())
+	wait()
+
+	g.Eq(p.MustEval(`() => 1`).Int(), 1)
+	g.Gte(len(b.MustPages()), 1)
+}
+
+func TestBrowserReconnectGiveUp(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New()
+	g.Cleanup(l.Kill)
+	u, err := url.Parse(l.MustLaunch())
+	g.E(err)
+
+	proxy := newDropProxy(g, u.Host)
+
+	b := rod.New().
+		ControlURL(strings.Replace(u.String(), u.Host, proxy.ln.Addr().String(), 1)).
+		Reconnect(&rod.ReconnectPolicy{Sleeper: func() utils.Sleeper { return utils.CountSleeper(0) }}).
+		MustConnect()
+
+	p := b.MustPage(g.blank())
+
+	_ = proxy.ln.Close()
+	proxy.drop()
+
+	// the eval isn't idempotent, so it isn't retried
+	_, err = p.Eval(`() => new Promise(r => {})`)
+	g.Err(err)
+
+	_, err = proto.PageGetLayoutMetrics{}.Call(p)
+	g.Is(err, &utils.ErrMaxSleepCount{})
+
+	g.Err(rod.New().ControlURL("ws://127.0.0.1:1").Reconnect(nil).Connect())
+}
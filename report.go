@@ -0,0 +1,349 @@
+package rod
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod/lib/assets"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// the max number of the requests that a Reporter keeps
+// Reporter 保留的请求的最大数量
+const reportRequestLimit = 1000
+
+// ReportOptions for Page.Report
+// Page.Report 的选项
+type ReportOptions struct {
+	// Title of the report, the default is the url of the page when the recording starts
+	// Title 是报告的标题，默认为开始记录时页面的 url
+	Title string
+
+	// Screenshots after each action, such as the clicks and the inputs. It makes the actions slower.
+	// Screenshots 在每个操作之后截图，例如点击和输入。它会使操作变慢。
+	Screenshots bool
+
+	// Quality of the jpeg screenshots, the default is 50
+	// Quality 是 jpeg 截图的质量，默认为 50
+	Quality int
+}
+
+// ReportEntry is an entry of the timeline of the report
+// ReportEntry 是报告时间线中的一个条目
+type ReportEntry struct {
+	Time time.Time
+
+	// Type is the TraceType for the actions, "screenshot", "console" or "error" for the others
+	// Type 对于操作是 TraceType，对于其他条目是 "screenshot"、"console" 或 "error"
+	Type string
+
+	Detail string
+
+	// Duration of the action, it's 0 for the others
+	// Duration 是操作的耗时，对于其他条目为 0
+	Duration time.Duration
+
+	// Screenshot in jpeg, it can be nil
+	// Screenshot 是 jpeg 格式的截图，可以为 nil
+	Screenshot []byte
+}
+
+// ReportRequest is a network request of the report
+// ReportRequest 是报告中的一个网络请求
+type ReportRequest struct {
+	Method   string
+	URL      string
+	Type     string
+	Status   int
+	Size     float64
+	Error    string
+	Start    time.Time
+	Duration time.Duration
+}
+
+// Reporter records the actions, screenshots, timings, console errors and network requests of a page, and renders
+// them as a single self-contained html report, such as for the artifacts of CI. Use Page.Report to create it.
+// Reporter 记录页面的操作、截图、耗时、console 错误以及网络请求，并将它们渲染为一个独立的 html 报告，例如用作 CI 的产物。
+// 使用 Page.Report 创建它。
+type Reporter struct {
+	page  *Page
+	opts  ReportOptions
+	start time.Time
+	stop  func()
+
+	lock     sync.Mutex
+	entries  []*ReportEntry
+	requests []*ReportRequest
+	pending  map[proto.NetworkRequestID]*ReportRequest
+}
+
+type reporterKey struct {
+	targetID proto.TargetTargetID
+}
+
+// Report starts to record the page for an html report, call Reporter.Stop when the run is done
+// Report 开始为 html 报告记录页面，在运行结束时调用 Reporter.Stop
+func (p *Page) Report(opts ReportOptions) *Reporter {
+	if opts.Title == "" {
+		if info, err := p.Info(); err == nil {
+			opts.Title = info.URL
+		}
+	}
+	if opts.Quality == 0 {
+		opts.Quality = 50
+	}
+
+	r := &Reporter{
+		page:    p,
+		opts:    opts,
+		start:   time.Now(),
+		pending: map[proto.NetworkRequestID]*ReportRequest{},
+	}
+
+	page, cancel := p.WithCancel()
+	stopConsole := page.OnConsole(func(m *ConsoleMessage) {
+		if m.Level == proto.RuntimeConsoleAPICalledTypeError || m.Level == proto.RuntimeConsoleAPICalledTypeWarning {
+			r.add(&ReportEntry{Time: time.Now(), Type: "console", Detail: m.String()})
+		}
+	})
+	stopErrors := page.OnPageError(func(e *PageError) {
+		r.add(&ReportEntry{Time: time.Now(), Type: "error", Detail: e.Error()})
+	})
+	go page.EachEvent(r.onRequest, r.onResponse, r.onFinished, r.onFailed)()
+
+	r.stop = func() {
+		cancel()
+		stopConsole()
+		stopErrors()
+	}
+
+	p.browser.states.Store(reporterKey{p.TargetID}, r)
+
+	return r
+}
+
+// Stop recording
+// 停止记录
+func (r *Reporter) Stop() {
+	r.stop()
+	r.page.browser.RemoveState(reporterKey{r.page.TargetID})
+}
+
+// Screenshot adds a screenshot of the page to the timeline, name describes it
+// Screenshot 为时间线添加一张页面的截图，name 用于描述它
+func (r *Reporter) Screenshot(name string) error {
+	bin, err := r.screenshot()
+	if err != nil {
+		return err
+	}
+	r.add(&ReportEntry{Time: time.Now(), Type: "screenshot", Detail: name, Screenshot: bin})
+	return nil
+}
+
+// Entries returns the entries of the timeline in order
+// Entries 按顺序返回时间线中的条目
+func (r *Reporter) Entries() []*ReportEntry {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]*ReportEntry{}, r.entries...)
+}
+
+// Requests returns the network requests in order
+// Requests 按顺序返回网络请求
+func (r *Reporter) Requests() []*ReportRequest {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]*ReportRequest{}, r.requests...)
+}
+
+// Render the html report to w, the screenshots are embedded so the report is a single file
+// Render 将 html 报告渲染到 w，截图会被内嵌，所以报告是单个文件
+func (r *Reporter) Render(w io.Writer) error {
+	tpl, err := template.New("report").Parse(assets.Report)
+	if err != nil {
+		return err
+	}
+	return tpl.Execute(w, r.reportData())
+}
+
+// WriteFile renders the html report to the file
+// WriteFile 将 html 报告渲染到文件
+func (r *Reporter) WriteFile(file string) error {
+	buf := bytes.NewBuffer(nil)
+	err := r.Render(buf)
+	if err != nil {
+		return err
+	}
+	return utils.OutputFile(file, buf.Bytes())
+}
+
+func (r *Reporter) add(e *ReportEntry) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.entries = append(r.entries, e)
+}
+
+func (r *Reporter) screenshot() ([]byte, error) {
+	return r.page.Screenshot(false, &proto.PageCaptureScreenshot{
+		Format:  proto.PageCaptureScreenshotFormatJpeg,
+		Quality: &r.opts.Quality,
+	})
+}
+
+// record the action, the returned function records its duration and the screenshot
+// 记录该操作，返回的函数记录它的耗时和截图
+func (r *Reporter) action(typ TraceType, msg []interface{}, target interface{}) func() {
+	e := &ReportEntry{
+		Time:   time.Now(),
+		Type:   string(typ),
+		Detail: strings.TrimSuffix(fmt.Sprintln(append(append([]interface{}{}, msg...), target)...), "\n"),
+	}
+	r.add(e)
+
+	return func() {
+		d := time.Since(e.Time)
+
+		var bin []byte
+		if r.opts.Screenshots {
+			bin, _ = r.screenshot()
+		}
+
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		e.Duration = d
+		e.Screenshot = bin
+	}
+}
+
+// record the action for the reporter of the page if there's one
+// 如果页面有报告器，为它记录该操作
+func (p *Page) recordReport(typ TraceType, msg []interface{}, target interface{}) func() {
+	if r, has := p.browser.states.Load(reporterKey{p.TargetID}); has {
+		return r.(*Reporter).action(typ, msg, target)
+	}
+	return func() {}
+}
+
+func (r *Reporter) onRequest(e *proto.NetworkRequestWillBeSent) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.requests) >= reportRequestLimit {
+		return
+	}
+
+	req := &ReportRequest{
+		Method: e.Request.Method,
+		URL:    e.Request.URL,
+		Type:   string(e.Type),
+		Start:  time.Now(),
+	}
+	r.pending[e.RequestID] = req
+	r.requests = append(r.requests, req)
+}
+
+func (r *Reporter) onResponse(e *proto.NetworkResponseReceived) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if req, has := r.pending[e.RequestID]; has {
+		req.Status = e.Response.Status
+	}
+}
+
+func (r *Reporter) onFinished(e *proto.NetworkLoadingFinished) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if req, has := r.pending[e.RequestID]; has {
+		req.Size = e.EncodedDataLength
+		req.Duration = time.Since(req.Start)
+		delete(r.pending, e.RequestID)
+	}
+}
+
+func (r *Reporter) onFailed(e *proto.NetworkLoadingFailed) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if req, has := r.pending[e.RequestID]; has {
+		req.Error = e.ErrorText
+		req.Duration = time.Since(req.Start)
+		delete(r.pending, e.RequestID)
+	}
+}
+
+type reportData struct {
+	Title          string
+	Start          time.Time
+	Duration       time.Duration
+	Actions        int
+	Errors         int
+	FailedRequests int
+	Received       string
+	Entries        []*reportDataEntry
+	Requests       []*ReportRequest
+}
+
+type reportDataEntry struct {
+	*ReportEntry
+	Kind     string
+	Offset   time.Duration
+	Duration time.Duration
+	Image    template.URL
+}
+
+func (r *Reporter) reportData() *reportData {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	data := &reportData{
+		Title:    r.opts.Title,
+		Start:    r.start,
+		Duration: time.Since(r.start).Round(time.Millisecond),
+		Entries:  []*reportDataEntry{},
+		Requests: []*ReportRequest{},
+	}
+
+	for _, e := range r.entries {
+		entry := &reportDataEntry{
+			ReportEntry: e,
+			Kind:        e.Type,
+			Offset:      e.Time.Sub(r.start).Round(time.Millisecond),
+			Duration:    e.Duration.Round(time.Millisecond),
+		}
+		switch e.Type {
+		case "error":
+			data.Errors++
+		case "console", "screenshot":
+		default:
+			entry.Kind = "action"
+			data.Actions++
+		}
+		if e.Screenshot != nil {
+			entry.Image = template.URL("data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(e.Screenshot))
+		}
+		data.Entries = append(data.Entries, entry)
+	}
+
+	var received float64
+	for _, req := range r.requests {
+		clone := *req
+		clone.Duration = clone.Duration.Round(time.Millisecond)
+		data.Requests = append(data.Requests, &clone)
+		received += req.Size
+		if req.Error != "" {
+			data.FailedRequests++
+		}
+	}
+	data.Received = fmt.Sprintf("%.1fKB", received/1024)
+
+	return data
+}
@@ -0,0 +1,55 @@
+package rod_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+func TestReport(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/", ".html", `<html><body><button>click me</button></body></html>`)
+
+	p := g.newPage(s.URL()).MustWaitLoad()
+	r := p.Report(rod.ReportOptions{Title: "report test", Screenshots: true})
+
+	p.MustElement("button").MustClick()
+	p.MustEval(`() => console.error("report error")`)
+	p.MustEval(`() => fetch('/not-found')`)
+	utils.Sleep(0.3)
+	r.MustScreenshot("the end")
+	r.Stop()
+
+	entries := r.Entries()
+	var click *rod.ReportEntry
+	for _, e := range entries {
+		if strings.HasPrefix(e.Detail, "left click") {
+			click = e
+		}
+	}
+	g.Eq(click.Type, string(rod.TraceTypeInput))
+	g.Gt(click.Duration, 0)
+	g.Gt(len(click.Screenshot), 0)
+	g.Eq(entries[len(entries)-2].Type, "console")
+	g.Eq(entries[len(entries)-1].Type, "screenshot")
+
+	reqs := r.Requests()
+	g.Len(reqs, 1)
+	g.Eq(reqs[0].Status, 404)
+
+	file := filepath.Join(os.TempDir(), "rod", "report", g.RandStr(8)+".html")
+	r.MustWriteFile(file)
+	html, err := utils.ReadString(file)
+	g.E(err)
+	g.Has(html, "<title>report test</title>")
+	g.Has(html, "left click")
+	g.Has(html, "report error")
+	g.Has(html, "/not-found")
+	g.Has(html, "data:image/jpeg;base64,")
+}
@@ -0,0 +1,186 @@
+package rod
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// Resource fetched by ResourceFetcher
+// Resource 是 ResourceFetcher 获取到的资源
+type Resource struct {
+	URL         string
+	ContentType string
+	Body        []byte
+}
+
+// ResourceFetcher fetches the resources of the page. It reads the resource from the page first, if the page hasn't
+// loaded it, the browser will load it for the frame of the page via Network.loadNetworkResource, so the request uses
+// the page's cookies, proxy and TLS settings like the other requests of the page.
+// Use Page.ResourceFetcher to create it.
+// ResourceFetcher 获取页面的资源。它首先从页面中读取资源，如果页面没有加载过该资源，
+// 浏览器会通过 Network.loadNetworkResource 为页面的 frame 加载它，所以该请求会像页面的其他请求一样使用页面的 Cookie、代理和 TLS 设置。
+// 使用 Page.ResourceFetcher 创建它。
+type ResourceFetcher struct {
+	page *Page
+
+	// Retries of a request when it fails or responds with 5xx or 429
+	// Retries 是请求失败或者响应 5xx、429 时的重试次数
+	Retries int
+
+	// Sleeper between the retries
+	// Sleeper 是每次重试之间的等待
+	Sleeper func() utils.Sleeper
+
+	// Concurrency limits the requests at the same time of ResourceFetcher.FetchAll
+	// Concurrency 限制 ResourceFetcher.FetchAll 同时进行的请求数
+	Concurrency int
+}
+
+// ResourceFetcher creates a ResourceFetcher with the default options
+// ResourceFetcher 使用默认选项创建一个 ResourceFetcher
+func (p *Page) ResourceFetcher() *ResourceFetcher {
+	return &ResourceFetcher{
+		page:        p,
+		Retries:     2,
+		Sleeper:     func() utils.Sleeper { return utils.BackoffSleeper(100*time.Millisecond, time.Second, nil) },
+		Concurrency: 4,
+	}
+}
+
+// Fetch the resource of the url
+// 获取 url 对应的资源
+func (f *ResourceFetcher) Fetch(url string) (*Resource, error) {
+	body, err := f.page.GetResource(url)
+	if err == nil {
+		return &Resource{URL: url, ContentType: f.mimeType(url), Body: body}, nil
+	}
+
+	var res *Resource
+	count := 0
+	sleeper := f.Sleeper()
+
+	err = utils.Retry(f.page.ctx, sleeper, func() (bool, error) {
+		r, status, err := f.load(url)
+		res = r
+		retry := err != nil || status >= 500 || status == http.StatusTooManyRequests
+		if !retry || count >= f.Retries {
+			return true, err
+		}
+		count++
+		return false, nil
+	})
+	return res, err
+}
+
+// FetchAll fetches the resources of the urls concurrently, the results are in the same order as the urls
+// FetchAll 并发地获取 urls 对应的资源，结果的顺序与 urls 相同
+func (f *ResourceFetcher) FetchAll(urls []string) ([]*Resource, error) {
+	limit := f.Concurrency
+	if limit < 1 {
+		limit = 1
+	}
+
+	list := make([]*Resource, len(urls))
+	errs := make([]error, len(urls))
+	sem := make(chan struct{}, limit)
+	wg := sync.WaitGroup{}
+
+	for i, u := range urls {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, u string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			list[i], errs[i] = f.Fetch(u)
+		}(i, u)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+// the mime type of the resource that the page has loaded
+// 页面已加载的资源的 mime 类型
+func (f *ResourceFetcher) mimeType(url string) string {
+	tree, err := proto.PageGetResourceTree{}.Call(rodCall(f.page))
+	if err != nil {
+		return ""
+	}
+
+	var find func(t *proto.PageFrameResourceTree) string
+	find = func(t *proto.PageFrameResourceTree) string {
+		if t.Frame.URL == url {
+			return t.Frame.MIMEType
+		}
+		for _, r := range t.Resources {
+			if r.URL == url {
+				return r.MIMEType
+			}
+		}
+		for _, child := range t.ChildFrames {
+			if m := find(child); m != "" {
+				return m
+			}
+		}
+		return ""
+	}
+	return find(tree.FrameTree)
+}
+
+// load the resource by the browser for the frame of the page
+// 由浏览器为页面的 frame 加载资源
+func (f *ResourceFetcher) load(url string) (*Resource, int, error) {
+	restore := f.page.EnableDomain(&proto.NetworkEnable{})
+	defer restore()
+
+	res, err := proto.NetworkLoadNetworkResource{
+		FrameID: f.page.FrameID,
+		URL:     url,
+		Options: &proto.NetworkLoadNetworkResourceOptions{IncludeCredentials: true},
+	}.Call(rodCall(f.page))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	r := res.Resource
+	status := 0
+	if r.HTTPStatusCode != nil {
+		status = int(*r.HTTPStatusCode)
+	}
+	if !r.Success {
+		if status >= 400 {
+			return nil, status, &ErrResourceStatus{URL: url, Status: status}
+		}
+		return nil, status, &ErrResourceLoad{URL: url, NetError: r.NetErrorName}
+	}
+
+	stream := NewStreamReader(f.page, r.Stream)
+	defer func() { _ = stream.Close() }()
+
+	body, err := ioutil.ReadAll(stream)
+	if err != nil {
+		return nil, status, err
+	}
+
+	contentType := ""
+	for k, v := range r.Headers {
+		if strings.EqualFold(k, "Content-Type") {
+			contentType = v.Str()
+		}
+	}
+
+	return &Resource{URL: url, ContentType: contentType, Body: body}, status, nil
+}
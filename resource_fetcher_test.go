@@ -0,0 +1,89 @@
+package rod_test
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+func TestResourceFetcher(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/page", ".html", `<html><img src="/img.png"></html>`)
+	s.Route("/img.png", ".png", "img")
+
+	s.Mux.HandleFunc("/protected", func(w http.ResponseWriter, r *http.Request) {
+		c, err := r.Cookie("token")
+		if err != nil || c.Value != "ok" || r.Header.Get("User-Agent") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("secret"))
+	})
+
+	page := g.newPage(s.URL("/page")).MustWaitLoad()
+	page.MustSetCookies(&proto.NetworkCookieParam{Name: "token", Value: "ok", URL: s.URL()})
+
+	f := page.ResourceFetcher()
+
+	// from the page
+	img := f.MustFetch(s.URL("/img.png"))
+	g.Eq(string(img.Body), "img")
+	g.Eq(img.ContentType, "image/png")
+
+	// request as the page
+	res := f.MustFetch(s.URL("/protected"))
+	g.Eq(string(res.Body), "secret")
+	g.Eq(res.ContentType, "text/plain")
+
+	list := f.MustFetchAll(s.URL("/protected"), s.URL("/img.png"))
+	g.Eq(string(list[0].Body), "secret")
+	g.Eq(string(list[1].Body), "img")
+
+	page.MustSetCookies()
+	_, err := f.Fetch(s.URL("/protected"))
+	g.Is(err, &rod.ErrResourceStatus{})
+	g.Has(err.Error(), "status code: 403")
+
+	_, err = f.FetchAll([]string{s.URL("/protected")})
+	g.Is(err, &rod.ErrResourceStatus{})
+}
+
+func TestResourceFetcherRetry(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	var count int32
+	s.Mux.HandleFunc("/flaky", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&count, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	page := g.newPage(g.blank()).MustWaitLoad()
+
+	f := page.ResourceFetcher()
+	f.Sleeper = func() utils.Sleeper { return utils.BackoffSleeper(time.Millisecond, time.Millisecond, nil) }
+
+	g.Eq(string(f.MustFetch(s.URL("/flaky")).Body), "ok")
+	g.Eq(atomic.LoadInt32(&count), int32(3))
+
+	atomic.StoreInt32(&count, 0)
+	f.Retries = 1
+	_, err := f.Fetch(s.URL("/flaky"))
+	g.Is(err, &rod.ErrResourceStatus{})
+	g.Eq(atomic.LoadInt32(&count), int32(2))
+
+	f.Retries = 0
+	_, err = f.Fetch("http://not-exists.localhost:1")
+	g.Is(err, &rod.ErrResourceLoad{})
+}
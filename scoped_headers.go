@@ -0,0 +1,66 @@
+package rod
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// SetScopedExtraHeaders is similar to Page.SetExtraHeaders, but the headers are only sent with the requests whose url
+// matches one of the patterns, so that the auth headers won't leak to the third-party requests.
+// The pattern doc is the same as the proto.FetchRequestPattern.URLPattern, an origin without path such as
+// "https://example.com" will match all the urls of the origin.
+// It's implemented via HijackRouter, so it shouldn't be used with other Fetch domain interceptions of the page.
+// SetScopedExtraHeaders 类似于 Page.SetExtraHeaders，但是只会在 url 匹配某个 pattern 的请求中发送这些请求头，
+// 这样认证相关的请求头就不会泄露给第三方的请求。pattern 的文档与 proto.FetchRequestPattern.URLPattern 相同，
+// 没有路径的源，例如 "https://example.com"，会匹配该源的所有 url。
+// 它是通过 HijackRouter 实现的，所以不应该和页面上其他的 Fetch 域拦截同时使用。
+func (p *Page) SetScopedExtraHeaders(patterns []string, dict []string) (func(), error) {
+	headers := http.Header{}
+	for i := 0; i < len(dict); i += 2 {
+		headers.Set(dict[i], dict[i+1])
+	}
+
+	router := p.HijackRequests()
+
+	handler := func(ctx *Hijack) {
+		list := []*proto.FetchHeaderEntry{}
+		for k, v := range ctx.Request.Headers() {
+			if _, has := headers[http.CanonicalHeaderKey(k)]; !has {
+				list = append(list, &proto.FetchHeaderEntry{Name: k, Value: v.String()})
+			}
+		}
+		for k := range headers {
+			list = append(list, &proto.FetchHeaderEntry{Name: k, Value: headers.Get(k)})
+		}
+		ctx.ContinueRequest(&proto.FetchContinueRequest{Headers: list})
+	}
+
+	for _, pattern := range patterns {
+		err := router.Add(scopedHeadersPattern(pattern), "", handler)
+		if err != nil {
+			_ = router.Stop()
+			return nil, err
+		}
+	}
+
+	go router.Run()
+
+	return func() { _ = router.Stop() }, nil
+}
+
+// convert the origin without path to the pattern of all the urls of the origin
+// 将没有路径的源转换为匹配该源所有 url 的 pattern
+func scopedHeadersPattern(pattern string) string {
+	if strings.ContainsAny(pattern, "*?") {
+		return pattern
+	}
+
+	u, err := url.Parse(pattern)
+	if err == nil && u.Scheme != "" && u.Host != "" && (u.Path == "" || u.Path == "/") && u.RawQuery == "" {
+		return u.Scheme + "://" + u.Host + "/*"
+	}
+	return pattern
+}
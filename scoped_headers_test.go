@@ -0,0 +1,47 @@
+package rod_test
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestSetScopedExtraHeaders(t *testing.T) {
+	g := setup(t)
+
+	third := g.Serve()
+	s := g.Serve()
+
+	wg := sync.WaitGroup{}
+	var header, thirdHeader http.Header
+	third.Mux.HandleFunc("/img", func(rw http.ResponseWriter, r *http.Request) {
+		thirdHeader = r.Header
+		wg.Done()
+	})
+	s.Mux.HandleFunc("/", func(rw http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = rw.Write([]byte(`<html><img src="` + third.URL("/img") + `"></html>`))
+		wg.Done()
+	})
+
+	p := g.newPage()
+	cleanup := p.MustSetScopedExtraHeaders([]string{s.URL()}, "Authorization", "token", "b", "2")
+	defer cleanup()
+
+	wg.Add(2)
+	p.MustNavigate(s.URL())
+	wg.Wait()
+
+	g.Eq(header.Get("Authorization"), "token")
+	g.Eq(header.Get("b"), "2")
+	g.Has(header.Get("User-Agent"), "Mozilla")
+	g.Eq(thirdHeader.Get("Authorization"), "")
+
+	g.Panic(func() {
+		g.mc.stubErr(2, proto.FetchEnable{})
+		g.page.MustSetScopedExtraHeaders([]string{"*"}, "a", "b")
+	})
+}
@@ -0,0 +1,333 @@
+package rod
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// SessionSnapshot is the state of the browser that keeps a user logged in, such as the cookies and the storages.
+// Use Browser.SaveSession and Browser.LoadSession to persist it across runs.
+// SessionSnapshot 是浏览器中保持用户登录的状态，例如 Cookie 和各种存储。
+// 使用 Browser.SaveSession 和 Browser.LoadSession 在多次运行之间持久化它。
+type SessionSnapshot struct {
+	Cookies []*proto.NetworkCookie `json:"cookies"`
+	Origins []*OriginSnapshot      `json:"origins"`
+}
+
+// OriginSnapshot is the storages of an origin
+// OriginSnapshot 是一个源的存储
+type OriginSnapshot struct {
+	Origin         string            `json:"origin"`
+	LocalStorage   map[string]string `json:"localStorage,omitempty"`
+	SessionStorage map[string]string `json:"sessionStorage,omitempty"`
+	Caches         []*CacheSnapshot  `json:"caches,omitempty"`
+}
+
+// CacheSnapshot is a cache of the CacheStorage
+// CacheSnapshot 是 CacheStorage 中的一个缓存
+type CacheSnapshot struct {
+	Name    string                `json:"name"`
+	Entries []*CacheEntrySnapshot `json:"entries"`
+}
+
+// CacheEntrySnapshot is a cached response
+// CacheEntrySnapshot 是一个被缓存的响应
+type CacheEntrySnapshot struct {
+	URL        string                      `json:"url"`
+	Status     int                         `json:"status"`
+	StatusText string                      `json:"statusText"`
+	Headers    []*proto.CacheStorageHeader `json:"headers"`
+	Body       []byte                      `json:"body"`
+}
+
+// SessionOptions for Browser.Session
+// Browser.Session 的选项
+type SessionOptions struct {
+	// Cache includes the CacheStorage of the origins
+	// Cache 包含各个源的 CacheStorage
+	Cache bool
+}
+
+// Session captures the cookies of the browser, and the storages of the http and https origins of the frames
+// in the opened pages. The origins that have no opened page can't be captured.
+// Session 捕获浏览器的 Cookie，以及已打开页面中各个 frame 的 http 和 https 源的存储。没有打开页面的源无法被捕获。
+func (b *Browser) Session(opts *SessionOptions) (*SessionSnapshot, error) {
+	if opts == nil {
+		opts = &SessionOptions{}
+	}
+
+	cookies, err := b.GetCookies()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SessionSnapshot{Cookies: cookies, Origins: []*OriginSnapshot{}}
+
+	origins, err := b.originPages(true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, o := range origins {
+		snapshot, err := o.page.originSnapshot(o.origin, opts)
+		if err != nil {
+			return nil, err
+		}
+		s.Origins = append(s.Origins, snapshot)
+	}
+
+	return s, nil
+}
+
+// SaveSession encodes the Browser.Session as JSON to the w
+// SaveSession 将 Browser.Session 编码为 JSON 写入 w
+func (b *Browser) SaveSession(w io.Writer, opts *SessionOptions) error {
+	s, err := b.Session(opts)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(s)
+}
+
+// LoadSession decodes the JSON from the r and restores it via Browser.RestoreSession
+// LoadSession 从 r 中解码 JSON 并通过 Browser.RestoreSession 恢复它
+func (b *Browser) LoadSession(r io.Reader) error {
+	var s SessionSnapshot
+	err := json.NewDecoder(r).Decode(&s)
+	if err != nil {
+		return err
+	}
+	return b.RestoreSession(&s)
+}
+
+// RestoreSession restores the cookies and the storages. If there's no opened page for an origin,
+// a temporary page will be used to restore its storages without sending any request to the origin.
+// The sessionStorage belongs to a page, so it's only restored to the opened pages of the origin.
+// RestoreSession 恢复 Cookie 和各种存储。如果某个源没有已打开的页面，将使用一个临时页面来恢复它的存储，
+// 不会向该源发送任何请求。sessionStorage 属于页面，所以它只会被恢复到该源已打开的页面中。
+func (b *Browser) RestoreSession(s *SessionSnapshot) error {
+	if len(s.Cookies) > 0 {
+		err := b.SetCookies(proto.CookiesToParams(s.Cookies))
+		if err != nil {
+			return err
+		}
+	}
+
+	opened, err := b.originPages(false)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range s.Origins {
+		var page *Page
+		for _, op := range opened {
+			if op.origin == o.Origin {
+				page = op.page
+				break
+			}
+		}
+
+		if page == nil {
+			err = b.restoreOriginInTempPage(o)
+		} else {
+			err = page.restoreOrigin(o, true)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type originPage struct {
+	origin string
+	page   *Page
+}
+
+// list the http and https origins of the opened pages, if withFrames is false only the main frames are used
+// 列出已打开页面的 http 和 https 源，如果 withFrames 为 false 则只使用主 frame
+func (b *Browser) originPages(withFrames bool) ([]*originPage, error) {
+	pages, err := b.Pages()
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*originPage{}
+	has := map[string]bool{}
+
+	var walk func(p *Page, tree *proto.PageFrameTree)
+	walk = func(p *Page, tree *proto.PageFrameTree) {
+		origin := tree.Frame.SecurityOrigin
+		if u, err := url.Parse(origin); err == nil && (u.Scheme == "http" || u.Scheme == "https") && !has[origin] {
+			has[origin] = true
+			list = append(list, &originPage{origin, p})
+		}
+
+		if withFrames {
+			for _, child := range tree.ChildFrames {
+				walk(p, child)
+			}
+		}
+	}
+
+	for _, p := range pages {
+		tree, err := proto.PageGetFrameTree{}.Call(p)
+		if err != nil {
+			return nil, err
+		}
+		walk(p, tree.FrameTree)
+	}
+
+	return list, nil
+}
+
+func (p *Page) originSnapshot(origin string, opts *SessionOptions) (*OriginSnapshot, error) {
+	s := &OriginSnapshot{Origin: origin}
+
+	for _, local := range []bool{true, false} {
+		res, err := proto.DOMStorageGetDOMStorageItems{
+			StorageID: &proto.DOMStorageStorageID{SecurityOrigin: origin, IsLocalStorage: local},
+		}.Call(rodCall(p))
+		if err != nil {
+			return nil, err
+		}
+
+		items := map[string]string{}
+		for _, item := range res.Entries {
+			if len(item) == 2 {
+				items[item[0]] = item[1]
+			}
+		}
+
+		if local {
+			s.LocalStorage = items
+		} else {
+			s.SessionStorage = items
+		}
+	}
+
+	if !opts.Cache {
+		return s, nil
+	}
+
+	caches, err := p.cachesOf(origin)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range caches {
+		cache := &CacheSnapshot{Name: c.CacheName, Entries: []*CacheEntrySnapshot{}}
+
+		entries, err := c.Entries("")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range entries {
+			body, err := c.Response(e)
+			if err != nil {
+				return nil, err
+			}
+
+			cache.Entries = append(cache.Entries, &CacheEntrySnapshot{
+				URL:        e.RequestURL,
+				Status:     e.ResponseStatus,
+				StatusText: e.ResponseStatusText,
+				Headers:    e.ResponseHeaders,
+				Body:       body,
+			})
+		}
+
+		s.Caches = append(s.Caches, cache)
+	}
+
+	return s, nil
+}
+
+// restore the storages of the origin, the main frame of the page must be the origin
+// 恢复源的存储，页面的主 frame 必须是该源
+func (p *Page) restoreOrigin(s *OriginSnapshot, withSessionStorage bool) error {
+	set := func(local bool, items map[string]string) error {
+		for k, v := range items {
+			err := proto.DOMStorageSetDOMStorageItem{
+				StorageID: &proto.DOMStorageStorageID{SecurityOrigin: s.Origin, IsLocalStorage: local},
+				Key:       k,
+				Value:     v,
+			}.Call(rodCall(p))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	err := set(true, s.LocalStorage)
+	if err != nil {
+		return err
+	}
+
+	if withSessionStorage {
+		err = set(false, s.SessionStorage)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, c := range s.Caches {
+		_, err := p.Eval(`async (name, entries) => {
+			const cache = await caches.open(name)
+			for (const e of entries) {
+				// opaque responses can't be constructed
+				if (e.status < 200) continue
+				const body = Uint8Array.from(atob(e.body || ''), c => c.charCodeAt(0))
+				await cache.put(e.url, new Response(body, {
+					status: e.status,
+					statusText: e.statusText,
+					headers: (e.headers || []).map(h => [h.name, h.value]),
+				}))
+			}
+		}`, c.Name, c.Entries)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// open the origin with a blank document served by hijacking, so that no request is sent to the origin
+// 通过劫持提供一个空白文档来打开该源，这样不会向该源发送任何请求
+func (b *Browser) restoreOriginInTempPage(s *OriginSnapshot) error {
+	p, err := b.Page(proto.TargetCreateTarget{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = p.Close() }()
+
+	router := p.HijackRequests()
+	defer func() { _ = router.Stop() }()
+
+	err = router.Add("*", "", func(h *Hijack) {
+		h.Response.SetHeader("Content-Type", "text/html; charset=utf-8")
+		h.Response.SetBody("<html></html>")
+	})
+	if err != nil {
+		return err
+	}
+	go router.Run()
+
+	err = p.Navigate(s.Origin + "/")
+	if err != nil {
+		return err
+	}
+	err = p.WaitLoad()
+	if err != nil {
+		return err
+	}
+
+	return p.restoreOrigin(s, false)
+}
@@ -0,0 +1,249 @@
+package rod
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/ysmood/goob"
+)
+
+// sessionRouter dispatches the events of the connection to the subscribers of each session, so the events of a
+// session are delivered in order and a subscriber never sees the events of other sessions. It's shared by all the
+// clones of a Browser, it also tracks the sessions attached via the connection to detect the misuse of them.
+// sessionRouter 将连接上的事件分发给每个 session 的订阅者，所以一个 session 的事件会按顺序送达，
+// 并且订阅者永远不会看到其他 session 的事件。它被 Browser 的所有克隆共享，
+// 它还会跟踪通过该连接附加的 session，以检测对它们的误用。
+type sessionRouter struct {
+	lock     sync.Mutex
+	ctx      context.Context
+	routes   map[proto.TargetSessionID]*sessionRoute
+	attached map[proto.TargetSessionID]proto.TargetTargetID
+	filters  map[*eventFilter]struct{}
+
+	buffer EventBufferOptions
+	stats  *eventStats
+}
+
+type sessionRoute struct {
+	ctx    context.Context
+	event  *goob.Observable
+	cancel func()
+}
+
+// eventFilter only buffers the events of its methods for the subscriber, empty methods means all the events, so a subscriber that listens to a few
+// event types won't have to buffer and skip the heavy traffic of the other events, such as the Network events.
+// eventFilter 只为订阅者缓冲其方法的事件，所以只监听少数事件类型的订阅者不需要缓冲并跳过其他事件的大量流量，
+// 例如 Network 事件。
+type eventFilter struct {
+	sessionID proto.TargetSessionID // empty means all the sessions
+	methods   map[string]struct{}
+	write     func(*Message)
+}
+
+func newSessionRouter() *sessionRouter {
+	return &sessionRouter{
+		routes:   map[proto.TargetSessionID]*sessionRoute{},
+		attached: map[proto.TargetSessionID]proto.TargetTargetID{},
+		filters:  map[*eventFilter]struct{}{},
+		stats:    newEventStats(),
+	}
+}
+
+// start to route the events of a new connection
+// 开始为一个新的连接路由事件
+func (r *sessionRouter) start(ctx context.Context) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.ctx = ctx
+	r.routes = map[proto.TargetSessionID]*sessionRoute{}
+	r.attached = map[proto.TargetSessionID]proto.TargetTargetID{}
+	r.filters = map[*eventFilter]struct{}{}
+}
+
+// subscribe the events of the session, the channel will be closed when the session is detached
+// 订阅 session 的事件，当 session 被分离时，通道会被关闭
+func (r *sessionRouter) subscribe(ctx context.Context, sessionID proto.TargetSessionID) goob.Events {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.route(sessionID).event.Subscribe(ctx)
+}
+
+// subscribe the events of the methods only, if sessionID is empty the events of all the sessions will be included,
+// if methods is empty all the events will be included. If buffered is true the buffer is limited by the
+// Browser.EventBuffer, or it's unlimited.
+// The channel will be closed when the ctx is done or the session is detached.
+// 只订阅这些方法的事件，如果 sessionID 为空则包含所有 session 的事件，如果 methods 为空则包含所有事件。
+// 如果 buffered 为 true，缓冲区受 Browser.EventBuffer 限制，否则没有限制。当 ctx 结束或者 session 被分离时，通道会被关闭。
+func (r *sessionRouter) subscribeMethods(
+	ctx context.Context, sessionID proto.TargetSessionID, methods []string, buffered bool,
+) goob.Events {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	parent := r.ctx
+	if sessionID != "" {
+		parent = r.route(sessionID).ctx
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	opts := EventBufferOptions{}
+	if buffered {
+		opts = r.buffer
+	}
+	write, events := newEventPipe(ctx, opts, r.stats)
+
+	f := &eventFilter{sessionID: sessionID, methods: map[string]struct{}{}, write: write}
+	for _, m := range methods {
+		f.methods[m] = struct{}{}
+	}
+	r.filters[f] = struct{}{}
+	filters := r.filters
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-parent.Done():
+		}
+
+		r.lock.Lock()
+		delete(filters, f)
+		r.lock.Unlock()
+		cancel()
+	}()
+
+	return events
+}
+
+// the route of the session, it will be created if not exists, the caller must hold the lock
+// session 的路由，如果不存在则创建它，调用者必须持有锁
+func (r *sessionRouter) route(sessionID proto.TargetSessionID) *sessionRoute {
+	route, has := r.routes[sessionID]
+	if !has {
+		routeCtx, cancel := context.WithCancel(r.ctx)
+		route = &sessionRoute{ctx: routeCtx, event: goob.New(routeCtx), cancel: cancel}
+		r.routes[sessionID] = route
+	}
+	return route
+}
+
+func (r *sessionRouter) publish(msg *Message) {
+	// only decode the target events, the other events are routed as they are
+	// 只解码 target 事件，其他事件按原样路由
+	switch msg.Method {
+	case proto.TargetAttachedToTarget{}.ProtoEvent():
+		attached := proto.TargetAttachedToTarget{}
+		msg.Load(&attached)
+		if attached.TargetInfo != nil {
+			r.attach(attached.SessionID, attached.TargetInfo.TargetID)
+		}
+	case proto.TargetDetachedFromTarget{}.ProtoEvent():
+		detached := proto.TargetDetachedFromTarget{}
+		msg.Load(&detached)
+		r.detach(detached.SessionID)
+	case proto.TargetTargetDestroyed{}.ProtoEvent():
+		destroyed := proto.TargetTargetDestroyed{}
+		msg.Load(&destroyed)
+		r.detach(r.sessionsOf(destroyed.TargetID)...)
+	}
+
+	r.lock.Lock()
+	route := r.routes[msg.SessionID]
+	var filters []*eventFilter
+	for f := range r.filters {
+		if f.match(msg) {
+			filters = append(filters, f)
+		}
+	}
+	r.lock.Unlock()
+
+	if route != nil {
+		route.event.Publish(msg)
+	}
+
+	// write outside of the lock, the write may block with the EventBlock policy
+	// 在锁之外写入，在 EventBlock 策略下写入可能会阻塞
+	for _, f := range filters {
+		f.write(msg)
+	}
+}
+
+func (f *eventFilter) match(msg *Message) bool {
+	if f.sessionID != "" && f.sessionID != msg.SessionID {
+		return false
+	}
+	if len(f.methods) == 0 {
+		return true
+	}
+	_, has := f.methods[msg.Method]
+	return has
+}
+
+// track the sessions attached by the calls
+// 跟踪调用附加的 session
+func (r *sessionRouter) track(method string, params interface{}, res []byte) {
+	req, ok := params.(proto.TargetAttachToTarget)
+	if !ok || method != req.ProtoReq() {
+		return
+	}
+
+	var result proto.TargetAttachToTargetResult
+	if json.Unmarshal(res, &result) == nil {
+		r.attach(result.SessionID, req.TargetID)
+	}
+}
+
+func (r *sessionRouter) attach(sessionID proto.TargetSessionID, targetID proto.TargetTargetID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.attached[sessionID] = targetID
+}
+
+func (r *sessionRouter) sessionsOf(targetID proto.TargetTargetID) []proto.TargetSessionID {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	list := []proto.TargetSessionID{}
+	for s, t := range r.attached {
+		if t == targetID {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// detach the sessions and close their routes
+// 分离 session 并关闭它们的路由
+func (r *sessionRouter) detach(sessionIDs ...proto.TargetSessionID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, s := range sessionIDs {
+		delete(r.attached, s)
+		if route, has := r.routes[s]; has {
+			route.cancel()
+			delete(r.routes, s)
+		}
+	}
+}
+
+// owns tells if the session is attached via the connection and not detached yet
+// owns 判断 session 是否通过该连接附加并且还没有被分离
+func (r *sessionRouter) owns(sessionID proto.TargetSessionID) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	_, has := r.attached[sessionID]
+	return has
+}
+
+// StrictSessions makes the calls fail with ErrSessionMisuse if their sessions are not attached via the connection
+// of the browser, or have been detached. Such as a Page of another Browser, or a closed Page shared between
+// goroutines. It's disabled by default.
+// StrictSessions 使得那些 session 不是通过该浏览器的连接附加的、或者已经被分离的调用以 ErrSessionMisuse 失败。
+// 例如另一个 Browser 的 Page，或者在 goroutine 之间共享的已关闭的 Page。默认是禁用的。
+func (b *Browser) StrictSessions(enable bool) *Browser {
+	b.strictSessions = enable
+	return b
+}
@@ -0,0 +1,49 @@
+package rod_test
+
+import (
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestSessionRouter(t *testing.T) {
+	g := setup(t)
+
+	p1 := g.newPage(g.blank())
+	p2 := g.newPage(g.blank())
+
+	// each page only receives the events of its own session, in order
+	// 每个页面只会收到它自己 session 的事件，并且是按顺序的
+	list := []proto.TargetSessionID{}
+	wait := p1.EachEvent(func(e *proto.RuntimeConsoleAPICalled, id proto.TargetSessionID) bool {
+		list = append(list, id)
+		return len(list) == 3
+	})
+	p2.MustEval(`() => console.log("p2")`)
+	p1.MustEval(`() => { console.log(1); console.log(2); console.log(3) }`)
+	wait()
+	g.Eq(list, []proto.TargetSessionID{p1.SessionID, p1.SessionID, p1.SessionID})
+
+	// the context of the page is canceled once the page is closed
+	// 页面关闭后，页面的 context 会被取消
+	p2.MustClose()
+	<-p2.GetContext().Done()
+}
+
+func TestStrictSessions(t *testing.T) {
+	g := setup(t)
+
+	b := g.browser.Context(g.Context()).StrictSessions(true)
+
+	p := b.MustPage(g.blank())
+	g.Eq(p.MustEval(`() => 1`).Int(), 1)
+
+	_, err := b.Call(g.Context(), "not-exists", proto.PageEnable{}.ProtoReq(), nil)
+	g.Is(err, &rod.ErrSessionMisuse{})
+	g.Has(err.Error(), "not-exists")
+
+	p.MustClose()
+	_, err = b.Call(g.Context(), string(p.SessionID), proto.PageEnable{}.ProtoReq(), nil)
+	g.Is(err, &rod.ErrSessionMisuse{})
+}
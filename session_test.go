@@ -0,0 +1,63 @@
+package rod_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestBrowserSession(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/", ".html", `<html></html>`)
+
+	b := g.browser.MustIncognito()
+	defer b.MustClose()
+
+	page := b.MustPage(s.URL()).MustWaitLoad()
+	page.MustEval(`async () => {
+		document.cookie = "a=1"
+		localStorage.setItem("l", "1")
+		sessionStorage.setItem("s", "2")
+		const cache = await caches.open("c")
+		await cache.put("/x", new Response("cached"))
+	}`)
+
+	buf := bytes.NewBuffer(nil)
+	b.MustSaveSession(buf, &rod.SessionOptions{Cache: true})
+
+	snapshot := b.MustSession(nil)
+	g.Len(snapshot.Origins, 1)
+	g.Eq(snapshot.Origins[0].LocalStorage["l"], "1")
+	g.Eq(snapshot.Origins[0].SessionStorage["s"], "2")
+	g.Len(snapshot.Origins[0].Caches, 0)
+
+	// restore to a new incognito browser that has no page of the origin
+	b2 := g.browser.MustIncognito()
+	defer b2.MustClose()
+	b2.MustLoadSession(bytes.NewReader(buf.Bytes()))
+
+	p2 := b2.MustPage(s.URL()).MustWaitLoad()
+	g.Eq(p2.MustEval(`() => document.cookie`).Str(), "a=1")
+	g.Eq(p2.LocalStorage().MustGet("l"), "1")
+	g.Eq(p2.MustEval(`async () => (await (await caches.open("c")).match("/x")).text()`).Str(), "cached")
+
+	// restore to the opened page, the session storage is restored too
+	p2.SessionStorage().MustClear()
+	b2.MustLoadSession(bytes.NewReader(buf.Bytes()))
+	g.Eq(p2.SessionStorage().MustGet("s"), "2")
+
+	g.Err(b2.LoadSession(bytes.NewBufferString("{")))
+
+	g.mc.stubErr(1, proto.StorageGetCookies{})
+	g.Err(b.Session(nil))
+	g.mc.stubErr(1, proto.PageGetFrameTree{})
+	g.Err(b.Session(nil))
+	g.mc.stubErr(1, proto.DOMStorageGetDOMStorageItems{})
+	g.Err(b.Session(nil))
+	g.mc.stubErr(1, proto.CacheStorageRequestCacheNames{})
+	g.Err(b.Session(&rod.SessionOptions{Cache: true}))
+}
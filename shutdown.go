@@ -0,0 +1,84 @@
+package rod
+
+import (
+	"context"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// CloseGracefully closes the browser step by step so that nothing is cut off halfway:
+// it stops the hijack routers, waits for the in-flight downloads of the download managers and the
+// navigations of the pages until the ctx is done, closes the pages one by one after releasing their
+// remote objects, and only then closes the browser. The ctx only limits the waiting, the browser will
+// be closed anyway. The user data dir of a launched browser should still be removed via launcher.Launcher.Cleanup .
+// CloseGracefully 逐步关闭浏览器，以免任何操作被中途打断：
+// 它会停止劫持路由，在 ctx 结束之前等待下载管理器中正在进行的下载和页面的导航，
+// 在释放远程对象后逐个关闭页面，最后才关闭浏览器。ctx 只限制等待的时间，浏览器无论如何都会被关闭。
+// 启动的浏览器的用户数据目录仍然需要通过 launcher.Launcher.Cleanup 删除。
+func (b *Browser) CloseGracefully(ctx context.Context) error {
+	routers := []*HijackRouter{}
+	managers := []*DownloadManager{}
+	b.states.Range(func(_, v interface{}) bool {
+		switch v := v.(type) {
+		case *HijackRouter:
+			routers = append(routers, v)
+		case *DownloadManager:
+			managers = append(managers, v)
+		}
+		return true
+	})
+
+	for _, r := range routers {
+		_ = r.Stop()
+	}
+
+	for _, m := range managers {
+		for _, d := range m.Downloads() {
+			select {
+			case <-ctx.Done():
+			case <-d.done:
+			}
+		}
+		_ = m.Close()
+	}
+
+	pages, err := b.Pages()
+	if err == nil {
+		for _, p := range pages {
+			_ = p.Context(ctx).WaitLoad()
+		}
+
+		for _, p := range pages {
+			p.releaseObjects()
+			_ = p.Close()
+		}
+	}
+
+	return b.Close()
+}
+
+// release the js context objects and the helpers of the page
+// 释放页面的 js 上下文对象和 helper
+func (p *Page) releaseObjects() {
+	if p.helpersLock == nil {
+		return
+	}
+
+	ids := []proto.RuntimeRemoteObjectID{}
+
+	p.helpersLock.Lock()
+	for jsCtxID, helpers := range p.helpers {
+		for _, id := range helpers {
+			ids = append(ids, id)
+		}
+		ids = append(ids, jsCtxID)
+	}
+	p.helpers = nil
+	p.helpersLock.Unlock()
+
+	p.unsetJSCtxID()
+
+	for _, id := range ids {
+		_ = proto.RuntimeReleaseObject{ObjectID: id}.Call(p)
+	}
+}
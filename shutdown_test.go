@@ -0,0 +1,63 @@
+package rod_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestBrowserCloseGracefully(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/d", ".bin", "content")
+	s.Route("/page", ".html", fmt.Sprintf(`<html><a href="%s/d" download>click</a></html>`, s.URL()))
+
+	l := launcher.New()
+	g.Cleanup(l.Kill)
+	b := rod.New().ControlURL(l.MustLaunch()).MustConnect()
+
+	router := b.HijackRequests()
+	router.MustAdd("*", func(ctx *rod.Hijack) {
+		ctx.ContinueRequest(&proto.FetchContinueRequest{})
+	})
+	go router.Run()
+
+	dir := filepath.Join(os.TempDir(), "rod", "downloads")
+	m := b.MustDownloadManager(dir)
+
+	page := b.MustPage(s.URL("/page")).MustWaitLoad()
+	page.MustEval(`() => 1`)
+
+	wait := m.WaitBegin()
+	page.MustElement("a").MustClick()
+	d := wait()
+
+	b.MustCloseGracefully(g.Timeout(5 * time.Second))
+
+	g.E(d.Wait())
+	_, err := os.Stat(d.Path())
+	g.E(err)
+
+	g.Err(b.Version())
+}
+
+func TestBrowserCloseGracefullyTimeout(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New()
+	g.Cleanup(l.Kill)
+	b := rod.New().ControlURL(l.MustLaunch()).MustConnect()
+	b.MustPage(g.blank())
+
+	// the waiting is skipped when the ctx is done, but the browser is still closed
+	b.MustCloseGracefully(g.Timeout(0))
+
+	g.Err(b.Version())
+}
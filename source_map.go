@@ -0,0 +1,91 @@
+package rod
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/sourcemap"
+)
+
+type sourceMapKey struct {
+	url string
+}
+
+// SetSourceMap registers the source map of the script url for the pages of the browser. When the injected js throws,
+// the positions of the ErrEval that are in the script will be rewritten to the original source.
+// The url can be the src of the script, or the "//# sourceURL=" comment of the js.
+// SetSourceMap 为浏览器的页面注册脚本 url 对应的 source map。当注入的 js 抛出异常时，
+// ErrEval 中位于该脚本的位置将被重写为原始源码的位置。url 可以是脚本的 src，或者 js 中的 "//# sourceURL=" 注释。
+func (b *Browser) SetSourceMap(scriptURL string, sourceMap []byte) error {
+	m, err := sourcemap.Parse(sourceMap)
+	if err != nil {
+		return err
+	}
+	b.states.Store(sourceMapKey{scriptURL}, m)
+	return nil
+}
+
+// RemoveSourceMap removes the source map of the script url
+// RemoveSourceMap 删除脚本 url 对应的 source map
+func (b *Browser) RemoveSourceMap(scriptURL string) {
+	b.states.Delete(sourceMapKey{scriptURL})
+}
+
+func (b *Browser) sourceMap(scriptURL string) *sourcemap.Map {
+	if m, has := b.states.Load(sourceMapKey{scriptURL}); has {
+		return m.(*sourcemap.Map)
+	}
+	return nil
+}
+
+// such as "    at fn (https://a.com/a.js:1:2)" or "    at https://a.com/a.js:1:2"
+// 例如 "    at fn (https://a.com/a.js:1:2)" 或 "    at https://a.com/a.js:1:2"
+var regStackFrame = regexp.MustCompile(`(?m)^(\s*at (?:.*\()?)([^\s()]+):(\d+):(\d+)`)
+
+// rewrite the positions of the exception with the registered source maps
+// 使用已注册的 source map 重写异常中的位置
+func (b *Browser) mapException(d *proto.RuntimeExceptionDetails) {
+	if pos, ok := b.lookupSource(d.URL, d.LineNumber, d.ColumnNumber); ok {
+		d.URL, d.LineNumber, d.ColumnNumber = pos.Source, pos.Line, pos.Column
+	}
+
+	for st := d.StackTrace; st != nil; st = st.Parent {
+		for _, f := range st.CallFrames {
+			if pos, ok := b.lookupSource(f.URL, f.LineNumber, f.ColumnNumber); ok {
+				f.URL, f.LineNumber, f.ColumnNumber = pos.Source, pos.Line, pos.Column
+			}
+		}
+	}
+
+	if d.Exception == nil {
+		return
+	}
+
+	// the positions in the description are one-based
+	// 描述中的位置是从 1 开始的
+	d.Exception.Description = regStackFrame.ReplaceAllStringFunc(d.Exception.Description, func(s string) string {
+		m := regStackFrame.FindStringSubmatch(s)
+		line, _ := strconv.Atoi(m[3])
+		col, _ := strconv.Atoi(m[4])
+
+		pos, ok := b.lookupSource(m[2], line-1, col-1)
+		if !ok {
+			return s
+		}
+		return fmt.Sprintf("%s%s:%d:%d", m[1], pos.Source, pos.Line+1, pos.Column+1)
+	})
+}
+
+func (b *Browser) lookupSource(scriptURL string, line, col int) (*sourcemap.Position, bool) {
+	if scriptURL == "" {
+		return nil, false
+	}
+
+	m := b.sourceMap(scriptURL)
+	if m == nil {
+		return nil, false
+	}
+	return m.Lookup(line, col)
+}
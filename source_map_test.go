@@ -0,0 +1,43 @@
+package rod_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-rod/rod"
+)
+
+func TestSourceMap(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.blank()).MustWaitLoad()
+
+	page.MustAddScript(&rod.ScriptTagOptions{
+		Content: "window.boom = function () { throw new Error('boom') }\n//# sourceURL=helper.js",
+	})
+
+	// map the whole first line to the line 10 column 5 of the helper.ts
+	g.browser.MustSetSourceMap("helper.js", []byte(`{
+		"version": 3,
+		"sources": ["helper.ts"],
+		"names": [],
+		"mappings": "AASI"
+	}`))
+	defer g.browser.RemoveSourceMap("helper.js")
+
+	_, err := page.Eval(`() => boom()`)
+	g.Has(err.Error(), "at window.boom (helper.ts:10:5)")
+
+	var e *rod.ErrEval
+	g.True(errors.As(err, &e))
+	if e.StackTrace != nil {
+		g.Eq(e.StackTrace.CallFrames[0].URL, "helper.ts")
+		g.Eq(e.StackTrace.CallFrames[0].LineNumber, 9)
+	}
+
+	g.browser.RemoveSourceMap("helper.js")
+	_, err = page.Eval(`() => boom()`)
+	g.Has(err.Error(), "helper.js:1:")
+
+	g.Err(g.browser.SetSourceMap("x.js", []byte(`{}`)))
+}
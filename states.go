@@ -123,4 +123,11 @@ func (p *Page) DisableDomain(method proto.Request) (restore func()) {
 
 func (p *Page) cleanupStates() {
 	p.browser.RemoveState(p.TargetID)
+	p.browser.RemoveState(initScriptsKey{p.TargetID})
+	p.removeDownloadDir()
+	p.browser.RemoveState(debugRecorderKey{p.TargetID})
+	p.browser.RemoveState(crashKey{p.TargetID})
+	p.removeQueryCaches()
+	p.browser.RemoveState(reporterKey{p.TargetID})
+	p.browser.RemoveState(screencastViewersKey{p.TargetID})
 }
@@ -0,0 +1,38 @@
+package rod
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// StorageUsage returns the storage usage and quota of the page's current origin
+// StorageUsage 返回页面当前源的存储使用量和配额
+func (p *Page) StorageUsage() (*proto.StorageGetUsageAndQuotaResult, error) {
+	origin, err := p.origin()
+	if err != nil {
+		return nil, err
+	}
+	return proto.StorageGetUsageAndQuota{Origin: origin}.Call(rodCall(p))
+}
+
+// SetStorageQuota overrides the storage quota of the page's current origin to the size in bytes,
+// it's useful to trigger the quota-exceeded code paths deterministically in tests.
+// Use Page.ResetStorageQuota to remove the override.
+// SetStorageQuota 将页面当前源的存储配额覆盖为 size 字节，用于在测试中稳定地触发超出配额的代码路径。
+// 使用 Page.ResetStorageQuota 移除覆盖。
+func (p *Page) SetStorageQuota(size float64) error {
+	origin, err := p.origin()
+	if err != nil {
+		return err
+	}
+	return proto.StorageOverrideQuotaForOrigin{Origin: origin, QuotaSize: &size}.Call(rodCall(p))
+}
+
+// ResetStorageQuota removes the override of Page.SetStorageQuota
+// ResetStorageQuota 移除 Page.SetStorageQuota 设置的覆盖
+func (p *Page) ResetStorageQuota() error {
+	origin, err := p.origin()
+	if err != nil {
+		return err
+	}
+	return proto.StorageOverrideQuotaForOrigin{Origin: origin}.Call(rodCall(p))
+}
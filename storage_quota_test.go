@@ -0,0 +1,53 @@
+package rod_test
+
+import (
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestStorageQuota(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/", ".html", `<html></html>`)
+
+	page := g.newPage(s.URL()).MustWaitLoad()
+
+	page.MustSetStorageQuota(1024)
+	defer page.MustResetStorageQuota()
+
+	usage := page.MustStorageUsage()
+	g.True(usage.OverrideActive)
+	g.Eq(usage.Quota, 1024.0)
+
+	_, err := page.Eval(`async () => {
+		const cache = await caches.open("quota")
+		try {
+			await cache.put("/big", new Response("x".repeat(1024 * 1024)))
+		} finally {
+			await caches.delete("quota")
+		}
+	}`)
+	g.Has(err.Error(), "QuotaExceededError")
+
+	page.MustResetStorageQuota()
+	g.False(page.MustStorageUsage().OverrideActive)
+
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		page.MustStorageUsage()
+	})
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.StorageGetUsageAndQuota{})
+		page.MustStorageUsage()
+	})
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		page.MustSetStorageQuota(1)
+	})
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		page.MustResetStorageQuota()
+	})
+}
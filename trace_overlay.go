@@ -0,0 +1,250 @@
+package rod
+
+import (
+	"fmt"
+	"html"
+	"time"
+
+	"github.com/go-rod/rod/lib/js"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// TraceOverlayPosition of the message of the page trace overlay
+// 页面 trace 叠加层中消息的位置
+type TraceOverlayPosition string
+
+const (
+	// TraceOverlayTopLeft is the default position
+	// TraceOverlayTopLeft 是默认位置
+	TraceOverlayTopLeft TraceOverlayPosition = "top-left"
+
+	// TraceOverlayTopRight position
+	// TraceOverlayTopRight 位置
+	TraceOverlayTopRight TraceOverlayPosition = "top-right"
+
+	// TraceOverlayBottomLeft position
+	// TraceOverlayBottomLeft 位置
+	TraceOverlayBottomLeft TraceOverlayPosition = "bottom-left"
+
+	// TraceOverlayBottomRight position
+	// TraceOverlayBottomRight 位置
+	TraceOverlayBottomRight TraceOverlayPosition = "bottom-right"
+)
+
+// TraceOverlayOptions for Browser.TraceOverlay, the zero values mean the defaults
+// Browser.TraceOverlay 的选项，零值表示使用默认值
+type TraceOverlayOptions struct {
+	// Types of the traces to draw, empty means all. The traces of the other types are still logged.
+	// Types 是需要绘制的 trace 的类型，为空表示全部。其他类型的 trace 仍然会被记录到日志。
+	Types []TraceType
+
+	// BorderColor of the box, such as "red", "#00ff00"
+	// BorderColor 是边框的颜色，例如 "red"、"#00ff00"
+	BorderColor string
+
+	// TextColor of the message
+	// TextColor 是消息的文字颜色
+	TextColor string
+
+	// Background of the message
+	// Background 是消息的背景
+	Background string
+
+	// FontSize of the message in pixels
+	// FontSize 是消息的字体大小，单位为像素
+	FontSize int
+
+	// Position of the message of the page traces, it's useful when the message covers the element under test
+	// Position 是页面 trace 消息的位置，当消息遮挡住被测元素时很有用
+	Position TraceOverlayPosition
+
+	// AutoHide removes the overlay after the delay even if the action isn't done, 0 means remove it when it's done
+	// AutoHide 在延迟之后移除叠加层，即使操作还没有完成，0 表示在操作完成时移除
+	AutoHide time.Duration
+
+	// HighlightQueries draws a box around the elements found by each query, including the Elements queries,
+	// it's removed after the AutoHide, or 1 second if AutoHide is 0
+	// HighlightQueries 为每次查询找到的元素绘制一个框，包括 Elements 查询，它会在 AutoHide 之后被移除，如果 AutoHide 为 0 则在 1 秒后移除
+	HighlightQueries bool
+}
+
+// the style applied by jsOverlayStyle
+// 由 jsOverlayStyle 应用的样式
+type overlayStyle struct {
+	Border     string               `json:"border,omitempty"`
+	Color      string               `json:"color,omitempty"`
+	Background string               `json:"background,omitempty"`
+	FontSize   int                  `json:"fontSize,omitempty"`
+	Position   TraceOverlayPosition `json:"position,omitempty"`
+
+	// Hide delay in milliseconds
+	// Hide 是隐藏的延迟，单位为毫秒
+	Hide int64 `json:"hide,omitempty"`
+}
+
+// the js to apply the overlayStyle to an overlay drawn by js.Overlay, it's not in the lib/js/helper.js because only
+// the trace overlay uses it
+// 将 overlayStyle 应用到由 js.Overlay 绘制的叠加层上的 js，它不在 lib/js/helper.js 中，因为只有 trace 叠加层使用它
+var jsOverlayStyle = &js.Function{
+	Name: "overlayStyle",
+	Definition: `function(id, style) {
+		const div = document.getElementById(id)
+		if (!div) return
+
+		if (style.hide) {
+			setTimeout(() => functions.removeOverlay(id), style.hide)
+		}
+		if (style.border) div.style.borderColor = style.border
+
+		const msg = div.firstElementChild
+		if (!msg) return
+
+		if (style.color) msg.style.color = style.color
+		if (style.background) msg.style.background = style.background
+		if (style.fontSize) msg.style.fontSize = style.fontSize + 'px'
+
+		// such as "top-right", "bottom-left"
+		const position = style.position || ''
+		const rect = div.getBoundingClientRect()
+
+		if (position.includes('bottom')) {
+			div.style.top = 'auto'
+			div.style.bottom = '0'
+			msg.style.top = 'auto'
+			msg.style.bottom = '0'
+		} else {
+			msg.style.top = rect.height + 'px'
+			if (window.innerHeight < msg.offsetHeight + rect.top + rect.height) {
+				msg.style.top = -msg.offsetHeight - 2 + 'px'
+			}
+		}
+
+		if (position.includes('right')) {
+			div.style.left = 'auto'
+			div.style.right = '0'
+			msg.style.left = 'auto'
+			msg.style.right = '0'
+		} else {
+			msg.style.left = ''
+			if (window.innerWidth < msg.offsetWidth + rect.left) {
+				msg.style.left = window.innerWidth - msg.offsetWidth - rect.left + 'px'
+			}
+		}
+	}`,
+	Dependencies: []*js.Function{js.RemoveOverlay},
+}
+
+// js.Overlay with the overlayStyle
+// 带有 overlayStyle 的 js.Overlay
+var jsOverlay = &js.Function{
+	Name: "styledOverlay",
+	Definition: `async function(id, left, top, width, height, msg, style) {
+		await functions.overlay(id, left, top, width, height, msg)
+		functions.overlayStyle(id, style)
+	}`,
+	Dependencies: []*js.Function{js.Overlay, jsOverlayStyle},
+}
+
+// js.ElementOverlay with the overlayStyle
+// 带有 overlayStyle 的 js.ElementOverlay
+var jsElementOverlay = &js.Function{
+	Name: "styledElementOverlay",
+	Definition: `async function(id, msg, style) {
+		await functions.elementOverlay.call(this, id, msg)
+		functions.overlayStyle(id, style)
+	}`,
+	Dependencies: []*js.Function{js.ElementOverlay, jsOverlayStyle},
+}
+
+// TraceOverlay sets how the visual trace enabled by Browser.Trace is drawn, nil to use the defaults
+// TraceOverlay 设置由 Browser.Trace 启用的可视化 trace 如何绘制，设置为 nil 则使用默认值
+func (b *Browser) TraceOverlay(opts *TraceOverlayOptions) *Browser {
+	b.traceOverlay = opts
+	return b
+}
+
+// if the trace of the type should be drawn
+// 该类型的 trace 是否需要被绘制
+func (b *Browser) drawTrace(typ TraceType) bool {
+	if b.traceOverlay == nil || len(b.traceOverlay.Types) == 0 {
+		return true
+	}
+	for _, t := range b.traceOverlay.Types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Browser) overlayStyle() *overlayStyle {
+	o := b.traceOverlay
+	if o == nil {
+		return &overlayStyle{}
+	}
+	return &overlayStyle{
+		Border:     o.BorderColor,
+		Color:      o.TextColor,
+		Background: o.Background,
+		FontSize:   o.FontSize,
+		Hide:       o.AutoHide.Milliseconds(),
+	}
+}
+
+// the overlay of the page traces, it uses the Position of the options
+// 页面 trace 的叠加层，它使用选项中的 Position
+func (p *Page) traceOverlay(msg string) (remove func()) {
+	style := p.browser.overlayStyle()
+	if p.browser.traceOverlay != nil {
+		style.Position = p.browser.traceOverlay.Position
+	}
+	return p.overlay(0, 0, 500, 0, msg, style)
+}
+
+func (p *Page) overlay(left, top, width, height float64, msg string, style *overlayStyle) (remove func()) {
+	id := utils.RandString(8)
+
+	_, _ = p.root.Evaluate(evalHelper(jsOverlay,
+		id,
+		left,
+		top,
+		width,
+		height,
+		msg,
+		style,
+	).ByPromise())
+
+	return func() {
+		_, _ = p.root.Evaluate(evalHelper(js.RemoveOverlay, id))
+	}
+}
+
+func (el *Element) overlay(msg string, style *overlayStyle) (remove func()) {
+	id := utils.RandString(8)
+
+	_, _ = el.Evaluate(evalHelper(jsElementOverlay,
+		id,
+		msg,
+		style,
+	).ByPromise())
+
+	return func() {
+		_, _ = el.Evaluate(evalHelper(js.RemoveOverlay, id))
+	}
+}
+
+// highlight the element found by the query if TraceOverlayOptions.HighlightQueries is enabled
+// 如果启用了 TraceOverlayOptions.HighlightQueries，高亮查询找到的元素
+func (p *Page) tryHighlightQuery(el *Element, opts *EvalOptions) {
+	o := p.browser.traceOverlay
+	if !p.browser.trace || o == nil || !o.HighlightQueries || !p.browser.drawTrace(TraceTypeQuery) {
+		return
+	}
+
+	style := p.browser.overlayStyle()
+	if style.Hide == 0 {
+		style.Hide = time.Second.Milliseconds()
+	}
+
+	el.overlay(fmt.Sprintf("<code>%s</code>", html.EscapeString(opts.String())), style)
+}
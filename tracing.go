@@ -0,0 +1,109 @@
+package rod
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// DefaultTraceCategories are the categories of StartTrace when no categories are specified,
+// they are the same as the performance panel of the devtools
+// DefaultTraceCategories 是未指定分类时 StartTrace 使用的分类，它们与 devtools 的 performance 面板相同
+var DefaultTraceCategories = []string{
+	"-*",
+	"devtools.timeline",
+	"v8.execute",
+	"disabled-by-default-devtools.timeline",
+	"disabled-by-default-devtools.timeline.frame",
+	"toplevel",
+	"blink.console",
+	"blink.user_timing",
+	"latencyInfo",
+	"disabled-by-default-devtools.timeline.stack",
+	"disabled-by-default-v8.cpu_profiler",
+}
+
+// StartTrace starts to record the chrome trace of the browser, a category with the "-" prefix will be excluded,
+// such as "-*". Use Browser.StopTrace to save the result.
+// StartTrace 开始记录浏览器的 chrome trace，带 "-" 前缀的分类将被排除，例如 "-*"。使用 Browser.StopTrace 保存结果。
+func (b *Browser) StartTrace(categories ...string) error {
+	return startTrace(b, categories)
+}
+
+// StopTrace stops the trace and streams the result to the file, the file can be loaded by chrome://tracing
+// or the performance panel of the devtools
+// StopTrace 停止 trace 并将结果以流的方式写入文件，该文件可以被 chrome://tracing 或者 devtools 的 performance 面板加载
+func (b *Browser) StopTrace(file string) error {
+	return stopTrace(b, b.WaitEvent, file)
+}
+
+// StartTrace is similar to Browser.StartTrace, but only for the page
+// StartTrace 类似于 Browser.StartTrace，但是只针对该页面
+func (p *Page) StartTrace(categories ...string) error {
+	return startTrace(p, categories)
+}
+
+// StopTrace is similar to Browser.StopTrace
+// StopTrace 类似于 Browser.StopTrace
+func (p *Page) StopTrace(file string) error {
+	return stopTrace(p, p.WaitEvent, file)
+}
+
+func startTrace(c proto.Client, categories []string) error {
+	if len(categories) == 0 {
+		categories = DefaultTraceCategories
+	}
+
+	conf := &proto.TracingTraceConfig{IncludedCategories: []string{}, ExcludedCategories: []string{}}
+	for _, cat := range categories {
+		if strings.HasPrefix(cat, "-") {
+			conf.ExcludedCategories = append(conf.ExcludedCategories, strings.TrimPrefix(cat, "-"))
+		} else {
+			conf.IncludedCategories = append(conf.IncludedCategories, cat)
+		}
+	}
+
+	return proto.TracingStart{
+		TransferMode: proto.TracingStartTransferModeReturnAsStream,
+		StreamFormat: proto.TracingStreamFormatJSON,
+		TraceConfig:  conf,
+	}.Call(c)
+}
+
+func stopTrace(c proto.Client, waitEvent func(proto.Event) func(), file string) error {
+	e := &proto.TracingTracingComplete{}
+	wait := waitEvent(e)
+
+	err := proto.TracingEnd{}.Call(c)
+	if err != nil {
+		return err
+	}
+	wait()
+
+	if e.Stream == "" {
+		return errors.New("the trace has no stream")
+	}
+
+	r := NewStreamReader(c, e.Stream)
+	defer func() { _ = r.Close() }()
+
+	err = os.MkdirAll(filepath.Dir(file), 0755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.CopyTo(f)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,54 @@
+package rod_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+	"github.com/ysmood/gson"
+)
+
+func TestPageTrace(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank()).MustWaitLoad()
+
+	file := filepath.Join(os.TempDir(), "rod", "trace", g.RandStr(8)+".json")
+
+	p.MustStartTrace()
+	p.MustEval(`() => console.timeStamp("rod-trace")`)
+	p.MustStopTrace(file)
+
+	data, err := utils.ReadString(file)
+	g.E(err)
+	g.Gt(len(gson.NewFrom(data).Get("traceEvents").Arr()), 0)
+
+	g.Err(p.StopTrace(file))
+}
+
+func TestBrowserTrace(t *testing.T) {
+	g := setup(t)
+
+	file := filepath.Join(os.TempDir(), "rod", "trace", g.RandStr(8)+".json")
+
+	g.browser.MustStartTrace("-*", "toplevel")
+	g.browser.MustStopTrace(file)
+
+	data, err := utils.ReadString(file)
+	g.E(err)
+	g.True(gson.NewFrom(data).Has("traceEvents"))
+}
+
+func TestTraceErr(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank())
+
+	g.mc.stubErr(1, proto.TracingStart{})
+	g.Err(p.StartTrace())
+
+	g.mc.stubErr(1, proto.TracingEnd{})
+	g.Err(p.StopTrace(""))
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -33,12 +34,15 @@ type Message struct {
 	SessionID proto.TargetSessionID
 	Method    string
 
-	lock  *sync.Mutex
-	data  json.RawMessage
-	event reflect.Value
+	lock   *sync.Mutex
+	data   json.RawMessage
+	loaded bool
+	event  proto.Event
 }
 
 // 将数据加载到 e 中，如果 e 符合事件类型，则返回 true。
+// 第一次 Load 会直接解码到 e 中，因为大多数事件只有一个订阅者。之后事件只会通过 proto.NewEvent 解码一次，
+// 再之后的 Load 会复制解码后的值。
 func (msg *Message) Load(e proto.Event) bool {
 	if msg.Method != e.ProtoEvent() {
 		return false
@@ -48,18 +52,33 @@ func (msg *Message) Load(e proto.Event) bool {
 	if eVal.Kind() != reflect.Ptr {
 		return true
 	}
-	eVal = reflect.Indirect(eVal)
+	eVal = eVal.Elem()
 
 	msg.lock.Lock()
 	defer msg.lock.Unlock()
-	if msg.data == nil {
-		eVal.Set(msg.event)
+
+	if !msg.loaded {
+		msg.loaded = true
+		utils.E(json.Unmarshal(msg.data, e))
 		return true
 	}
 
+	if msg.event == nil {
+		msg.event = proto.NewEvent(msg.Method)
+		if msg.event != nil {
+			utils.E(json.Unmarshal(msg.data, msg.event))
+		}
+	}
+
+	if msg.event != nil {
+		if cached := reflect.ValueOf(msg.event).Elem(); cached.Type() == eVal.Type() {
+			eVal.Set(cached)
+			return true
+		}
+	}
+
+	// 事件是未知的或者是另一种类型，例如通过 proto.Register 注册的类型
 	utils.E(json.Unmarshal(msg.data, e))
-	msg.event = eVal
-	msg.data = nil
 	return true
 }
 
@@ -78,6 +97,7 @@ var DefaultSleeper = func() utils.Sleeper {
 // 使用通道来限制并发性是一种常见的做法，对于rod来说并不特殊。
 // 这个helper程序更像是一个使用Go Channel的例子。
 // 参考: https://golang.org/doc/effective_go#channels
+// 如果需要自动回收和替换页面，请使用 ManagedPagePool。
 type PagePool chan *Page
 
 // NewPagePool实例
@@ -152,15 +172,23 @@ func (bp BrowserPool) Cleanup(iteratee func(*Browser)) {
 	}
 }
 
-var _ io.ReadCloser = &StreamReader{}
+var _ interface {
+	io.ReadSeeker
+	io.Closer
+} = &StreamReader{}
 
 // 浏览器数据流的StreamReader
 type StreamReader struct {
+	// Offset 如果不为 nil，下一次读取会先移动到该位置，读取后它会被重置为 nil
 	Offset *int
 
 	c      proto.Client
+	ctx    context.Context
 	handle proto.IOStreamHandle
 	buf    *bytes.Buffer
+	pos    int64
+	size   int64
+	eof    bool
 }
 
 // NewStreamReader实例
@@ -169,33 +197,112 @@ func NewStreamReader(c proto.Client, h proto.IOStreamHandle) *StreamReader {
 		c:      c,
 		handle: h,
 		buf:    &bytes.Buffer{},
+		size:   -1,
 	}
 }
 
+// WithContext 设置读取使用的 ctx，ctx 被取消时正在进行的读取会被中止
+func (sr *StreamReader) WithContext(ctx context.Context) *StreamReader {
+	sr.ctx = ctx
+	return sr
+}
+
+// SetSize 设置流的总大小，当创建流的一方知道它的大小时使用，这样 Size 和 Seek 的 io.SeekEnd 可以立即使用
+func (sr *StreamReader) SetSize(size int64) *StreamReader {
+	sr.size = size
+	return sr
+}
+
+// Size 返回流的总大小，如果大小未知，known 为 false。读取到流的末尾后大小总是已知的
+func (sr *StreamReader) Size() (size int64, known bool) {
+	return sr.size, sr.size >= 0
+}
+
 func (sr *StreamReader) Read(p []byte) (n int, err error) {
+	for sr.buf.Len() == 0 && !sr.eof {
+		err = sr.fetch()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n, err = sr.buf.Read(p)
+	sr.pos += int64(n)
+	return n, err
+}
+
+// 从浏览器读取下一块数据到 buf 中
+func (sr *StreamReader) fetch() error {
+	c := sr.c
+	if sr.ctx != nil {
+		if err := sr.ctx.Err(); err != nil {
+			return err
+		}
+		c = &streamClient{sr.c, sr.ctx}
+	}
+
 	res, err := proto.IORead{
 		Handle: sr.handle,
 		Offset: sr.Offset,
-	}.Call(sr.c)
+	}.Call(c)
 	if err != nil {
-		return 0, err
+		return err
 	}
+	sr.Offset = nil
 
-	if !res.EOF {
-		var bin []byte
-		if res.Base64Encoded {
-			bin, err = base64.StdEncoding.DecodeString(res.Data)
-			if err != nil {
-				return 0, err
-			}
-		} else {
-			bin = []byte(res.Data)
+	var bin []byte
+	if res.Base64Encoded {
+		bin, err = base64.StdEncoding.DecodeString(res.Data)
+		if err != nil {
+			return err
 		}
+	} else {
+		bin = []byte(res.Data)
+	}
 
-		_, _ = sr.buf.Write(bin)
+	_, _ = sr.buf.Write(bin)
+
+	if res.EOF {
+		sr.eof = true
+		sr.size = sr.pos + int64(sr.buf.Len())
+	}
+
+	return nil
+}
+
+// Seek 实现了 io.Seeker，需要浏览器中的流支持偏移量，例如 PDF 和追踪的流。
+// 只有在大小已知时才能使用 io.SeekEnd
+func (sr *StreamReader) Seek(offset int64, whence int) (int64, error) {
+	if whence == io.SeekCurrent && offset == 0 {
+		return sr.pos, nil
 	}
 
-	return sr.buf.Read(p)
+	switch whence {
+	case io.SeekCurrent:
+		offset += sr.pos
+	case io.SeekEnd:
+		if sr.size < 0 {
+			return 0, errors.New("can't seek from the end, the size of the stream is unknown")
+		}
+		offset += sr.size
+	}
+
+	if offset < 0 {
+		return 0, fmt.Errorf("invalid seek position: %d", offset)
+	}
+
+	o := int(offset)
+	sr.Offset = &o
+	sr.pos = offset
+	sr.buf.Reset()
+	sr.eof = false
+
+	return offset, nil
+}
+
+// CopyTo 将流剩余的数据复制到 w 中，返回复制的字节数
+func (sr *StreamReader) CopyTo(w io.Writer) (int64, error) {
+	return io.Copy(w, sr)
 }
 
 // 关闭流，丢弃任何临时性的备份存储。
@@ -203,6 +310,23 @@ func (sr *StreamReader) Close() error {
 	return proto.IOClose{Handle: sr.handle}.Call(sr.c)
 }
 
+// 使用指定 ctx 发送请求的客户端
+type streamClient struct {
+	proto.Client
+	ctx context.Context
+}
+
+func (c *streamClient) GetContext() context.Context {
+	return c.ctx
+}
+
+func (c *streamClient) GetSessionID() proto.TargetSessionID {
+	if s, ok := c.Client.(proto.Sessionable); ok {
+		return s.GetSessionID()
+	}
+	return ""
+}
+
 // 试着用recover来尝试fn，将panic作为rod.ErrTry返回。
 func Try(fn func()) (err error) {
 	defer func() {
@@ -271,6 +395,11 @@ func httHTML(w http.ResponseWriter, body string) {
 	_, _ = w.Write([]byte(body))
 }
 
+func httJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Add("Content-Type", "application/json")
+	utils.E(w.Write(utils.MustToJSONBytes(value)))
+}
+
 func mustToJSONForDev(value interface{}) string {
 	buf := new(bytes.Buffer)
 	enc := json.NewEncoder(buf)
@@ -0,0 +1,201 @@
+package rod
+
+import (
+	"strings"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// WebStorage is the localStorage or sessionStorage of the page's origin, it uses the DOMStorage domain,
+// so it works even if the js of the page is paused or overrides the window.localStorage .
+// WebStorage 是页面的源对应的 localStorage 或 sessionStorage，它使用 DOMStorage 域，
+// 所以即使页面的 js 被暂停或者覆盖了 window.localStorage 也能工作。
+type WebStorage struct {
+	page  *Page
+	local bool
+}
+
+// WebStorageChangeType enum
+// WebStorage 变更类型的枚举
+type WebStorageChangeType string
+
+const (
+	// WebStorageAdded type
+	WebStorageAdded WebStorageChangeType = "added"
+
+	// WebStorageUpdated type
+	WebStorageUpdated WebStorageChangeType = "updated"
+
+	// WebStorageRemoved type
+	WebStorageRemoved WebStorageChangeType = "removed"
+
+	// WebStorageCleared type, the Key will be empty
+	// WebStorageCleared 类型，此时 Key 为空
+	WebStorageCleared WebStorageChangeType = "cleared"
+)
+
+// WebStorageChange is a change of the storage
+// WebStorageChange 是存储的一次变更
+type WebStorageChange struct {
+	Type     WebStorageChangeType
+	Key      string
+	OldValue string
+	NewValue string
+}
+
+// LocalStorage of the page's current origin
+// 页面当前源的 localStorage
+func (p *Page) LocalStorage() *WebStorage {
+	return &WebStorage{page: p, local: true}
+}
+
+// SessionStorage of the page's current origin
+// 页面当前源的 sessionStorage
+func (p *Page) SessionStorage() *WebStorage {
+	return &WebStorage{page: p, local: false}
+}
+
+// the origin of the page's current document
+// 页面当前文档的源
+func (p *Page) origin() (string, error) {
+	res, err := p.Eval(`() => location.origin`)
+	if err != nil {
+		return "", err
+	}
+	return res.Value.Str(), nil
+}
+
+func (s *WebStorage) id() (*proto.DOMStorageStorageID, error) {
+	origin, err := s.page.origin()
+	if err != nil {
+		return nil, err
+	}
+	return &proto.DOMStorageStorageID{SecurityOrigin: origin, IsLocalStorage: s.local}, nil
+}
+
+func (s *WebStorage) entries() ([]proto.DOMStorageItem, error) {
+	id, err := s.id()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := proto.DOMStorageGetDOMStorageItems{StorageID: id}.Call(rodCall(s.page))
+	if err != nil {
+		return nil, err
+	}
+	return res.Entries, nil
+}
+
+// Items returns all the key-value pairs of the storage
+// Items 返回存储中所有的键值对
+func (s *WebStorage) Items() (map[string]string, error) {
+	entries, err := s.entries()
+	if err != nil {
+		return nil, err
+	}
+
+	items := map[string]string{}
+	for _, item := range entries {
+		if len(item) == 2 {
+			items[item[0]] = item[1]
+		}
+	}
+	return items, nil
+}
+
+// Get the value of the key, has will be false if the key doesn't exist
+// 获取 key 对应的值，如果 key 不存在 has 为 false
+func (s *WebStorage) Get(key string) (value string, has bool, err error) {
+	items, err := s.Items()
+	if err != nil {
+		return "", false, err
+	}
+	value, has = items[key]
+	return
+}
+
+// Keys of the storage
+// 存储中所有的 key
+func (s *WebStorage) Keys() ([]string, error) {
+	entries, err := s.entries()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	for _, item := range entries {
+		if len(item) > 0 {
+			keys = append(keys, item[0])
+		}
+	}
+	return keys, nil
+}
+
+// Set the value of the key
+// 设置 key 的值
+func (s *WebStorage) Set(key, value string) error {
+	id, err := s.id()
+	if err != nil {
+		return err
+	}
+	return proto.DOMStorageSetDOMStorageItem{StorageID: id, Key: key, Value: value}.Call(rodCall(s.page))
+}
+
+// Remove the key
+// 删除 key
+func (s *WebStorage) Remove(key string) error {
+	id, err := s.id()
+	if err != nil {
+		return err
+	}
+	return proto.DOMStorageRemoveDOMStorageItem{StorageID: id, Key: key}.Call(rodCall(s.page))
+}
+
+// Clear all the keys of the storage
+// 清空存储中所有的 key
+func (s *WebStorage) Clear() error {
+	id, err := s.id()
+	if err != nil {
+		return err
+	}
+	return proto.DOMStorageClear{StorageID: id}.Call(rodCall(s.page))
+}
+
+// OnChange calls fn when the storage of the current origin changes, no matter the change is made by the page or rod.
+// Call the returned cancel to unsubscribe.
+// OnChange 当前源的存储发生变更时调用 fn，无论变更是由页面还是 rod 发起的。调用返回的 cancel 来取消订阅。
+func (s *WebStorage) OnChange(fn func(*WebStorageChange)) (cancel func(), err error) {
+	id, err := s.id()
+	if err != nil {
+		return nil, err
+	}
+
+	match := func(e *proto.DOMStorageStorageID) bool {
+		if e == nil || e.IsLocalStorage != id.IsLocalStorage {
+			return false
+		}
+		return e.SecurityOrigin == id.SecurityOrigin ||
+			strings.TrimSuffix(string(e.StorageKey), "/") == id.SecurityOrigin
+	}
+
+	p, cancel := s.page.WithCancel()
+	go p.EachEvent(func(e *proto.DOMStorageDomStorageItemAdded) {
+		if match(e.StorageID) {
+			fn(&WebStorageChange{Type: WebStorageAdded, Key: e.Key, NewValue: e.NewValue})
+		}
+	}, func(e *proto.DOMStorageDomStorageItemUpdated) {
+		if match(e.StorageID) {
+			fn(&WebStorageChange{Type: WebStorageUpdated, Key: e.Key, OldValue: e.OldValue, NewValue: e.NewValue})
+		}
+	}, func(e *proto.DOMStorageDomStorageItemRemoved) {
+		if match(e.StorageID) {
+			fn(&WebStorageChange{Type: WebStorageRemoved, Key: e.Key})
+		}
+	}, func(e *proto.DOMStorageDomStorageItemsCleared) {
+		if match(e.StorageID) {
+			fn(&WebStorageChange{Type: WebStorageCleared})
+		}
+	})()
+
+	return cancel, nil
+}
@@ -0,0 +1,73 @@
+package rod_test
+
+import (
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestWebStorage(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/", ".html", `<html></html>`)
+
+	page := g.newPage(s.URL())
+
+	for _, local := range []bool{true, false} {
+		storage := page.SessionStorage()
+		if local {
+			storage = page.LocalStorage()
+		}
+		storage.MustClear()
+
+		changes := make(chan *rod.WebStorageChange, 10)
+		cancel := storage.MustOnChange(func(c *rod.WebStorageChange) { changes <- c })
+
+		storage.MustSet("a", "1").MustSet("b", "2")
+		g.Eq(storage.MustGet("a"), "1")
+		g.Eq(storage.MustItems(), map[string]string{"a": "1", "b": "2"})
+		g.Len(storage.MustKeys(), 2)
+
+		_, has, err := storage.Get("c")
+		g.E(err)
+		g.False(has)
+
+		page.MustEval(`(local) => (local ? localStorage : sessionStorage).setItem("a", "3")`, local)
+
+		storage.MustRemove("b")
+		g.Eq(storage.MustKeys(), []string{"a"})
+
+		storage.MustClear()
+		g.Len(storage.MustItems(), 0)
+
+		g.Eq((<-changes).Type, rod.WebStorageAdded)
+		g.Eq((<-changes).Key, "b")
+		c := <-changes
+		g.Eq(c.Type, rod.WebStorageUpdated)
+		g.Eq(c.OldValue, "1")
+		g.Eq(c.NewValue, "3")
+		g.Eq((<-changes).Type, rod.WebStorageRemoved)
+		g.Eq((<-changes).Type, rod.WebStorageCleared)
+
+		cancel()
+	}
+
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		page.LocalStorage().MustItems()
+	})
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.DOMStorageGetDOMStorageItems{})
+		page.LocalStorage().MustKeys()
+	})
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.DOMStorageSetDOMStorageItem{})
+		page.LocalStorage().MustSet("a", "1")
+	})
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		page.SessionStorage().MustOnChange(func(*rod.WebStorageChange) {})
+	})
+}